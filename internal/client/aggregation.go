@@ -0,0 +1,136 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package client
+
+import (
+	"sort"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// aggregationMerger merges the aggregations computed by each partition.
+type aggregationMerger struct {
+	aggs    []*vearchpb.Aggregation
+	results []*vearchpb.AggregationResult
+	buckets []map[string]*vearchpb.AggregationBucket
+	err     *vearchpb.Error
+}
+
+func newAggregationMerger(aggs []*vearchpb.Aggregation) *aggregationMerger {
+	m := &aggregationMerger{
+		aggs:    aggs,
+		results: make([]*vearchpb.AggregationResult, len(aggs)),
+		buckets: make([]map[string]*vearchpb.AggregationBucket, len(aggs)),
+	}
+	for i, agg := range aggs {
+		m.results[i] = &vearchpb.AggregationResult{Name: agg.Name}
+		m.buckets[i] = make(map[string]*vearchpb.AggregationBucket)
+	}
+	return m
+}
+
+// add merges the aggregations of the response of one partition, they are in
+// the order of the aggregations of the request. Aggregations are wrong
+// without any partition, so its error is kept.
+func (m *aggregationMerger) add(pd *vearchpb.PartitionData) {
+	if pd == nil {
+		return
+	}
+	if pd.Err != nil {
+		m.err = pd.Err
+		return
+	}
+	resp := pd.SearchResponse
+	if resp == nil {
+		return
+	}
+	if resp.Head != nil && resp.Head.Err != nil {
+		m.err = resp.Head.Err
+		return
+	}
+	for i, p := range resp.Aggregations {
+		if i >= len(m.results) || p == nil {
+			break
+		}
+		result, buckets := m.results[i], m.buckets[i]
+		result.OtherCount += p.OtherCount
+		for _, b := range p.Buckets {
+			if bucket, ok := buckets[b.Key]; ok {
+				bucket.Count += b.Count
+				continue
+			}
+			buckets[b.Key] = &vearchpb.AggregationBucket{Key: b.Key, Count: b.Count, From: b.From, To: b.To}
+		}
+		if p.Count == 0 {
+			continue
+		}
+		if result.Count == 0 || p.Min < result.Min {
+			result.Min = p.Min
+		}
+		if result.Count == 0 || p.Max > result.Max {
+			result.Max = p.Max
+		}
+		result.Count += p.Count
+		result.Sum += p.Sum
+	}
+}
+
+// setTo sets the aggregations of all partitions to resp, or the error of a
+// partition. Terms keep the top size buckets, the counts of the others are
+// added to other_count.
+func (m *aggregationMerger) setTo(resp *vearchpb.SearchResponse) {
+	if m.err != nil {
+		if resp.Head == nil {
+			resp.Head = &vearchpb.ResponseHead{}
+		}
+		resp.Head.Err = m.err
+		return
+	}
+	for i, agg := range m.aggs {
+		result := m.results[i]
+		result.Buckets = make([]*vearchpb.AggregationBucket, 0, len(m.buckets[i]))
+		for _, bucket := range m.buckets[i] {
+			result.Buckets = append(result.Buckets, bucket)
+		}
+		switch agg.Type {
+		case vearchpb.Aggregation_TERMS:
+			sort.Slice(result.Buckets, func(i, j int) bool {
+				if result.Buckets[i].Count != result.Buckets[j].Count {
+					return result.Buckets[i].Count > result.Buckets[j].Count
+				}
+				return result.Buckets[i].Key < result.Buckets[j].Key
+			})
+			if len(result.Buckets) > int(agg.Size) {
+				for _, bucket := range result.Buckets[agg.Size:] {
+					result.OtherCount += bucket.Count
+				}
+				result.Buckets = result.Buckets[:agg.Size]
+			}
+		case vearchpb.Aggregation_RANGE:
+			order := make(map[string]int, len(agg.Ranges))
+			for i, r := range agg.Ranges {
+				order[r.Key] = i
+			}
+			sort.Slice(result.Buckets, func(i, j int) bool {
+				return order[result.Buckets[i].Key] < order[result.Buckets[j].Key]
+			})
+		case vearchpb.Aggregation_HISTOGRAM:
+			sort.Slice(result.Buckets, func(i, j int) bool {
+				return result.Buckets[i].From < result.Buckets[j].From
+			})
+		}
+	}
+	resp.Aggregations = m.results
+}
@@ -41,7 +41,10 @@ import (
 	"github.com/vearch/vearch/v3/internal/pkg/atomic"
 	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/pkg/netutil"
 	"github.com/vearch/vearch/v3/internal/pkg/number"
+	server "github.com/vearch/vearch/v3/internal/pkg/server/rpc"
+	"github.com/vearch/vearch/v3/internal/pkg/tlsutil"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 )
 
@@ -53,6 +56,9 @@ type Client struct {
 
 // NewClient create a new client by config
 func NewClient(conf *config.Config) (client *Client, err error) {
+	if err = initTLS(conf); err != nil {
+		return nil, err
+	}
 	client = &Client{}
 	err = client.initPsClient()
 	if err != nil {
@@ -65,6 +71,26 @@ func NewClient(conf *config.Config) (client *Client, err error) {
 	return client, err
 }
 
+// initTLS makes the clients of master and ps use TLS if they listen with it.
+func initTLS(conf *config.Config) error {
+	if conf.TLS == nil {
+		return nil
+	}
+	masterTLS, err := tlsutil.ClientConfig(conf.TLS.Master)
+	if err != nil {
+		return err
+	}
+	if masterTLS != nil {
+		netutil.SetTLSClientConfig(masterTLS)
+	}
+	psTLS, err := tlsutil.ClientConfig(conf.TLS.PS)
+	if err != nil {
+		return err
+	}
+	server.ClientOption.TLSConfig = psTLS
+	return nil
+}
+
 func (client *Client) initPsClient() error {
 	client.ps = &psClient{client: client}
 	client.ps.initFaultylist()
@@ -105,6 +131,12 @@ func (client *Client) Space(ctx context.Context, dbName, spaceName string) (*ent
 const (
 	// MessageID the key of message
 	MessageID = "message_id"
+	// PrunedPartitions the key in the response head of how many partitions
+	// were skipped by the partition rule
+	PrunedPartitions = "pruned_partitions"
+	// WriteToken the key in the response head of the raft index of each
+	// partition written, for reads of read_your_writes
+	WriteToken = "write_token"
 )
 
 // NewRouterRequest create a new request for router
@@ -120,6 +152,8 @@ type routerRequest struct {
 	docs    []*vearchpb.Document
 	space   *entity.Space
 	sendMap map[entity.PartitionID]*vearchpb.PartitionData
+	// pruned partitions of a search or query by the partition rule
+	pruned int
 	// Err if error else nil
 	Err error
 }
@@ -286,7 +320,7 @@ func (r *routerRequest) UpsertByPartitions(partitions []uint32) *routerRequest {
 			for _, field := range doc.Fields {
 				if field.Name == r.space.PartitionRule.Field {
 					found = true
-					pids, err := r.space.PartitionIdsByRangeField(field.Value, field.Type)
+					pids, err := r.space.PartitionIdsByRuleField(field.Value)
 					if err != nil {
 						r.Err = err
 						return r
@@ -352,7 +386,7 @@ func copyMap(src map[string]string) map[string]string {
 func (r *routerRequest) Execute() []*vearchpb.Item {
 	isNormal := false
 	normalField := make(map[string]string)
-	if r.md[HandlerType] == BatchHandler {
+	if r.md[HandlerType] == BatchHandler || r.md[HandlerType] == UpdateHandler {
 		indexType := r.space.Index.Type
 		if indexType != "" && indexType != "BINARYIVF" {
 			isNormal = true
@@ -373,6 +407,10 @@ func (r *routerRequest) Execute() []*vearchpb.Item {
 	respChain := make(chan *vearchpb.PartitionData, len(r.sendMap))
 	for partitionID, pData := range r.sendMap {
 		wg.Add(1)
+		// gets check their consistency by the head
+		if r.head.GetConsistency() != "" {
+			pData.Head = r.head
+		}
 		c := context.WithValue(r.ctx, share.ReqMetaDataKey, copyMap(r.md))
 		go func(ctx context.Context, pid entity.PartitionID, d *vearchpb.PartitionData) {
 			defer wg.Done()
@@ -450,8 +488,12 @@ func (r *routerRequest) Execute() []*vearchpb.Item {
 	}
 
 	items := make([]*vearchpb.Item, len(r.docs))
+	writeIndex := make(map[uint32]uint64)
 	for resp := range respChain {
 		setPartitionErr(resp)
+		if resp.WriteIndex > 0 {
+			writeIndex[resp.PartitionID] = resp.WriteIndex
+		}
 		for _, item := range resp.Items {
 			if item == nil || item.Doc == nil {
 				log.Error("item or doc is nil")
@@ -467,6 +509,9 @@ func (r *routerRequest) Execute() []*vearchpb.Item {
 			}
 		}
 	}
+	if token := request.EncodeWriteToken(writeIndex); token != "" {
+		r.md[WriteToken] = token
+	}
 	return items
 }
 
@@ -777,8 +822,15 @@ func (r *routerRequest) SearchFieldSortExecute(desc bool) *vearchpb.SearchRespon
 	var searchResponse *vearchpb.SearchResponse
 
 	mergeStartTime := time.Now()
+	var aggs *aggregationMerger
+	if searchReq != nil && len(searchReq.Aggregations) > 0 {
+		aggs = newAggregationMerger(searchReq.Aggregations)
+	}
 	var finalErr *vearchpb.Error
 	for r := range respChain {
+		if aggs != nil && r != nil {
+			aggs.add(r.PartitionData)
+		}
 		if r != nil && r.PartitionData.Err != nil {
 			finalErr = r.PartitionData.Err
 			continue
@@ -791,6 +843,12 @@ func (r *routerRequest) SearchFieldSortExecute(desc bool) *vearchpb.SearchRespon
 			}
 		}
 
+		if !request.EngineRanked(searchReq) {
+			// ranks of the retrievers are computed over the items of all partitions
+			AddMergeResultArr(result, r.PartitionData.SearchResponse.Results)
+			continue
+		}
+
 		if len(result) <= len(r.PartitionData.SearchResponse.Results) {
 			for i := range result {
 				AddMergeSort(result[i], r.PartitionData.SearchResponse.Results[i], int(searchReq.TopN), desc)
@@ -803,13 +861,25 @@ func (r *routerRequest) SearchFieldSortExecute(desc bool) *vearchpb.SearchRespon
 	}
 
 	for _, resp := range result {
-		quickSort(resp.ResultItems, desc, 0, len(resp.ResultItems)-1)
+		if !request.EngineRanked(searchReq) {
+			if err := request.FuseScores(resp.ResultItems, searchReq, desc); err != nil {
+				log.Error("fuse search scores err: [%v]", err)
+			} else if len(resp.ResultItems) > 0 {
+				resp.MaxScore = resp.ResultItems[0].Score
+			}
+		} else {
+			quickSort(resp.ResultItems, desc, 0, len(resp.ResultItems)-1)
+		}
+		if searchReq.GroupBy != nil {
+			resp.ResultItems = collapseResultItems(resp.ResultItems, searchReq.GroupBy)
+		}
 		if resp.ResultItems != nil && len(resp.ResultItems) > 0 && searchReq.TopN > 0 {
 			len := len(resp.ResultItems)
 			if int32(len) > searchReq.TopN {
 				resp.ResultItems = resp.ResultItems[0:searchReq.TopN]
 			}
 		}
+		resp.ResultItems = skipOffset(resp.ResultItems, searchReq.Offset)
 	}
 
 	if searchResponse == nil {
@@ -827,6 +897,10 @@ func (r *routerRequest) SearchFieldSortExecute(desc bool) *vearchpb.SearchRespon
 		searchResponse.Head.Params["searchExecute"] = searchExecuteStr
 	}
 	searchResponse.Results = result
+	if aggs != nil {
+		aggs.setTo(searchResponse)
+	}
+	r.setPruned(searchResponse)
 	searchResponse.Head.RequestId = r.GetMsgID()
 	return searchResponse
 }
@@ -960,12 +1034,29 @@ func (r *routerRequest) QueryFieldSortExecute() *vearchpb.SearchResponse {
 	wg.Wait()
 	close(respChain)
 
+	// documents are merged in the order of partitions, so pages of a query
+	// by offset don't overlap
+	responses := make([]*response.SearchDocResult, 0, len(sendPartitionMap))
+	for r := range respChain {
+		responses = append(responses, r)
+	}
+	sort.Slice(responses, func(i, j int) bool {
+		return responses[i].PartitionData.PartitionID < responses[j].PartitionData.PartitionID
+	})
+
 	var result []*vearchpb.SearchResult
 	var searchResponse *vearchpb.SearchResponse
 	var head vearchpb.ResponseHead
 
+	var aggs *aggregationMerger
+	if searchReq != nil && len(searchReq.Aggregations) > 0 {
+		aggs = newAggregationMerger(searchReq.Aggregations)
+	}
 	var finalErr *vearchpb.Error
-	for r := range respChain {
+	for _, r := range responses {
+		if aggs != nil && r != nil {
+			aggs.add(r.PartitionData)
+		}
 		if r != nil && r.PartitionData.Err != nil {
 			finalErr = r.PartitionData.Err
 			continue
@@ -1005,6 +1096,10 @@ func (r *routerRequest) QueryFieldSortExecute() *vearchpb.SearchResponse {
 		searchResponse = &vearchpb.SearchResponse{}
 		responseHead := &vearchpb.ResponseHead{}
 		searchResponse.Head = responseHead
+		if aggs != nil {
+			aggs.setTo(searchResponse)
+		}
+		r.setPruned(searchResponse)
 		return searchResponse
 	}
 
@@ -1024,6 +1119,7 @@ func (r *routerRequest) QueryFieldSortExecute() *vearchpb.SearchResponse {
 					resp.ResultItems = resp.ResultItems[0:searchReq.Limit]
 				}
 			}
+			resp.ResultItems = skipOffset(resp.ResultItems, searchReq.Offset)
 		}
 	} else {
 		// order by document_ids
@@ -1044,9 +1140,55 @@ func (r *routerRequest) QueryFieldSortExecute() *vearchpb.SearchResponse {
 	}
 
 	searchResponse.Results = result
+	if aggs != nil {
+		aggs.setTo(searchResponse)
+	}
+	r.setPruned(searchResponse)
 	return searchResponse
 }
 
+// collapseResultItems keeps the first group_size items of each value of the
+// group by field in the merged and sorted items, and the first groups values.
+// Items without the field are one group.
+func collapseResultItems(items []*vearchpb.ResultItem, groupBy *vearchpb.GroupBy) []*vearchpb.ResultItem {
+	groupSize := groupBy.GroupSize
+	if groupSize <= 0 {
+		groupSize = 1
+	}
+	counts := make(map[string]int32)
+	collapsed := items[:0]
+	for _, item := range items {
+		var key string
+		for _, field := range item.Fields {
+			if field != nil && field.Name == groupBy.Field {
+				key = string(field.Value)
+				break
+			}
+		}
+		count, ok := counts[key]
+		if !ok && groupBy.Groups > 0 && int32(len(counts)) >= groupBy.Groups {
+			continue
+		}
+		if count >= groupSize {
+			continue
+		}
+		counts[key] = count + 1
+		collapsed = append(collapsed, item)
+	}
+	return collapsed
+}
+
+// skipOffset drops the first offset items of merged results.
+func skipOffset(items []*vearchpb.ResultItem, offset int32) []*vearchpb.ResultItem {
+	if offset <= 0 {
+		return items
+	}
+	if int(offset) >= len(items) {
+		return items[:0]
+	}
+	return items[offset:]
+}
+
 func quickSort(items []*vearchpb.ResultItem, desc bool, low, high int) {
 	if low < high {
 		var pivot = partition(items, desc, low, high)
@@ -1126,6 +1268,10 @@ func (r *routerRequest) QueryByPartitions(queryReq *vearchpb.QueryRequest) *rout
 		return r
 	}
 
+	// partitions return the documents skipped by offset too, they are
+	// dropped after merging
+	queryReq.Limit += queryReq.Offset
+
 	sendMap := make(map[entity.PartitionID]*vearchpb.PartitionData)
 	if queryReq.PartitionId != 0 {
 		partitionID := uint32(queryReq.PartitionId)
@@ -1136,7 +1282,7 @@ func (r *routerRequest) QueryByPartitions(queryReq *vearchpb.QueryRequest) *rout
 			sendMap[partitionID] = d
 		}
 	} else {
-		for _, partitionInfo := range r.space.Partitions {
+		for _, partitionInfo := range r.prunePartitions(queryReq.RangeFilters, queryReq.TermFilters, queryReq.Operator, queryReq.FilterTree) {
 			partitionID := partitionInfo.Id
 			if d, ok := sendMap[partitionID]; ok {
 				log.Error("db Id:%d , space Id:%d, have multiple partitionID:%d", partitionInfo.DBId, partitionInfo.SpaceId, partitionID)
@@ -1151,6 +1297,45 @@ func (r *routerRequest) QueryByPartitions(queryReq *vearchpb.QueryRequest) *rout
 	return r
 }
 
+// prunePartitions returns the partitions of the space which can hold
+// documents matching the filters by the partition rule. One partition is
+// kept when none can, so the response has the shape of an empty result.
+func (r *routerRequest) prunePartitions(rangeFilters []*vearchpb.RangeFilter, termFilters []*vearchpb.TermFilter, operator int32, tree *vearchpb.FilterNode) []*entity.Partition {
+	if r.space.PartitionRule == nil {
+		return r.space.Partitions
+	}
+	names := r.space.PartitionRule.MatchingNames(rangeFilters, termFilters, operator, tree)
+	if names == nil {
+		return r.space.Partitions
+	}
+	partitions := make([]*entity.Partition, 0, len(r.space.Partitions))
+	for _, p := range r.space.Partitions {
+		if names[p.Name] {
+			partitions = append(partitions, p)
+		}
+	}
+	if len(partitions) == 0 && len(r.space.Partitions) > 0 {
+		partitions = append(partitions, r.space.Partitions[0])
+	}
+	r.pruned = len(r.space.Partitions) - len(partitions)
+	return partitions
+}
+
+// setPruned reports the pruned partitions in the head of resp for spaces
+// with a partition rule.
+func (r *routerRequest) setPruned(resp *vearchpb.SearchResponse) {
+	if r.space == nil || r.space.PartitionRule == nil {
+		return
+	}
+	if resp.Head == nil {
+		resp.Head = &vearchpb.ResponseHead{}
+	}
+	if resp.Head.Params == nil {
+		resp.Head.Params = make(map[string]string)
+	}
+	resp.Head.Params[PrunedPartitions] = strconv.Itoa(r.pruned)
+}
+
 type RoundRobin[K comparable, V any] struct {
 	counterMap sync.Map
 }
@@ -1179,8 +1364,11 @@ func (r *routerRequest) SearchByPartitions(searchReq *vearchpb.SearchRequest) *r
 	if r.Err != nil {
 		return r
 	}
+	// partitions return the results skipped by offset too, they are dropped
+	// after merging
+	searchReq.TopN += searchReq.Offset
 	r.sendMap = make(map[entity.PartitionID]*vearchpb.PartitionData)
-	for _, p := range r.space.Partitions {
+	for _, p := range r.prunePartitions(searchReq.RangeFilters, searchReq.TermFilters, searchReq.Operator, searchReq.FilterTree) {
 		if _, ok := r.sendMap[p.Id]; ok {
 			log.Error("db Id:%d , space Id:%d, have multiple partitionID:%d", p.DBId, p.SpaceId, p.Id)
 			continue
@@ -1722,7 +1910,11 @@ func (r *routerRequest) DelByQueryeExecute() *vearchpb.DelByQueryeResponse {
 	close(respChain)
 
 	delByQueryResponse := &vearchpb.DelByQueryeResponse{}
+	writeIndex := make(map[uint32]uint64)
 	for resp := range respChain {
+		if resp.WriteIndex > 0 {
+			writeIndex[resp.PartitionID] = resp.WriteIndex
+		}
 		if resp.Err != nil || resp.DelByQueryResponse == nil {
 			log.Error("err: %s", resp.Err)
 			continue
@@ -1732,5 +1924,80 @@ func (r *routerRequest) DelByQueryeExecute() *vearchpb.DelByQueryeResponse {
 		}
 	}
 	delByQueryResponse.DelNum = int32(len(delByQueryResponse.IdsStr))
+	if token := request.EncodeWriteToken(writeIndex); token != "" {
+		delByQueryResponse.Head = &vearchpb.ResponseHead{Params: map[string]string{WriteToken: token}}
+	}
 	return delByQueryResponse
 }
+
+// ChangesByPartitions reads the changes of every partition
+func (r *routerRequest) ChangesByPartitions(args *vearchpb.ChangesRequest) *routerRequest {
+	if r.Err != nil {
+		return r
+	}
+	sendMap := make(map[entity.PartitionID]*vearchpb.PartitionData)
+	for _, partitionInfo := range r.space.Partitions {
+		sendMap[partitionInfo.Id] = &vearchpb.PartitionData{PartitionID: partitionInfo.Id, MessageID: r.GetMsgID(), ChangesRequest: args}
+	}
+	r.sendMap = sendMap
+	return r
+}
+
+// ChangesExecute reads the changes from the leader of each partition, the
+// events are in index order of each partition
+func (r *routerRequest) ChangesExecute() *vearchpb.ChangesResponse {
+	var wg sync.WaitGroup
+	respChain := make(chan *vearchpb.PartitionData, len(r.sendMap))
+	for partitionID, pData := range r.sendMap {
+		wg.Add(1)
+		c := context.WithValue(r.ctx, share.ReqMetaDataKey, copyMap(r.md))
+		go func(ctx context.Context, pid entity.PartitionID, d *vearchpb.PartitionData) {
+			defer wg.Done()
+			replyPartition := new(vearchpb.PartitionData)
+			defer func() {
+				if r := recover(); r != nil {
+					d.Err = &vearchpb.Error{Code: vearchpb.ErrorEnum_RECOVER, Msg: fmt.Sprintf("[Recover] partitionID: [%v], err: [%v]", pid, r)}
+					respChain <- d
+				}
+			}()
+			partition, e := r.client.Master().Cache().PartitionByCache(ctx, r.space.Name, pid)
+			if e != nil {
+				panic(e.Error())
+			}
+			err := r.client.PS().GetOrCreateRPCClient(ctx, partition.LeaderID).Execute(ctx, UnaryHandler, d, replyPartition)
+			if err != nil {
+				replyPartition.PartitionID = pid
+				replyPartition.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+			}
+			respChain <- replyPartition
+		}(c, partitionID, pData)
+	}
+	wg.Wait()
+	close(respChain)
+
+	replies := make([]*vearchpb.PartitionData, 0, len(r.sendMap))
+	for resp := range respChain {
+		replies = append(replies, resp)
+	}
+	sort.Slice(replies, func(i, j int) bool { return replies[i].PartitionID < replies[j].PartitionID })
+
+	changesResponse := &vearchpb.ChangesResponse{Head: &vearchpb.ResponseHead{}, Positions: make(map[uint32]uint64)}
+	for _, resp := range replies {
+		err := resp.Err
+		if err == nil && resp.ChangesResponse != nil {
+			err = resp.ChangesResponse.GetHead().GetErr()
+		}
+		if err != nil && err.Code != vearchpb.ErrorEnum_SUCCESS {
+			changesResponse.Head.Err = &vearchpb.Error{Code: err.Code, Msg: fmt.Sprintf("partition %d: %s", resp.PartitionID, err.Msg)}
+			return changesResponse
+		}
+		if resp.ChangesResponse == nil {
+			continue
+		}
+		changesResponse.Events = append(changesResponse.Events, resp.ChangesResponse.Events...)
+		for pid, position := range resp.ChangesResponse.Positions {
+			changesResponse.Positions[pid] = position
+		}
+	}
+	return changesResponse
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/pkg/vjson"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
 const (
@@ -166,12 +167,58 @@ func (m *masterClient) QueryUserByPassword(ctx context.Context, username, passwo
 		return nil, err
 	}
 
-	if user.Password != nil && *user.Password != password {
+	if user.Password != nil && !entity.CheckPassword(*user.Password, password) {
 		return nil, vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, nil)
 	}
 	return user, nil
 }
 
+// MigrateUserPassword replaces the plaintext password of a user created by
+// an older version with its hash, once the password is checked.
+func (m *masterClient) MigrateUserPassword(ctx context.Context, username, password string) error {
+	hash, err := entity.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	return m.STM(ctx, func(stm concurrency.STM) error {
+		value := stm.Get(entity.UserKey(username))
+		if value == "" {
+			return vearchpb.NewError(vearchpb.ErrorEnum_USER_NOT_EXIST, nil)
+		}
+		user := new(entity.User)
+		if err := vjson.Unmarshal([]byte(value), user); err != nil {
+			return err
+		}
+		// changed since it was checked
+		if user.Password == nil || entity.IsPasswordHashed(*user.Password) || *user.Password != password {
+			return nil
+		}
+		user.Password = &hash
+		bs, err := vjson.Marshal(user)
+		if err != nil {
+			return err
+		}
+		stm.Put(entity.UserKey(username), string(bs))
+		return nil
+	})
+}
+
+// QueryAPIKey query api key from etcd by key /api_key/{id}
+func (m *masterClient) QueryAPIKey(ctx context.Context, id string) (*entity.APIKey, error) {
+	bytes, err := m.Get(ctx, entity.APIKeyKey(id))
+	if err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err)
+	}
+	if bytes == nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, nil)
+	}
+	key := new(entity.APIKey)
+	if err = vjson.Unmarshal(bytes, key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // QueryRole query role info from etcd by key /role/{rolename}
 func (m *masterClient) QueryRole(ctx context.Context, rolename string) (*entity.Role, error) {
 	bytes, err := m.Get(ctx, entity.RoleKey(rolename))
@@ -670,14 +717,17 @@ func (m *masterClient) HTTPRequest(ctx context.Context, method string, url strin
 }
 
 // proxy HTTP request
-func (m *masterClient) ProxyHTTPRequest(method string, url string, reqBody string, authHeader string) (response []byte, e error) {
+func (m *masterClient) ProxyHTTPRequest(method string, url string, reqBody string, header map[string]string) (response []byte, e error) {
 	// process panic
 	defer func() {
 		if info := recover(); info != nil {
 			e = fmt.Errorf("%v", info)
 		}
 	}()
-	query := netutil.NewQuery().SetHeader(Authorization, authHeader)
+	query := netutil.NewQuery()
+	for key, value := range header {
+		query.SetHeader(key, value)
+	}
 	query.SetMethod(method)
 	query.SetUrlPath(url)
 	query.SetReqBody(reqBody)
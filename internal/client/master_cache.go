@@ -59,6 +59,10 @@ type clientCache struct {
 	cancel                                                                                                             context.CancelFunc
 	lock                                                                                                               sync.Mutex
 	userCache, spaceCache, spaceIDCache, partitionCache, serverCache, aliasCache, roleCache, mastersCache, routerCache *cache.Cache
+	apiKeyCache                                                                                                        *cache.Cache
+	// missingAPIKeyCache holds the api key ids not found, so unknown tokens
+	// don't read etcd on every request
+	missingAPIKeyCache *cache.Cache
 }
 
 func newClientCache(serverCtx context.Context, masterClient *masterClient) (*clientCache, error) {
@@ -76,6 +80,9 @@ func newClientCache(serverCtx context.Context, masterClient *masterClient) (*cli
 		roleCache:      cache.New(cache.NoExpiration, cache.NoExpiration),
 		mastersCache:   cache.New(cache.NoExpiration, cache.NoExpiration),
 		routerCache:    cache.New(cache.NoExpiration, cache.NoExpiration),
+		apiKeyCache:    cache.New(cache.NoExpiration, cache.NoExpiration),
+
+		missingAPIKeyCache: cache.New(missingAPIKeyTTL, missingAPIKeyTTL),
 	}
 
 	if err := cc.startCacheJob(ctx); err != nil {
@@ -162,6 +169,34 @@ func (cliCache *clientCache) reloadUserCache(ctx context.Context, sync bool, use
 	return nil
 }
 
+const (
+	// missingAPIKeyTTL is how long an api key id not found is remembered, a
+	// new key is added by the watcher before it
+	missingAPIKeyTTL = 30 * time.Second
+	// maxMissingAPIKeys bounds the missing api key ids remembered
+	maxMissingAPIKeys = 10000
+)
+
+// find an api key by cache, a key not in cache is read once from etcd
+func (cliCache *clientCache) APIKeyByCache(ctx context.Context, id string) (*entity.APIKey, error) {
+	if get, found := cliCache.apiKeyCache.Get(id); found {
+		return get.(*entity.APIKey), nil
+	}
+	if _, found := cliCache.missingAPIKeyCache.Get(id); found {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, nil)
+	}
+	key, err := cliCache.mc.QueryAPIKey(ctx, id)
+	if err != nil {
+		if vErr, ok := err.(*vearchpb.VearchErr); ok && vErr.GetError().Code == vearchpb.ErrorEnum_AUTHENTICATION_FAILED &&
+			cliCache.missingAPIKeyCache.ItemCount() < maxMissingAPIKeys {
+			cliCache.missingAPIKeyCache.SetDefault(id, struct{}{})
+		}
+		return nil, err
+	}
+	cliCache.apiKeyCache.Set(id, key, cache.NoExpiration)
+	return key, nil
+}
+
 // find a role by cache
 func (cliCache *clientCache) RoleByCache(ctx context.Context, roleName string) (*entity.Role, error) {
 
@@ -434,8 +469,8 @@ func (cliCache *clientCache) startCacheJob(ctx context.Context) error {
 			if err := vjson.Unmarshal(value, user); err != nil {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("put event user cache err, can't unmarshal event value: %s, error: %s", string(value), err.Error()))
 			}
-			log.Debug("[%v] add to user cache.", *user)
-			cliCache.userCache.Set(entity.UserKey(user.Name), user, cache.NoExpiration)
+			log.Debug("[%s] add to user cache.", user.Name)
+			cliCache.userCache.Set(user.Name, user, cache.NoExpiration)
 			return nil
 		},
 		delete: func(key string) (err error) {
@@ -448,6 +483,31 @@ func (cliCache *clientCache) startCacheJob(ctx context.Context) error {
 	}
 	userJob.start()
 
+	// init api key
+	if err := cliCache.initAPIKey(ctx); err != nil {
+		return err
+	}
+	apiKeyJob := watcherJob{ctx: ctx, prefix: entity.PrefixAPIKey, masterClient: cliCache.mc, cache: cliCache.apiKeyCache,
+		put: func(key, value []byte) (err error) {
+			apiKey := &entity.APIKey{}
+			if err := vjson.Unmarshal(value, apiKey); err != nil {
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("put event api key cache err, can't unmarshal event value: %s, error: %s", string(value), err.Error()))
+			}
+			log.Debug("[%s] add to api key cache.", apiKey.ID)
+			cliCache.apiKeyCache.Set(apiKey.ID, apiKey, cache.NoExpiration)
+			cliCache.missingAPIKeyCache.Delete(apiKey.ID)
+			return nil
+		},
+		delete: func(key string) (err error) {
+			keySplit := strings.Split(key, "/")
+			id := keySplit[len(keySplit)-1]
+			log.Debug("[%s] delete from api key cache.", id)
+			cliCache.apiKeyCache.Delete(id)
+			return nil
+		},
+	}
+	apiKeyJob.start()
+
 	// init space
 	if err := cliCache.initSpace(ctx); err != nil {
 		return err
@@ -610,7 +670,7 @@ func (cliCache *clientCache) startCacheJob(ctx context.Context) error {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("put event role cache err, can't unmarshal event value: %s, error: %s", string(value), err.Error()))
 			}
 			log.Debug("[%v] add to role cache.", *role)
-			cliCache.roleCache.Set(entity.RoleKey(role.Name), role, cache.NoExpiration)
+			cliCache.roleCache.Set(role.Name, role, cache.NoExpiration)
 			return nil
 		},
 		delete: func(key string) (err error) {
@@ -738,6 +798,23 @@ func (cliCache *clientCache) initUser(ctx context.Context) error {
 	return nil
 }
 
+func (cliCache *clientCache) initAPIKey(ctx context.Context) error {
+	_, values, err := cliCache.mc.PrefixScan(ctx, entity.PrefixAPIKey)
+	if err != nil {
+		log.Error("init api key cache err: %s", err.Error())
+		return err
+	}
+	for _, value := range values {
+		key := &entity.APIKey{}
+		if err := vjson.Unmarshal(value, key); err != nil {
+			log.Error("unmarshal api key cache err [%s]", err.Error())
+			continue
+		}
+		cliCache.apiKeyCache.Set(key.ID, key, cache.NoExpiration)
+	}
+	return nil
+}
+
 func (cliCache *clientCache) initSpace(ctx context.Context) error {
 	spaces, err := cliCache.mc.QuerySpacesByKey(ctx, entity.PrefixSpace)
 	if err != nil {
@@ -51,7 +51,9 @@ const (
 
 	SearchHandler        = "SearchHandler"
 	QueryHandler         = "QueryHandler"
+	ScrollHandler        = "ScrollHandler"
 	DeleteByQueryHandler = "DeleteByQueryHandler"
+	ChangesHandler       = "ChangesHandler"
 
 	GetDocHandler                 = "GetDocHandler"
 	GetDocsHandler                = "GetDocsHandler"
@@ -59,6 +61,7 @@ const (
 	GetNextDocsByPartitionHandler = "GetNextDocsByPartitionHandler"
 	DeleteDocsHandler             = "DeleteDocsHandler"
 	BatchHandler                  = "BatchHandler"
+	UpdateHandler                 = "UpdateHandler"
 	ForceMergeHandler             = "ForceMergeHandler"
 	RebuildIndexHandler           = "RebuildIndexHandler"
 	FlushHandler                  = "FlushHandler"
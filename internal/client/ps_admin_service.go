@@ -15,8 +15,12 @@
 package client
 
 import (
+	"context"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/smallnest/rpcx/share"
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/pkg/metrics/mserver"
@@ -106,6 +110,83 @@ func BackupSpace(addr string, backup *entity.BackupSpaceRequest, pid entity.Part
 	return nil
 }
 
+// RebuildIndex rebuilds the index of the replica of partition pid on addr,
+// it waits until the rebuild is done or timeout.
+func RebuildIndex(addr string, pid entity.PartitionID, indexRequest *vearchpb.IndexRequest, timeout time.Duration) error {
+	md := map[string]string{
+		HandlerType:                 RebuildIndexHandler,
+		string(entity.RPC_TIME_OUT): strconv.FormatInt(timeout.Milliseconds(), 10),
+	}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), share.ReqMetaDataKey, md), timeout)
+	defer cancel()
+	args := &vearchpb.PartitionData{PartitionID: pid, IndexRequest: indexRequest}
+	reply := new(vearchpb.PartitionData)
+	if err := execute(ctx, addr, UnaryHandler, args, reply); err != nil {
+		return err
+	}
+	if reply.Err != nil && reply.Err.Code != vearchpb.ErrorEnum_SUCCESS {
+		return vearchpb.NewErrorInfo(reply.Err.Code, reply.Err.Msg)
+	}
+	return nil
+}
+
+// docsTimeout is how long reading or writing a batch of documents of a
+// partition replica can take
+const docsTimeout = time.Minute
+
+func executeDocs(addr, method string, args *vearchpb.PartitionData) (*vearchpb.PartitionData, error) {
+	md := map[string]string{
+		HandlerType:                 method,
+		string(entity.RPC_TIME_OUT): strconv.FormatInt(docsTimeout.Milliseconds(), 10),
+	}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), share.ReqMetaDataKey, md), docsTimeout)
+	defer cancel()
+	reply := new(vearchpb.PartitionData)
+	if err := execute(ctx, addr, UnaryHandler, args, reply); err != nil {
+		return nil, err
+	}
+	if reply.Err != nil && reply.Err.Code != vearchpb.ErrorEnum_SUCCESS {
+		return nil, vearchpb.NewErrorInfo(reply.Err.Code, reply.Err.Msg)
+	}
+	return reply, nil
+}
+
+// ScrollDocs reads the documents of the replica of partition pid on addr
+// after req.Docid, without the cache of the router.
+func ScrollDocs(addr string, pid entity.PartitionID, req *vearchpb.ScrollRequest) (*vearchpb.ScrollResponse, error) {
+	reply, err := executeDocs(addr, ScrollHandler, &vearchpb.PartitionData{PartitionID: pid, ScrollRequest: req})
+	if err != nil {
+		return nil, err
+	}
+	resp := reply.ScrollResponse
+	if resp == nil || resp.Head == nil {
+		return nil, vearchpb.NewErrorInfo(vearchpb.ErrorEnum_INTERNAL_ERROR, "scroll response is nil")
+	}
+	if resp.Head.Err != nil && resp.Head.Err.Code != vearchpb.ErrorEnum_SUCCESS {
+		return nil, vearchpb.NewErrorInfo(resp.Head.Err.Code, resp.Head.Err.Msg)
+	}
+	return resp, nil
+}
+
+// UpsertDocs writes the documents to the leader of partition pid on addr,
+// the documents must have the _id field.
+func UpsertDocs(addr string, pid entity.PartitionID, docs []*vearchpb.Document) error {
+	items := make([]*vearchpb.Item, 0, len(docs))
+	for _, doc := range docs {
+		items = append(items, &vearchpb.Item{Doc: doc})
+	}
+	reply, err := executeDocs(addr, BatchHandler, &vearchpb.PartitionData{PartitionID: pid, Items: items})
+	if err != nil {
+		return err
+	}
+	for _, item := range reply.Items {
+		if item.Err != nil && item.Err.Code != vearchpb.ErrorEnum_SUCCESS {
+			return vearchpb.NewErrorInfo(item.Err.Code, item.Err.Msg)
+		}
+	}
+	return nil
+}
+
 func ResourceLimit(addr string, resource *entity.ResourceLimit, pid entity.PartitionID) error {
 	value, err := vjson.Marshal(resource)
 	if err != nil {
@@ -0,0 +1,112 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/smallnest/rpcx/share"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// retryableScrollErr reports whether a scroll failed on a replica which
+// can't serve the partition now, another replica may.
+func retryableScrollErr(code vearchpb.ErrorEnum) bool {
+	switch code {
+	case vearchpb.ErrorEnum_PARTITION_NOT_LEADER, vearchpb.ErrorEnum_PARTITION_IS_CLOSED,
+		vearchpb.ErrorEnum_PARTITION_IS_INVALID, vearchpb.ErrorEnum_PARTITION_NOT_EXIST:
+		return true
+	}
+	return false
+}
+
+func scrollErrResponse(err *vearchpb.Error) *vearchpb.ScrollResponse {
+	return &vearchpb.ScrollResponse{Head: &vearchpb.ResponseHead{Err: err}}
+}
+
+// ScrollExecute reads the next documents of one partition. The replicas of
+// the partition are tried in turn, so a scroll goes on when a node fails or
+// the leader changes.
+func (r *routerRequest) ScrollExecute(partitionID entity.PartitionID, req *vearchpb.ScrollRequest) *vearchpb.ScrollResponse {
+	if r.Err != nil {
+		return scrollErrResponse(vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, r.Err).GetError())
+	}
+	ctx := context.WithValue(r.ctx, share.ReqMetaDataKey, copyMap(r.md))
+
+	partition, err := r.client.Master().Cache().PartitionByCache(ctx, r.space.Name, partitionID)
+	if err != nil {
+		return scrollErrResponse(&vearchpb.Error{Code: vearchpb.ErrorEnum_PARTITION_NOT_EXIST, Msg: "scroll partition cache err partitionID:" + fmt.Sprint(partitionID)})
+	}
+
+	servers := r.client.Master().Cache().serverCache
+	tried := make(map[entity.NodeID]bool, len(partition.Replicas))
+	lastErr := &vearchpb.Error{Code: vearchpb.ErrorEnum_ROUTER_NO_PS_CLIENT, Msg: fmt.Sprintf("no replica of partitionID: %d can be read", partitionID)}
+	for range partition.Replicas {
+		nodeID := GetNodeIdsByClientType(req.Head.ClientType, partition, servers, r.client)
+		if nodeID == 0 || tried[nodeID] {
+			nodeID = 0
+			for _, id := range partition.Replicas {
+				if !tried[id] && !r.client.PS().TestFaulty(id) {
+					nodeID = id
+					break
+				}
+			}
+			if nodeID == 0 {
+				break
+			}
+		}
+		tried[nodeID] = true
+
+		rpcClient := r.client.PS().GetOrCreateRPCClient(ctx, nodeID)
+		if rpcClient == nil {
+			lastErr = &vearchpb.Error{Code: vearchpb.ErrorEnum_ROUTER_NO_PS_CLIENT, Msg: "no ps client by nodeID:" + fmt.Sprint(nodeID)}
+			continue
+		}
+
+		pd := &vearchpb.PartitionData{PartitionID: partitionID, MessageID: r.GetMsgID(), ScrollRequest: req}
+		reply := &vearchpb.PartitionData{}
+		if err := rpcClient.Execute(ctx, UnaryHandler, pd, reply); err != nil {
+			log.Error("scroll nodeID %v partitionID: %d rpc err [%v]", nodeID, partitionID, err)
+			if strings.Contains(err.Error(), "connect: connection refused") {
+				r.client.PS().AddFaulty(nodeID, time.Second*30)
+			}
+			lastErr = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+			continue
+		}
+		if reply.Err != nil {
+			lastErr = reply.Err
+			if retryableScrollErr(reply.Err.Code) {
+				continue
+			}
+			break
+		}
+		resp := reply.ScrollResponse
+		if resp == nil || resp.Head == nil {
+			lastErr = &vearchpb.Error{Code: vearchpb.ErrorEnum_INTERNAL_ERROR, Msg: "scroll response is nil"}
+			break
+		}
+		if resp.Head.Err != nil && retryableScrollErr(resp.Head.Err.Code) {
+			lastErr = resp.Head.Err
+			continue
+		}
+		return resp
+	}
+	return scrollErrResponse(lastErr)
+}
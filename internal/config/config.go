@@ -89,6 +89,8 @@ type Config struct {
 	Masters    Masters    `toml:"masters,omitempty" json:"masters"`
 	Router     *RouterCfg `toml:"router,omitempty" json:"router"`
 	PS         *PSCfg     `toml:"ps,omitempty" json:"ps"`
+	Audit      *AuditCfg  `toml:"audit,omitempty" json:"audit"`
+	TLS        *TLSCfgs   `toml:"tls,omitempty" json:"tls"`
 	mu         *sync.RWMutex
 }
 
@@ -208,6 +210,45 @@ type GlobalCfg struct {
 	Path              string  `toml:"path,omitempty" json:"path"`
 }
 
+// AuditCfg configures the audit log of the mutating calls of master and
+// routers.
+type AuditCfg struct {
+	Enable bool `toml:"enable" json:"enable"`
+	// Log is the dir of the audit log files, the log dir of global if empty
+	Log string `toml:"log,omitempty" json:"log"`
+	// Etcd also keeps the last RingSize records in etcd for /audit
+	Etcd     bool  `toml:"etcd" json:"etcd"`
+	RingSize int64 `toml:"ring_size,omitempty" json:"ring_size"`
+	// SkipDocumentWrites doesn't audit document upserts and updates, which
+	// are usually too many
+	SkipDocumentWrites bool `toml:"skip_document_writes" json:"skip_document_writes"`
+}
+
+// TLSCfgs are the TLS of the listeners, a listener without it is plaintext.
+type TLSCfgs struct {
+	Master    *TLSCfg `toml:"master,omitempty" json:"master"`
+	Router    *TLSCfg `toml:"router,omitempty" json:"router"`
+	RouterRpc *TLSCfg `toml:"router_rpc,omitempty" json:"router_rpc"`
+	PS        *TLSCfg `toml:"ps,omitempty" json:"ps"`
+}
+
+// TLSCfg is the TLS of a listener and of the clients of the cluster
+// connecting to it. Clients verify the listener by CA and present Cert as
+// their client certificate. The files are reloaded when they change.
+type TLSCfg struct {
+	Cert string `toml:"cert,omitempty" json:"cert"`
+	Key  string `toml:"key,omitempty" json:"key"`
+	// CA verifies the listener and client certificates, the system roots
+	// verify the listener if it's empty
+	CA string `toml:"ca,omitempty" json:"ca"`
+	// MinVersion is 1.2 or 1.3, 1.2 if empty
+	MinVersion string `toml:"min_version,omitempty" json:"min_version"`
+	// ClientAuth requires clients to present a certificate signed by CA
+	ClientAuth bool `toml:"client_auth,omitempty" json:"client_auth"`
+	// ServerName is the name clients verify, the host they dial if empty
+	ServerName string `toml:"server_name,omitempty" json:"server_name"`
+}
+
 type EtcdCfg struct {
 	AddressList    []string `toml:"address,omitempty" json:"address"`
 	EtcdClientPort uint16   `toml:"etcd_client_port,omitempty" json:"etcd_client_port"`
@@ -256,6 +297,12 @@ type MasterCfg struct {
 }
 
 func (m *MasterCfg) ApiUrl() string {
+	if c := Conf(); c != nil && c.TLS != nil && c.TLS.Master != nil {
+		if m.ApiPort == 443 {
+			return "https://" + m.Address
+		}
+		return "https://" + m.Address + ":" + cast.ToString(m.ApiPort)
+	}
 	if m.ApiPort == 80 {
 		return "http://" + m.Address
 	}
@@ -351,16 +398,23 @@ func (config *Config) GetEmbed() (*embed.Config, error) {
 }
 
 type RouterCfg struct {
-	Port          uint16   `toml:"port,omitempty" json:"port"`
-	PprofPort     uint16   `toml:"pprof_port,omitempty" json:"pprof_port"`
-	RpcPort       uint16   `toml:"rpc_port,omitempty" json:"rpc_port"`
-	MonitorPort   uint16   `toml:"monitor_port" json:"monitor_port"`
-	ConnLimit     int      `toml:"conn_limit" json:"conn_limit"`
-	CloseTimeout  int64    `toml:"close_timeout" json:"close_timeout"`
-	RouterIPS     []string `toml:"router_ips" json:"router_ips"`
-	ConcurrentNum int      `toml:"concurrent_num" json:"concurrent_num"`
-	RpcTimeOut    int      `toml:"rpc_timeout" json:"rpc_timeout"` // ms
-	AllowOrigins  []string `toml:"allow_origins" json:"allow_origins"`
+	Port            uint16   `toml:"port,omitempty" json:"port"`
+	PprofPort       uint16   `toml:"pprof_port,omitempty" json:"pprof_port"`
+	RpcPort         uint16   `toml:"rpc_port,omitempty" json:"rpc_port"`
+	MonitorPort     uint16   `toml:"monitor_port" json:"monitor_port"`
+	ConnLimit       int      `toml:"conn_limit" json:"conn_limit"`
+	CloseTimeout    int64    `toml:"close_timeout" json:"close_timeout"`
+	RouterIPS       []string `toml:"router_ips" json:"router_ips"`
+	ConcurrentNum   int      `toml:"concurrent_num" json:"concurrent_num"`
+	RpcTimeOut      int      `toml:"rpc_timeout" json:"rpc_timeout"` // ms
+	AllowOrigins    []string `toml:"allow_origins" json:"allow_origins"`
+	ScrollKeepAlive int64    `toml:"scroll_keep_alive" json:"scroll_keep_alive"` // seconds
+	MaxResultWindow int32    `toml:"max_result_window" json:"max_result_window"`
+	// ImportRoot is the dir local files are imported from, local imports
+	// are disabled if it's empty
+	ImportRoot string `toml:"import_root" json:"import_root"`
+	// ImportS3Endpoints are the s3 endpoints files are imported from
+	ImportS3Endpoints []string `toml:"import_s3_endpoints" json:"import_s3_endpoints"`
 }
 
 func (routerCfg *RouterCfg) ApiUrl(keyNumber int) string {
@@ -368,6 +422,12 @@ func (routerCfg *RouterCfg) ApiUrl(keyNumber int) string {
 	if routerCfg.RouterIPS != nil && len(routerCfg.RouterIPS) > 0 && keyNumber < len(routerCfg.RouterIPS) {
 		Addr = routerCfg.RouterIPS[keyNumber]
 	}
+	if c := Conf(); c != nil && c.TLS != nil && c.TLS.Router != nil {
+		if routerCfg.Port == 443 {
+			return "https://" + Addr
+		}
+		return "https://" + Addr + ":" + cast.ToString(routerCfg.Port)
+	}
 	if routerCfg.Port == 80 {
 		return "http://" + Addr
 	}
@@ -393,6 +453,23 @@ type PSCfg struct {
 	FlushCountThreshold         uint32 `toml:"flush_count_threshold" json:"flush_count_threshold"`
 	ConcurrentNum               int    `toml:"concurrent_num" json:"concurrent_num"`
 	RpcTimeOut                  int    `toml:"rpc_timeout" json:"rpc_timeout"`
+	// concurrent bulk writes of a partition within the window are merged
+	// into one raft entry, 0 disables it
+	WriteBatchWindow   int `toml:"write_batch_window" json:"write_batch_window"` // microseconds
+	WriteBatchMaxDocs  int `toml:"write_batch_max_docs" json:"write_batch_max_docs"`
+	WriteBatchMaxBytes int `toml:"write_batch_max_bytes" json:"write_batch_max_bytes"`
+	// changes retained in memory of each partition for the changes api,
+	// 10000 if 0 and disabled if negative
+	ChangeLogSize int `toml:"change_log_size" json:"change_log_size"`
+	// seconds between checks for expired documents of spaces with ttl,
+	// 60 if 0 and disabled if negative
+	TTLCheckInterval int `toml:"ttl_check_interval" json:"ttl_check_interval"`
+	// raft entries are written as protobuf instead of json, PS of older
+	// versions can't read them, so it's enabled after all PS are upgraded
+	RaftProtobufCommand bool `toml:"raft_protobuf_command" json:"raft_protobuf_command"`
+	// the raft heartbeat and replicate ports have no tls, a PS with tls
+	// doesn't start unless they are accepted in plaintext by it
+	RaftPlaintext bool `toml:"raft_plaintext" json:"raft_plaintext"`
 }
 
 func InitConfig(path string) {
@@ -404,6 +481,7 @@ func InitConfig(path string) {
 		PS: &PSCfg{
 			ReplicaAutoRecoverTime: -1,
 		},
+		TLS: &TLSCfgs{},
 	}
 	LoadConfig(single, path)
 }
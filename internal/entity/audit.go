@@ -0,0 +1,65 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"time"
+)
+
+const (
+	DefaultAuditRingSize = 10000
+	DefaultAuditLimit    = 100
+	MaxAuditLimit        = 1000
+)
+
+// AuditRecord is a mutating call of the master or a router.
+type AuditRecord struct {
+	// Seq orders the records kept in etcd
+	Seq       int64     `json:"seq,omitempty"`
+	Time      time.Time `json:"time"`
+	Module    string    `json:"module"`
+	User      string    `json:"user,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	AuthType  string    `json:"auth_type,omitempty"`
+	SourceIP  string    `json:"source_ip,omitempty"`
+	Method    string    `json:"method"`
+	Endpoint  string    `json:"endpoint"`
+	DB        string    `json:"db,omitempty"`
+	Space     string    `json:"space,omitempty"`
+	Status    int       `json:"status"`
+	Code      int       `json:"code"`
+	Msg       string    `json:"msg,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// AuditQuery filters the records kept in etcd, zero values don't filter.
+type AuditQuery struct {
+	StartTime time.Time
+	EndTime   time.Time
+	User      string
+	Limit     int
+}
+
+// Match tells whether the record is in the time range and by the user of
+// the query.
+func (q *AuditQuery) Match(record *AuditRecord) bool {
+	if !q.StartTime.IsZero() && record.Time.Before(q.StartTime) {
+		return false
+	}
+	if !q.EndTime.IsZero() && !record.Time.Before(q.EndTime) {
+		return false
+	}
+	return q.User == "" || q.User == record.User
+}
@@ -0,0 +1,253 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	// passwordHashPrefix marks a stored password as a salted hash, others are
+	// plaintext passwords of users created by older versions.
+	passwordHashPrefix = "pbkdf2_sha256"
+	passwordIterations = 100000
+	passwordSaltLen    = 16
+	passwordKeyLen     = 32
+
+	apiKeyIDLen     = 8
+	apiKeySecretLen = 24
+)
+
+const (
+	BasicAuth  = "Basic"
+	BearerAuth = "Bearer"
+)
+
+// context keys of the authenticated user, the type of its credentials and
+// its role
+const (
+	AuthUserKey = "auth_user"
+	AuthTypeKey = "auth_type"
+	AuthRoleKey = "auth_role"
+)
+
+const (
+	// verifiedPasswordsSize bounds the passwords cached, the least recently
+	// used are evicted
+	verifiedPasswordsSize = 4096
+	// verifiedPasswordTTL is how long a checked password is cached
+	verifiedPasswordTTL = 10 * time.Minute
+)
+
+// verifiedPasswords caches when the passwords checked against a hash expire,
+// hashing on every request is too slow. The hash is part of the key, so a
+// changed password doesn't match.
+var verifiedPasswords, _ = lru.New(verifiedPasswordsSize)
+
+func passwordVerified(cacheKey string) bool {
+	expire, ok := verifiedPasswords.Get(cacheKey)
+	if !ok {
+		return false
+	}
+	if time.Now().After(expire.(time.Time)) {
+		verifiedPasswords.Remove(cacheKey)
+		return false
+	}
+	return true
+}
+
+// HashPassword returns a salted hash of password to be stored.
+func HashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := pbkdf2.Key([]byte(password), salt, passwordIterations, passwordKeyLen, sha256.New)
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashPrefix, passwordIterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// IsPasswordHashed tells whether a stored password is a hash.
+func IsPasswordHashed(stored string) bool {
+	return strings.HasPrefix(stored, passwordHashPrefix+"$")
+}
+
+// CheckPassword checks password against a stored password, which is a hash
+// or a plaintext password not yet migrated.
+func CheckPassword(stored, password string) bool {
+	if !IsPasswordHashed(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+	}
+	sum := sha256.Sum256([]byte(stored + "\x00" + password))
+	cacheKey := hex.EncodeToString(sum[:])
+	if passwordVerified(cacheKey) {
+		return true
+	}
+
+	parts := strings.Split(stored, "$")
+	if len(parts) != 4 {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil {
+		return false
+	}
+	key := pbkdf2.Key([]byte(password), salt, iterations, len(want), sha256.New)
+	if subtle.ConstantTimeCompare(key, want) != 1 {
+		return false
+	}
+	verifiedPasswords.Add(cacheKey, time.Now().Add(verifiedPasswordTTL))
+	return true
+}
+
+// ParseAuthHeader parses the Authorization header of a request. A Basic
+// header has the user name and password, a Bearer header has the token of
+// an api key as secret.
+func ParseAuthHeader(header string) (authType, name, secret string, err error) {
+	if header == "" {
+		return "", "", "", fmt.Errorf("auth header is empty")
+	}
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 {
+		return "", "", "", fmt.Errorf("auth header type is invalid")
+	}
+	switch parts[0] {
+	case BasicAuth:
+		decoded, err := base64.StdEncoding.DecodeString(parts[1])
+		if err != nil {
+			return "", "", "", err
+		}
+		credentials := strings.SplitN(string(decoded), ":", 2)
+		if len(credentials) != 2 {
+			return "", "", "", fmt.Errorf("auth header credentials is invalid")
+		}
+		return BasicAuth, credentials[0], credentials[1], nil
+	case BearerAuth:
+		token := strings.TrimSpace(parts[1])
+		if token == "" {
+			return "", "", "", fmt.Errorf("auth header token is empty")
+		}
+		return BearerAuth, "", token, nil
+	}
+	return "", "", "", fmt.Errorf("auth header type is invalid")
+}
+
+// APIKey lets a user authenticate with a bearer token instead of its
+// password. Only the hash of the secret of the token is stored.
+type APIKey struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	User       string  `json:"user"`
+	Hash       string  `json:"hash,omitempty"`
+	RoleName   *string `json:"role_name,omitempty"`
+	ExpireTime int64   `json:"expire_time,omitempty"`
+	CreateTime int64   `json:"create_time"`
+	// Token is only returned when the key is created
+	Token string `json:"token,omitempty"`
+}
+
+// APIKeyRequest creates an api key, the key has the privileges of the user
+// limited to the role if one is given. It never expires without expire
+// seconds.
+type APIKeyRequest struct {
+	Name          string  `json:"name"`
+	RoleName      *string `json:"role_name,omitempty"`
+	ExpireSeconds int64   `json:"expire_seconds,omitempty"`
+}
+
+func (req *APIKeyRequest) Validate() error {
+	if err := ValidateName(req.Name, APIKeyNameType, false); err != nil {
+		return err
+	}
+	if req.RoleName != nil && *req.RoleName == "" {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("api key role name is empty"))
+	}
+	if req.ExpireSeconds < 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("api key expire seconds should not be negative"))
+	}
+	return nil
+}
+
+// NewAPIKey creates an api key of user, its token is id.secret.
+func NewAPIKey(user string, req *APIKeyRequest) (*APIKey, error) {
+	id := make([]byte, apiKeyIDLen)
+	secret := make([]byte, apiKeySecretLen)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	key := &APIKey{
+		ID:         hex.EncodeToString(id),
+		Name:       req.Name,
+		User:       user,
+		RoleName:   req.RoleName,
+		CreateTime: now.Unix(),
+	}
+	if req.ExpireSeconds > 0 {
+		key.ExpireTime = now.Unix() + req.ExpireSeconds
+	}
+	key.Hash = hashAPIKeySecret(hex.EncodeToString(secret))
+	key.Token = key.ID + "." + hex.EncodeToString(secret)
+	return key, nil
+}
+
+// secrets are random, a hash without salt is enough
+func hashAPIKeySecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAPIKeyToken splits a token into the id of its key and its secret.
+func ParseAPIKeyToken(token string) (id, secret string, err error) {
+	id, secret, ok := strings.Cut(token, ".")
+	if !ok || id == "" || secret == "" {
+		return "", "", vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("api key token is invalid"))
+	}
+	return id, secret, nil
+}
+
+// Check checks the secret of a token and that the key is not expired.
+func (key *APIKey) Check(secret string) error {
+	if subtle.ConstantTimeCompare([]byte(hashAPIKeySecret(secret)), []byte(key.Hash)) != 1 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("api key token is invalid"))
+	}
+	if key.ExpireTime > 0 && time.Now().Unix() >= key.ExpireTime {
+		return vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("api key %s is expired", key.Name))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestVerifiedPasswords(t *testing.T) {
+	verifiedPasswords.Purge()
+	defer verifiedPasswords.Purge()
+
+	hash, err := HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !CheckPassword(hash, "secret") || verifiedPasswords.Len() != 1 {
+		t.Fatalf("checked password not cached, cached %d", verifiedPasswords.Len())
+	}
+	if CheckPassword(hash, "wrong") || verifiedPasswords.Len() != 1 {
+		t.Fatalf("wrong password cached, cached %d", verifiedPasswords.Len())
+	}
+
+	// expired passwords are checked again
+	key := verifiedPasswords.Keys()[0].(string)
+	verifiedPasswords.Add(key, time.Now().Add(-time.Second))
+	if passwordVerified(key) || verifiedPasswords.Contains(key) {
+		t.Fatal("expired password should not be verified")
+	}
+
+	for i := range verifiedPasswordsSize + 10 {
+		verifiedPasswords.Add(fmt.Sprint(i), time.Now().Add(time.Minute))
+	}
+	if verifiedPasswords.Len() != verifiedPasswordsSize || passwordVerified("0") || !passwordVerified(fmt.Sprint(verifiedPasswordsSize+9)) {
+		t.Fatalf("cached passwords not bounded, cached %d", verifiedPasswords.Len())
+	}
+}
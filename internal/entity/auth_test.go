@@ -0,0 +1,97 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+func TestCheckPassword(t *testing.T) {
+	hash, err := entity.HashPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !entity.IsPasswordHashed(hash) {
+		t.Fatalf("%s is not a hash", hash)
+	}
+	if other, _ := entity.HashPassword("secret"); other == hash {
+		t.Errorf("hashes of a password should have different salts")
+	}
+	for i := 0; i < 2; i++ {
+		if !entity.CheckPassword(hash, "secret") {
+			t.Errorf("CheckPassword(hash, secret) = false")
+		}
+		if entity.CheckPassword(hash, "wrong") {
+			t.Errorf("CheckPassword(hash, wrong) = true")
+		}
+	}
+
+	// plaintext passwords of older versions
+	if entity.IsPasswordHashed("secret") || !entity.CheckPassword("secret", "secret") || entity.CheckPassword("secret", "wrong") {
+		t.Errorf("plaintext password check failed")
+	}
+}
+
+func TestParseAuthHeader(t *testing.T) {
+	basic := "Basic " + base64.StdEncoding.EncodeToString([]byte("root:a:b"))
+	authType, name, secret, err := entity.ParseAuthHeader(basic)
+	if err != nil || authType != entity.BasicAuth || name != "root" || secret != "a:b" {
+		t.Errorf("ParseAuthHeader(basic) = %s, %s, %s, %v", authType, name, secret, err)
+	}
+	authType, _, secret, err = entity.ParseAuthHeader("Bearer abc.def")
+	if err != nil || authType != entity.BearerAuth || secret != "abc.def" {
+		t.Errorf("ParseAuthHeader(bearer) = %s, %s, %v", authType, secret, err)
+	}
+	for _, header := range []string{"", "Basic", "Digest abc", "Bearer  ", "Basic " + base64.StdEncoding.EncodeToString([]byte("root"))} {
+		if _, _, _, err := entity.ParseAuthHeader(header); err == nil {
+			t.Errorf("ParseAuthHeader(%q) should fail", header)
+		}
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	key, err := entity.NewAPIKey("user", &entity.APIKeyRequest{Name: "ci"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, secret, err := entity.ParseAPIKeyToken(key.Token)
+	if err != nil || id != key.ID {
+		t.Fatalf("ParseAPIKeyToken() = %s, %v", id, err)
+	}
+	if err := key.Check(secret); err != nil {
+		t.Errorf("Check() = %v", err)
+	}
+	if err := key.Check(secret + "0"); err == nil {
+		t.Errorf("Check() of a wrong secret should fail")
+	}
+	if key.ExpireTime != 0 {
+		t.Errorf("ExpireTime = %d, want 0", key.ExpireTime)
+	}
+
+	key.ExpireTime = time.Now().Unix() - 1
+	if err := key.Check(secret); err == nil {
+		t.Errorf("Check() of an expired key should fail")
+	}
+	if _, _, err := entity.ParseAPIKeyToken("abc"); err == nil {
+		t.Errorf("ParseAPIKeyToken(abc) should fail")
+	}
+	if err := (&entity.APIKeyRequest{Name: "ci", ExpireSeconds: -1}).Validate(); err == nil {
+		t.Errorf("Validate() of negative expire seconds should fail")
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// BackupManifestFile is put in the dir of a backup by master
+	BackupManifestFile = "manifest.json"
+	// BackupFilesFile is put in the dir of a partition of a backup by ps
+	// after its files are uploaded
+	BackupFilesFile = "files.json"
+
+	// minBackupInterval is the min interval of scheduled backups
+	minBackupInterval = time.Minute
+)
+
+// BackupManifest describes a backup of a space.
+type BackupManifest struct {
+	BackupID     int     `json:"backup_id"`
+	DbName       string  `json:"db_name"`
+	SpaceName    string  `json:"space_name"`
+	CreateTime   int64   `json:"create_time"`
+	SpaceVersion Version `json:"space_version"`
+	// BaseBackupID is set if the backup is incremental
+	BaseBackupID int `json:"base_backup_id,omitempty"`
+	// Partitions map the partitions of the space to the parts of the backup
+	Partitions []*BackupPartition `json:"partitions"`
+	// DocNum and Size are summed from the partitions done
+	DocNum uint64 `json:"doc_num"`
+	Size   int64  `json:"size"`
+	// Done is set if all the partitions are done
+	Done bool `json:"done"`
+}
+
+type BackupPartition struct {
+	Part        PartitionID `json:"part"`
+	PartitionID PartitionID `json:"partition_id"`
+	DocNum      uint64      `json:"doc_num,omitempty"`
+	Size        int64       `json:"size,omitempty"`
+	Done        bool        `json:"done"`
+}
+
+// BackupFiles are the files of a partition of a backup.
+type BackupFiles struct {
+	DocNum uint64        `json:"doc_num"`
+	Files  []*BackupFile `json:"files"`
+}
+
+type BackupFile struct {
+	// Path is relative to the dir of the partition, like data/000010.sst
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"`
+	// BackupID is the backup the file is uploaded to, an unchanged file
+	// of an incremental backup is kept in an earlier backup
+	BackupID int `json:"backup_id"`
+}
+
+// File returns the file of path, nil if it's not in the backup.
+func (f *BackupFiles) File(path string) *BackupFile {
+	for _, file := range f.Files {
+		if file.Path == path {
+			return file
+		}
+	}
+	return nil
+}
+
+// ExpiredBackups returns the ids of the backups to delete. The newest
+// keepLast backups created in keepDays are kept, 0 means no limit. The
+// newest backup is always kept.
+func ExpiredBackups(backups []*BackupManifest, keepLast, keepDays int, now time.Time) []int {
+	sorted := make([]*BackupManifest, len(backups))
+	copy(sorted, backups)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].BackupID > sorted[j].BackupID
+	})
+
+	expired := make([]int, 0)
+	for i, backup := range sorted {
+		if i == 0 {
+			continue
+		}
+		if keepLast > 0 && i >= keepLast {
+			expired = append(expired, backup.BackupID)
+			continue
+		}
+		if keepDays > 0 && now.Sub(time.Unix(backup.CreateTime, 0)) > time.Duration(keepDays)*24*time.Hour {
+			expired = append(expired, backup.BackupID)
+		}
+	}
+	return expired
+}
+
+// BackupSchedule creates a backup of a space periodically.
+type BackupSchedule struct {
+	DbName    string `json:"db_name"`
+	SpaceName string `json:"space_name"`
+	// Interval is a duration like 24h
+	Interval string              `json:"interval"`
+	Backup   *BackupSpaceRequest `json:"backup"`
+	// User submits the backup tasks
+	User string `json:"user,omitempty"`
+	// LastRunTime is when the last backup task is submitted
+	LastRunTime int64 `json:"last_run_time,omitempty"`
+	LastTaskID  int64 `json:"last_task_id,omitempty"`
+}
+
+func (s *BackupSchedule) Validate() error {
+	interval, err := time.ParseDuration(s.Interval)
+	if err != nil {
+		return fmt.Errorf("invalid interval %s: %v", s.Interval, err)
+	}
+	if interval < minBackupInterval {
+		return fmt.Errorf("interval %s should not be less than %s", s.Interval, minBackupInterval)
+	}
+	if s.Backup == nil {
+		return fmt.Errorf("backup of schedule is empty")
+	}
+	s.Backup.Command = "create"
+	s.Backup.BackupID = 0
+	return s.Backup.Validate()
+}
+
+// Due tells whether the next backup should be created at now.
+func (s *BackupSchedule) Due(now time.Time) bool {
+	interval, err := time.ParseDuration(s.Interval)
+	if err != nil {
+		return false
+	}
+	return now.Sub(time.Unix(s.LastRunTime, 0)) >= interval
+}
+
+// Redact hides the secrets of a schedule returned to users.
+func (s *BackupSchedule) Redact() {
+	if s.Backup != nil && s.Backup.S3Param.SecretKey != "" {
+		backup := *s.Backup
+		backup.S3Param.SecretKey = "******"
+		s.Backup = &backup
+	}
+}
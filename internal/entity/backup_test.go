@@ -0,0 +1,124 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestExpiredBackups(t *testing.T) {
+	now := time.Now()
+	day := int64(24 * time.Hour / time.Second)
+	backups := []*BackupManifest{
+		{BackupID: 3, CreateTime: now.Unix() - 1*day},
+		{BackupID: 1, CreateTime: now.Unix() - 10*day},
+		{BackupID: 4, CreateTime: now.Unix()},
+		{BackupID: 2, CreateTime: now.Unix() - 5*day},
+	}
+
+	tests := []struct {
+		name     string
+		keepLast int
+		keepDays int
+		want     []int
+	}{
+		{"keep all", 0, 0, []int{}},
+		{"keep last 2", 2, 0, []int{2, 1}},
+		{"keep 3 days", 0, 3, []int{2, 1}},
+		{"keep last 2 in 7 days", 2, 7, []int{2, 1}},
+		{"keep last 3 in 3 days", 3, 3, []int{2, 1}},
+		{"keep last 1", 1, 0, []int{3, 2, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpiredBackups(backups, tt.keepLast, tt.keepDays, now)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("ExpiredBackups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	old := []*BackupManifest{{BackupID: 1, CreateTime: now.Unix() - 10*day}}
+	if got := ExpiredBackups(old, 0, 1, now); len(got) != 0 {
+		t.Fatalf("the newest backup should be kept, got %v", got)
+	}
+}
+
+func TestBackupSchedule(t *testing.T) {
+	schedule := &BackupSchedule{Interval: "1h", Backup: &BackupSpaceRequest{Command: "restore", BackupID: 3}}
+	if err := schedule.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Backup.Command != "create" || schedule.Backup.BackupID != 0 {
+		t.Fatalf("scheduled backup should be created, got %+v", schedule.Backup)
+	}
+
+	for _, interval := range []string{"", "1d", "30s"} {
+		bad := &BackupSchedule{Interval: interval, Backup: &BackupSpaceRequest{}}
+		if err := bad.Validate(); err == nil {
+			t.Fatalf("interval %q should be invalid", interval)
+		}
+	}
+	if err := (&BackupSchedule{Interval: "1h"}).Validate(); err == nil {
+		t.Fatal("schedule without backup should be invalid")
+	}
+
+	now := time.Now()
+	schedule.LastRunTime = now.Add(-30 * time.Minute).Unix()
+	if schedule.Due(now) {
+		t.Fatal("schedule should not be due in interval")
+	}
+	schedule.LastRunTime = now.Add(-time.Hour).Unix()
+	if !schedule.Due(now) {
+		t.Fatal("schedule should be due after interval")
+	}
+
+	schedule.Backup.S3Param.SecretKey = "secret-value"
+	backup := schedule.Backup
+	schedule.Redact()
+	if schedule.Backup.S3Param.SecretKey != "******" || backup.S3Param.SecretKey != "secret-value" {
+		t.Fatalf("Redact() should mask a copy of the secret, got %+v", schedule.Backup.S3Param)
+	}
+}
+
+func TestRestoreSource(t *testing.T) {
+	req := &BackupSpaceRequest{Command: "restore", BackupID: 2}
+	want := BackupSource{ClusterName: "staging", DbName: "db", SpaceName: "space", BackupID: 2}
+	if got := req.RestoreSource("staging", "db", "space"); *got != want {
+		t.Fatalf("RestoreSource() = %+v, want %+v", got, want)
+	}
+
+	req.Source = &BackupSource{ClusterName: "prod", SpaceName: "space1", BackupID: 5}
+	want = BackupSource{ClusterName: "prod", DbName: "db", SpaceName: "space1", BackupID: 5}
+	if got := req.RestoreSource("staging", "db", "space"); *got != want {
+		t.Fatalf("RestoreSource() = %+v, want %+v", got, want)
+	}
+	if req.Source.DbName != "" {
+		t.Fatalf("RestoreSource() should not change the source, got %+v", req.Source)
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	req.Command = "create"
+	if err := req.Validate(); err == nil {
+		t.Fatal("source should only be valid for restore")
+	}
+	if err := (&BackupSpaceRequest{Command: "restore", PartitionNum: -1}).Validate(); err == nil {
+		t.Fatal("negative partition_num should be invalid")
+	}
+}
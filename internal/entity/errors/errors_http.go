@@ -124,3 +124,20 @@ func NewErrUnauthorized(err error) *ErrRequest {
 		httpCode: http.StatusUnauthorized,
 	}
 }
+
+func NewErrTooManyRequests(err error) *ErrRequest {
+	if vErr, ok := err.(*vearchpb.VearchErr); ok {
+		return &ErrRequest{
+			err:      fmt.Errorf(vErr.Error()),
+			msg:      vErr.Error(),
+			code:     int(vErr.GetError().Code),
+			httpCode: http.StatusTooManyRequests,
+		}
+	}
+	return &ErrRequest{
+		err:      err,
+		msg:      err.Error(),
+		code:     int(vearchpb.ErrorEnum_RATE_LIMITED),
+		httpCode: http.StatusTooManyRequests,
+	}
+}
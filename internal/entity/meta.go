@@ -63,6 +63,10 @@ func LockUserKey(username string) string {
 	return fmt.Sprintf("%s%s", PrefixLock, username)
 }
 
+func APIKeyKey(id string) string {
+	return fmt.Sprintf("%s%s", PrefixAPIKey, id)
+}
+
 func RoleKey(rolename string) string {
 	return fmt.Sprintf("%s%s", PrefixRole, rolename)
 }
@@ -93,6 +97,26 @@ func MasterMemberKey(ID uint64) string {
 	return fmt.Sprintf("%s%d", PrefixMasterMember, ID)
 }
 
+// AuditKey is the key of a slot of the ring of audit records
+func AuditKey(slot int64) string {
+	return fmt.Sprintf("%s%d", PrefixAudit, slot)
+}
+
+// TaskKey is the key of an admin task
+func TaskKey(id int64) string {
+	return fmt.Sprintf("%s%d", PrefixTask, id)
+}
+
+// LockTaskKey is held by the master running a task
+func LockTaskKey(id int64) string {
+	return fmt.Sprintf("%stask/%d", PrefixLock, id)
+}
+
+// BackupScheduleKey is the key of the backup schedule of a space
+func BackupScheduleKey(db, space string) string {
+	return fmt.Sprintf("%s%s/%s", PrefixBackupSchedule, db, space)
+}
+
 func LockAliasKey(aliasName string) string {
 	return fmt.Sprintf("%s%s", PrefixLock, aliasName)
 }
@@ -107,8 +131,11 @@ func SetPrefixAndSequence(cluster_id string) {
 	SpaceIdSequence = PrefixEtcdClusterID + PrefixSpaceId
 	DBIdSequence = PrefixEtcdClusterID + PrefixDBId
 	PartitionIdSequence = PrefixEtcdClusterID + PrefixPartitionId
+	AuditSequence = PrefixEtcdClusterID + PrefixAuditId
+	TaskSequence = PrefixEtcdClusterID + PrefixTaskId
 
 	PrefixUser = PrefixEtcdClusterID + PrefixUser
+	PrefixAPIKey = PrefixEtcdClusterID + PrefixAPIKey
 	PrefixLock = PrefixEtcdClusterID + PrefixLock
 	PrefixLockCluster = PrefixEtcdClusterID + PrefixLockCluster
 	PrefixServer = PrefixEtcdClusterID + PrefixServer
@@ -122,6 +149,9 @@ func SetPrefixAndSequence(cluster_id string) {
 	PrefixAlias = PrefixEtcdClusterID + PrefixAlias
 	PrefixRole = PrefixEtcdClusterID + PrefixRole
 	PrefixMasterMember = PrefixEtcdClusterID + PrefixMasterMember
+	PrefixAudit = PrefixEtcdClusterID + PrefixAudit
+	PrefixTask = PrefixEtcdClusterID + PrefixTask
+	PrefixBackupSchedule = PrefixEtcdClusterID + PrefixBackupSchedule
 }
 
 // sids sequence key for etcd
@@ -130,28 +160,36 @@ var (
 	SpaceIdSequence     = "/id/space"
 	DBIdSequence        = "/id/db"
 	PartitionIdSequence = "/id/partition"
+	AuditSequence       = "/id/audit"
+	TaskSequence        = "/id/task"
 )
 
 var (
-	Prefix             = "/"
-	PrefixUser         = "/user/"
-	PrefixLock         = "/lock/"
-	PrefixLockCluster  = "/lock/cluster"
-	PrefixServer       = "/server/"
-	PrefixSpace        = "/space/"
-	PrefixSpaceConfig  = "/space_config/"
-	PrefixPartition    = "/partition/"
-	PrefixDataBase     = "/db/"
-	PrefixDataBaseBody = "/db/body/"
-	PrefixFailServer   = "/fail/server/"
-	PrefixRouter       = "/router/"
-	PrefixNodeId       = "/id/node"
-	PrefixSpaceId      = "/id/space"
-	PrefixDBId         = "/id/db"
-	PrefixPartitionId  = "/id/partition"
-	PrefixAlias        = "/alias/"
-	PrefixRole         = "/role/"
-	PrefixMasterMember = "/member/"
+	Prefix               = "/"
+	PrefixUser           = "/user/"
+	PrefixAPIKey         = "/api_key/"
+	PrefixLock           = "/lock/"
+	PrefixLockCluster    = "/lock/cluster"
+	PrefixServer         = "/server/"
+	PrefixSpace          = "/space/"
+	PrefixSpaceConfig    = "/space_config/"
+	PrefixPartition      = "/partition/"
+	PrefixDataBase       = "/db/"
+	PrefixDataBaseBody   = "/db/body/"
+	PrefixFailServer     = "/fail/server/"
+	PrefixRouter         = "/router/"
+	PrefixNodeId         = "/id/node"
+	PrefixSpaceId        = "/id/space"
+	PrefixDBId           = "/id/db"
+	PrefixPartitionId    = "/id/partition"
+	PrefixAlias          = "/alias/"
+	PrefixRole           = "/role/"
+	PrefixMasterMember   = "/member/"
+	PrefixAuditId        = "/id/audit"
+	PrefixAudit          = "/audit/"
+	PrefixTaskId         = "/id/task"
+	PrefixTask           = "/task/"
+	PrefixBackupSchedule = "/backup_schedule/"
 )
 
 var PrefixEtcdClusterID = "/vearch/default/"
@@ -15,11 +15,17 @@
 package entity
 
 import (
+	"bytes"
 	"fmt"
+	"math"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/cubefs/cubefs/depends/tiglabs/raft"
+	"github.com/spaolacci/murmur3"
 	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 )
@@ -96,6 +102,37 @@ type PartitionInfo struct {
 	IndexNum     int               `json:"index_num"`
 	MaxDocid     int               `json:"max_docid"`
 	Error        string            `json:"error,omitempty"`
+	// BackupProgress is the last backup command of the partition
+	BackupProgress *BackupProgress `json:"backup_progress,omitempty"`
+	// WriteBatch is the batching of bulk writes on this replica
+	WriteBatch *WriteBatchStats `json:"write_batch,omitempty"`
+	// Expiry is the documents deleted by the ttl of the space, counted on
+	// the replica while it's the leader
+	Expiry *ExpiryStats `json:"expiry,omitempty"`
+}
+
+// WriteBatchStats counts the bulk writes merged into raft entries.
+type WriteBatchStats struct {
+	// Batches is the raft entries submitted
+	Batches uint64 `json:"batches"`
+	// Writes is the bulk writes merged into the batches
+	Writes uint64 `json:"writes"`
+	Docs   uint64 `json:"docs"`
+	// FullBatches is the batches submitted before the window for the size cap
+	FullBatches uint64 `json:"full_batches"`
+	// AvgWritesPerBatch and AvgWaitMicros are since the start of the replica
+	AvgWritesPerBatch float64 `json:"avg_writes_per_batch"`
+	AvgWaitMicros     float64 `json:"avg_wait_micros"`
+}
+
+// ExpiryStats counts the expired documents deleted by the expiry job.
+type ExpiryStats struct {
+	Expired uint64 `json:"expired"`
+	// Failed is the deletes of expired documents which failed, they are
+	// retried by the next check
+	Failed uint64 `json:"failed"`
+	// LastCheck is the unix seconds of the last check for expired documents
+	LastCheck int64 `json:"last_check,omitempty"`
 }
 
 type ResourceLimit struct {
@@ -125,7 +162,7 @@ type PartitionRule struct {
 	Field      string        `json:"field,omitempty"`
 	Partitions int           `json:"partitions,omitempty"`
 	Ranges     []Range       `json:"ranges,omitempty"`
-	// Lists      []List        `json:"lists,omitempty"`
+	Lists      []List        `json:"lists,omitempty"`
 	// SubPartition *PartitionRule `json:"sub_partition,omitempty"`
 }
 
@@ -134,33 +171,60 @@ func (pr *PartitionRule) Validate(space *Space, check_field bool) error {
 		if space.PartitionRule.Field == "" {
 			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partition rule field is empty"))
 		}
-		if value, exist := space.SpaceProperties[space.PartitionRule.Field]; !exist {
+		value, exist := space.SpaceProperties[space.PartitionRule.Field]
+		if !exist {
 			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s not in space fields", space.PartitionRule.Field))
-		} else {
+		}
+		switch pr.Type {
+		case RangePartition:
 			if value.FieldType != vearchpb.FieldType_DATE {
-				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("currently only partition field with %s data type are supported", vearchpb.FieldType_DATE.String()))
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s partition field should be %s data type", pr.Type, vearchpb.FieldType_DATE.String()))
+			}
+		case ListPartition:
+			if value.FieldType != vearchpb.FieldType_STRING {
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s partition field should be %s data type", pr.Type, vearchpb.FieldType_STRING.String()))
+			}
+		case HashPartition:
+			if value.FieldType == vearchpb.FieldType_VECTOR || value.FieldType == vearchpb.FieldType_STRINGARRAY {
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s partition field can't be %s data type", pr.Type, value.FieldType.String()))
 			}
 		}
 	}
-	return pr.ValidateRange(space)
+	switch pr.Type {
+	case RangePartition:
+		return pr.ValidateRange(space)
+	case ListPartition:
+		return pr.ValidateList(space)
+	case HashPartition:
+		return pr.ValidateHash(space)
+	}
+	return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition type should be %s, %s or %s", RangePartition, ListPartition, HashPartition))
+}
+
+// validatePartitions checks the number of partitions of the rule.
+func (pr *PartitionRule) validatePartitions(space *Space) error {
+	if pr.Partitions == 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("empty space partition rule of %s", pr.Type))
+	}
+	if pr.Partitions > MaxPartitions {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partitions[%d] beyond MaxPartitions[%d]",
+			pr.Partitions, MaxPartitions))
+	}
+	if pr.Partitions*space.PartitionNum > MaxTotalPartitions {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space total partitions[%d] beyond MaxTotalPartitions[%d]",
+			pr.Partitions*space.PartitionNum, MaxTotalPartitions))
+	}
+	return nil
 }
 
 func (pr *PartitionRule) ValidateRange(space *Space) error {
 	if pr.Type != RangePartition {
-		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("now only support partition type of RANGE"))
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition type %s is not RANGE", pr.Type))
 	}
 
 	space.PartitionRule.Partitions = len(space.PartitionRule.Ranges)
-	if space.PartitionRule.Partitions == 0 {
-		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("empty space partition rule of ranges"))
-	}
-	if space.PartitionRule.Partitions > MaxPartitions {
-		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partitions[%d] beyond MaxPartitions[%d]",
-			space.PartitionRule.Partitions, MaxPartitions))
-	}
-	if space.PartitionRule.Partitions*space.PartitionNum > MaxTotalPartitions {
-		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space total partitions[%d] beyond MaxTotalPartitions[%d]",
-			space.PartitionRule.Partitions*space.PartitionNum, MaxTotalPartitions))
+	if err := pr.validatePartitions(space); err != nil {
+		return err
 	}
 	// check name and value，value should be increase
 	var before = int64(-1)
@@ -197,6 +261,93 @@ func (pr *PartitionRule) ValidateRange(space *Space) error {
 	return nil
 }
 
+// ValidateList checks the lists of a LIST rule, a value can only be in one
+// list.
+func (pr *PartitionRule) ValidateList(space *Space) error {
+	if pr.Type != ListPartition {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition type %s is not LIST", pr.Type))
+	}
+	pr.Partitions = len(pr.Lists)
+	if err := pr.validatePartitions(space); err != nil {
+		return err
+	}
+	_, err := (&PartitionRule{}).ListIsSame(pr.Lists)
+	return err
+}
+
+// ValidateHash checks a HASH rule, documents are spread over Partitions
+// groups of partitions by the hash of the field value.
+func (pr *PartitionRule) ValidateHash(space *Space) error {
+	if pr.Type != HashPartition {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition type %s is not HASH", pr.Type))
+	}
+	if len(pr.Ranges) > 0 || len(pr.Lists) > 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("HASH partition rule can't have ranges or lists"))
+	}
+	if pr.Partitions < 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("HASH partition rule partitions[%d] should be greater than 0", pr.Partitions))
+	}
+	return pr.validatePartitions(space)
+}
+
+// HashPartitionName is the name of the partitions of hash value i of a HASH
+// rule.
+func HashPartitionName(i int) string {
+	return "hash_" + strconv.Itoa(i)
+}
+
+// Names returns the names of the partition groups of the rule, each group
+// has partition_num partitions.
+func (pr *PartitionRule) Names() []string {
+	names := make([]string, 0, pr.Partitions)
+	switch pr.Type {
+	case RangePartition:
+		for _, r := range pr.Ranges {
+			names = append(names, r.Name)
+		}
+	case ListPartition:
+		for _, l := range pr.Lists {
+			names = append(names, l.Name)
+		}
+	case HashPartition:
+		for i := 0; i < pr.Partitions; i++ {
+			names = append(names, HashPartitionName(i))
+		}
+	}
+	return names
+}
+
+// GroupName returns the name of the partitions holding the documents whose
+// rule field is value.
+func (pr *PartitionRule) GroupName(value []byte) (string, error) {
+	switch pr.Type {
+	case RangePartition:
+		ts := cbbytes.Bytes2Int(value)
+		for _, r := range pr.Ranges {
+			rangeValue, _ := ToTimestamp(r.Value)
+			if ts < rangeValue {
+				return r.Name, nil
+			}
+		}
+		return "", vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("can't set partition for field value %s, space ranges %v", time.Unix(ts/1e9, ts%1e9), pr.Ranges))
+	case ListPartition:
+		for _, l := range pr.Lists {
+			for _, v := range l.Values {
+				if v == string(value) {
+					return l.Name, nil
+				}
+			}
+		}
+		return "", vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("can't set partition for field value %s, space lists %v", string(value), pr.Lists))
+	case HashPartition:
+		if pr.Partitions <= 0 {
+			return "", vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("HASH partition rule has no partitions"))
+		}
+		return HashPartitionName(int(murmur3.Sum32WithSeed(value, 0) % uint32(pr.Partitions))), nil
+	}
+	return "", vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("unsupported partition type %s", pr.Type))
+}
+
 func ToTimestamp(value string) (int64, error) {
 	i, err := cast.ToInt64E(value)
 	if err != nil {
@@ -273,6 +424,41 @@ func (pr *PartitionRule) RangeIsSame(ranges []Range) (bool, error) {
 	return false, nil
 }
 
+// ListIsSame reports an error if a name or value of lists is empty, or is
+// already in the lists of the rule or in another of lists.
+func (pr *PartitionRule) ListIsSame(lists []List) (bool, error) {
+	nameMap := make(map[string]bool)
+	valueMap := make(map[string]string)
+	for _, l := range pr.Lists {
+		nameMap[l.Name] = true
+		for _, v := range l.Values {
+			valueMap[v] = l.Name
+		}
+	}
+	for _, l := range lists {
+		if l.Name == "" {
+			return true, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partition rule list name is empty, lists %v", lists))
+		}
+		if nameMap[l.Name] {
+			return true, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partition rule list name %s has same one, space lists %v, lists %v", l.Name, pr.Lists, lists))
+		}
+		nameMap[l.Name] = true
+		if len(l.Values) == 0 {
+			return true, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partition rule list %s values is empty", l.Name))
+		}
+		for _, v := range l.Values {
+			if v == "" {
+				return true, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partition rule list %s has empty value", l.Name))
+			}
+			if name, ok := valueMap[v]; ok {
+				return true, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space partition rule list value %s is in both list %s and %s", v, name, l.Name))
+			}
+			valueMap[v] = l.Name
+		}
+	}
+	return false, nil
+}
+
 type Range struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
@@ -292,3 +478,103 @@ const (
 	Create  = 0
 	Restore = 1
 )
+
+// filter operator and is_union values of vearchpb filters
+const (
+	filterOperatorAnd int32 = 0
+	filterUnionNotIn  int32 = 2
+)
+
+// MatchingNames returns the names of the partition groups which can hold
+// documents matching the filters, nil if any group can. Only the filters
+// every matching document must pass are used: the filters of an AND at the
+// top level which fix the rule field to some values, or for a RANGE rule
+// bound it.
+func (pr *PartitionRule) MatchingNames(rangeFilters []*vearchpb.RangeFilter, termFilters []*vearchpb.TermFilter, operator int32, tree *vearchpb.FilterNode) map[string]bool {
+	if tree != nil {
+		operator, rangeFilters, termFilters = tree.Operator, tree.RangeFilters, tree.TermFilters
+	}
+	if operator != filterOperatorAnd {
+		return nil
+	}
+
+	var names map[string]bool
+	intersectNames := func(matched map[string]bool) {
+		if matched == nil {
+			return
+		}
+		if names == nil {
+			names = matched
+			return
+		}
+		for name := range names {
+			if !matched[name] {
+				delete(names, name)
+			}
+		}
+	}
+	intersect := func(values [][]byte) {
+		matched := make(map[string]bool)
+		for _, v := range values {
+			if name, err := pr.GroupName(v); err == nil {
+				matched[name] = true
+			}
+		}
+		intersectNames(matched)
+	}
+	switch pr.Type {
+	case RangePartition:
+		for _, rf := range rangeFilters {
+			if rf.Field == pr.Field && rf.IsUnion != filterUnionNotIn && len(rf.LowerValue) == 8 && len(rf.UpperValue) == 8 {
+				intersectNames(pr.rangeNames(cbbytes.Bytes2Int(rf.LowerValue), rf.IncludeLower, cbbytes.Bytes2Int(rf.UpperValue), rf.IncludeUpper))
+			}
+		}
+	case ListPartition, HashPartition:
+		for _, tf := range termFilters {
+			if tf.Field == pr.Field && tf.IsUnion != filterUnionNotIn {
+				intersect(bytes.Split(tf.Value, []byte{'\001'}))
+			}
+		}
+		for _, rf := range rangeFilters {
+			if rf.Field == pr.Field && rf.IsUnion != filterUnionNotIn && rf.IncludeLower && rf.IncludeUpper && bytes.Equal(rf.LowerValue, rf.UpperValue) {
+				intersect([][]byte{rf.LowerValue})
+			}
+		}
+	}
+	return names
+}
+
+// rangeNames returns the names of the ranges overlapping the time range from
+// lower to upper in nanoseconds. A range holds the times from the value of
+// the range before it up to its own value. It is nil if a value of the
+// ranges is invalid.
+func (pr *PartitionRule) rangeNames(lower int64, includeLower bool, upper int64, includeUpper bool) map[string]bool {
+	names := make(map[string]bool)
+	if !includeLower {
+		if lower == math.MaxInt64 {
+			return names
+		}
+		lower++
+	}
+	if !includeUpper {
+		if upper == math.MinInt64 {
+			return names
+		}
+		upper--
+	}
+	if lower > upper {
+		return names
+	}
+	from := int64(math.MinInt64)
+	for _, r := range pr.Ranges {
+		to, err := ToTimestamp(r.Value)
+		if err != nil {
+			return nil
+		}
+		if lower < to && upper >= from {
+			names[r.Name] = true
+		}
+		from = to
+	}
+	return names
+}
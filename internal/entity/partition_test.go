@@ -0,0 +1,157 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+func partitionRuleSpace(rule *entity.PartitionRule) *entity.Space {
+	return &entity.Space{
+		PartitionNum:  1,
+		PartitionRule: rule,
+		SpaceProperties: map[string]*entity.SpaceProperties{
+			"tenant": {FieldType: vearchpb.FieldType_STRING},
+			"age":    {FieldType: vearchpb.FieldType_INT},
+			"tags":   {FieldType: vearchpb.FieldType_STRINGARRAY},
+		},
+	}
+}
+
+func TestPartitionRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    *entity.PartitionRule
+		wantErr bool
+	}{
+		{"list", &entity.PartitionRule{Type: entity.ListPartition, Field: "tenant", Lists: []entity.List{{Name: "a", Values: []string{"t1", "t2"}}, {Name: "b", Values: []string{"t3"}}}}, false},
+		{"list on int", &entity.PartitionRule{Type: entity.ListPartition, Field: "age", Lists: []entity.List{{Name: "a", Values: []string{"1"}}}}, true},
+		{"list without lists", &entity.PartitionRule{Type: entity.ListPartition, Field: "tenant"}, true},
+		{"list value twice", &entity.PartitionRule{Type: entity.ListPartition, Field: "tenant", Lists: []entity.List{{Name: "a", Values: []string{"t1"}}, {Name: "b", Values: []string{"t1"}}}}, true},
+		{"list name twice", &entity.PartitionRule{Type: entity.ListPartition, Field: "tenant", Lists: []entity.List{{Name: "a", Values: []string{"t1"}}, {Name: "a", Values: []string{"t2"}}}}, true},
+		{"hash", &entity.PartitionRule{Type: entity.HashPartition, Field: "age", Partitions: 4}, false},
+		{"hash without partitions", &entity.PartitionRule{Type: entity.HashPartition, Field: "age"}, true},
+		{"hash on string array", &entity.PartitionRule{Type: entity.HashPartition, Field: "tags", Partitions: 4}, true},
+		{"key", &entity.PartitionRule{Type: entity.KeyPartition, Field: "age", Partitions: 4}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			space := partitionRuleSpace(tt.rule)
+			if err := tt.rule.Validate(space, true); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPartitionRule_GroupName(t *testing.T) {
+	list := &entity.PartitionRule{Type: entity.ListPartition, Field: "tenant", Lists: []entity.List{{Name: "a", Values: []string{"t1", "t2"}}, {Name: "b", Values: []string{"t3"}}}}
+	if name, err := list.GroupName([]byte("t2")); err != nil || name != "a" {
+		t.Errorf("GroupName(t2) = %s, %v", name, err)
+	}
+	if _, err := list.GroupName([]byte("t4")); err == nil {
+		t.Errorf("GroupName(t4) should fail")
+	}
+
+	hash := &entity.PartitionRule{Type: entity.HashPartition, Field: "age", Partitions: 4}
+	names := map[string]bool{}
+	for _, name := range hash.Names() {
+		names[name] = true
+	}
+	for i := int32(0); i < 100; i++ {
+		name, err := hash.GroupName(cbbytes.Int32ToByte(i))
+		if err != nil || !names[name] {
+			t.Fatalf("GroupName(%d) = %s, %v", i, name, err)
+		}
+		if again, _ := hash.GroupName(cbbytes.Int32ToByte(i)); again != name {
+			t.Fatalf("GroupName(%d) is not stable", i)
+		}
+	}
+}
+
+func TestPartitionRule_MatchingNames(t *testing.T) {
+	rule := &entity.PartitionRule{Type: entity.ListPartition, Field: "tenant", Lists: []entity.List{{Name: "a", Values: []string{"t1", "t2"}}, {Name: "b", Values: []string{"t3"}}, {Name: "c", Values: []string{"t4"}}}}
+	in := &vearchpb.TermFilter{Field: "tenant", Value: []byte("t1\001t3"), IsUnion: 1}
+
+	names := rule.MatchingNames(nil, []*vearchpb.TermFilter{in}, 0, nil)
+	if len(names) != 2 || !names["a"] || !names["b"] {
+		t.Errorf("MatchingNames(IN t1 t3) = %v", names)
+	}
+	narrow := &vearchpb.TermFilter{Field: "tenant", Value: []byte("t3"), IsUnion: 1}
+	names = rule.MatchingNames(nil, []*vearchpb.TermFilter{in, narrow}, 0, nil)
+	if len(names) != 1 || !names["b"] {
+		t.Errorf("MatchingNames(IN t1 t3 AND IN t3) = %v", names)
+	}
+	if names := rule.MatchingNames(nil, []*vearchpb.TermFilter{in}, 1, nil); names != nil {
+		t.Errorf("MatchingNames of OR = %v, want nil", names)
+	}
+	notIn := &vearchpb.TermFilter{Field: "tenant", Value: []byte("t1"), IsUnion: 2}
+	if names := rule.MatchingNames(nil, []*vearchpb.TermFilter{notIn}, 0, nil); names != nil {
+		t.Errorf("MatchingNames(NOT IN) = %v, want nil", names)
+	}
+	tree := &vearchpb.FilterNode{Operator: 0, TermFilters: []*vearchpb.TermFilter{narrow}, Children: []*vearchpb.FilterNode{{Operator: 1}}}
+	names = rule.MatchingNames(nil, nil, 1, tree)
+	if len(names) != 1 || !names["b"] {
+		t.Errorf("MatchingNames(tree) = %v", names)
+	}
+}
+
+func TestPartitionRule_MatchingNamesRange(t *testing.T) {
+	rule := &entity.PartitionRule{Type: entity.RangePartition, Field: "date", Ranges: []entity.Range{{Name: "p0", Value: "100"}, {Name: "p1", Value: "200"}, {Name: "p2", Value: "300"}}}
+	dateRange := func(lower, upper int64, includeLower, includeUpper bool) *vearchpb.RangeFilter {
+		return &vearchpb.RangeFilter{Field: "date", LowerValue: cbbytes.Int64ToByte(lower * 1e9), UpperValue: cbbytes.Int64ToByte(upper * 1e9), IncludeLower: includeLower, IncludeUpper: includeUpper}
+	}
+
+	tests := []struct {
+		name    string
+		filters []*vearchpb.RangeFilter
+		want    []string
+	}{
+		{"inside one range", []*vearchpb.RangeFilter{dateRange(120, 180, true, true)}, []string{"p1"}},
+		{"across ranges", []*vearchpb.RangeFilter{dateRange(50, 250, true, true)}, []string{"p0", "p1", "p2"}},
+		{"upper at range value", []*vearchpb.RangeFilter{dateRange(50, 100, true, false)}, []string{"p0"}},
+		{"lower at range value", []*vearchpb.RangeFilter{dateRange(100, 150, true, true)}, []string{"p1"}},
+		{"equal", []*vearchpb.RangeFilter{dateRange(200, 200, true, true)}, []string{"p2"}},
+		{"after last range", []*vearchpb.RangeFilter{dateRange(400, 500, true, true)}, []string{}},
+		{"two filters", []*vearchpb.RangeFilter{dateRange(0, 250, true, true), dateRange(150, 400, true, true)}, []string{"p1", "p2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := rule.MatchingNames(tt.filters, nil, 0, nil)
+			if names == nil || len(names) != len(tt.want) {
+				t.Fatalf("MatchingNames() = %v, want %v", names, tt.want)
+			}
+			for _, name := range tt.want {
+				if !names[name] {
+					t.Fatalf("MatchingNames() = %v, want %v", names, tt.want)
+				}
+			}
+		})
+	}
+
+	notIn := dateRange(120, 120, true, true)
+	notIn.IsUnion = 2
+	if names := rule.MatchingNames([]*vearchpb.RangeFilter{notIn}, nil, 0, nil); names != nil {
+		t.Errorf("MatchingNames(NOT IN) = %v, want nil", names)
+	}
+	other := &vearchpb.RangeFilter{Field: "age", LowerValue: cbbytes.Int32ToByte(1), UpperValue: cbbytes.Int32ToByte(2), IncludeLower: true, IncludeUpper: true}
+	if names := rule.MatchingNames([]*vearchpb.RangeFilter{other}, nil, 0, nil); names != nil {
+		t.Errorf("MatchingNames(other field) = %v, want nil", names)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxChangesLimit is the most changes of a partition read at a time
+	MaxChangesLimit = 10000
+	// MaxChangesWaitMs is the longest a read waits for changes
+	MaxChangesWaitMs = 60000
+)
+
+// ChangesRequest reads the upserts and deletes of a space. Positions is
+// the raft index of the last change read of each partition, partitions not
+// in it are read from their oldest change retained.
+type ChangesRequest struct {
+	DbName    string            `json:"db_name"`
+	SpaceName string            `json:"space_name"`
+	Positions map[uint32]uint64 `json:"positions,omitempty"`
+	// Limit is the changes of each partition read at a time
+	Limit int32 `json:"limit,omitempty"`
+	// WaitMs is how long to wait for changes if there are none
+	WaitMs int64 `json:"wait_ms,omitempty"`
+}
+
+func (r *ChangesRequest) Validate() error {
+	if r.DbName == "" || r.SpaceName == "" {
+		return fmt.Errorf("db_name and space_name are required")
+	}
+	if r.Limit < 0 || r.Limit > MaxChangesLimit {
+		return fmt.Errorf("limit %d should be in [0, %d]", r.Limit, MaxChangesLimit)
+	}
+	if r.WaitMs < 0 || r.WaitMs > MaxChangesWaitMs {
+		return fmt.Errorf("wait_ms %d should be in [0, %d]", r.WaitMs, MaxChangesWaitMs)
+	}
+	return nil
+}
+
+// FormatPositions formats positions as pid:index,..., the id of the server
+// sent events of changes.
+func FormatPositions(positions map[uint32]uint64) string {
+	pids := make([]uint32, 0, len(positions))
+	for pid := range positions {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+	parts := make([]string, len(pids))
+	for i, pid := range pids {
+		parts[i] = fmt.Sprintf("%d:%d", pid, positions[pid])
+	}
+	return strings.Join(parts, ",")
+}
+
+// ParsePositions parses the positions of FormatPositions.
+func ParsePositions(s string) (map[uint32]uint64, error) {
+	positions := make(map[uint32]uint64)
+	if s == "" {
+		return positions, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		pid, index, ok := strings.Cut(part, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid positions [%s]", s)
+		}
+		p, err := strconv.ParseUint(pid, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid positions [%s]", s)
+		}
+		i, err := strconv.ParseUint(index, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid positions [%s]", s)
+		}
+		positions[uint32(p)] = i
+	}
+	return positions, nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChangesRequestValidate(t *testing.T) {
+	req := &ChangesRequest{DbName: "ts_db", SpaceName: "ts_space"}
+	if err := req.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	req.Limit = MaxChangesLimit + 1
+	if err := req.Validate(); err == nil {
+		t.Fatal("limit should be limited")
+	}
+	req.Limit = 0
+	req.WaitMs = -1
+	if err := req.Validate(); err == nil {
+		t.Fatal("wait_ms should not be negative")
+	}
+	req.WaitMs = 0
+	req.SpaceName = ""
+	if err := req.Validate(); err == nil {
+		t.Fatal("space_name should be required")
+	}
+}
+
+func TestPositions(t *testing.T) {
+	positions := map[uint32]uint64{3: 0, 1: 42}
+	s := FormatPositions(positions)
+	if s != "1:42,3:0" {
+		t.Fatalf("positions = %s", s)
+	}
+	parsed, err := ParsePositions(s)
+	if err != nil || !reflect.DeepEqual(parsed, positions) {
+		t.Fatalf("parsed = %v, err = %v", parsed, err)
+	}
+	if parsed, err := ParsePositions(""); err != nil || len(parsed) != 0 {
+		t.Fatalf("parsed = %v, err = %v", parsed, err)
+	}
+	for _, s := range []string{"1", "a:1", "1:-1", "1:2,"} {
+		if _, err := ParsePositions(s); err == nil {
+			t.Fatalf("positions [%s] should be invalid", s)
+		}
+	}
+}
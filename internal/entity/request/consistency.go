@@ -0,0 +1,63 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// Consistency levels of reads.
+const (
+	// Eventual reads any replica as it is
+	Eventual = "eventual"
+	// BoundedStaleness reads a replica lagging at most max_staleness
+	// entries behind the commit of its leader
+	BoundedStaleness = "bounded_staleness"
+	// ReadYourWrites reads a replica which has applied the writes of a
+	// write token
+	ReadYourWrites = "read_your_writes"
+	// Linearizable reads the leader after a raft read index
+	Linearizable = "linearizable"
+)
+
+// EncodeWriteToken encodes the raft index of each partition written, it's
+// returned by writes and given to reads of read_your_writes.
+func EncodeWriteToken(indexes map[uint32]uint64) string {
+	if len(indexes) == 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(FormatPositions(indexes)))
+}
+
+// DecodeWriteToken decodes the tokens of EncodeWriteToken, the largest
+// index of each partition is kept if there are many tokens.
+func DecodeWriteToken(tokens ...string) (map[uint32]uint64, error) {
+	indexes := make(map[uint32]uint64)
+	for _, token := range tokens {
+		data, err := base64.RawURLEncoding.DecodeString(token)
+		if err != nil || len(data) == 0 {
+			return nil, fmt.Errorf("invalid write_token [%s]", token)
+		}
+		positions, err := ParsePositions(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("invalid write_token [%s]", token)
+		}
+		for pid, index := range positions {
+			indexes[pid] = max(indexes[pid], index)
+		}
+	}
+	return indexes, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func TestWriteToken(t *testing.T) {
+	if token := EncodeWriteToken(nil); token != "" {
+		t.Fatalf("token = %s", token)
+	}
+
+	token := EncodeWriteToken(map[uint32]uint64{2: 15, 1: 7})
+	if token != EncodeWriteToken(map[uint32]uint64{1: 7, 2: 15}) {
+		t.Fatal("token should not depend on the map order")
+	}
+	indexes, err := DecodeWriteToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indexes, map[uint32]uint64{1: 7, 2: 15}) {
+		t.Fatalf("indexes = %v", indexes)
+	}
+
+	// the largest index of each partition is kept
+	indexes, err = DecodeWriteToken(token, EncodeWriteToken(map[uint32]uint64{1: 3, 2: 20, 3: 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(indexes, map[uint32]uint64{1: 7, 2: 20, 3: 1}) {
+		t.Fatalf("indexes = %v", indexes)
+	}
+
+	for _, token := range []string{"", "!", base64.RawURLEncoding.EncodeToString([]byte("1")), base64.RawURLEncoding.EncodeToString([]byte("a:1")), base64.RawURLEncoding.EncodeToString([]byte("1:-1"))} {
+		if _, err := DecodeWriteToken(token); err == nil {
+			t.Fatalf("token [%s] should be invalid", token)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+// MaxImportBatchSize is the most documents an import upserts at a time.
+const MaxImportBatchSize = 1000
+
+// ImportRequest imports files into a space. Files are uploaded as the
+// "file" parts of a multipart form, with the other params in the query,
+// or read from the storage of Source.
+type ImportRequest struct {
+	DbName    string `json:"db_name"`
+	SpaceName string `json:"space_name"`
+	// Format of the files, detected by their names if empty
+	Format string `json:"format,omitempty"`
+	// Mapping renames the columns of the files to the fields of the space,
+	// only the columns mapped and _id are imported if it's set
+	Mapping   map[string]string `json:"mapping,omitempty"`
+	BatchSize int               `json:"batch_size,omitempty"`
+	// Source is the storage of the files, only its storage, s3_param and
+	// local_param are used
+	Source *entity.BackupSpaceRequest `json:"source,omitempty"`
+	// Keys of the files in the storage, all files under a key ending
+	// with "/" are imported, such as the dir of an export
+	Keys []string `json:"keys,omitempty"`
+}
+
+func (r *ImportRequest) Validate(upload bool) error {
+	if r.DbName == "" || r.SpaceName == "" {
+		return fmt.Errorf("db_name and space_name are required")
+	}
+	if r.BatchSize < 0 || r.BatchSize > MaxImportBatchSize {
+		return fmt.Errorf("batch_size %d should be in [0, %d]", r.BatchSize, MaxImportBatchSize)
+	}
+	if upload {
+		return nil
+	}
+	if r.Source == nil || len(r.Keys) == 0 {
+		return fmt.Errorf("source and keys are required if no files are uploaded")
+	}
+	if r.Source.Command != "" {
+		return fmt.Errorf("source.command should be empty")
+	}
+	for _, key := range r.Keys {
+		if path.IsAbs(key) || filepath.IsAbs(key) || slices.Contains(strings.Split(filepath.ToSlash(key), "/"), "..") {
+			return fmt.Errorf("key [%s] should be relative to the storage and not contain ..", key)
+		}
+	}
+	return r.Source.Validate()
+}
+
+// CheckSource checks the storage of the files is allowed by the router, a
+// local path should be under root and an s3 endpoint one of endpoints. An
+// empty root or endpoints disallows the storage.
+func (r *ImportRequest) CheckSource(root string, endpoints []string) error {
+	switch r.Source.Storage {
+	case entity.BackupStorageLocal:
+		if root == "" {
+			return fmt.Errorf("import from local storage is disabled, set import_root of router to allow it")
+		}
+		rel, err := filepath.Rel(filepath.Clean(root), filepath.Clean(r.Source.LocalParam.Path))
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("local_param.path [%s] should be under import_root of router", r.Source.LocalParam.Path)
+		}
+	default:
+		if !slices.Contains(endpoints, r.Source.S3Param.EndPoint) {
+			return fmt.Errorf("s3_param.endpoint [%s] should be one of import_s3_endpoints of router", r.Source.S3Param.EndPoint)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+func TestImportRequestValidate(t *testing.T) {
+	req := &ImportRequest{DbName: "ts_db", SpaceName: "ts_space"}
+	if err := req.Validate(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.Validate(false); err == nil {
+		t.Fatal("source and keys should be required without files uploaded")
+	}
+
+	req.Source = &entity.BackupSpaceRequest{Storage: entity.BackupStorageLocal}
+	req.Source.LocalParam.Path = "/backup"
+	req.Keys = []string{"export/"}
+	if err := req.Validate(false); err != nil {
+		t.Fatal(err)
+	}
+	req.Source.LocalParam.Path = "backup"
+	if err := req.Validate(false); err == nil {
+		t.Fatal("relative local path should be rejected")
+	}
+
+	req.BatchSize = MaxImportBatchSize + 1
+	if err := req.Validate(true); err == nil {
+		t.Fatal("batch_size should be limited")
+	}
+	req.BatchSize = 0
+	req.SpaceName = ""
+	if err := req.Validate(true); err == nil {
+		t.Fatal("space_name should be required")
+	}
+}
+
+func TestImportRequestSource(t *testing.T) {
+	req := &ImportRequest{DbName: "ts_db", SpaceName: "ts_space", Keys: []string{"export/"}}
+	req.Source = &entity.BackupSpaceRequest{Storage: entity.BackupStorageLocal}
+	req.Source.LocalParam.Path = "/data/import/db"
+
+	for _, key := range []string{"/etc/passwd", "../export/", "export/../../x"} {
+		req.Keys = []string{key}
+		if err := req.Validate(false); err == nil {
+			t.Fatalf("key %s out of the storage should be rejected", key)
+		}
+	}
+	req.Keys = []string{"export/..x/"}
+	if err := req.Validate(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := req.CheckSource("/data/import", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := req.CheckSource("", nil); err == nil {
+		t.Fatal("local storage should be disabled without import root")
+	}
+	for _, path := range []string{"/data", "/data/importx", "/data/import/../x"} {
+		req.Source.LocalParam.Path = path
+		if err := req.CheckSource("/data/import", nil); err == nil {
+			t.Fatalf("path %s out of the import root should be rejected", path)
+		}
+	}
+
+	req.Source = &entity.BackupSpaceRequest{Storage: entity.BackupStorageS3}
+	req.Source.S3Param.EndPoint = "s3.local:9000"
+	if err := req.CheckSource("/data/import", []string{"s3.local:9000"}); err != nil {
+		t.Fatal(err)
+	}
+	req.Source.S3Param.EndPoint = "169.254.169.254"
+	if err := req.CheckSource("/data/import", []string{"s3.local:9000"}); err == nil {
+		t.Fatal("endpoint not configured should be rejected")
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+const (
+	WeightedRankerType = "WeightedRanker"
+	RRFRankerType      = "RRF"
+	DefaultRRFK        = 60
+)
+
+// Retriever is a vector or text query of a search whose score of an item is
+// kept in ResultItem.Scores under Key.
+type Retriever struct {
+	Key string
+	// Ascending is true if a smaller score is better, like a L2 distance
+	Ascending bool
+}
+
+// VectorRetriever keys the score of the i-th vector of a fused search.
+func VectorRetriever(i int) string {
+	return "_vector_" + strconv.Itoa(i)
+}
+
+// TextRetriever keys the BM25 score of the i-th text query of a fused search.
+func TextRetriever(i int) string {
+	return "_match_" + strconv.Itoa(i)
+}
+
+// SearchRetrievers returns the retrievers of req in the order of the ranker
+// params, vectors first, desc is the order of vector scores.
+func SearchRetrievers(req *vearchpb.SearchRequest, desc bool) []Retriever {
+	retrievers := make([]Retriever, 0, len(req.VecFields)+len(req.TextQueries))
+	for i := range req.VecFields {
+		retrievers = append(retrievers, Retriever{Key: VectorRetriever(i), Ascending: !desc})
+	}
+	for i := range req.TextQueries {
+		retrievers = append(retrievers, Retriever{Key: TextRetriever(i)})
+	}
+	return retrievers
+}
+
+// Fusion is a strategy combining the scores of the retrievers of a search.
+type Fusion interface {
+	// Check validates the params of a ranker for retrieverNum retrievers.
+	Check(params json.RawMessage, retrieverNum int) error
+	// Fuse sets the score of every item to the fusion of its retriever
+	// scores, items miss the scores of retrievers not finding them.
+	Fuse(params json.RawMessage, items []*vearchpb.ResultItem, retrievers []Retriever) error
+}
+
+var (
+	rankersMu sync.RWMutex
+	rankers   = make(map[string]Fusion)
+)
+
+// RegisterRanker makes a ranker available by its type name.
+func RegisterRanker(name string, fusion Fusion) {
+	rankersMu.Lock()
+	defer rankersMu.Unlock()
+	if _, ok := rankers[name]; ok {
+		panic("ranker " + name + " registered twice")
+	}
+	rankers[name] = fusion
+}
+
+// GetRanker returns the ranker registered as name.
+func GetRanker(name string) (Fusion, bool) {
+	rankersMu.RLock()
+	defer rankersMu.RUnlock()
+	fusion, ok := rankers[name]
+	return fusion, ok
+}
+
+func rankerNames() []string {
+	rankersMu.RLock()
+	defer rankersMu.RUnlock()
+	names := make([]string, 0, len(rankers))
+	for name := range rankers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterRanker(WeightedRankerType, weightedRanker{})
+	RegisterRanker(RRFRankerType, rrfRanker{})
+}
+
+// ParseRanker unmarshals a ranker of a registered type, data of an empty
+// ranker gives nil.
+func ParseRanker(data []byte) (*Ranker, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	ranker := &Ranker{}
+	if err := json.Unmarshal(data, ranker); err != nil {
+		return nil, fmt.Errorf("ranker param convert json %s err: %v", string(data), err)
+	}
+	if _, ok := GetRanker(ranker.Type); !ok {
+		return nil, fmt.Errorf("unsupport ranker type: %s, now only support %s", ranker.Type, strings.Join(rankerNames(), ", "))
+	}
+	return ranker, nil
+}
+
+// Check validates the params of r for retrieverNum retrievers.
+func (r *Ranker) Check(retrieverNum int) error {
+	fusion, _ := GetRanker(r.Type)
+	return fusion.Check(r.Params, retrieverNum)
+}
+
+// EngineRanked reports whether the engine ranks the results of req itself,
+// it only combines the vectors of a search by weights. Otherwise partitions
+// return the score of every retriever and the router fuses them.
+func EngineRanked(req *vearchpb.SearchRequest) bool {
+	if len(req.TextQueries) > 0 {
+		return false
+	}
+	if len(req.VecFields) <= 1 || req.Ranker == "" {
+		return true
+	}
+	ranker, err := ParseRanker([]byte(req.Ranker))
+	return err != nil || ranker == nil || ranker.Type == WeightedRankerType
+}
+
+// FuseScores fuses the retriever scores of items by the ranker of req, RRF if
+// req has none, and sorts items by the fused score.
+func FuseScores(items []*vearchpb.ResultItem, req *vearchpb.SearchRequest, desc bool) error {
+	ranker, err := ParseRanker([]byte(req.Ranker))
+	if err != nil {
+		return err
+	}
+	if ranker == nil {
+		ranker = &Ranker{Type: RRFRankerType}
+	}
+	fusion, _ := GetRanker(ranker.Type)
+	if err := fusion.Fuse(ranker.Params, items, SearchRetrievers(req, desc)); err != nil {
+		return err
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Score > items[j].Score
+	})
+	return nil
+}
+
+// weightedRanker sums the retriever scores by weights, a score of an
+// ascending retriever is converted to 1/(1+score) first.
+type weightedRanker struct{}
+
+func (weightedRanker) weights(params json.RawMessage, retrieverNum int) ([]float64, error) {
+	weights := make([]float64, 0)
+	if err := json.Unmarshal(params, &weights); err != nil {
+		return nil, fmt.Errorf("%s params should be an array of weights, err: %v", WeightedRankerType, err)
+	}
+	if len(weights) != retrieverNum {
+		return nil, fmt.Errorf("%s params length:[%d] should be the number of vectors and match conditions:[%d]", WeightedRankerType, len(weights), retrieverNum)
+	}
+	return weights, nil
+}
+
+func (w weightedRanker) Check(params json.RawMessage, retrieverNum int) error {
+	_, err := w.weights(params, retrieverNum)
+	return err
+}
+
+func (w weightedRanker) Fuse(params json.RawMessage, items []*vearchpb.ResultItem, retrievers []Retriever) error {
+	weights, err := w.weights(params, len(retrievers))
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		score := 0.0
+		for i, retriever := range retrievers {
+			s, ok := item.Scores[retriever.Key]
+			if !ok {
+				continue
+			}
+			if retriever.Ascending {
+				s = 1 / (1 + s)
+			}
+			score += weights[i] * s
+		}
+		item.Score = score
+	}
+	return nil
+}
+
+// rrfRanker is reciprocal rank fusion, an item scores the sum of
+// 1/(k+rank) of the retrievers finding it, rank starts from 1.
+type rrfRanker struct{}
+
+func (rrfRanker) k(params json.RawMessage) (float64, error) {
+	p := struct {
+		K *float64 `json:"k"`
+	}{}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return 0, fmt.Errorf("%s params should be like {\"k\": %d}, err: %v", RRFRankerType, DefaultRRFK, err)
+		}
+	}
+	if p.K == nil {
+		return DefaultRRFK, nil
+	}
+	if *p.K <= 0 {
+		return 0, fmt.Errorf("%s params k:%v should be positive", RRFRankerType, *p.K)
+	}
+	return *p.K, nil
+}
+
+func (r rrfRanker) Check(params json.RawMessage, retrieverNum int) error {
+	_, err := r.k(params)
+	return err
+}
+
+func (r rrfRanker) Fuse(params json.RawMessage, items []*vearchpb.ResultItem, retrievers []Retriever) error {
+	k, err := r.k(params)
+	if err != nil {
+		return err
+	}
+	scores := make([]float64, len(items))
+	for _, retriever := range retrievers {
+		ranked := make([]int, 0, len(items))
+		for i, item := range items {
+			if _, ok := item.Scores[retriever.Key]; ok {
+				ranked = append(ranked, i)
+			}
+		}
+		key, ascending := retriever.Key, retriever.Ascending
+		sort.SliceStable(ranked, func(a, b int) bool {
+			sa, sb := items[ranked[a]].Scores[key], items[ranked[b]].Scores[key]
+			if ascending {
+				return sa < sb
+			}
+			return sa > sb
+		})
+		for rank, i := range ranked {
+			scores[i] += 1 / (k + float64(rank+1))
+		}
+	}
+	for i, item := range items {
+		item.Score = scores[i]
+	}
+	return nil
+}
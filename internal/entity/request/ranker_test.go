@@ -0,0 +1,122 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+func TestParseRanker(t *testing.T) {
+	if r, err := ParseRanker(nil); r != nil || err != nil {
+		t.Fatalf("empty ranker should be nil, got %v %v", r, err)
+	}
+	if _, err := ParseRanker([]byte(`{"type": "Unknown"}`)); err == nil {
+		t.Fatal("unregistered ranker should fail")
+	}
+
+	r, err := ParseRanker([]byte(`{"type": "WeightedRanker", "params": [0.5, 0.5]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Check(2); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Check(3); err == nil {
+		t.Fatal("weights should match the number of retrievers")
+	}
+
+	r, _ = ParseRanker([]byte(`{"type": "RRF", "params": {"k": -1}}`))
+	if err := r.Check(2); err == nil {
+		t.Fatal("RRF k should be positive")
+	}
+}
+
+func TestEngineRanked(t *testing.T) {
+	vecFields := []*vearchpb.VectorQuery{{Name: "a"}, {Name: "b"}}
+	cases := []struct {
+		req    *vearchpb.SearchRequest
+		expect bool
+	}{
+		{&vearchpb.SearchRequest{VecFields: vecFields[:1], Ranker: `{"type": "RRF"}`}, true},
+		{&vearchpb.SearchRequest{VecFields: vecFields}, true},
+		{&vearchpb.SearchRequest{VecFields: vecFields, Ranker: `{"type": "WeightedRanker", "params": [1, 1]}`}, true},
+		{&vearchpb.SearchRequest{VecFields: vecFields, Ranker: `{"type": "RRF"}`}, false},
+		{&vearchpb.SearchRequest{TextQueries: []*vearchpb.TextQuery{{Field: "t", Query: "q"}}}, false},
+	}
+	for i, c := range cases {
+		if got := EngineRanked(c.req); got != c.expect {
+			t.Fatalf("case %d expect %v, got %v", i, c.expect, got)
+		}
+	}
+}
+
+func fusedItems() []*vearchpb.ResultItem {
+	return []*vearchpb.ResultItem{
+		{PKey: "a", Scores: map[string]float64{VectorRetriever(0): 0.1, VectorRetriever(1): 3}},
+		{PKey: "b", Scores: map[string]float64{VectorRetriever(0): 0.2}},
+		{PKey: "c", Scores: map[string]float64{VectorRetriever(1): 5}},
+	}
+}
+
+func TestFuseScoresRRF(t *testing.T) {
+	req := &vearchpb.SearchRequest{
+		VecFields: []*vearchpb.VectorQuery{{Name: "v0"}, {Name: "v1"}},
+		Ranker:    `{"type": "RRF", "params": {"k": 1}}`,
+	}
+	items := fusedItems()
+	// ascending scores, a smaller distance ranks first
+	if err := FuseScores(items, req, false); err != nil {
+		t.Fatal(err)
+	}
+	// a: 1/(1+1) + 1/(1+1), b: 1/(1+2), c: 1/(1+2)
+	expect := []struct {
+		key   string
+		score float64
+	}{{"a", 1}, {"b", 1.0 / 3}, {"c", 1.0 / 3}}
+	for i, e := range expect {
+		if items[i].PKey != e.key || math.Abs(items[i].Score-e.score) > 1e-9 {
+			t.Fatalf("item %d expect %s:%v, got %s:%v", i, e.key, e.score, items[i].PKey, items[i].Score)
+		}
+	}
+}
+
+func TestFuseScoresWeighted(t *testing.T) {
+	params, _ := json.Marshal(map[string]any{"type": WeightedRankerType, "params": []float64{1, 0.5}})
+	req := &vearchpb.SearchRequest{
+		VecFields:   []*vearchpb.VectorQuery{{Name: "v0"}},
+		TextQueries: []*vearchpb.TextQuery{{Field: "t", Query: "q"}},
+		Ranker:      string(params),
+	}
+	items := []*vearchpb.ResultItem{
+		{PKey: "a", Scores: map[string]float64{VectorRetriever(0): 0.9}},
+		{PKey: "b", Scores: map[string]float64{VectorRetriever(0): 0.5, TextRetriever(0): 2}},
+	}
+	if err := FuseScores(items, req, true); err != nil {
+		t.Fatal(err)
+	}
+	if items[0].PKey != "b" || math.Abs(items[0].Score-1.5) > 1e-9 || math.Abs(items[1].Score-0.9) > 1e-9 {
+		t.Fatalf("unexpected fused items %v", items)
+	}
+
+	// weights should match the retrievers
+	req.TextQueries = nil
+	if err := FuseScores(items, req, true); err == nil {
+		t.Fatal("fuse should fail with wrong number of weights")
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+// MaxScrollKeepAlive is the longest keep alive a scroll can ask for.
+const MaxScrollKeepAlive = 24 * time.Hour
+
+// ScrollDocumentRequest starts a scroll of a space when ScrollID is empty,
+// otherwise it reads the next page of the scroll of ScrollID.
+type ScrollDocumentRequest struct {
+	DbName      string   `json:"db_name,omitempty"`
+	SpaceName   string   `json:"space_name,omitempty"`
+	Filters     *Filter  `json:"filters,omitempty"`
+	Fields      []string `json:"fields,omitempty"`
+	VectorValue bool     `json:"vector_value"`
+	Limit       int32    `json:"limit"`
+	KeepAlive   string   `json:"keep_alive,omitempty"`
+	ScrollID    string   `json:"scroll_id,omitempty"`
+	LoadBalance string   `json:"load_balance"`
+}
+
+// ScrollPosition is the last docid read from a partition. Docids are the
+// same on all replicas, so any replica can continue from it.
+type ScrollPosition struct {
+	PartitionID entity.PartitionID `json:"p"`
+	Docid       int32              `json:"d"`
+	Done        bool               `json:"e,omitempty"`
+}
+
+// ScrollCursor is the state of a scroll. It is encoded into the scroll id
+// returned to the client, so the scroll can be continued by any router.
+type ScrollCursor struct {
+	DbName      string            `json:"db"`
+	SpaceName   string            `json:"space"`
+	SpaceID     entity.SpaceID    `json:"space_id"`
+	Filters     *Filter           `json:"filters,omitempty"`
+	Fields      []string          `json:"fields,omitempty"`
+	VectorValue bool              `json:"vector_value,omitempty"`
+	LoadBalance string            `json:"load_balance,omitempty"`
+	Positions   []*ScrollPosition `json:"positions"`
+	ExpireAt    int64             `json:"expire_at"`
+}
+
+// NewScrollCursor starts a scroll of space, partitions are read one after
+// another in the order of their ids.
+func NewScrollCursor(req *ScrollDocumentRequest, space *entity.Space) *ScrollCursor {
+	cursor := &ScrollCursor{
+		DbName:      req.DbName,
+		SpaceName:   space.Name,
+		SpaceID:     space.Id,
+		Filters:     req.Filters,
+		Fields:      req.Fields,
+		VectorValue: req.VectorValue,
+		LoadBalance: req.LoadBalance,
+		Positions:   make([]*ScrollPosition, 0, len(space.Partitions)),
+	}
+	for _, partition := range space.Partitions {
+		cursor.Positions = append(cursor.Positions, &ScrollPosition{PartitionID: partition.Id, Docid: -1})
+	}
+	sort.Slice(cursor.Positions, func(i, j int) bool {
+		return cursor.Positions[i].PartitionID < cursor.Positions[j].PartitionID
+	})
+	return cursor
+}
+
+// Done reports whether all partitions are read.
+func (c *ScrollCursor) Done() bool {
+	for _, pos := range c.Positions {
+		if !pos.Done {
+			return false
+		}
+	}
+	return true
+}
+
+// Encode returns the scroll id of c.
+func (c *ScrollCursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeScrollCursor returns the cursor of scrollID, it fails if the scroll
+// expired before now.
+func DecodeScrollCursor(scrollID string, now time.Time) (*ScrollCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(scrollID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scroll_id: %v", err)
+	}
+	cursor := &ScrollCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("invalid scroll_id: %v", err)
+	}
+	if cursor.ExpireAt < now.Unix() {
+		return nil, fmt.Errorf("scroll_id expired at %s", time.Unix(cursor.ExpireAt, 0).Format(time.RFC3339))
+	}
+	return cursor, nil
+}
+
+// ParseKeepAlive parses the keep alive of a scroll such as "5m", an empty
+// keepAlive is defaultKeepAlive.
+func ParseKeepAlive(keepAlive string, defaultKeepAlive time.Duration) (time.Duration, error) {
+	if keepAlive == "" {
+		return defaultKeepAlive, nil
+	}
+	d, err := time.ParseDuration(keepAlive)
+	if err != nil {
+		return 0, fmt.Errorf("invalid keep_alive [%s]: %v", keepAlive, err)
+	}
+	if d <= 0 || d > MaxScrollKeepAlive {
+		return 0, fmt.Errorf("keep_alive [%s] should be greater than 0 and at most %s", keepAlive, MaxScrollKeepAlive)
+	}
+	return d, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package request
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+func TestScrollCursor(t *testing.T) {
+	space := &entity.Space{
+		Id:         7,
+		Name:       "ts_space",
+		Partitions: []*entity.Partition{{Id: 3}, {Id: 1}},
+	}
+	req := &ScrollDocumentRequest{
+		DbName:  "ts_db",
+		Filters: &Filter{Operator: "AND", Conditions: []Condition{{Field: "f", Operator: ">", Value: []byte("1")}}},
+		Fields:  []string{"f"},
+	}
+	cursor := NewScrollCursor(req, space)
+	if len(cursor.Positions) != 2 || cursor.Positions[0].PartitionID != 1 || cursor.Positions[1].Docid != -1 {
+		t.Fatalf("unexpected positions %+v", cursor.Positions)
+	}
+	if cursor.Done() {
+		t.Fatal("new cursor should not be done")
+	}
+
+	now := time.Now()
+	cursor.ExpireAt = now.Add(time.Minute).Unix()
+	cursor.Positions[0].Docid = 99
+	cursor.Positions[0].Done = true
+	id, err := cursor.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := DecodeScrollCursor(id, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.SpaceID != 7 || decoded.DbName != "ts_db" || decoded.Positions[0].Docid != 99 || !decoded.Positions[0].Done {
+		t.Fatalf("unexpected cursor %+v", decoded)
+	}
+	if decoded.Filters == nil || string(decoded.Filters.Conditions[0].Value) != "1" {
+		t.Fatalf("filters not kept: %+v", decoded.Filters)
+	}
+
+	if _, err := DecodeScrollCursor(id, now.Add(2*time.Minute)); err == nil {
+		t.Fatal("expired cursor should fail")
+	}
+	if _, err := DecodeScrollCursor("not a cursor", now); err == nil {
+		t.Fatal("invalid cursor should fail")
+	}
+}
+
+func TestParseKeepAlive(t *testing.T) {
+	if d, err := ParseKeepAlive("", time.Minute); err != nil || d != time.Minute {
+		t.Fatalf("default keep alive got %v %v", d, err)
+	}
+	if d, err := ParseKeepAlive("30s", time.Minute); err != nil || d != 30*time.Second {
+		t.Fatalf("keep alive got %v %v", d, err)
+	}
+	for _, keepAlive := range []string{"abc", "-1m", "48h"} {
+		if _, err := ParseKeepAlive(keepAlive, time.Minute); err == nil {
+			t.Fatalf("keep alive %s should fail", keepAlive)
+		}
+	}
+}
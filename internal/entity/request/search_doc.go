@@ -42,6 +42,23 @@ type DocumentRequest struct {
 	Partitions *[]entity.PartitionID `json:"partitions,omitempty"`
 }
 
+// UpdateDocument is a partial update of the document of ID, each operation
+// maps field names to values given like the documents of upsert.
+type UpdateDocument struct {
+	ID        string          `json:"_id"`
+	Set       json.RawMessage `json:"set,omitempty"`
+	Increment json.RawMessage `json:"increment,omitempty"`
+	Append    json.RawMessage `json:"append,omitempty"`
+	Remove    json.RawMessage `json:"remove,omitempty"`
+}
+
+type UpdateDocumentRequest struct {
+	Documents  []*UpdateDocument     `json:"documents,omitempty"`
+	DbName     string                `json:"db_name,omitempty"`
+	SpaceName  string                `json:"space_name,omitempty"`
+	Partitions *[]entity.PartitionID `json:"partitions,omitempty"`
+}
+
 type IndexRequest struct {
 	DbName            string `json:"db_name,omitempty"`
 	SpaceName         string `json:"space_name,omitempty"`
@@ -57,9 +74,13 @@ type Condition struct {
 	Value    json.RawMessage `json:"value,omitempty"`
 }
 
+// Filter is a group of conditions combined by Operator ("AND", "OR" or
+// "NOT"). Filters holds nested groups which are combined with the conditions
+// of this group by the same operator.
 type Filter struct {
 	Operator   string      `json:"operator"`
 	Conditions []Condition `json:"conditions,omitempty"`
+	Filters    []Filter    `json:"filters,omitempty"`
 }
 
 type Ranker struct {
@@ -67,25 +88,60 @@ type Ranker struct {
 	Params json.RawMessage `json:"params,omitempty"`
 }
 
+// Aggregation is a terms, range, histogram or stats aggregation of a field,
+// computed over the documents matching the filters of a search or query.
+type Aggregation struct {
+	Type     string             `json:"type"`
+	Field    string             `json:"field"`
+	Size     int32              `json:"size,omitempty"`
+	Ranges   []AggregationRange `json:"ranges,omitempty"`
+	Interval json.RawMessage    `json:"interval,omitempty"`
+}
+
+// AggregationRange is a bucket of a range aggregation, From is inclusive and
+// To exclusive, either may be omitted.
+type AggregationRange struct {
+	Key  string          `json:"key,omitempty"`
+	From json.RawMessage `json:"from,omitempty"`
+	To   json.RawMessage `json:"to,omitempty"`
+}
+
+// GroupBy collapses search results to the best GroupSize hits of each value
+// of Field, Groups is how many values are returned.
+type GroupBy struct {
+	Field     string `json:"field"`
+	GroupSize int32  `json:"group_size,omitempty"`
+	Groups    int32  `json:"groups,omitempty"`
+}
+
 type SearchDocumentRequest struct {
-	Limit         int32             `json:"limit,omitempty"`
-	Fields        []string          `json:"fields,omitempty"`
-	Filters       *Filter           `json:"filters,omitempty"`
-	Vectors       []json.RawMessage `json:"vectors,omitempty"`
-	Sort          json.RawMessage   `json:"sort,omitempty"`
-	IndexParams   json.RawMessage   `json:"index_params,omitempty"`
-	L2Sqrt        bool              `json:"l2_sqrt,omitempty"`
-	VectorValue   bool              `json:"vector_value,omitempty"`
-	IsBruteSearch int32             `json:"is_brute_search"`
-	DbName        string            `json:"db_name,omitempty"`
-	SpaceName     string            `json:"space_name,omitempty"`
-	LoadBalance   string            `json:"load_balance"`
-	DocumentIds   *[]string         `json:"document_ids,omitempty"`
-	PartitionId   *uint32           `json:"partition_id,omitempty"`
-	Next          *bool             `json:"next,omitempty"`
-	Ranker        json.RawMessage   `json:"ranker,omitempty"`
-	GetByHash     bool              `json:"get_by_hash,omitempty"`
-	sortOrder     sortorder.SortOrder
+	Limit         int32                   `json:"limit,omitempty"`
+	Offset        int32                   `json:"offset,omitempty"`
+	Fields        []string                `json:"fields,omitempty"`
+	Filters       *Filter                 `json:"filters,omitempty"`
+	Vectors       []json.RawMessage       `json:"vectors,omitempty"`
+	Sort          json.RawMessage         `json:"sort,omitempty"`
+	IndexParams   json.RawMessage         `json:"index_params,omitempty"`
+	L2Sqrt        bool                    `json:"l2_sqrt,omitempty"`
+	VectorValue   bool                    `json:"vector_value,omitempty"`
+	IsBruteSearch int32                   `json:"is_brute_search"`
+	DbName        string                  `json:"db_name,omitempty"`
+	SpaceName     string                  `json:"space_name,omitempty"`
+	LoadBalance   string                  `json:"load_balance"`
+	DocumentIds   *[]string               `json:"document_ids,omitempty"`
+	PartitionId   *uint32                 `json:"partition_id,omitempty"`
+	Next          *bool                   `json:"next,omitempty"`
+	Ranker        json.RawMessage         `json:"ranker,omitempty"`
+	GetByHash     bool                    `json:"get_by_hash,omitempty"`
+	Aggregations  map[string]*Aggregation `json:"aggregations,omitempty"`
+	GroupBy       *GroupBy                `json:"group_by,omitempty"`
+	// Consistency of the read, eventual by default
+	Consistency  string `json:"consistency,omitempty"`
+	MaxStaleness uint64 `json:"max_staleness,omitempty"`
+	// WriteToken is returned by writes for read_your_writes, tokens of
+	// many writes are joined by ","
+	WriteToken string `json:"write_token,omitempty"`
+	sortOrder  sortorder.SortOrder
 }
 
 func (s *SearchDocumentRequest) SortOrder() (sortorder.SortOrder, error) {
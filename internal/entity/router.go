@@ -1,12 +1,44 @@
 package entity
 
-import "golang.org/x/time/rate"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"golang.org/x/time/rate"
+)
 
 type RouterLimitCfg struct {
 	RequestLimitEnabled bool    `json:"request_limit_enabled"`
 	TotalReadLimit      float64 `json:"read_request_limit_count,omitempty"`
 	TotalWriteLimit     float64 `json:"write_request_limit_count,omitempty"`
+	// limits of users and of spaces keyed by db_name/space_name, they are
+	// applied whether request_limit_enabled is set or not
+	UserLimits  map[string]*RateLimit  `json:"user_limits,omitempty"`
+	SpaceLimits map[string]*RateLimit  `json:"space_limits,omitempty"`
+	SpaceQuotas map[string]*SpaceQuota `json:"space_quotas,omitempty"`
+}
+
+// RateLimit is the requests per second of the cluster, 0 is no limit.
+type RateLimit struct {
+	ReadLimit  float64 `json:"read_request_limit_count,omitempty"`
+	WriteLimit float64 `json:"write_request_limit_count,omitempty"`
+}
+
+// SpaceQuota limits the storage of a space, 0 is no limit. MaxBytes is
+// compared with the data size of the partition leaders. Quotas are checked by
+// each router with the usage it queries from the partitions, so between the
+// queries a router only accepts its share of what's left of the quota.
+type SpaceQuota struct {
+	MaxDocuments int64 `json:"max_documents,omitempty"`
+	MaxBytes     int64 `json:"max_bytes,omitempty"`
 }
+
+// LimitSpaceKey is the key of a space in the limits and quotas.
+func LimitSpaceKey(db, space string) string {
+	return db + "/" + space
+}
+
 type Router struct {
 	Count       float64
 	LimitConfig *RouterLimitCfg
@@ -22,12 +54,27 @@ var (
 	WriteLimiter = rate.NewLimiter(rate.Limit(rate.Inf), 0)
 )
 
+// keyedLimiter is the read and write limiters of a user or a space.
+type keyedLimiter struct {
+	limit *RateLimit
+	read  *rate.Limiter
+	write *rate.Limiter
+}
+
+var (
+	keyedMu       sync.RWMutex
+	userLimiters  = make(map[string]*keyedLimiter)
+	spaceLimiters = make(map[string]*keyedLimiter)
+	spaceQuotas   = make(map[string]*SpaceQuota)
+)
+
 var routerInfo = &Router{
 	Count:       0.0,
 	LimitConfig: &RouterLimitCfg{},
 }
 
 func SetRequestLimit(router *RouterLimitCfg) {
+	setKeyedLimits(router)
 	if router.RequestLimitEnabled {
 		routerInfo.LimitConfig.RequestLimitEnabled = true
 
@@ -64,6 +111,14 @@ func SetRequestLimit(router *RouterLimitCfg) {
 	}
 }
 
+// RouterCount returns the routers of the cluster, at least 1.
+func RouterCount() int64 {
+	if routerInfo.Count < 1 {
+		return 1
+	}
+	return int64(routerInfo.Count)
+}
+
 func SetRouterCount(add bool) {
 	if add {
 		routerInfo.Count++
@@ -80,4 +135,109 @@ func SetRouterCount(add bool) {
 		WriteLimiter.SetLimit(limit)
 		WriteLimiter.SetBurst(int(limit * 1.1))
 	}
+	resetKeyedLimiters()
+}
+
+// newLimiter returns the limiter of a router for a cluster limit, no limit
+// if it's 0.
+func newLimiter(total float64) *rate.Limiter {
+	if total <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	count := routerInfo.Count
+	if count < 1 {
+		count = 1
+	}
+	limit := rate.Limit(total / count)
+	burst := int(limit * 1.1)
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(limit, burst)
+}
+
+func newKeyedLimiters(limits map[string]*RateLimit) map[string]*keyedLimiter {
+	limiters := make(map[string]*keyedLimiter, len(limits))
+	for key, limit := range limits {
+		if limit == nil {
+			continue
+		}
+		limiters[key] = &keyedLimiter{
+			limit: limit,
+			read:  newLimiter(limit.ReadLimit),
+			write: newLimiter(limit.WriteLimit),
+		}
+	}
+	return limiters
+}
+
+func setKeyedLimits(router *RouterLimitCfg) {
+	quotas := make(map[string]*SpaceQuota, len(router.SpaceQuotas))
+	for key, quota := range router.SpaceQuotas {
+		if quota != nil {
+			quotas[key] = quota
+		}
+	}
+	keyedMu.Lock()
+	defer keyedMu.Unlock()
+	routerInfo.LimitConfig.UserLimits = router.UserLimits
+	routerInfo.LimitConfig.SpaceLimits = router.SpaceLimits
+	userLimiters = newKeyedLimiters(router.UserLimits)
+	spaceLimiters = newKeyedLimiters(router.SpaceLimits)
+	spaceQuotas = quotas
+}
+
+// resetKeyedLimiters splits the limits again when the router count changes.
+func resetKeyedLimiters() {
+	keyedMu.Lock()
+	defer keyedMu.Unlock()
+	userLimiters = newKeyedLimiters(routerInfo.LimitConfig.UserLimits)
+	spaceLimiters = newKeyedLimiters(routerInfo.LimitConfig.SpaceLimits)
+}
+
+func allow(limiters map[string]*keyedLimiter, key string, write bool) (bool, float64) {
+	l, ok := limiters[key]
+	if !ok {
+		return true, 0
+	}
+	if write {
+		return l.write.Allow(), l.limit.WriteLimit
+	}
+	return l.read.Allow(), l.limit.ReadLimit
+}
+
+// AllowRequest checks the limits of user and of db/space, it returns a
+// RATE_LIMITED error if one of them is reached.
+func AllowRequest(user, db, space string, write bool) error {
+	keyedMu.RLock()
+	defer keyedMu.RUnlock()
+	kind := "read"
+	if write {
+		kind = "write"
+	}
+	if user != "" {
+		if ok, limit := allow(userLimiters, user, write); !ok {
+			return vearchpb.NewErrorInfo(vearchpb.ErrorEnum_RATE_LIMITED, fmt.Sprintf("user %s %s request too frequency, have reached limit %v", user, kind, limit))
+		}
+	}
+	if db != "" && space != "" {
+		if ok, limit := allow(spaceLimiters, LimitSpaceKey(db, space), write); !ok {
+			return vearchpb.NewErrorInfo(vearchpb.ErrorEnum_RATE_LIMITED, fmt.Sprintf("space %s/%s %s request too frequency, have reached limit %v", db, space, kind, limit))
+		}
+	}
+	return nil
+}
+
+// GetSpaceQuota returns the quota of a space, nil if it has none.
+func GetSpaceQuota(db, space string) *SpaceQuota {
+	keyedMu.RLock()
+	defer keyedMu.RUnlock()
+	return spaceQuotas[LimitSpaceKey(db, space)]
+}
+
+// HasSpaceQuota tells whether any space has a quota.
+func HasSpaceQuota() bool {
+	keyedMu.RLock()
+	defer keyedMu.RUnlock()
+	return len(spaceQuotas) > 0
 }
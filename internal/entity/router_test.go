@@ -0,0 +1,81 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+func TestAllowRequest(t *testing.T) {
+	defer SetRequestLimit(&RouterLimitCfg{})
+	SetRequestLimit(&RouterLimitCfg{
+		UserLimits: map[string]*RateLimit{
+			"alice": {WriteLimit: 0.001},
+		},
+		SpaceLimits: map[string]*RateLimit{
+			LimitSpaceKey("db", "space"): {ReadLimit: 0.001},
+		},
+		SpaceQuotas: map[string]*SpaceQuota{
+			LimitSpaceKey("db", "space"): {MaxDocuments: 10},
+			LimitSpaceKey("db", "other"): nil,
+		},
+	})
+
+	tests := []struct {
+		name      string
+		user      string
+		db, space string
+		write     bool
+		limited   bool
+	}{
+		{"first write of user", "alice", "db", "other", true, false},
+		{"second write of user", "alice", "db", "other", true, true},
+		{"read of user without read limit", "alice", "db", "other", false, false},
+		{"write of other user", "bob", "db", "other", true, false},
+		{"first read of space", "bob", "db", "space", false, false},
+		{"second read of space", "bob", "db", "space", false, true},
+		{"write of space without write limit", "bob", "db", "space", true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AllowRequest(tt.user, tt.db, tt.space, tt.write)
+			if (err != nil) != tt.limited {
+				t.Fatalf("AllowRequest() error = %v, limited %v", err, tt.limited)
+			}
+			if err != nil {
+				if vErr, ok := err.(*vearchpb.VearchErr); !ok || vErr.GetError().Code != vearchpb.ErrorEnum_RATE_LIMITED {
+					t.Fatalf("AllowRequest() error = %v, want RATE_LIMITED", err)
+				}
+			}
+		})
+	}
+
+	if q := GetSpaceQuota("db", "space"); q == nil || q.MaxDocuments != 10 {
+		t.Fatalf("GetSpaceQuota() = %v", q)
+	}
+	if q := GetSpaceQuota("db", "other"); q != nil {
+		t.Fatalf("GetSpaceQuota() of null quota = %v", q)
+	}
+
+	SetRequestLimit(&RouterLimitCfg{})
+	if HasSpaceQuota() {
+		t.Fatal("quotas should be removed")
+	}
+	if err := AllowRequest("alice", "db", "other", true); err != nil {
+		t.Fatalf("limits should be removed, got %v", err)
+	}
+}
@@ -17,11 +17,10 @@ package entity
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"slices"
-	"time"
 	"unicode"
 
-	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 )
@@ -31,6 +30,9 @@ const (
 	ScoreField = "_score"
 )
 
+// FullTextIndexType is the index type of a text field searched by BM25
+const FullTextIndexType = "FULLTEXT"
+
 const (
 	FieldOption_Null        vearchpb.FieldOption = 0
 	FieldOption_Index       vearchpb.FieldOption = 1
@@ -88,6 +90,7 @@ type Space struct {
 	Fields                json.RawMessage             `json:"fields"`
 	Index                 *Index                      `json:"index,omitempty"`
 	PartitionRule         *PartitionRule              `json:"partition_rule,omitempty"`
+	TTL                   *SpaceTTL                   `json:"ttl,omitempty"`
 	SpaceProperties       map[string]*SpaceProperties `json:"space_properties,omitempty"`
 	RefreshInterval       *int32                      `json:"refresh_interval,omitempty"`
 	PartitionName         *string                     `json:"partition_name,omitempty"` // partition name for partition rule
@@ -119,6 +122,7 @@ type SpaceInfo struct {
 	ReplicaNum    uint8            `json:"replica_num"`
 	Schema        *SpaceSchema     `json:"schema"`
 	PartitionRule *PartitionRule   `json:"partition_rule,omitempty"`
+	TTL           *SpaceTTL        `json:"ttl,omitempty"`
 	Status        string           `json:"status,omitempty"`
 	Partitions    []*PartitionInfo `json:"partitions"`
 	Errors        []string         `json:"errors,omitempty"`
@@ -128,7 +132,26 @@ type BackupSpaceRequest struct {
 	Command  string      `json:"command,omitempty"`
 	BackupID int         `json:"backup_id,omitempty"`
 	Part     PartitionID `json:"part"`
-	S3Param  struct {
+	// Incremental only uploads the engine files changed since the last
+	// backup of the space
+	Incremental bool `json:"incremental,omitempty"`
+	// BaseBackupID is the backup the files of an incremental backup are
+	// compared with, it's set by master
+	BaseBackupID int `json:"base_backup_id,omitempty"`
+	// KeepLast and KeepDays delete the old backups after a backup task
+	// succeeds, 0 keeps all
+	KeepLast int `json:"keep_last,omitempty"`
+	KeepDays int `json:"keep_days,omitempty"`
+	// TaskID is the task running the command on the partitions
+	TaskID int64 `json:"task_id,omitempty"`
+	// Source is the backup to restore, it may be of another space, db or
+	// cluster in the same storage
+	Source *BackupSource `json:"source,omitempty"`
+	// PartitionNum and ReplicaNum of the space restored, the backup's by
+	// default. The documents are resharded if partition_num differs.
+	PartitionNum int   `json:"partition_num,omitempty"`
+	ReplicaNum   uint8 `json:"replica_num,omitempty"`
+	S3Param      struct {
 		Region     string `json:"region"`
 		BucketName string `json:"bucket_name"`
 		EndPoint   string `json:"endpoint"`
@@ -136,11 +159,94 @@ type BackupSpaceRequest struct {
 		SecretKey  string `json:"secret_key"`
 		UseSSL     bool   `json:"use_ssl"`
 	} `json:"s3_param,omitempty"`
+	// Storage is where the backup is kept, s3 by default
+	Storage    BackupStorage `json:"storage,omitempty"`
+	LocalParam struct {
+		// Path must be mounted on all masters and ps, e.g. by NFS, since
+		// a partition may be restored on another node
+		Path string `json:"path"`
+	} `json:"local_param,omitempty"`
+}
+
+// BackupSource is where a backup is restored from, the fields not set are
+// of the cluster and space restored to.
+type BackupSource struct {
+	ClusterName string `json:"cluster_name,omitempty"`
+	DbName      string `json:"db_name,omitempty"`
+	SpaceName   string `json:"space_name,omitempty"`
+	BackupID    int    `json:"backup_id,omitempty"`
+}
+
+// RestoreSource returns the source of a restore to the space of the
+// cluster, with the fields not set in Source filled.
+func (r *BackupSpaceRequest) RestoreSource(clusterName, dbName, spaceName string) *BackupSource {
+	src := &BackupSource{}
+	if r.Source != nil {
+		*src = *r.Source
+	}
+	if src.ClusterName == "" {
+		src.ClusterName = clusterName
+	}
+	if src.DbName == "" {
+		src.DbName = dbName
+	}
+	if src.SpaceName == "" {
+		src.SpaceName = spaceName
+	}
+	if src.BackupID == 0 {
+		src.BackupID = r.BackupID
+	}
+	return src
+}
+
+type BackupStorage string
+
+const (
+	BackupStorageS3    BackupStorage = "s3"
+	BackupStorageLocal BackupStorage = "local"
+)
+
+// Validate checks the params of the storage of the backup.
+func (r *BackupSpaceRequest) Validate() error {
+	if r.KeepLast < 0 || r.KeepDays < 0 {
+		return fmt.Errorf("keep_last %d and keep_days %d should not be negative", r.KeepLast, r.KeepDays)
+	}
+	if r.PartitionNum < 0 {
+		return fmt.Errorf("partition_num %d should not be negative", r.PartitionNum)
+	}
+	if (r.Source != nil || r.PartitionNum > 0 || r.ReplicaNum > 0) && r.Command != "restore" {
+		return fmt.Errorf("source, partition_num and replica_num only work for restore")
+	}
+	switch r.Storage {
+	case "", BackupStorageS3:
+		return nil
+	case BackupStorageLocal:
+		if r.LocalParam.Path == "" || !filepath.IsAbs(r.LocalParam.Path) {
+			return fmt.Errorf("local_param.path should be an absolute path, got [%s]", r.LocalParam.Path)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown backup storage %s", r.Storage)
 }
 
 type BackupSpaceResponse struct {
-	BackupID  int   `json:"backup_id,omitempty"`
-	BackupIDs []int `json:"backup_ids,omitempty"`
+	BackupID     int   `json:"backup_id,omitempty"`
+	BackupIDs    []int `json:"backup_ids,omitempty"`
+	BaseBackupID int   `json:"base_backup_id,omitempty"`
+	// Backup is returned by describe
+	Backup *BackupManifest `json:"backup,omitempty"`
+	// ReshardSpace is the space a backup is restored to before its
+	// documents are resharded to the space restored
+	ReshardSpace string `json:"reshard_space,omitempty"`
+}
+
+// BackupProgress is the last backup command run on a partition by a ps.
+type BackupProgress struct {
+	TaskID   int64      `json:"task_id,omitempty"`
+	Command  string     `json:"command"`
+	BackupID int        `json:"backup_id"`
+	Status   TaskStatus `json:"status"`
+	Error    string     `json:"error,omitempty"`
 }
 
 type SpaceProperties struct {
@@ -154,6 +260,12 @@ type SpaceProperties struct {
 	Option     vearchpb.FieldOption `json:"option,omitempty"`
 }
 
+// IsFullText reports whether the field is a text field with a BM25 index,
+// such a field is not indexed by the engine.
+func (sp *SpaceProperties) IsFullText() bool {
+	return sp.Index != nil && sp.Index.Type == FullTextIndexType
+}
+
 func (s *Space) String() string {
 	return fmt.Sprintf("%d_%s_%d_%d_%d_%d",
 		s.Id, s.Name, s.Version, s.DBId, s.PartitionNum, s.ReplicaNum)
@@ -196,35 +308,27 @@ func (s *Space) PartitionId(slotID SlotID) PartitionID {
 	return arr[low-1].Id
 }
 
-func (s *Space) PartitionIdsByRangeField(value []byte, field_type vearchpb.FieldType) ([]PartitionID, error) {
+// PartitionIdsByRuleField returns the partitions a document can be written
+// to by the value of its partition rule field.
+func (s *Space) PartitionIdsByRuleField(value []byte) ([]PartitionID, error) {
 	pids := make([]PartitionID, 0)
 	if len(s.Partitions) == 1 {
 		pids = append(pids, s.Partitions[0].Id)
 		return pids, nil
 	}
 
-	arr := s.Partitions
-	partition_name := ""
-	ts := cbbytes.Bytes2Int(value)
-	found := false
-	for _, r := range s.PartitionRule.Ranges {
-		value, _ := ToTimestamp(r.Value)
-		if ts < value {
-			partition_name = r.Name
-			found = true
-			break
-		}
-	}
-	if !found {
-		u := cbbytes.Bytes2Int(value)
-		timeStr := time.Unix(u/1e9, u%1e9)
-		return pids, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("can't set partition for field value %s, space ranges %v", timeStr, s.PartitionRule.Ranges))
+	partitionName, err := s.PartitionRule.GroupName(value)
+	if err != nil {
+		return pids, err
 	}
-	for i := 0; i < len(arr); i++ {
-		if arr[i].Name == partition_name {
-			pids = append(pids, arr[i].Id)
+	for _, p := range s.Partitions {
+		if p.Name == partitionName {
+			pids = append(pids, p.Id)
 		}
 	}
+	if len(pids) == 0 {
+		return pids, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space has no partition named %s", partitionName))
+	}
 	return pids, nil
 }
 
@@ -242,7 +346,8 @@ func (index *Index) UnmarshalJSON(bs []byte) error {
 	}
 
 	indexTypeMap := map[string]string{"IVFPQ": "IVFPQ", "IVFFLAT": "IVFFLAT", "BINARYIVF": "BINARYIVF", "FLAT": "FLAT",
-		"HNSW": "HNSW", "GPU_IVFPQ": "GPU_IVFPQ", "GPU_IVFFLAT": "GPU_IVFFLAT", "SSG": "SSG", "IVFPQ_RELAYOUT": "IVFPQ_RELAYOUT", "SCANN": "SCANN", "SCALAR": "SCALAR"}
+		"HNSW": "HNSW", "GPU_IVFPQ": "GPU_IVFPQ", "GPU_IVFFLAT": "GPU_IVFFLAT", "SSG": "SSG", "IVFPQ_RELAYOUT": "IVFPQ_RELAYOUT", "SCANN": "SCANN", "SCALAR": "SCALAR",
+		FullTextIndexType: FullTextIndexType}
 	if tempIndex.Type == "" {
 		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("index type is null"))
 	}
@@ -257,6 +362,22 @@ func (index *Index) UnmarshalJSON(bs []byte) error {
 			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("index params:%s json.Unmarshal err :[%s]", tempIndex.Params, err.Error()))
 		}
 
+		if tempIndex.Type == FullTextIndexType {
+			var bm25Params struct {
+				K1 float64 `json:"k1"`
+				B  float64 `json:"b"`
+			}
+			if err := json.Unmarshal(tempIndex.Params, &bm25Params); err != nil {
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("index params:%s json.Unmarshal err :[%s]", tempIndex.Params, err.Error()))
+			}
+			if bm25Params.K1 < 0 {
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("index params k1:%v should not be negative", bm25Params.K1))
+			}
+			if bm25Params.B < 0 || bm25Params.B > 1 {
+				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("index params b:%v should in [0, 1]", bm25Params.B))
+			}
+		}
+
 		if indexParams.MetricType != "" && indexParams.MetricType != "InnerProduct" && indexParams.MetricType != "L2" {
 			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("index params metric_type not support: %s, should be L2 or InnerProduct", indexParams.MetricType))
 		}
@@ -419,7 +540,12 @@ func UnmarshalPropertyJSON(propertity []byte) (map[string]*SpaceProperties, erro
 		}
 		sp.Index = data.Index
 
-		if sp.Index != nil {
+		if sp.IsFullText() {
+			if sp.Type != "text" {
+				return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("field:[%s] type:[%s] can not set %s index, only text field can", data.Name, sp.Type, FullTextIndexType))
+			}
+			sp.Option = FieldOption_Null
+		} else if sp.Index != nil {
 			sp.Option = FieldOption_Index
 		} else {
 			sp.Option = FieldOption_Null
@@ -0,0 +1,136 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TaskType is a long-running admin operation run by master in background.
+type TaskType string
+
+const (
+	TaskBackupSpace       TaskType = "backup_space"
+	TaskExpandPartitions  TaskType = "expand_partitions"
+	TaskChangeReplicas    TaskType = "change_replicas"
+	TaskRecoverFailServer TaskType = "recover_fail_server"
+	TaskRebuildIndex      TaskType = "rebuild_index"
+)
+
+func (t TaskType) Validate() error {
+	switch t {
+	case TaskBackupSpace, TaskExpandPartitions, TaskChangeReplicas, TaskRecoverFailServer, TaskRebuildIndex:
+		return nil
+	}
+	return fmt.Errorf("unknown task type %s", t)
+}
+
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "pending"
+	TaskRunning   TaskStatus = "running"
+	TaskSucceeded TaskStatus = "succeeded"
+	TaskFailed    TaskStatus = "failed"
+	TaskCanceled  TaskStatus = "canceled"
+)
+
+// Finished tells whether a task or step won't run any more.
+func (s TaskStatus) Finished() bool {
+	return s == TaskSucceeded || s == TaskFailed || s == TaskCanceled
+}
+
+// TaskStep is the progress of a task on a partition. Steps planned before
+// they run are kept, so a task resumed by another master does the same work.
+type TaskStep struct {
+	Name        string      `json:"name"`
+	PartitionID PartitionID `json:"partition_id,omitempty"`
+	NodeID      NodeID      `json:"node_id,omitempty"`
+	Status      TaskStatus  `json:"status"`
+	Error       string      `json:"error,omitempty"`
+	UpdateTime  int64       `json:"update_time,omitempty"`
+}
+
+// Task is stored in etcd and run by the master holding its lock.
+type Task struct {
+	ID        int64           `json:"task_id"`
+	Type      TaskType        `json:"type"`
+	Status    TaskStatus      `json:"status"`
+	DbName    string          `json:"db_name,omitempty"`
+	SpaceName string          `json:"space_name,omitempty"`
+	Params    json.RawMessage `json:"params,omitempty"`
+	Steps     []*TaskStep     `json:"steps,omitempty"`
+	// Result is set by the task, e.g. the backup id of a backup
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	// Owner is the master running the task
+	Owner           string `json:"owner,omitempty"`
+	User            string `json:"user,omitempty"`
+	CancelRequested bool   `json:"cancel_requested,omitempty"`
+	CreateTime      int64  `json:"create_time"`
+	UpdateTime      int64  `json:"update_time"`
+}
+
+// Step returns the step of name on a partition replica, nil if it's not
+// planned.
+func (t *Task) Step(name string, pid PartitionID, nodeID NodeID) *TaskStep {
+	for _, step := range t.Steps {
+		if step.Name == name && step.PartitionID == pid && step.NodeID == nodeID {
+			return step
+		}
+	}
+	return nil
+}
+
+// Redact hides the secrets in the params of a task returned to users.
+func (t *Task) Redact() {
+	if t.Type != TaskBackupSpace || len(t.Params) == 0 {
+		return
+	}
+	req := &BackupSpaceRequest{}
+	if err := json.Unmarshal(t.Params, req); err != nil || req.S3Param.SecretKey == "" {
+		return
+	}
+	req.S3Param.SecretKey = "******"
+	if params, err := json.Marshal(req); err == nil {
+		t.Params = params
+	}
+}
+
+// TaskRequest submits a task by POST /tasks.
+type TaskRequest struct {
+	Type      TaskType        `json:"type"`
+	DbName    string          `json:"db_name,omitempty"`
+	SpaceName string          `json:"space_name,omitempty"`
+	Params    json.RawMessage `json:"params,omitempty"`
+}
+
+// RebuildIndexTaskParams are the params of a rebuild_index task.
+type RebuildIndexTaskParams struct {
+	DropBeforeRebuild bool        `json:"drop_before_rebuild,omitempty"`
+	LimitCPU          int         `json:"limit_cpu,omitempty"`
+	PartitionId       PartitionID `json:"partition_id,omitempty"`
+}
+
+// ExpandPartitionsTaskParams are the params of an expand_partitions task.
+type ExpandPartitionsTaskParams struct {
+	PartitionNum int `json:"partition_num"`
+}
+
+// ChangeReplicasTaskResult is the result of a change_replicas task.
+type ChangeReplicasTaskResult struct {
+	ReplicaNum uint8 `json:"replica_num"`
+}
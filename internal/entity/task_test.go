@@ -0,0 +1,66 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTaskRedact(t *testing.T) {
+	req := &BackupSpaceRequest{Command: "create"}
+	req.S3Param.AccessKey = "access"
+	req.S3Param.SecretKey = "secret-value"
+	params, _ := json.Marshal(req)
+	task := &Task{Type: TaskBackupSpace, Params: params}
+	task.Redact()
+	if strings.Contains(string(task.Params), "secret-value") {
+		t.Fatalf("secret key is not redacted: %s", task.Params)
+	}
+	redacted := &BackupSpaceRequest{}
+	if err := json.Unmarshal(task.Params, redacted); err != nil {
+		t.Fatal(err)
+	}
+	if redacted.Command != "create" || redacted.S3Param.AccessKey != "access" {
+		t.Fatalf("params are changed: %s", task.Params)
+	}
+
+	params = json.RawMessage(`{"partition_num":4}`)
+	task = &Task{Type: TaskExpandPartitions, Params: params}
+	task.Redact()
+	if string(task.Params) != string(params) {
+		t.Fatalf("params of %s are changed: %s", task.Type, task.Params)
+	}
+}
+
+func TestTaskStep(t *testing.T) {
+	task := &Task{Steps: []*TaskStep{
+		{Name: "add_member", PartitionID: 1, NodeID: 2},
+		{Name: "remove_member", PartitionID: 1, NodeID: 3},
+	}}
+	if step := task.Step("remove_member", 1, 3); step != task.Steps[1] {
+		t.Fatalf("Step() = %v", step)
+	}
+	if step := task.Step("add_member", 1, 3); step != nil {
+		t.Fatalf("Step() of not planned step = %v", step)
+	}
+	if TaskRunning.Finished() || !TaskCanceled.Finished() {
+		t.Fatal("wrong Finished()")
+	}
+	if err := TaskType("unknown").Validate(); err == nil {
+		t.Fatal("unknown task type should be invalid")
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+const (
+	// ExpireAtField is the expiry time of the documents of a space whose
+	// ttl has no field, it's added to the space once it's created
+	ExpireAtField = "_expire_at"
+	// TTLField is the seconds a document lives set on upsert, it overrides
+	// the seconds of the ttl of its space
+	TTLField = "_ttl"
+)
+
+// SpaceTTL expires the documents of a space. With Field a document expires
+// Seconds after the value of the date field, otherwise it expires Seconds
+// or its _ttl after it's written. Documents without the time never expire.
+type SpaceTTL struct {
+	Seconds int64  `json:"seconds,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+// ExpiryField returns the date field the expiry of documents is decided by.
+func (t *SpaceTTL) ExpiryField() string {
+	if t.Field != "" {
+		return t.Field
+	}
+	return ExpireAtField
+}
+
+// Validate checks the ttl by the fields of space.
+func (t *SpaceTTL) Validate(space *Space) error {
+	if t.Seconds < 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ttl seconds %d should not be negative", t.Seconds))
+	}
+	if t.Field != "" && t.Seconds == 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ttl seconds should be set with ttl field %s", t.Field))
+	}
+	field, ok := space.SpaceProperties[t.ExpiryField()]
+	if !ok {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ttl field %s not in space fields", t.ExpiryField()))
+	}
+	if field.FieldType != vearchpb.FieldType_DATE {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ttl field %s should be %s data type", t.ExpiryField(), vearchpb.FieldType_DATE.String()))
+	}
+	if field.Option&FieldOption_Index != FieldOption_Index {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ttl field %s should set index", t.ExpiryField()))
+	}
+	return nil
+}
+
+// WithExpireField returns the fields of a space with the expire field added
+// if the ttl needs it.
+func (t *SpaceTTL) WithExpireField(fields json.RawMessage) (json.RawMessage, error) {
+	if t.Field != "" {
+		return fields, nil
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(fields, &raw); err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	for _, f := range raw {
+		field := struct {
+			Name string `json:"name"`
+		}{}
+		if err := json.Unmarshal(f, &field); err == nil && field.Name == ExpireAtField {
+			return fields, nil
+		}
+	}
+	expireField, err := json.Marshal(Field{Name: ExpireAtField, Type: "date", Index: &Index{Name: ExpireAtField, Type: "SCALAR"}})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(append(raw, expireField))
+}
+
+// ExpireAt returns the expiry time in nanoseconds of a document written at
+// now whose _ttl is ttl seconds, 0 is never.
+func (t *SpaceTTL) ExpireAt(now time.Time, ttl int64) int64 {
+	if ttl <= 0 {
+		ttl = t.Seconds
+	}
+	if ttl <= 0 {
+		return 0
+	}
+	return now.Add(time.Duration(ttl) * time.Second).UnixNano()
+}
+
+// threshold returns the time in nanoseconds that documents whose expiry
+// field is no later than are expired.
+func (t *SpaceTTL) threshold(now time.Time) int64 {
+	if t.Field != "" {
+		return now.Add(-time.Duration(t.Seconds) * time.Second).UnixNano()
+	}
+	return now.UnixNano()
+}
+
+// Expired reports whether a document whose expiry field is value is expired.
+func (t *SpaceTTL) Expired(value []byte, now time.Time) bool {
+	if len(value) != 8 {
+		return false
+	}
+	v := cbbytes.Bytes2Int(value)
+	return v > 0 && v <= t.threshold(now)
+}
+
+// ExpiredFilter returns the range filter of the documents expired at now,
+// notIn returns the documents not expired instead.
+func (t *SpaceTTL) ExpiredFilter(now time.Time, notIn bool) *vearchpb.RangeFilter {
+	isUnion := int32(1)
+	if notIn {
+		isUnion = 2
+	}
+	return &vearchpb.RangeFilter{
+		Field:        t.ExpiryField(),
+		LowerValue:   cbbytes.Int64ToByte(0),
+		UpperValue:   cbbytes.Int64ToByte(max(t.threshold(now), 0)),
+		IncludeLower: false,
+		IncludeUpper: true,
+		IsUnion:      isUnion,
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+)
+
+func ttlSpace(t *testing.T, ttl *SpaceTTL, fields string) *Space {
+	raw, err := ttl.WithExpireField(json.RawMessage(fields))
+	if err != nil {
+		t.Fatal(err)
+	}
+	properties, err := UnmarshalPropertyJSON(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Space{Name: "ts", Fields: raw, SpaceProperties: properties, TTL: ttl}
+}
+
+func TestSpaceTTLValidate(t *testing.T) {
+	fields := `[{"name": "created", "type": "date", "index": {"name": "created", "type": "SCALAR"}},
+		{"name": "updated", "type": "date"},
+		{"name": "count", "type": "integer", "index": {"name": "count", "type": "SCALAR"}}]`
+
+	tests := []struct {
+		name  string
+		ttl   *SpaceTTL
+		valid bool
+	}{
+		{"per document", &SpaceTTL{}, true},
+		{"seconds", &SpaceTTL{Seconds: 3600}, true},
+		{"date field", &SpaceTTL{Seconds: 3600, Field: "created"}, true},
+		{"negative seconds", &SpaceTTL{Seconds: -1}, false},
+		{"field without seconds", &SpaceTTL{Field: "created"}, false},
+		{"field not exist", &SpaceTTL{Seconds: 3600, Field: "deleted"}, false},
+		{"field not indexed", &SpaceTTL{Seconds: 3600, Field: "updated"}, false},
+		{"field not date", &SpaceTTL{Seconds: 3600, Field: "count"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ttl.Validate(ttlSpace(t, tt.ttl, fields))
+			if (err == nil) != tt.valid {
+				t.Fatalf("Validate() = %v, valid %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestSpaceTTLWithExpireField(t *testing.T) {
+	fields := `[{"name": "count", "type": "integer"}]`
+	space := ttlSpace(t, &SpaceTTL{}, fields)
+	if len(space.SpaceProperties) != 2 || space.SpaceProperties[ExpireAtField] == nil {
+		t.Fatalf("expire field not added, got %s", space.Fields)
+	}
+
+	// added once
+	raw, err := space.TTL.WithExpireField(space.Fields)
+	if err != nil || string(raw) != string(space.Fields) {
+		t.Fatalf("expire field added twice, got %s, err %v", raw, err)
+	}
+
+	ttl := &SpaceTTL{Seconds: 60, Field: "count"}
+	if raw, _ := ttl.WithExpireField(json.RawMessage(fields)); string(raw) != fields {
+		t.Fatalf("ttl of a field should not add the expire field, got %s", raw)
+	}
+	if _, err := (&SpaceTTL{}).WithExpireField(json.RawMessage(`{}`)); err == nil {
+		t.Fatal("fields not an array should fail")
+	}
+}
+
+func TestSpaceTTLExpire(t *testing.T) {
+	now := time.Now()
+	ttl := &SpaceTTL{Seconds: 60}
+	if got := ttl.ExpireAt(now, 0); got != now.Add(time.Minute).UnixNano() {
+		t.Fatalf("ExpireAt() = %d, want the seconds of the space", got)
+	}
+	if got := ttl.ExpireAt(now, 10); got != now.Add(10*time.Second).UnixNano() {
+		t.Fatalf("ExpireAt() = %d, want the _ttl", got)
+	}
+	if got := (&SpaceTTL{}).ExpireAt(now, 0); got != 0 {
+		t.Fatalf("ExpireAt() = %d, want never", got)
+	}
+
+	date := func(t time.Time) []byte { return cbbytes.Int64ToByte(t.UnixNano()) }
+	if !ttl.Expired(date(now.Add(-time.Second)), now) || !ttl.Expired(date(now), now) {
+		t.Fatal("documents expired before now should be expired")
+	}
+	if ttl.Expired(date(now.Add(time.Second)), now) || ttl.Expired(cbbytes.Int64ToByte(0), now) || ttl.Expired(nil, now) {
+		t.Fatal("documents not expired or without expiry should not be expired")
+	}
+
+	// a date field expires the seconds after it
+	created := &SpaceTTL{Seconds: 60, Field: "created"}
+	if created.Expired(date(now.Add(-30*time.Second)), now) || !created.Expired(date(now.Add(-time.Minute)), now) {
+		t.Fatal("documents should expire the seconds after the date field")
+	}
+
+	filter := created.ExpiredFilter(now, false)
+	if filter.Field != "created" || filter.IsUnion != 1 || filter.IncludeLower || !filter.IncludeUpper {
+		t.Fatalf("ExpiredFilter() = %v", filter)
+	}
+	if cbbytes.Bytes2Int(filter.UpperValue) != now.Add(-time.Minute).UnixNano() || cbbytes.Bytes2Int(filter.LowerValue) != 0 {
+		t.Fatalf("ExpiredFilter() bounds = %v", filter)
+	}
+	if live := ttl.ExpiredFilter(now, true); live.Field != ExpireAtField || live.IsUnion != 2 {
+		t.Fatalf("ExpiredFilter() of live documents = %v", live)
+	}
+}
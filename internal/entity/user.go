@@ -16,6 +16,7 @@ package entity
 
 import (
 	"fmt"
+	"path"
 	"strings"
 	"unicode"
 
@@ -85,6 +86,24 @@ type Role struct {
 	Name       string                 `json:"name,omitempty"`
 	Operator   OperatorType           `json:"operator,omitempty"`
 	Privileges map[Resource]Privilege `json:"privileges,omitempty"`
+	Scopes     []*RoleScope           `json:"scopes,omitempty"`
+}
+
+// RoleScope grants privileges on the databases and spaces matching db and
+// space only. They are names or patterns such as "team_a_*", an empty space
+// matches all spaces of the databases.
+type RoleScope struct {
+	DB         string                 `json:"db"`
+	Space      string                 `json:"space,omitempty"`
+	Privileges map[Resource]Privilege `json:"privileges,omitempty"`
+}
+
+// ScopeResources are the resources which can be granted by a scope.
+var ScopeResources = map[Resource]bool{
+	ResourceDB:       true,
+	ResourceSpace:    true,
+	ResourceDocument: true,
+	ResourceIndex:    true,
 }
 
 var RootPrivilege = map[Resource]Privilege{
@@ -157,8 +176,9 @@ var RoleMap = map[string]Role{
 type NameType string
 
 const (
-	RoleNameType NameType = "Role"
-	UserNameType NameType = "User"
+	RoleNameType   NameType = "Role"
+	UserNameType   NameType = "User"
+	APIKeyNameType NameType = "APIKey"
 )
 
 func ValidateName(name string, name_type NameType, check_root bool) error {
@@ -227,7 +247,7 @@ func ParseResources(endpoint string, method string) (resource Resource, privileg
 
 	if strings.HasPrefix(endpoint, "/document") {
 		resource = ResourceDocument
-		if strings.Contains(endpoint, "query") || strings.Contains(endpoint, "search") {
+		if strings.Contains(endpoint, "query") || strings.Contains(endpoint, "search") || strings.Contains(endpoint, "scroll") || strings.Contains(endpoint, "changes") {
 			privilege = ReadOnly
 		} else {
 			privilege = WriteOnly
@@ -275,7 +295,69 @@ func (role *Role) Validate() error {
 	if role.Operator != "" && role.Operator != Grant && role.Operator != Revoke {
 		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("role privilege operator type : %s, should be %s or %s", role.Operator, Grant, Revoke))
 	}
-	for resource, privilege := range role.Privileges {
+	if err := validatePrivileges(role.Privileges); err != nil {
+		return err
+	}
+	for _, scope := range role.Scopes {
+		if err := scope.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (scope *RoleScope) Validate() error {
+	if scope == nil || scope.DB == "" {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("role scope db is empty"))
+	}
+	if _, err := path.Match(scope.DB, ""); err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("role scope db %s is invalid: %v", scope.DB, err))
+	}
+	if _, err := path.Match(scope.Space, ""); err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("role scope space %s is invalid: %v", scope.Space, err))
+	}
+	for resource := range scope.Privileges {
+		if !ScopeResources[resource] {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("role scope resource: %s, should be %s, %s, %s or %s",
+				resource, ResourceDB, ResourceSpace, ResourceDocument, ResourceIndex))
+		}
+	}
+	return validatePrivileges(scope.Privileges)
+}
+
+// Match tells whether the scope covers a space of a database, or the
+// database itself when space is empty.
+func (scope *RoleScope) Match(db, space string) bool {
+	if db == "" {
+		return false
+	}
+	if ok, _ := path.Match(scope.DB, db); !ok {
+		return false
+	}
+	if scope.Space == "" || scope.Space == "*" {
+		return true
+	}
+	if space == "" {
+		return false
+	}
+	ok, _ := path.Match(scope.Space, space)
+	return ok
+}
+
+// SameTarget tells whether two scopes are on the same databases and spaces.
+func (scope *RoleScope) SameTarget(other *RoleScope) bool {
+	return scope.DB == other.DB && scopeSpace(scope.Space) == scopeSpace(other.Space)
+}
+
+func scopeSpace(space string) string {
+	if space == "*" {
+		return ""
+	}
+	return space
+}
+
+func validatePrivileges(privileges map[Resource]Privilege) error {
+	for resource, privilege := range privileges {
 		if _, exists := ResourceMap[resource]; !exists {
 			keys := make([]Resource, 0, len(ResourceMap))
 			for k := range ResourceMap {
@@ -297,21 +379,61 @@ func (role *Role) Validate() error {
 
 const RootName = "root"
 
-func (role *Role) HasPermissionForResources(endpoint string, method string) error {
+// HasPermission checks the role has privilege on resource, by its
+// privileges on all the cluster or by one of its scopes covering db and
+// space.
+func (role *Role) HasPermission(resource Resource, privilege Privilege, db, space string) error {
 	if role.Name == RootName {
 		return nil
 	}
-	resource, privilege := ParseResources(endpoint, method)
-	if value, ok := role.Privileges[resource]; ok {
-		if privilege == value || value == WriteRead {
+	if grants(role.Privileges[resource], privilege) {
+		return nil
+	}
+	for _, scope := range role.Scopes {
+		if scope.Match(db, space) && grants(scope.Privileges[resource], privilege) {
 			return nil
 		}
-	} else {
-		return vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("role:%s don't have privilege for resource: %s", role.Name, resource))
+	}
+	if db != "" {
+		return vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("role:%s don't have %s privilege for resource: %s of db: %s space: %s", role.Name, privilege, resource, db, space))
+	}
+	return vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("role:%s don't have %s privilege for resource: %s", role.Name, privilege, resource))
+}
+
+// HasScopes tells whether the privileges of the role depend on the database
+// and space of a request.
+func (role *Role) HasScopes() bool {
+	return len(role.Scopes) > 0
+}
+
+// Authorize checks every role has privilege on resource. The database and
+// space of the request are only got by target when a role has scopes.
+func Authorize(roles []*Role, resource Resource, privilege Privilege, target func() (string, string)) error {
+	var db, space string
+	resolved := false
+	for _, role := range roles {
+		err := role.HasPermission(resource, privilege, "", "")
+		if err == nil {
+			continue
+		}
+		if !role.HasScopes() {
+			return err
+		}
+		if !resolved {
+			db, space = target()
+			resolved = true
+		}
+		if err := role.HasPermission(resource, privilege, db, space); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func grants(value Privilege, privilege Privilege) bool {
+	return value == privilege || value == WriteRead
+}
+
 type User struct {
 	Name        string  `json:"name"`
 	Password    *string `json:"password,omitempty"`
@@ -0,0 +1,114 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package entity_test
+
+import (
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+func scopedRole() *entity.Role {
+	return &entity.Role{
+		Name:       "scoped",
+		Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDB: entity.ReadOnly},
+		Scopes: []*entity.RoleScope{
+			{DB: "db_a*", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.WriteRead}},
+			{DB: "db_b", Space: "space_x", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.ReadOnly}},
+		},
+	}
+}
+
+func TestRole_HasPermission(t *testing.T) {
+	role := scopedRole()
+	tests := []struct {
+		name      string
+		resource  entity.Resource
+		privilege entity.Privilege
+		db, space string
+		wantErr   bool
+	}{
+		{"global privilege", entity.ResourceDB, entity.ReadOnly, "", "", false},
+		{"global privilege too low", entity.ResourceDB, entity.WriteOnly, "", "", true},
+		{"wildcard db", entity.ResourceDocument, entity.WriteOnly, "db_a1", "s", false},
+		{"wildcard db without space", entity.ResourceDocument, entity.ReadOnly, "db_a1", "", false},
+		{"db not matched", entity.ResourceDocument, entity.ReadOnly, "db_c", "s", true},
+		{"space matched", entity.ResourceDocument, entity.ReadOnly, "db_b", "space_x", false},
+		{"write on read only scope", entity.ResourceDocument, entity.WriteOnly, "db_b", "space_x", true},
+		{"space not matched", entity.ResourceDocument, entity.ReadOnly, "db_b", "space_y", true},
+		{"space missing", entity.ResourceDocument, entity.ReadOnly, "db_b", "", true},
+		{"no target", entity.ResourceDocument, entity.ReadOnly, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := role.HasPermission(tt.resource, tt.privilege, tt.db, tt.space); (err != nil) != tt.wantErr {
+				t.Errorf("HasPermission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	root := &entity.Role{Name: entity.RootName}
+	if err := root.HasPermission(entity.ResourceCluster, entity.WriteRead, "", ""); err != nil {
+		t.Errorf("root HasPermission() = %v", err)
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	resolved := 0
+	target := func() (string, string) {
+		resolved++
+		return "db_a1", "s"
+	}
+	global := &entity.Role{Name: "global", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.WriteRead}}
+	if err := entity.Authorize([]*entity.Role{global}, entity.ResourceDocument, entity.WriteOnly, target); err != nil || resolved != 0 {
+		t.Errorf("Authorize(global) = %v, resolved %d times", err, resolved)
+	}
+	if err := entity.Authorize([]*entity.Role{global, scopedRole()}, entity.ResourceDocument, entity.WriteOnly, target); err != nil || resolved != 1 {
+		t.Errorf("Authorize(scoped) = %v, resolved %d times", err, resolved)
+	}
+
+	// an api key role limits the privileges of its user
+	readOnly := &entity.Role{Name: "reader", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.ReadOnly}}
+	if err := entity.Authorize([]*entity.Role{global, readOnly}, entity.ResourceDocument, entity.WriteOnly, target); err == nil {
+		t.Errorf("Authorize() of a read only role should fail")
+	}
+}
+
+func TestRoleScope_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   *entity.RoleScope
+		wantErr bool
+	}{
+		{"db", &entity.RoleScope{DB: "db", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceSpace: entity.ReadOnly}}, false},
+		{"pattern", &entity.RoleScope{DB: "db_*", Space: "s?", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.WriteRead}}, false},
+		{"no db", &entity.RoleScope{Space: "s", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.ReadOnly}}, true},
+		{"bad pattern", &entity.RoleScope{DB: "db_[", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: entity.ReadOnly}}, true},
+		{"cluster resource", &entity.RoleScope{DB: "db", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceCluster: entity.ReadOnly}}, true},
+		{"bad privilege", &entity.RoleScope{DB: "db", Privileges: map[entity.Resource]entity.Privilege{entity.ResourceDocument: "All"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.scope.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	a := &entity.RoleScope{DB: "db", Space: "*"}
+	if !a.SameTarget(&entity.RoleScope{DB: "db"}) || a.SameTarget(&entity.RoleScope{DB: "db", Space: "s"}) {
+		t.Errorf("SameTarget() is wrong")
+	}
+}
@@ -16,7 +16,6 @@ package master
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
@@ -33,6 +32,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/entity/errors"
 	"github.com/vearch/vearch/v3/internal/entity/response"
 	"github.com/vearch/vearch/v3/internal/monitor"
+	"github.com/vearch/vearch/v3/internal/pkg/audit"
 	"github.com/vearch/vearch/v3/internal/pkg/errutil"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/pkg/netutil"
@@ -46,11 +46,13 @@ const (
 	spaceName           = "space_name"
 	aliasName           = "alias_name"
 	userName            = "user_name"
+	apiKeyName          = "api_key_name"
 	roleName            = "role_name"
 	memberId            = "member_id"
 	peerAddrs           = "peer_addrs"
 	headerAuthKey       = "Authorization"
 	NodeID              = "node_id"
+	taskID              = "task_id"
 	DefaultResourceName = "default"
 )
 
@@ -89,49 +91,43 @@ func (ca *clusterAPI) handleError(c *gin.Context, err error) {
 
 func BasicAuthMiddleware(masterService *masterService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			err := fmt.Errorf("auth header is empty")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
-
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Basic" {
-			err := fmt.Errorf("auth header type is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
-
-		decoded, err := base64.StdEncoding.DecodeString(parts[1])
+		authType, name, secret, err := entity.ParseAuthHeader(c.GetHeader(headerAuthKey))
 		if err != nil {
 			response.New(c).JsonError(errors.NewErrUnauthorized(err))
 			c.Abort()
 			return
 		}
 
-		credentials := strings.SplitN(string(decoded), ":", 2)
-		if len(credentials) != 2 {
-			err := fmt.Errorf("auth header credentials is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
+		var keyRoleName *string
+		if authType == entity.BearerAuth {
+			key, err := masterService.User().QueryAPIKeyByToken(c, secret)
+			if err != nil {
+				response.New(c).JsonError(errors.NewErrUnauthorized(err))
+				c.Abort()
+				return
+			}
+			name, keyRoleName = key.User, key.RoleName
 		}
 
-		user, err := masterService.Role().QueryUserWithPassword(c, credentials[0], true)
+		user, err := masterService.Role().QueryUserWithPassword(c, name, true)
 		if err != nil {
-			ferr := fmt.Errorf("auth header user %s is invalid", credentials[0])
+			ferr := fmt.Errorf("auth header user %s is invalid", name)
 			response.New(c).JsonError(errors.NewErrUnauthorized(ferr))
 			c.Abort()
 			return
 		}
-		if *user.Password != credentials[1] {
-			err := fmt.Errorf("auth header password is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
+		if authType == entity.BasicAuth {
+			if user.Password == nil || !entity.CheckPassword(*user.Password, secret) {
+				err := fmt.Errorf("auth header password is invalid")
+				response.New(c).JsonError(errors.NewErrUnauthorized(err))
+				c.Abort()
+				return
+			}
+			if !entity.IsPasswordHashed(*user.Password) {
+				if err := masterService.User().MigratePassword(c, name, secret); err != nil {
+					log.Error("migrate password of user %s err: %v", name, err)
+				}
+			}
 		}
 
 		role, err := masterService.Role().QueryRole(c, user.Role.Name)
@@ -140,14 +136,30 @@ func BasicAuthMiddleware(masterService *masterService) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		endpoint := c.FullPath()
-		method := c.Request.Method
-		if err := role.HasPermissionForResources(endpoint, method); err != nil {
+		roles := []*entity.Role{role}
+		// an api key has at most the privileges of its user
+		if keyRoleName != nil {
+			keyRole, err := masterService.Role().QueryRole(c, *keyRoleName)
+			if err != nil {
+				response.New(c).JsonError(errors.NewErrUnauthorized(err))
+				c.Abort()
+				return
+			}
+			roles = append(roles, keyRole)
+		}
+		resource, privilege := entity.ParseResources(c.FullPath(), c.Request.Method)
+		target := func() (string, string) {
+			return c.Param(dbName), c.Param(spaceName)
+		}
+		if err := entity.Authorize(roles, resource, privilege, target); err != nil {
 			response.New(c).JsonError(errors.NewErrUnauthorized(err))
 			c.Abort()
 			return
 		}
 
+		c.Set(entity.AuthUserKey, name)
+		c.Set(entity.AuthTypeKey, authType)
+		c.Set(entity.AuthRoleKey, role.Name)
 		c.Next()
 	}
 }
@@ -222,13 +234,18 @@ func ExportToClusterHandler(router *gin.Engine, masterService *masterService, se
 	router.Use(RecoveryMiddleware())
 	router.Use(TimeoutMiddleware(10 * time.Second))
 
-	var groupAuth *gin.RouterGroup
-	var group *gin.RouterGroup = router.Group("")
+	// calls failing auth are audited too
+	authHandlers := make([]gin.HandlerFunc, 0, 2)
+	if auditor := audit.New(config.Conf().Audit, "master", masterService.Master().Store); auditor != nil {
+		authHandlers = append(authHandlers, auditor.Middleware(func(c *gin.Context) (string, string) {
+			return c.Param(dbName), c.Param(spaceName)
+		}))
+	}
 	if !config.Conf().Global.SkipAuth {
-		groupAuth = router.Group("", BasicAuthMiddleware(masterService))
-	} else {
-		groupAuth = router.Group("")
+		authHandlers = append(authHandlers, BasicAuthMiddleware(masterService))
 	}
+	var group *gin.RouterGroup = router.Group("")
+	groupAuth := router.Group("", authHandlers...)
 
 	group.GET("/", c.handleClusterInfo)
 
@@ -261,6 +278,10 @@ func ExportToClusterHandler(router *gin.Engine, masterService *masterService, se
 	groupAuth.PUT(fmt.Sprintf("/dbs/:%s/spaces/:%s", dbName, spaceName), c.updateSpace)
 	groupAuth.POST(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s", dbName, spaceName), c.backupSpace)
 	groupAuth.POST(fmt.Sprintf("/backup/dbs/:%s", dbName), c.backupDb)
+	groupAuth.GET("/backup/schedules", c.listBackupSchedules)
+	groupAuth.GET(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s/schedule", dbName, spaceName), c.getBackupSchedule)
+	groupAuth.PUT(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s/schedule", dbName, spaceName), c.setBackupSchedule)
+	groupAuth.DELETE(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s/schedule", dbName, spaceName), c.deleteBackupSchedule)
 
 	// modify engine config handler
 	groupAuth.POST("/config/:"+dbName+"/:"+spaceName, c.modifySpaceConfig)
@@ -298,6 +319,9 @@ func ExportToClusterHandler(router *gin.Engine, masterService *masterService, se
 	groupAuth.GET("/users", c.getUser)
 	groupAuth.DELETE(fmt.Sprintf("/users/:%s", userName), c.deleteUser)
 	groupAuth.PUT("/users", c.updateUser)
+	groupAuth.POST(fmt.Sprintf("/users/:%s/api_keys", userName), c.createAPIKey)
+	groupAuth.GET(fmt.Sprintf("/users/:%s/api_keys", userName), c.getAPIKeys)
+	groupAuth.DELETE(fmt.Sprintf("/users/:%s/api_keys/:%s", userName, apiKeyName), c.deleteAPIKey)
 
 	// role handler
 	groupAuth.POST("/roles", c.createRole)
@@ -315,6 +339,15 @@ func ExportToClusterHandler(router *gin.Engine, masterService *masterService, se
 	groupAuth.GET("/members/stats", c.getMemberStatus)
 	groupAuth.DELETE("/members", c.deleteMember)
 	groupAuth.POST("/members", c.addMember)
+
+	// audit handler
+	groupAuth.GET("/audit", c.getAudit)
+
+	// task handler
+	groupAuth.GET("/tasks", c.listTasks)
+	groupAuth.POST("/tasks", c.submitTask)
+	groupAuth.GET(fmt.Sprintf("/tasks/:%s", taskID), c.getTask)
+	groupAuth.POST(fmt.Sprintf("/tasks/:%s/cancel", taskID), c.cancelTask)
 }
 
 // got every partition servers system info
@@ -597,6 +630,7 @@ func (ca *clusterAPI) getSpace(c *gin.Context) {
 			spaceInfo.PartitionNum = space.PartitionNum
 			spaceInfo.ReplicaNum = space.ReplicaNum
 			spaceInfo.PartitionRule = space.PartitionRule
+			spaceInfo.TTL = space.TTL
 			if _, err := ca.masterService.Space().DescribeSpace(c, space, spaceInfo, detail_info); err != nil {
 				response.New(c).JsonError(errors.NewErrInternal(err))
 				return
@@ -619,6 +653,7 @@ func (ca *clusterAPI) getSpace(c *gin.Context) {
 				spaceInfo.PartitionNum = space.PartitionNum
 				spaceInfo.ReplicaNum = space.ReplicaNum
 				spaceInfo.PartitionRule = space.PartitionRule
+				spaceInfo.TTL = space.TTL
 				if _, err := ca.masterService.Space().DescribeSpace(c, space, spaceInfo, detail_info); err != nil {
 					response.New(c).JsonError(errors.NewErrInternal(err))
 					return
@@ -646,6 +681,11 @@ func (ca *clusterAPI) updateSpace(c *gin.Context) {
 
 	log.Debug("updateSpace %+v", space)
 
+	if isAsync(c) && space.PartitionNum > 0 {
+		ca.submitAsync(c, entity.TaskExpandPartitions, dbName, spaceName, &entity.ExpandPartitionsTaskParams{PartitionNum: space.PartitionNum})
+		return
+	}
+
 	if spaceResult, err := ca.masterService.Space().UpdateSpace(c, ca.masterService.DB(), dbName, spaceName, space, ""); err != nil {
 		response.New(c).JsonError(errors.NewErrInternal(err))
 	} else {
@@ -679,6 +719,26 @@ func (ca *clusterAPI) backupDb(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrBadRequest(err))
 		return
 	}
+	if err = validateBackup(c, backup); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+
+	if isAsync(c) && backupRunsAsTask(backup.Command) {
+		tasks := make([]*entity.Task, 0, len(spaces))
+		for _, space := range spaces {
+			task, err := ca.masterService.Task().SubmitTask(c, c.GetString(entity.AuthUserKey), &entity.TaskRequest{Type: entity.TaskBackupSpace, DbName: dbName, SpaceName: space.Name, Params: data})
+			if err != nil {
+				err = fmt.Errorf("backup space %s failed, err: %s", space.Name, err.Error())
+				response.New(c).JsonError(errors.NewErrInternal(err))
+				return
+			}
+			task.Redact()
+			tasks = append(tasks, task)
+		}
+		response.New(c).JsonSuccess(tasks)
+		return
+	}
 
 	res := &entity.BackupSpaceResponse{}
 	for _, space := range spaces {
@@ -709,6 +769,15 @@ func (ca *clusterAPI) backupSpace(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrBadRequest(err))
 		return
 	}
+	if err = validateBackup(c, backup); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+
+	if isAsync(c) && backupRunsAsTask(backup.Command) {
+		ca.submitAsync(c, entity.TaskBackupSpace, dbName, spaceName, backup)
+		return
+	}
 
 	res := &entity.BackupSpaceResponse{}
 
@@ -721,6 +790,75 @@ func (ca *clusterAPI) backupSpace(c *gin.Context) {
 	}
 }
 
+// backupRunsAsTask tells whether a backup command sends the partitions
+// commands, the others only access the backup storage.
+func backupRunsAsTask(command string) bool {
+	return command == "create" || command == "export" || command == "restore"
+}
+
+func validateBackup(c *gin.Context, backup *entity.BackupSpaceRequest) error {
+	if err := backup.Validate(); err != nil {
+		return err
+	}
+	if (backup.KeepLast > 0 || backup.KeepDays > 0) && (backup.Command != "create" || !isAsync(c)) {
+		return fmt.Errorf("keep_last and keep_days only work for create with async=true")
+	}
+	// the documents are resharded after the partitions are restored
+	if backup.PartitionNum > 0 && !isAsync(c) {
+		return fmt.Errorf("partition_num only works for restore with async=true")
+	}
+	return nil
+}
+
+func (ca *clusterAPI) getBackupSchedule(c *gin.Context) {
+	schedule, err := ca.masterService.Backup().GetSchedule(c, c.Param(dbName), c.Param(spaceName))
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrNotFound(err))
+		return
+	}
+	schedule.Redact()
+	response.New(c).JsonSuccess(schedule)
+}
+
+func (ca *clusterAPI) listBackupSchedules(c *gin.Context) {
+	schedules, err := ca.masterService.Backup().ListSchedules(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	for _, schedule := range schedules {
+		schedule.Redact()
+	}
+	response.New(c).JsonSuccess(schedules)
+}
+
+// setBackupSchedule creates the backups of a space periodically as tasks.
+func (ca *clusterAPI) setBackupSchedule(c *gin.Context) {
+	schedule := &entity.BackupSchedule{}
+	if err := c.ShouldBindJSON(schedule); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	schedule.DbName = c.Param(dbName)
+	schedule.SpaceName = c.Param(spaceName)
+	schedule.User = c.GetString(entity.AuthUserKey)
+	schedule.LastTaskID = 0
+	if err := ca.masterService.Backup().SetSchedule(c, schedule); err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	schedule.Redact()
+	response.New(c).JsonSuccess(schedule)
+}
+
+func (ca *clusterAPI) deleteBackupSchedule(c *gin.Context) {
+	if err := ca.masterService.Backup().DeleteSchedule(c, c.Param(dbName), c.Param(spaceName)); err != nil {
+		response.New(c).JsonError(errors.NewErrNotFound(err))
+		return
+	}
+	response.New(c).JsonSuccess(nil)
+}
+
 func (ca *clusterAPI) ResourceLimit(c *gin.Context) {
 	resourceLimit := &entity.ResourceLimit{}
 	if err := c.ShouldBindJSON(resourceLimit); err != nil {
@@ -879,45 +1017,78 @@ func (ca *clusterAPI) updateUser(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrBadRequest(err))
 		return
 	}
-	auth_user := ""
-	if !config.Conf().Global.SkipAuth {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			err := fmt.Errorf("auth header is empty")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
+	auth_user := c.GetString(entity.AuthUserKey)
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Basic" {
-			err := fmt.Errorf("auth header type is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
+	if err := ca.masterService.User().UpdateUser(c, ca.masterService.Role(), user, auth_user); err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+	} else {
+		// the password is not returned
+		response.New(c).JsonSuccess(&entity.User{Name: user.Name, RoleName: user.RoleName})
+	}
+}
 
-		decoded, err := base64.StdEncoding.DecodeString(parts[1])
-		if err != nil {
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
+// checkAPIKeyUser checks the authenticated user can manage the api keys of
+// user name, only root and the user itself can.
+func checkAPIKeyUser(c *gin.Context, name string) error {
+	if config.Conf().Global.SkipAuth {
+		return nil
+	}
+	authUser := c.GetString(entity.AuthUserKey)
+	if authUser != entity.RootName && authUser != name {
+		return vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("user %s can't manage api keys of user %s", authUser, name))
+	}
+	return nil
+}
 
-		credentials := strings.SplitN(string(decoded), ":", 2)
-		if len(credentials) != 2 {
-			err := fmt.Errorf("auth header credentials is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
-		auth_user = credentials[0]
+func (ca *clusterAPI) createAPIKey(c *gin.Context) {
+	name := c.Param(userName)
+	req := &entity.APIKeyRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	if err := checkAPIKeyUser(c, name); err != nil {
+		response.New(c).JsonError(errors.NewErrUnauthorized(err))
+		return
+	}
+	if c.GetString(entity.AuthTypeKey) == entity.BearerAuth {
+		err := fmt.Errorf("api key can't be created with an api key")
+		response.New(c).JsonError(errors.NewErrUnauthorized(err))
+		return
 	}
 
-	if err := ca.masterService.User().UpdateUser(c, ca.masterService.Role(), user, auth_user); err != nil {
+	log.Debug("create api key %s of user %s", req.Name, name)
+
+	if key, err := ca.masterService.User().CreateAPIKey(c, ca.masterService.Role(), name, req); err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+	} else {
+		response.New(c).JsonSuccess(key)
+	}
+}
+
+func (ca *clusterAPI) getAPIKeys(c *gin.Context) {
+	name := c.Param(userName)
+	if err := checkAPIKeyUser(c, name); err != nil {
+		response.New(c).JsonError(errors.NewErrUnauthorized(err))
+		return
+	}
+	if keys, err := ca.masterService.User().QueryAPIKeys(c, name); err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+	} else {
+		response.New(c).JsonSuccess(keys)
+	}
+}
+
+func (ca *clusterAPI) deleteAPIKey(c *gin.Context) {
+	name := c.Param(userName)
+	if err := checkAPIKeyUser(c, name); err != nil {
+		response.New(c).JsonError(errors.NewErrUnauthorized(err))
+		return
+	}
+	if err := ca.masterService.User().DeleteAPIKey(c, name, c.Param(apiKeyName)); err != nil {
 		response.New(c).JsonError(errors.NewErrInternal(err))
 	} else {
-		response.New(c).JsonSuccess(user)
+		response.New(c).SuccessDelete()
 	}
 }
 
@@ -1230,6 +1401,10 @@ func (cluster *clusterAPI) RecoverFailServer(c *gin.Context) {
 		return
 	}
 	log.Info("RecoverFailServer is %s,", rsStr)
+	if isAsync(c) {
+		cluster.submitAsync(c, entity.TaskRecoverFailServer, "", "", rs)
+		return
+	}
 	if err := cluster.masterService.Server().RecoverFailServer(c.Request.Context(), cluster.masterService.Space(), cluster.masterService.Member(), rs); err != nil {
 		response.New(c).JsonError(errors.NewErrInternal(fmt.Errorf("%s failed recover, err is %v", rsStr, err)))
 	} else {
@@ -1255,6 +1430,10 @@ func (cluster *clusterAPI) ChangeReplicas(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrBadRequest(fmt.Errorf("dbModify info incorrect [%s]", dbStr)))
 		return
 	}
+	if isAsync(c) {
+		cluster.submitAsync(c, entity.TaskChangeReplicas, dbModify.DbName, dbModify.SpaceName, dbModify)
+		return
+	}
 	if err := cluster.masterService.Member().ChangeReplica(c.Request.Context(), cluster.masterService.DB(), cluster.masterService.Space(), dbModify); err != nil {
 		response.New(c).JsonError(errors.NewErrInternal(fmt.Errorf("[%s] failed ChangeReplicas,err is %v", dbStr, err)))
 	} else {
@@ -1300,3 +1479,134 @@ func (ca *clusterAPI) modifyRequestLimitCfg(c *gin.Context) {
 		response.New(c).JsonSuccess(rlc)
 	}
 }
+
+// getAudit returns the audit records kept in etcd, filtered by start_time,
+// end_time and user. Times are unix seconds or date strings.
+func (ca *clusterAPI) getAudit(c *gin.Context) {
+	query := &entity.AuditQuery{User: c.Query("user"), Limit: entity.DefaultAuditLimit}
+	for param, t := range map[string]*time.Time{"start_time": &query.StartTime, "end_time": &query.EndTime} {
+		if value := c.Query(param); value != "" {
+			v, err := parseAuditTime(value)
+			if err != nil {
+				response.New(c).JsonError(errors.NewErrBadRequest(fmt.Errorf("%s %s is invalid: %v", param, value, err)))
+				return
+			}
+			*t = v
+		}
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > entity.MaxAuditLimit {
+			response.New(c).JsonError(errors.NewErrBadRequest(fmt.Errorf("limit %s should be between 1 and %d", value, entity.MaxAuditLimit)))
+			return
+		}
+		query.Limit = limit
+	}
+
+	records, err := audit.Query(c, ca.masterService.Master().Store, query)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	response.New(c).JsonSuccess(records)
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return cast.ToTimeE(value)
+}
+
+// isAsync tells whether a long-running admin call should be submitted as a
+// task by ?async=true, the call returns the task at once.
+func isAsync(c *gin.Context) bool {
+	async, _ := strconv.ParseBool(c.Query("async"))
+	return async
+}
+
+func (ca *clusterAPI) submitAsync(c *gin.Context, taskType entity.TaskType, dbName, spaceName string, params any) {
+	value, err := json.Marshal(params)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	ca.doSubmitTask(c, &entity.TaskRequest{Type: taskType, DbName: dbName, SpaceName: spaceName, Params: value})
+}
+
+func (ca *clusterAPI) doSubmitTask(c *gin.Context, req *entity.TaskRequest) {
+	task, err := ca.masterService.Task().SubmitTask(c, c.GetString(entity.AuthUserKey), req)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	task.Redact()
+	response.New(c).JsonSuccess(task)
+}
+
+func (ca *clusterAPI) submitTask(c *gin.Context) {
+	req := &entity.TaskRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	if err := req.Type.Validate(); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	if req.Type != entity.TaskRecoverFailServer && (req.DbName == "" || req.SpaceName == "") {
+		response.New(c).JsonError(errors.NewErrBadRequest(fmt.Errorf("task %s needs db_name and space_name", req.Type)))
+		return
+	}
+	ca.doSubmitTask(c, req)
+}
+
+func parseTaskID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(taskID), 10, 64)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(fmt.Errorf("task id %s is invalid", c.Param(taskID))))
+		return 0, false
+	}
+	return id, true
+}
+
+func (ca *clusterAPI) getTask(c *gin.Context) {
+	id, ok := parseTaskID(c)
+	if !ok {
+		return
+	}
+	task, err := ca.masterService.Task().GetTask(c, id)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrNotFound(err))
+		return
+	}
+	task.Redact()
+	response.New(c).JsonSuccess(task)
+}
+
+// listTasks returns the tasks filtered by status and type, newest first.
+func (ca *clusterAPI) listTasks(c *gin.Context) {
+	tasks, err := ca.masterService.Task().ListTasks(c, entity.TaskStatus(c.Query("status")), entity.TaskType(c.Query("type")))
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	for _, task := range tasks {
+		task.Redact()
+	}
+	response.New(c).JsonSuccess(tasks)
+}
+
+func (ca *clusterAPI) cancelTask(c *gin.Context) {
+	id, ok := parseTaskID(c)
+	if !ok {
+		return
+	}
+	task, err := ca.masterService.Task().CancelTask(c, id)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	task.Redact()
+	response.New(c).JsonSuccess(task)
+}
@@ -32,10 +32,11 @@ type masterService struct {
 	memberService    *services.MemberService
 	configService    *services.ConfigService
 	backupService    *services.BackupService
+	taskService      *services.TaskService
 }
 
 func newMasterService(client *client.Client) (*masterService, error) {
-	return &masterService{
+	ms := &masterService{
 		Client:           client,
 		dbService:        services.NewDBService(client),
 		spaceService:     services.NewSpaceService(client),
@@ -47,7 +48,10 @@ func newMasterService(client *client.Client) (*masterService, error) {
 		memberService:    services.NewMemberService(client),
 		configService:    services.NewConfigService(client),
 		backupService:    services.NewBackupService(client),
-	}, nil
+		taskService:      services.NewTaskService(client),
+	}
+	ms.registerTaskRunners()
+	return ms, nil
 }
 
 func (ms *masterService) DB() *services.DBService {
@@ -89,3 +93,7 @@ func (ms *masterService) Config() *services.ConfigService {
 func (ms *masterService) Backup() *services.BackupService {
 	return ms.backupService
 }
+
+func (ms *masterService) Task() *services.TaskService {
+	return ms.taskService
+}
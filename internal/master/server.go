@@ -29,6 +29,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/monitor"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/pkg/tlsutil"
 	"github.com/vearch/vearch/v3/internal/pkg/vjson"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"go.etcd.io/etcd/client/v3/concurrency"
@@ -151,7 +152,7 @@ func (s *Server) Start() (err error) {
 
 	// register monitor
 	go func() {
-		if err := httpServer.Run(":" + cast.ToString(config.Conf().Masters.Self().ApiPort)); err != nil {
+		if err := tlsutil.ListenAndServe(":"+cast.ToString(config.Conf().Masters.Self().ApiPort), httpServer, config.Conf().TLS.Master); err != nil {
 			panic(err)
 		}
 	}()
@@ -188,6 +189,10 @@ func (s *Server) Start() (err error) {
 	}
 	log.Debug("start WatchServerJob success!")
 
+	// resume the tasks of masters failed over
+	service.Task().Start(s.ctx)
+	service.Backup().StartSchedule(s.ctx, service.Task())
+
 	master := config.Conf().GetMasters().Self()
 	resp, err := s.client.Master().MemberList(context.Background())
 	if err != nil {
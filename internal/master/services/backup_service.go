@@ -20,19 +20,26 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/spaolacci/murmur3"
 	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/backupstore"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"go.etcd.io/etcd/client/v3/concurrency"
 )
 
+// backupScheduleInterval is how often the backup schedules are checked
+const backupScheduleInterval = time.Minute
+
 type BackupService struct {
 	client *client.Client
 }
@@ -41,9 +48,10 @@ func NewBackupService(client *client.Client) *BackupService {
 	return &BackupService{client: client}
 }
 
-func (s *BackupService) backupSchema(ctx context.Context, dbName, spaceName string, backup *entity.BackupSpaceRequest) (res *entity.BackupSpaceResponse, err error) {
+func (s *BackupService) backupSchema(ctx context.Context, store backupstore.Store, dbName, spaceName string, backup *entity.BackupSpaceRequest) (res *entity.BackupSpaceResponse, err error) {
 	res = &entity.BackupSpaceResponse{
-		BackupID: backup.BackupID,
+		BackupID:     backup.BackupID,
+		BaseBackupID: backup.BaseBackupID,
 	}
 	mc := s.client.Master()
 	dbID, err := mc.QueryDBName2ID(ctx, dbName)
@@ -56,6 +64,31 @@ func (s *BackupService) backupSchema(ctx context.Context, dbName, spaceName stri
 		return res, err
 	}
 
+	if backup.Command == "create" {
+		// the manifest is put before the schema, which lists the backup
+		manifest := &entity.BackupManifest{
+			BackupID:     backup.BackupID,
+			DbName:       dbName,
+			SpaceName:    space.Name,
+			CreateTime:   time.Now().Unix(),
+			SpaceVersion: space.Version,
+			BaseBackupID: backup.BaseBackupID,
+			Partitions:   make([]*entity.BackupPartition, 0, len(space.Partitions)),
+		}
+		for pid, part := range s.backupParts(ctx, space) {
+			manifest.Partitions = append(manifest.Partitions, &entity.BackupPartition{Part: part, PartitionID: pid})
+		}
+		sort.Slice(manifest.Partitions, func(i, j int) bool {
+			return manifest.Partitions[i].Part < manifest.Partitions[j].Part
+		})
+		key := filepath.Join(backupPath(dbName, space.Name, backup.BackupID), entity.BackupManifestFile)
+		if err := backupstore.PutJSON(ctx, store, key, manifest); err != nil {
+			err = fmt.Errorf("failed to put backup manifest: %+v", err)
+			log.Error(err)
+			return res, err
+		}
+	}
+
 	spaceJson, err := json.Marshal(space)
 	if err != nil {
 		log.Error("json.Marshal err: %v", err)
@@ -71,18 +104,6 @@ func (s *BackupService) backupSchema(ctx context.Context, dbName, spaceName stri
 		return res, err
 	}
 
-	minioClient, err := minio.New(backup.S3Param.EndPoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(backup.S3Param.AccessKey, backup.S3Param.SecretKey, ""),
-		Secure: backup.S3Param.UseSSL,
-		Region: backup.S3Param.Region,
-	})
-	if err != nil {
-		err = fmt.Errorf("failed to create minio client: %+v", err)
-		log.Error(err)
-		return res, err
-	}
-	bucketName := backup.S3Param.BucketName
-
 	var objectName string
 	if backup.Command == "create" {
 		objectName = filepath.Join(config.Conf().Global.Name, "backup", dbName, space.Name, fmt.Sprintf("%d/%s.schema", backup.BackupID, space.Name))
@@ -92,7 +113,7 @@ func (s *BackupService) backupSchema(ctx context.Context, dbName, spaceName stri
 		backup.BackupID = res.BackupID
 		objectName = filepath.Join(config.Conf().Global.Name, "export", dbName, space.Name, fmt.Sprintf("%d/%s.schema", res.BackupID, space.Name))
 	}
-	_, err = minioClient.FPutObject(ctx, bucketName, objectName, backupFileName, minio.PutObjectOptions{ContentType: "application/octet-stream"})
+	err = store.Put(ctx, objectName, backupFileName)
 	if err != nil {
 		err = fmt.Errorf("failed to backup space: %+v", err)
 		log.Error(err)
@@ -103,9 +124,13 @@ func (s *BackupService) backupSchema(ctx context.Context, dbName, spaceName stri
 	return res, nil
 }
 
-func (s *BackupService) restoreSchema(ctx context.Context, dbService *DBService, spaceService *SpaceService, configService *ConfigService, dbName, spaceName string, backup *entity.BackupSpaceRequest) (res *entity.BackupSpaceResponse, err error) {
+// restoreSchema creates the space restored from the schema of the backup.
+// If partition_num differs from the backup's, a reshard space with the
+// partitions of the backup is created too for the backup to be restored to.
+func (s *BackupService) restoreSchema(ctx context.Context, store backupstore.Store, dbService *DBService, spaceService *SpaceService, configService *ConfigService, dbName, spaceName string, backup *entity.BackupSpaceRequest) (res *entity.BackupSpaceResponse, err error) {
+	src := backup.RestoreSource(config.Conf().Global.Name, dbName, spaceName)
 	res = &entity.BackupSpaceResponse{
-		BackupID: backup.BackupID,
+		BackupID: src.BackupID,
 	}
 	mc := s.client.Master()
 	dbID, err := mc.QueryDBName2ID(ctx, dbName)
@@ -118,26 +143,16 @@ func (s *BackupService) restoreSchema(ctx context.Context, dbService *DBService,
 		err = fmt.Errorf("space duplicate")
 		return res, err
 	}
-	minioClient, err := minio.New(backup.S3Param.EndPoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(backup.S3Param.AccessKey, backup.S3Param.SecretKey, ""),
-		Secure: backup.S3Param.UseSSL,
-		Region: backup.S3Param.Region,
-	})
-	if err != nil {
-		err := fmt.Errorf("failed to create minio client: %+v", err)
-		return res, err
-	}
 
 	backupFileName := spaceName + ".schema"
-	bucketName := backup.S3Param.BucketName
-	objectName := fmt.Sprintf("%s/backup/%s/%s/%d/%s.schema", config.Conf().Global.Name, dbName, spaceName, backup.BackupID, spaceName)
-	err = minioClient.FGetObject(ctx, bucketName, objectName, backupFileName, minio.GetObjectOptions{})
+	objectName := filepath.Join(sourcePath(src), src.SpaceName+".schema")
+	err = store.Get(ctx, objectName, backupFileName)
 	if err != nil {
-		err := fmt.Errorf("failed to download %s from S3: %+v", objectName, err)
+		err := fmt.Errorf("failed to download %s from %s: %+v", objectName, backupStorage(backup), err)
 		return res, err
 	}
 	defer os.Remove(backupFileName)
-	log.Info("downloaded backup file from S3: %s", backupFileName)
+	log.Info("downloaded backup file from %s: %s", backupStorage(backup), backupFileName)
 
 	spaceJson, err := os.ReadFile(backupFileName)
 	if err != nil {
@@ -155,166 +170,593 @@ func (s *BackupService) restoreSchema(ctx context.Context, dbService *DBService,
 	}
 
 	partitionNum := len(space.Partitions)
-	space.Partitions = make([]*entity.Partition, 0)
-
-	s3Path := filepath.Join(config.Conf().Global.Name, "backup", dbName, spaceName, fmt.Sprintf("%d", backup.BackupID))
-	objectCh := minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
-		Prefix:    s3Path + "/",
-		Recursive: false,
-	})
-
-	partitionDirs := make(map[string]bool)
-	for object := range objectCh {
-		if object.Err != nil {
-			log.Error("failed to list S3 objects: %v", object.Err)
-			continue
+	parts, err := restoreParts(ctx, store, sourcePath(src))
+	if err != nil {
+		return res, err
+	}
+	log.Info("Total partition directories found: %d, expected: %d", len(parts), partitionNum)
+
+	if len(parts) != partitionNum {
+		err = fmt.Errorf("backup partition count %d does not match schema partition count %d, found partitions: %v",
+			len(parts), partitionNum, parts)
+		return res, err
+	}
+
+	create := func(space *entity.Space) error {
+		space.Partitions = make([]*entity.Partition, 0)
+		if err := spaceService.CreateSpace(ctx, dbService, dbName, space); err != nil {
+			log.Error("createSpace err: %v", err)
+			return err
+		}
+		cfg, err := configService.GetSpaceConfigByName(ctx, dbName, space.Name)
+		if err != nil {
+			log.Error("get space config err: %s", err.Error())
+			return err
+		}
+		if err := configService.UpdateSpaceConfig(ctx, space, cfg); err != nil {
+			log.Error("update space config err: %s", err.Error())
+			return err
 		}
+		return nil
+	}
+
+	space.Name = spaceName
+	if backup.ReplicaNum > 0 {
+		space.ReplicaNum = backup.ReplicaNum
+	}
+	if backup.PartitionNum > 0 && backup.PartitionNum != space.PartitionNum {
+		// the reshard space only lives until its documents are copied
+		reshard := &entity.Space{}
+		if err := json.Unmarshal(spaceJson, reshard); err != nil {
+			return res, fmt.Errorf("unmarshal file: %v", err)
+		}
+		reshard.Name = reshardSpaceName(spaceName, src.BackupID)
+		reshard.ReplicaNum = 1
+		if err := create(reshard); err != nil {
+			return res, err
+		}
+		res.ReshardSpace = reshard.Name
+		space.PartitionNum = backup.PartitionNum
+		log.Info("restore backup %d of space %s/%s to %s/%s with %d partitions, by reshard space %s",
+			src.BackupID, src.DbName, src.SpaceName, dbName, spaceName, space.PartitionNum, reshard.Name)
+	}
+	if err := create(space); err != nil {
+		return res, err
+	}
+	return res, nil
+}
 
-		relativePath := strings.TrimPrefix(object.Key, s3Path+"/")
+// reshardSpaceName is the name of the space a backup is restored to before
+// its documents are resharded.
+func reshardSpaceName(spaceName string, backupID int) string {
+	return fmt.Sprintf("%s_reshard_%d", spaceName, backupID)
+}
 
-		if relativePath == "" {
-			continue
+// reshardBatchSize is how many documents of a partition of the reshard
+// space are copied at a time
+const reshardBatchSize = 500
+
+// Reshard copies the documents of the reshard space a backup is restored to
+// into the space restored, then deletes the reshard space. It's done if the
+// reshard space doesn't exist, the documents are upserted so it can be run
+// again after a failure.
+func (s *BackupService) Reshard(ctx context.Context, spaceService *SpaceService, aliasService *AliasService, dbName, reshardName, spaceName string) error {
+	mc := s.client.Master()
+	dbID, err := mc.QueryDBName2ID(ctx, dbName)
+	if err != nil {
+		return err
+	}
+	reshard, err := mc.QuerySpaceByName(ctx, dbID, reshardName)
+	if err != nil {
+		if vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError().Code == vearchpb.ErrorEnum_SPACE_NOT_EXIST {
+			log.Info("reshard space %s/%s is deleted", dbName, reshardName)
+			return nil
+		}
+		return err
+	}
+	space, err := mc.QuerySpaceByName(ctx, dbID, spaceName)
+	if err != nil {
+		return err
+	}
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		if proMap, err = entity.UnmarshalPropertyJSON(space.Fields); err != nil {
+			return err
 		}
+	}
 
-		if strings.HasSuffix(relativePath, "/") {
-			dirName := strings.TrimSuffix(relativePath, "/")
-			if !strings.Contains(dirName, "/") && dirName != "" {
-				partitionDirs[dirName] = true
-				log.Info("Found partition directory: %s", dirName)
+	var total int
+	for _, p := range reshard.Partitions {
+		partition, err := mc.QueryPartition(ctx, p.Id)
+		if err != nil {
+			return err
+		}
+		if len(partition.Replicas) == 0 {
+			return fmt.Errorf("partition %d of reshard space %s has no replica", p.Id, reshardName)
+		}
+		server, err := mc.QueryServer(ctx, partition.Replicas[0])
+		if err != nil {
+			return err
+		}
+
+		req := &vearchpb.ScrollRequest{Head: &vearchpb.RequestHead{}, Size: reshardBatchSize, IsVectorValue: true}
+		for {
+			resp, err := client.ScrollDocs(server.RpcAddr(), p.Id, req)
+			if err != nil {
+				return fmt.Errorf("scroll partition %d of reshard space %s err: %v", p.Id, reshardName, err)
 			}
+			if err := s.upsertDocs(ctx, space, proMap, resp.Documents); err != nil {
+				return err
+			}
+			total += len(resp.Documents)
+			if resp.Done {
+				break
+			}
+			req.Docid = resp.Docid
 		}
+		log.Info("copied partition %d of reshard space %s/%s to space %s, %d documents in total", p.Id, dbName, reshardName, spaceName, total)
 	}
+	return spaceService.DeleteSpace(ctx, aliasService, dbName, reshardName)
+}
 
-	log.Info("Total partition directories found: %d, expected: %d", len(partitionDirs), partitionNum)
-	log.Debug("Partition directories: %v", partitionDirs)
-
-	if len(partitionDirs) != partitionNum {
-		err = fmt.Errorf("S3 partition directory count %d does not match schema partition count %d, found directories: %v",
-			len(partitionDirs), partitionNum, partitionDirs)
-		return res, err
+// upsertDocs writes the documents to the leaders of their partitions of
+// the space.
+func (s *BackupService) upsertDocs(ctx context.Context, space *entity.Space, proMap map[string]*entity.SpaceProperties, docs []*vearchpb.Document) error {
+	mc := s.client.Master()
+	docsMap := make(map[entity.PartitionID][]*vearchpb.Document)
+	for _, doc := range docs {
+		for _, field := range doc.Fields {
+			if field.Name == entity.IdField {
+				field.Type = vearchpb.FieldType_STRING
+			} else if pro := proMap[field.Name]; pro != nil {
+				field.Type = pro.FieldType
+			}
+		}
+		pid, err := docPartition(space, doc)
+		if err != nil {
+			return err
+		}
+		docsMap[pid] = append(docsMap[pid], doc)
 	}
+	for pid, docs := range docsMap {
+		partition, err := mc.QueryPartition(ctx, pid)
+		if err != nil {
+			return err
+		}
+		server, err := mc.QueryServer(ctx, partition.LeaderID)
+		if err != nil {
+			return err
+		}
+		if err := client.UpsertDocs(server.RpcAddr(), pid, docs); err != nil {
+			return fmt.Errorf("upsert documents to partition %d err: %v", pid, err)
+		}
+	}
+	return nil
+}
 
-	if err := spaceService.CreateSpace(ctx, dbService, dbName, space); err != nil {
-		log.Error("createSpace err: %v", err)
-		return res, err
+// docPartition returns the partition of the space a document is written to,
+// the same as the router.
+func docPartition(space *entity.Space, doc *vearchpb.Document) (entity.PartitionID, error) {
+	slot := murmur3.Sum32WithSeed([]byte(doc.PKey), 0)
+	if space.PartitionRule == nil {
+		return space.PartitionId(slot), nil
+	}
+	for _, field := range doc.Fields {
+		if field.Name != space.PartitionRule.Field {
+			continue
+		}
+		pids, err := space.PartitionIdsByRuleField(field.Value)
+		if err != nil {
+			return 0, err
+		}
+		return pids[slot%uint32(len(pids))], nil
 	}
+	return 0, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("document must have partition rule field"))
+}
 
-	cfg, err := configService.GetSpaceConfigByName(ctx, dbName, spaceName)
+// backupPath is the dir of a backup of the cluster in the store.
+func backupPath(dbName, spaceName string, backupID int) string {
+	return sourcePath(&entity.BackupSource{ClusterName: config.Conf().Global.Name, DbName: dbName, SpaceName: spaceName, BackupID: backupID})
+}
+
+// sourcePath is the dir of a backup to restore in the store.
+func sourcePath(src *entity.BackupSource) string {
+	return filepath.Join(src.ClusterName, "backup", src.DbName, src.SpaceName, strconv.Itoa(src.BackupID))
+}
+
+// backupDirs returns the ids of the backup dirs of the space in order,
+// including the backups deleted but with files used by others.
+func backupDirs(ctx context.Context, store backupstore.Store, dbName, spaceName string) ([]int, error) {
+	s3Path := filepath.Join(config.Conf().Global.Name, "backup", dbName, spaceName)
+	keys, err := store.List(ctx, s3Path, false)
 	if err != nil {
-		log.Error("get space config err: %s", err.Error())
-		return res, err
+		return nil, err
+	}
+	backupIDs := make([]int, 0, len(keys))
+	for _, key := range keys {
+		if !strings.HasSuffix(key, "/") {
+			continue
+		}
+		relPath := strings.TrimSuffix(strings.TrimPrefix(key, s3Path+"/"), "/")
+		backupID, err := strconv.Atoi(relPath)
+		if err != nil {
+			log.Error("failed to parse backup ID: %v", err)
+			continue
+		}
+		backupIDs = append(backupIDs, backupID)
 	}
+	sort.Ints(backupIDs)
+	return backupIDs, nil
+}
 
-	err = configService.UpdateSpaceConfig(ctx, space, cfg)
+// list returns the ids of the backups of the space in order, a backup is
+// listed while its schema exists.
+func list(ctx context.Context, store backupstore.Store, dbName, spaceName string) ([]int, error) {
+	dirs, err := backupDirs(ctx, store, dbName, spaceName)
 	if err != nil {
-		log.Error("update space config err: %s", err.Error())
-		return res, err
+		return nil, err
 	}
-	return res, nil
+	backupIDs := make([]int, 0, len(dirs))
+	for _, backupID := range dirs {
+		dir := backupPath(dbName, spaceName, backupID)
+		keys, err := store.List(ctx, dir, false)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(keys, filepath.Join(dir, spaceName+".schema")) {
+			backupIDs = append(backupIDs, backupID)
+		}
+	}
+	return backupIDs, nil
 }
 
-func extractBackupID(key string) (int64, error) {
-	parts := strings.Split(key, "/")
-	if len(parts) < 5 {
-		return 0, fmt.Errorf("invalid backup key format: %s", key)
+// restoreParts returns the parts of the backup in dir in order. The parts
+// of a backup without manifest are its partition dirs.
+func restoreParts(ctx context.Context, store backupstore.Store, dir string) ([]entity.PartitionID, error) {
+	manifest := &entity.BackupManifest{}
+	err := backupstore.GetJSON(ctx, store, filepath.Join(dir, entity.BackupManifestFile), manifest)
+	if err != nil && !backupstore.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to get backup manifest: %v", err)
 	}
 
-	backupIDStr := parts[len(parts)-2]
+	parts := make([]entity.PartitionID, 0)
+	if err == nil {
+		for _, p := range manifest.Partitions {
+			parts = append(parts, p.Part)
+		}
+	} else {
+		keys, err := store.List(ctx, dir, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backup objects: %v", err)
+		}
+		for _, key := range keys {
+			if !strings.HasSuffix(key, "/") {
+				continue
+			}
+			relPath := strings.TrimSuffix(strings.TrimPrefix(key, dir+"/"), "/")
+			part, err := strconv.ParseUint(relPath, 10, 32)
+			if err != nil {
+				log.Error("failed to parse partition ID: %v", err)
+				continue
+			}
+			parts = append(parts, entity.PartitionID(part))
+		}
+	}
+	slices.Sort(parts)
+	return parts, nil
+}
 
-	backupID, err := strconv.ParseInt(backupIDStr, 10, 64)
-	if err != nil {
-		return 0, fmt.Errorf("invalid backup ID: %s, err: %v", backupIDStr, err)
+// describe returns the manifest of a backup with the progress of its
+// partitions.
+func describe(ctx context.Context, store backupstore.Store, dbName, spaceName string, backupID int) (*entity.BackupManifest, error) {
+	dir := backupPath(dbName, spaceName, backupID)
+	manifest := &entity.BackupManifest{}
+	err := backupstore.GetJSON(ctx, store, filepath.Join(dir, entity.BackupManifestFile), manifest)
+	if backupstore.IsNotExist(err) {
+		manifest = &entity.BackupManifest{BackupID: backupID, DbName: dbName, SpaceName: spaceName}
+		parts, err := restoreParts(ctx, store, backupPath(dbName, spaceName, backupID))
+		if err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			manifest.Partitions = append(manifest.Partitions, &entity.BackupPartition{Part: part})
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get backup manifest: %v", err)
 	}
 
-	return backupID, nil
+	manifest.Done = len(manifest.Partitions) > 0
+	for _, p := range manifest.Partitions {
+		files := &entity.BackupFiles{}
+		err := backupstore.GetJSON(ctx, store, filepath.Join(dir, strconv.Itoa(int(p.Part)), entity.BackupFilesFile), files)
+		if err != nil {
+			if !backupstore.IsNotExist(err) {
+				return nil, err
+			}
+			manifest.Done = false
+			continue
+		}
+		p.Done = true
+		p.DocNum = files.DocNum
+		for _, file := range files.Files {
+			p.Size += file.Size
+		}
+		manifest.DocNum += p.DocNum
+		manifest.Size += p.Size
+	}
+	return manifest, nil
 }
 
-func list(ctx context.Context, backup *entity.BackupSpaceRequest, minioClient *minio.Client, dbName, spaceName string) (backupIDs []int) {
-	s3Path := filepath.Join(config.Conf().Global.Name, "backup", dbName, spaceName)
-	objectCh := minioClient.ListObjects(ctx, backup.S3Param.BucketName, minio.ListObjectsOptions{
-		Prefix:    s3Path + "/",
-		Recursive: false,
-	})
-	backupIDs = make([]int, 0)
-
-	for object := range objectCh {
-		if object.Err != nil {
-			log.Error("failed to list S3 objects: %v", object.Err)
+// baseBackup returns the last backup an incremental backup of the space can
+// be based on, 0 if there is none.
+func baseBackup(ctx context.Context, store backupstore.Store, dbName string, space *entity.Space) int {
+	backupIDs, err := list(ctx, store, dbName, space.Name)
+	if err != nil {
+		log.Error("failed to list backups: %v", err)
+		return 0
+	}
+	for i := len(backupIDs) - 1; i >= 0; i-- {
+		manifest, err := describe(ctx, store, dbName, space.Name, backupIDs[i])
+		if err != nil || !manifest.Done {
 			continue
 		}
-		relPath := strings.TrimPrefix(object.Key, s3Path+"/")
-		relPath = strings.TrimSuffix(relPath, "/")
-		log.Info("object: %s", relPath)
-		backupID, err := strconv.ParseInt(relPath, 10, 64)
+		// files of other partitions can't be compared
+		if manifest.SpaceVersion != space.Version || len(manifest.Partitions) != len(space.Partitions) {
+			return 0
+		}
+		return manifest.BackupID
+	}
+	return 0
+}
+
+// deleteBackup removes the schema and manifest of a backup, then the files
+// not used by the incremental backups left.
+func deleteBackup(ctx context.Context, store backupstore.Store, dbName, spaceName string, backupIDs ...int) error {
+	for _, backupID := range backupIDs {
+		dir := backupPath(dbName, spaceName, backupID)
+		for _, file := range []string{spaceName + ".schema", entity.BackupManifestFile} {
+			if err := store.Remove(ctx, filepath.Join(dir, file)); err != nil {
+				return err
+			}
+		}
+		log.Info("delete backup %d of space %s/%s", backupID, dbName, spaceName)
+	}
+	return gcBackups(ctx, store, dbName, spaceName)
+}
+
+// gcBackups removes the files of the deleted backups of the space which
+// aren't used by the backups left.
+func gcBackups(ctx context.Context, store backupstore.Store, dbName, spaceName string) error {
+	dirs, err := backupDirs(ctx, store, dbName, spaceName)
+	if err != nil {
+		return err
+	}
+	backupIDs, err := list(ctx, store, dbName, spaceName)
+	if err != nil {
+		return err
+	}
+
+	used := make(map[string]bool)
+	for _, backupID := range backupIDs {
+		parts, err := restoreParts(ctx, store, backupPath(dbName, spaceName, backupID))
 		if err != nil {
-			log.Error("failed to parse backup ID: %v", err)
+			return err
+		}
+		for _, part := range parts {
+			files := &entity.BackupFiles{}
+			key := filepath.Join(backupPath(dbName, spaceName, backupID), strconv.Itoa(int(part)), entity.BackupFilesFile)
+			if err := backupstore.GetJSON(ctx, store, key, files); err != nil {
+				if backupstore.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+			for _, file := range files.Files {
+				if file.BackupID != backupID {
+					used[filepath.Join(backupPath(dbName, spaceName, file.BackupID), strconv.Itoa(int(part)), file.Path)] = true
+				}
+			}
+		}
+	}
+
+	for _, backupID := range dirs {
+		if slices.Contains(backupIDs, backupID) {
 			continue
 		}
-		backupIDs = append(backupIDs, int(backupID))
+		keys, err := store.List(ctx, backupPath(dbName, spaceName, backupID), true)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if used[key] {
+				continue
+			}
+			if err := store.Remove(ctx, key); err != nil {
+				return err
+			}
+		}
 	}
-	return backupIDs
+	return nil
+}
+
+// ApplyRetention deletes the backups of the space expired by keep_last and
+// keep_days of req. Backups without manifest are kept.
+func (s *BackupService) ApplyRetention(ctx context.Context, dbName, spaceName string, req *entity.BackupSpaceRequest) ([]int, error) {
+	if req.KeepLast == 0 && req.KeepDays == 0 {
+		return nil, nil
+	}
+	store, err := newBackupStore(req)
+	if err != nil {
+		return nil, err
+	}
+	backupIDs, err := list(ctx, store, dbName, spaceName)
+	if err != nil {
+		return nil, err
+	}
+	manifests := make([]*entity.BackupManifest, 0, len(backupIDs))
+	for _, backupID := range backupIDs {
+		manifest := &entity.BackupManifest{}
+		key := filepath.Join(backupPath(dbName, spaceName, backupID), entity.BackupManifestFile)
+		if err := backupstore.GetJSON(ctx, store, key, manifest); err != nil {
+			if backupstore.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		manifests = append(manifests, manifest)
+	}
+	expired := entity.ExpiredBackups(manifests, req.KeepLast, req.KeepDays, time.Now())
+	if len(expired) == 0 {
+		return expired, nil
+	}
+	return expired, deleteBackup(ctx, store, dbName, spaceName, expired...)
+}
+
+// BackupTarget is a partition replica the backup command is sent to.
+type BackupTarget struct {
+	PartitionID entity.PartitionID
+	NodeID      entity.NodeID
+	Addr        string
+	// Part is the partition id in the backup
+	Part entity.PartitionID
+}
+
+func newBackupStore(req *entity.BackupSpaceRequest) (backupstore.Store, error) {
+	store, err := backupstore.New(req)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return store, nil
+}
+
+func backupStorage(req *entity.BackupSpaceRequest) entity.BackupStorage {
+	if req.Storage == "" {
+		return entity.BackupStorageS3
+	}
+	return req.Storage
 }
 
 func (s *BackupService) BackupSpace(ctx context.Context, dbService *DBService, spaceService *SpaceService, configService *ConfigService, dbName, spaceName string, req *entity.BackupSpaceRequest) (res *entity.BackupSpaceResponse, err error) {
 	// bucket/cluster/backup/db/space/backup_id/data_file
 	// bucket/cluster/export/db/space/json_file
+	// the bucket is local_param.path for local storage
 
 	res = &entity.BackupSpaceResponse{}
-	// scan space checkpoint list
-	minioClient, err := minio.New(req.S3Param.EndPoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(req.S3Param.AccessKey, req.S3Param.SecretKey, ""),
-		Secure: req.S3Param.UseSSL,
-		Region: req.S3Param.Region,
-	})
+	if req.Command == "restore" {
+		req.Source = req.RestoreSource(config.Conf().Global.Name, dbName, spaceName)
+	}
+	if req.Command == "list" {
+		// scan space checkpoint list
+		store, err := newBackupStore(req)
+		if err != nil {
+			return nil, err
+		}
+		res.BackupIDs, err = list(ctx, store, dbName, spaceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %v", err)
+		}
+		return res, nil
+	}
+	if req.Command == "describe" || req.Command == "delete" {
+		store, err := newBackupStore(req)
+		if err != nil {
+			return nil, err
+		}
+		backupIDs, err := list(ctx, store, dbName, spaceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %v", err)
+		}
+		if !slices.Contains(backupIDs, req.BackupID) {
+			return nil, fmt.Errorf("backup %d of space %s/%s not exist", req.BackupID, dbName, spaceName)
+		}
+		res.BackupID = req.BackupID
+		if req.Command == "describe" {
+			res.Backup, err = describe(ctx, store, dbName, spaceName, req.BackupID)
+		} else {
+			err = deleteBackup(ctx, store, dbName, spaceName, req.BackupID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	res, err = s.BackupSchema(ctx, dbService, spaceService, configService, dbName, spaceName, req)
 	if err != nil {
-		err := fmt.Errorf("failed to create minio client: %+v", err)
-		log.Error(err)
 		return nil, err
 	}
 
-	if req.Command == "list" {
-		res.BackupIDs = list(ctx, req, minioClient, dbName, spaceName)
-		return res, nil
+	targets, err := s.BackupTargets(ctx, dbName, spaceName, req)
+	if err != nil || targets == nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		log.Debug("invoke nodeID [%v], partition [%v] address [%+v]", target.NodeID, target.PartitionID, target.Addr)
+		req.Part = target.Part
+		err = client.BackupSpace(target.Addr, req, target.PartitionID)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
 	}
+	return res, nil
+}
 
-	if req.Command == "create" {
-		path := filepath.Join(config.Conf().Global.Name, "backup", dbName, spaceName)
-		objectCh := minioClient.ListObjects(ctx, req.S3Param.BucketName, minio.ListObjectsOptions{
-			Prefix: path,
-		})
-		backupID := int64(1) // only support bacup ID 1
-		for object := range objectCh {
-			if object.Err != nil {
-				log.Error("failed to list objects: %v", object.Err)
-				continue
-			}
+// BackupSchema sets the backup id of a new backup, then backups, exports or
+// restores the schema of the space.
+func (s *BackupService) BackupSchema(ctx context.Context, dbService *DBService, spaceService *SpaceService, configService *ConfigService, dbName, spaceName string, req *entity.BackupSpaceRequest) (res *entity.BackupSpaceResponse, err error) {
+	res = &entity.BackupSpaceResponse{}
+	store, err := newBackupStore(req)
+	if err != nil {
+		return nil, err
+	}
 
-			id, err := extractBackupID(object.Key)
+	if req.Command == "create" {
+		// ids of deleted backups aren't reused as their files may be kept
+		dirs, err := backupDirs(ctx, store, dbName, spaceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list backups: %v", err)
+		}
+		req.BackupID = 1
+		if len(dirs) > 0 {
+			req.BackupID = dirs[len(dirs)-1] + 1
+		}
+		req.BaseBackupID = 0
+		if req.Incremental {
+			mc := s.client.Master()
+			dbID, err := mc.QueryDBName2ID(ctx, dbName)
 			if err != nil {
-				log.Error("failed to extract backup ID: %v", err)
-				continue
+				return nil, err
 			}
-			log.Debug("found backup ID: %d", id)
-			if id > backupID {
-				backupID = id
+			space, err := mc.QuerySpaceByName(ctx, dbID, spaceName)
+			if err != nil {
+				return nil, err
 			}
+			req.BaseBackupID = baseBackup(ctx, store, dbName, space)
 		}
-		req.BackupID = int(backupID)
+		log.Info("create backup %d of space %s/%s, base backup %d", req.BackupID, dbName, spaceName, req.BaseBackupID)
 	}
 	if req.Command == "create" || req.Command == "export" {
-		res, err = s.backupSchema(ctx, dbName, spaceName, req)
+		res, err = s.backupSchema(ctx, store, dbName, spaceName, req)
 		if err != nil {
 			log.Error(err)
 			return nil, err
 		}
 	} else if req.Command == "restore" {
-		res, err = s.restoreSchema(ctx, dbService, spaceService, configService, dbName, spaceName, req)
+		res, err = s.restoreSchema(ctx, store, dbService, spaceService, configService, dbName, spaceName, req)
 		if err != nil {
 			log.Error(err)
 			return nil, err
 		}
 	}
+	return res, nil
+}
 
+// BackupTargets returns the partition replicas to send the backup command
+// to, the leaders for create and export and all replicas for restore. It's
+// nil if the space has no partitions.
+func (s *BackupService) BackupTargets(ctx context.Context, dbName, spaceName string, req *entity.BackupSpaceRequest) ([]*BackupTarget, error) {
 	mc := s.client.Master()
 	// get space info
 	dbID, err := mc.QueryDBName2ID(ctx, dbName)
@@ -333,62 +775,68 @@ func (s *BackupService) BackupSpace(ctx context.Context, dbService *DBService, s
 	// invoke all space nodeID
 	s3PartitionMap := make(map[entity.PartitionID]entity.PartitionID, 0)
 	if req.Command == "restore" {
-		s3Path := filepath.Join(config.Conf().Global.Name, "backup", dbName, spaceName, fmt.Sprintf("%d", req.BackupID))
-		objectCh := minioClient.ListObjects(ctx, req.S3Param.BucketName, minio.ListObjectsOptions{
-			Prefix:    s3Path + "/",
-			Recursive: false,
+		store, err := newBackupStore(req)
+		if err != nil {
+			return nil, err
+		}
+		src := req.RestoreSource(config.Conf().Global.Name, dbName, spaceName)
+		parts, err := restoreParts(ctx, store, sourcePath(src))
+		if err != nil {
+			return nil, err
+		}
+		// the partitions restored are created in the order of the parts
+		partitions := slices.Clone(space.Partitions)
+		sort.Slice(partitions, func(i, j int) bool {
+			return partitions[i].Id < partitions[j].Id
 		})
-
-		i := 0
-		p := space.Partitions[i]
-		for object := range objectCh {
-			if object.Err != nil {
-				log.Error("failed to list S3 objects: %v", object.Err)
-				continue
-			}
-			relPath := strings.TrimPrefix(object.Key, s3Path+"/")
-			relPath = strings.TrimSuffix(relPath, "/")
-			log.Info("object: %s", relPath)
-			partitionID, err := strconv.ParseInt(relPath, 10, 64)
-			if err != nil {
-				log.Error("failed to parse partition ID: %v", err)
-				continue
-			}
-			s3PartitionMap[p.Id] = entity.PartitionID(partitionID)
-			i++
-			if i < len(space.Partitions) {
-				p = space.Partitions[i]
+		for i, p := range partitions {
+			if i < len(parts) {
+				s3PartitionMap[p.Id] = parts[i]
 			}
 		}
 	} else if req.Command == "create" || req.Command == "export" {
-		remoteBasePid := entity.PartitionID(math.MaxUint32)
-		for _, p := range space.Partitions {
-			partition, err := mc.QueryPartition(ctx, p.Id)
-			if err != nil {
-				log.Error(err)
-				continue
-			}
-			if partition.Replicas == nil {
-				continue
-			}
+		s3PartitionMap = s.backupParts(ctx, space)
+	}
 
-			if remoteBasePid > partition.Id {
-				remoteBasePid = partition.Id
-			}
+	targets := make([]*BackupTarget, 0, len(space.Partitions))
+	for _, p := range space.Partitions {
+		partition, err := mc.QueryPartition(ctx, p.Id)
+		if err != nil {
+			log.Error(err)
+			continue
 		}
-		for _, p := range space.Partitions {
-			partition, err := mc.QueryPartition(ctx, p.Id)
-			if err != nil {
-				log.Error(err)
+		if partition.Replicas == nil {
+			continue
+		}
+
+		for _, nodeID := range partition.Replicas {
+			log.Debug("nodeID is [%+v], partition is [%+v], [%+v]", nodeID, partition.Id, partition.LeaderID)
+			// a single replica without leader is backuped too
+			single := len(partition.Replicas) == 1 && partition.LeaderID == 0
+			if !single && nodeID != partition.LeaderID && req.Command != "restore" {
 				continue
 			}
-			if partition.Replicas == nil {
+			server, err := mc.QueryServer(ctx, nodeID)
+			if err != nil {
+				log.Error(err)
 				continue
 			}
-			s3PartitionMap[p.Id] = p.Id - remoteBasePid + 1
+			targets = append(targets, &BackupTarget{
+				PartitionID: partition.Id,
+				NodeID:      nodeID,
+				Addr:        server.RpcAddr(),
+				Part:        s3PartitionMap[partition.Id],
+			})
 		}
 	}
+	return targets, nil
+}
 
+// backupParts maps the partitions of the space to the parts of a backup.
+func (s *BackupService) backupParts(ctx context.Context, space *entity.Space) map[entity.PartitionID]entity.PartitionID {
+	mc := s.client.Master()
+	parts := make(map[entity.PartitionID]entity.PartitionID, len(space.Partitions))
+	remoteBasePid := entity.PartitionID(math.MaxUint32)
 	for _, p := range space.Partitions {
 		partition, err := mc.QueryPartition(ctx, p.Id)
 		if err != nil {
@@ -399,39 +847,166 @@ func (s *BackupService) BackupSpace(ctx context.Context, dbService *DBService, s
 			continue
 		}
 
-		if len(partition.Replicas) == 1 && partition.LeaderID == 0 {
-			server, err := mc.QueryServer(ctx, partition.Replicas[0])
-			if err != nil {
-				log.Error(err)
-				continue
+		if remoteBasePid > partition.Id {
+			remoteBasePid = partition.Id
+		}
+	}
+	for _, p := range space.Partitions {
+		partition, err := mc.QueryPartition(ctx, p.Id)
+		if err != nil {
+			log.Error(err)
+			continue
+		}
+		if partition.Replicas == nil {
+			continue
+		}
+		parts[p.Id] = p.Id - remoteBasePid + 1
+	}
+	return parts
+}
+
+// SetSchedule saves the backup schedule of a space.
+func (s *BackupService) SetSchedule(ctx context.Context, schedule *entity.BackupSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	mc := s.client.Master()
+	dbID, err := mc.QueryDBName2ID(ctx, schedule.DbName)
+	if err != nil {
+		return err
+	}
+	if _, err := mc.QuerySpaceByName(ctx, dbID, schedule.SpaceName); err != nil {
+		return err
+	}
+	// the next backup is created an interval after the schedule is set
+	schedule.LastRunTime = time.Now().Unix()
+	value, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	return mc.Put(ctx, entity.BackupScheduleKey(schedule.DbName, schedule.SpaceName), value)
+}
+
+func (s *BackupService) GetSchedule(ctx context.Context, dbName, spaceName string) (*entity.BackupSchedule, error) {
+	value, err := s.client.Master().Get(ctx, entity.BackupScheduleKey(dbName, spaceName))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("backup schedule of space %s/%s not exist", dbName, spaceName))
+	}
+	schedule := &entity.BackupSchedule{}
+	if err := json.Unmarshal(value, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+func (s *BackupService) DeleteSchedule(ctx context.Context, dbName, spaceName string) error {
+	if _, err := s.GetSchedule(ctx, dbName, spaceName); err != nil {
+		return err
+	}
+	return s.client.Master().Delete(ctx, entity.BackupScheduleKey(dbName, spaceName))
+}
+
+func (s *BackupService) ListSchedules(ctx context.Context) ([]*entity.BackupSchedule, error) {
+	_, values, err := s.client.Master().PrefixScan(ctx, entity.PrefixBackupSchedule)
+	if err != nil {
+		return nil, err
+	}
+	schedules := make([]*entity.BackupSchedule, 0, len(values))
+	for _, value := range values {
+		schedule := &entity.BackupSchedule{}
+		if err := json.Unmarshal(value, schedule); err != nil {
+			log.Error("unmarshal backup schedule err: %v", err)
+			continue
+		}
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+// StartSchedule submits the backup tasks of the schedules when they are due.
+func (s *BackupService) StartSchedule(ctx context.Context, taskService *TaskService) {
+	go func() {
+		ticker := time.NewTicker(backupScheduleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runSchedules(ctx, taskService)
+			}
+		}
+	}()
+}
+
+func (s *BackupService) runSchedules(ctx context.Context, taskService *TaskService) {
+	schedules, err := s.ListSchedules(ctx)
+	if err != nil {
+		log.Error("list backup schedules err: %v", err)
+		return
+	}
+	now := time.Now()
+	for _, schedule := range schedules {
+		if !schedule.Due(now) {
+			continue
+		}
+		key := entity.BackupScheduleKey(schedule.DbName, schedule.SpaceName)
+		// only the master updating the last run time submits the task
+		claimed := false
+		err := s.client.Master().STM(ctx, func(stm concurrency.STM) error {
+			claimed = false
+			value := stm.Get(key)
+			if value == "" {
+				return nil
+			}
+			current := &entity.BackupSchedule{}
+			if err := json.Unmarshal([]byte(value), current); err != nil {
+				return err
+			}
+			if !current.Due(now) {
+				return nil
 			}
-			log.Debug("invoke nodeID [%+v], address [%+v]", partition.Id, server.RpcAddr())
-			req.Part = s3PartitionMap[partition.Id]
-			err = client.BackupSpace(server.RpcAddr(), req, partition.Id)
+			current.LastRunTime = now.Unix()
+			bytes, err := json.Marshal(current)
 			if err != nil {
-				log.Error(err)
-				continue
+				return err
 			}
-		} else {
-			for _, nodeID := range partition.Replicas {
-				log.Debug("nodeID is [%+v], partition is [%+v], [%+v]", nodeID, partition.Id, partition.LeaderID)
-				if nodeID != partition.LeaderID && req.Command != "restore" {
-					continue
-				}
-				server, err := mc.QueryServer(ctx, nodeID)
-				if err != nil {
-					log.Error(err)
-					continue
-				}
-				log.Debug("invoke nodeID [%v], partition [%v] address [%+v]", nodeID, partition.Id, server.RpcAddr())
-				req.Part = s3PartitionMap[partition.Id]
-				err = client.BackupSpace(server.RpcAddr(), req, partition.Id)
-				if err != nil {
-					log.Error(err)
-					continue
-				}
+			stm.Put(key, string(bytes))
+			schedule, claimed = current, true
+			return nil
+		})
+		if err != nil {
+			log.Error("update backup schedule of space %s/%s err: %v", schedule.DbName, schedule.SpaceName, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		params, err := json.Marshal(schedule.Backup)
+		if err != nil {
+			log.Error("marshal backup schedule err: %v", err)
+			continue
+		}
+		task, err := taskService.SubmitTask(ctx, schedule.User, &entity.TaskRequest{
+			Type:      entity.TaskBackupSpace,
+			DbName:    schedule.DbName,
+			SpaceName: schedule.SpaceName,
+			Params:    params,
+		})
+		if err != nil {
+			log.Error("submit scheduled backup of space %s/%s err: %v", schedule.DbName, schedule.SpaceName, err)
+			continue
+		}
+		log.Info("submit scheduled backup task %d of space %s/%s", task.ID, schedule.DbName, schedule.SpaceName)
+		schedule.LastTaskID = task.ID
+		if value, err := json.Marshal(schedule); err == nil {
+			if err := s.client.Master().Put(ctx, key, value); err != nil {
+				log.Error("update backup schedule of space %s/%s err: %v", schedule.DbName, schedule.SpaceName, err)
 			}
 		}
 	}
-	return res, nil
 }
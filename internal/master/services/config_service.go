@@ -17,11 +17,13 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 )
 
 type ConfigService struct {
@@ -211,6 +213,23 @@ func (s *ConfigService) ModifyRequestLimitCfg(ctx context.Context, cfg *entity.R
 		if cfg.RequestLimitEnabled && cfg.TotalWriteLimit > 0 {
 			new_cfg.TotalWriteLimit = cfg.TotalWriteLimit
 		}
+		new_cfg.UserLimits = mergeLimits(new_cfg.UserLimits, cfg.UserLimits)
+		new_cfg.SpaceLimits = mergeLimits(new_cfg.SpaceLimits, cfg.SpaceLimits)
+		new_cfg.SpaceQuotas = mergeLimits(new_cfg.SpaceQuotas, cfg.SpaceQuotas)
+	} else {
+		new_cfg.UserLimits = mergeLimits(nil, cfg.UserLimits)
+		new_cfg.SpaceLimits = mergeLimits(nil, cfg.SpaceLimits)
+		new_cfg.SpaceQuotas = mergeLimits(nil, cfg.SpaceQuotas)
+	}
+	for key := range new_cfg.SpaceLimits {
+		if err := checkLimitSpaceKey(key); err != nil {
+			return err
+		}
+	}
+	for key := range new_cfg.SpaceQuotas {
+		if err := checkLimitSpaceKey(key); err != nil {
+			return err
+		}
 	}
 
 	marshal, err := json.Marshal(new_cfg)
@@ -224,3 +243,30 @@ func (s *ConfigService) ModifyRequestLimitCfg(ctx context.Context, cfg *entity.R
 
 	return nil
 }
+
+// mergeLimits sets the limits of the keys in changes, a key with null is
+// removed.
+func mergeLimits[T any](limits, changes map[string]*T) map[string]*T {
+	if len(limits) == 0 && len(changes) == 0 {
+		return nil
+	}
+	merged := make(map[string]*T, len(limits)+len(changes))
+	for key, limit := range limits {
+		merged[key] = limit
+	}
+	for key, limit := range changes {
+		if limit == nil {
+			delete(merged, key)
+		} else {
+			merged[key] = limit
+		}
+	}
+	return merged
+}
+
+func checkLimitSpaceKey(key string) error {
+	if db, space, ok := strings.Cut(key, "/"); !ok || db == "" || space == "" {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("space key %s of request limit should be db_name/space_name", key))
+	}
+	return nil
+}
@@ -223,6 +223,36 @@ func isPartitionIdInSlice[T comparable](partitionId T, partitionIds []T) (bool,
 // change replicas, add or delete
 func (s *MemberService) ChangeReplica(ctx context.Context, dbService *DBService, spaceService *SpaceService, dbModify *entity.DBModify) (e error) {
 	// panic process
+	defer errutil.CatchError(&e)
+	changeServer, replicaNum, err := s.PlanChangeReplica(ctx, dbService, dbModify)
+	errutil.ThrowError(err)
+
+	changeServerStr, _ := json.Marshal(changeServer)
+	log.Info("need to change partition is [%+v] ", changeServerStr)
+	// sleep time
+	sleepTime := config.Conf().PS.RaftHeartbeatInterval
+	// change partition
+	for _, cm := range changeServer {
+		if e = s.ChangeMember(ctx, spaceService, cm); e != nil {
+			info := fmt.Sprintf("changing partition member [%+v] failed, error is %s ", cm, e)
+			log.Error(info)
+			panic(fmt.Errorf(info))
+		}
+		log.Info("changing partition member [%+v] succeeded ", cm)
+		if dbModify.Method == proto.ConfRemoveNode {
+			time.Sleep(time.Duration(sleepTime*10) * time.Millisecond)
+			log.Info("remove partition sleep [%+d] milliseconds", sleepTime)
+		}
+	}
+	log.Info("all partition member changes succeeded")
+
+	errutil.ThrowError(s.SetReplicaNum(ctx, spaceService, dbModify.DbName, dbModify.SpaceName, replicaNum))
+	return e
+}
+
+// PlanChangeReplica returns the member changes to add or delete a replica of
+// every partition of a space, and the replica num after them.
+func (s *MemberService) PlanChangeReplica(ctx context.Context, dbService *DBService, dbModify *entity.DBModify) (changeServer []*entity.ChangeMember, replicaNum uint8, e error) {
 	defer errutil.CatchError(&e)
 	mc := s.client.Master()
 	// query server
@@ -236,10 +266,10 @@ func (s *MemberService) ChangeReplica(ctx context.Context, dbService *DBService,
 	if dbModify.Method == proto.ConfAddNode && (int(space.ReplicaNum)+1) > len(servers) {
 		err := vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ReplicaNum [%d] exceeds server size [%d]",
 			int(space.ReplicaNum)+1, len(servers)))
-		return err
+		return nil, 0, err
 	}
 	// change space replicas of partition, add or delete one
-	changeServer := make([]*entity.ChangeMember, 0)
+	changeServer = make([]*entity.ChangeMember, 0)
 	for _, partition := range space.Partitions {
 		// sort servers, low to high
 		sort.Slice(servers, func(i, j int) bool {
@@ -268,28 +298,23 @@ func (s *MemberService) ChangeReplica(ctx context.Context, dbService *DBService,
 			}
 		}
 	}
-
-	changeServerStr, _ := json.Marshal(changeServer)
-	log.Info("need to change partition is [%+v] ", changeServerStr)
-	// sleep time
-	sleepTime := config.Conf().PS.RaftHeartbeatInterval
-	// change partition
-	for _, cm := range changeServer {
-		if e = s.ChangeMember(ctx, spaceService, cm); e != nil {
-			info := fmt.Sprintf("changing partition member [%+v] failed, error is %s ", cm, e)
-			log.Error(info)
-			panic(fmt.Errorf(info))
-		}
-		log.Info("changing partition member [%+v] succeeded ", cm)
-		if dbModify.Method == proto.ConfRemoveNode {
-			time.Sleep(time.Duration(sleepTime*10) * time.Millisecond)
-			log.Info("remove partition sleep [%+d] milliseconds", sleepTime)
-		}
+	replicaNum = space.ReplicaNum
+	switch dbModify.Method {
+	case proto.ConfAddNode:
+		replicaNum = space.ReplicaNum + 1
+	case proto.ConfRemoveNode:
+		replicaNum = space.ReplicaNum - 1
 	}
-	log.Info("all partition member changes succeeded")
+	return changeServer, replicaNum, nil
+}
 
+// SetReplicaNum updates the replica num of a space after its partition
+// members are changed.
+func (s *MemberService) SetReplicaNum(ctx context.Context, spaceService *SpaceService, dbName, spaceName string, replicaNum uint8) (e error) {
+	defer errutil.CatchError(&e)
+	mc := s.client.Master()
 	// update space ReplicaNum, it will lock cluster, to create space
-	mutex := mc.NewLock(ctx, entity.LockSpaceKey(dbModify.DbName, dbModify.SpaceName), time.Second*300)
+	mutex := mc.NewLock(ctx, entity.LockSpaceKey(dbName, spaceName), time.Second*300)
 	if _, err := mutex.TryLock(); err != nil {
 		errutil.ThrowError(err)
 	}
@@ -298,16 +323,38 @@ func (s *MemberService) ChangeReplica(ctx context.Context, dbService *DBService,
 			log.Error("failed to unlock space, the error is: %v ", err)
 		}
 	}()
-	space, err = mc.QuerySpaceByName(ctx, dbID, dbModify.SpaceName)
+	dbID, err := mc.QueryDBName2ID(ctx, dbName)
 	errutil.ThrowError(err)
-	switch dbModify.Method {
-	case proto.ConfAddNode:
-		space.ReplicaNum = space.ReplicaNum + 1
-	case proto.ConfRemoveNode:
-		space.ReplicaNum = space.ReplicaNum - 1
-	}
+	space, err := mc.QuerySpaceByName(ctx, dbID, spaceName)
+	errutil.ThrowError(err)
+	space.ReplicaNum = replicaNum
 	err = spaceService.UpdateSpaceData(ctx, space)
 	log.Info("updateSpace space [%+v] succeeded", space)
 	errutil.ThrowError(err)
-	return e
+	return nil
+}
+
+// ApplyChangeMember changes a partition member unless the change is already
+// applied, so a resumed task can apply its changes again.
+func (s *MemberService) ApplyChangeMember(ctx context.Context, spaceService *SpaceService, cm *entity.ChangeMember) error {
+	mc := s.client.Master()
+	partition, err := mc.QueryPartition(ctx, cm.PartitionID)
+	if err != nil {
+		return err
+	}
+	// replicas of the space are changed by ChangeMember
+	space, err := mc.QuerySpaceByID(ctx, partition.DBId, partition.SpaceId)
+	if err != nil {
+		return err
+	}
+	spacePartition := space.GetPartition(cm.PartitionID)
+	if spacePartition == nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_NOT_EXIST, fmt.Errorf("partition %d not in space %s", cm.PartitionID, space.Name))
+	}
+	exist := slices.Contains(spacePartition.Replicas, cm.NodeID)
+	if (cm.Method == proto.ConfAddNode && exist) || (cm.Method == proto.ConfRemoveNode && !exist) {
+		log.Info("partition member change [%+v] is already applied", cm)
+		return nil
+	}
+	return s.ChangeMember(ctx, spaceService, cm)
 }
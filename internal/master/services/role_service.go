@@ -219,6 +219,14 @@ func (s *RoleService) ChangeRolePrivilege(ctx context.Context, role *entity.Role
 				delete(old_role.Privileges, resource)
 			}
 		}
+		for _, scope := range role.Scopes {
+			if role.Operator == entity.Grant {
+				old_role.Scopes = grantScope(old_role.Scopes, scope)
+			}
+			if role.Operator == entity.Revoke {
+				old_role.Scopes = revokeScope(old_role.Scopes, scope)
+			}
+		}
 		marshal, err := json.Marshal(old_role)
 		if err != nil {
 			return err
@@ -228,3 +236,40 @@ func (s *RoleService) ChangeRolePrivilege(ctx context.Context, role *entity.Role
 	})
 	return old_role, nil
 }
+
+// grantScope adds the privileges of scope to the scope of scopes on the same
+// databases and spaces, or adds scope.
+func grantScope(scopes []*entity.RoleScope, scope *entity.RoleScope) []*entity.RoleScope {
+	for _, s := range scopes {
+		if !s.SameTarget(scope) {
+			continue
+		}
+		if s.Privileges == nil {
+			s.Privileges = make(map[entity.Resource]entity.Privilege)
+		}
+		for resource, privilege := range scope.Privileges {
+			s.Privileges[resource] = privilege
+		}
+		return scopes
+	}
+	return append(scopes, scope)
+}
+
+// revokeScope removes the resources of scope from the scope of scopes on the
+// same databases and spaces, the scope is removed without resources left or
+// when scope has no privileges.
+func revokeScope(scopes []*entity.RoleScope, scope *entity.RoleScope) []*entity.RoleScope {
+	kept := make([]*entity.RoleScope, 0, len(scopes))
+	for _, s := range scopes {
+		if s.SameTarget(scope) {
+			for resource := range scope.Privileges {
+				delete(s.Privileges, resource)
+			}
+			if len(scope.Privileges) == 0 || len(s.Privileges) == 0 {
+				continue
+			}
+		}
+		kept = append(kept, s)
+	}
+	return kept
+}
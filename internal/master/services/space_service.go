@@ -63,6 +63,13 @@ func (s *SpaceService) CreateSpace(ctx context.Context, dbs *DBService, dbName s
 		return err
 	}
 
+	// the expiry time of documents is kept in a field of the space
+	if space.TTL != nil {
+		if space.Fields, err = space.TTL.WithExpireField(space.Fields); err != nil {
+			return err
+		}
+	}
+
 	// to validate schema
 	_, err = mapping.SchemaMap(space.Fields)
 	if err != nil {
@@ -120,11 +127,18 @@ func (s *SpaceService) CreateSpace(ctx context.Context, dbs *DBService, dbName s
 		}
 	}
 
+	if space.TTL != nil {
+		if err := space.TTL.Validate(space); err != nil {
+			return err
+		}
+	}
+
 	if space.PartitionRule != nil {
 		err := space.PartitionRule.Validate(space, true)
 		if err != nil {
 			return err
 		}
+		names := space.PartitionRule.Names()
 		slotWidth := math.MaxUint32 / (space.PartitionNum * space.PartitionRule.Partitions)
 		for i := range space.PartitionNum * space.PartitionRule.Partitions {
 			partitionID, err := masterClient.NewIDGenerate(ctx, entity.PartitionIdSequence, 1, 5*time.Second)
@@ -135,7 +149,7 @@ func (s *SpaceService) CreateSpace(ctx context.Context, dbs *DBService, dbName s
 
 			space.Partitions = append(space.Partitions, &entity.Partition{
 				Id:      entity.PartitionID(partitionID),
-				Name:    space.PartitionRule.Ranges[i/space.PartitionNum].Name,
+				Name:    names[i/space.PartitionNum],
 				SpaceId: space.Id,
 				DBId:    space.DBId,
 				Slot:    entity.SlotID(i * slotWidth),
@@ -543,7 +557,7 @@ func (s *SpaceService) handleResourceUpdate(ctx context.Context, dbs *DBService,
 				fmt.Errorf("partition operator type should be %s or %s, but is %s",
 					entity.Add, entity.Drop, *updateRequest.PartitionOperatorType))
 		}
-		if space.PartitionRule == nil || space.PartitionRule.Ranges == nil {
+		if space.PartitionRule == nil || (space.PartitionRule.Ranges == nil && space.PartitionRule.Lists == nil) {
 			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR,
 				fmt.Errorf("space %s partition rule is empty", space.Name))
 		}
@@ -618,6 +632,16 @@ func (s *SpaceService) handleConfigurationUpdate(ctx context.Context, space *ent
 		}
 	}
 
+	// the expiry field of the ttl should stay an indexed date field
+	if temp.TTL != nil {
+		space.TTL = temp.TTL
+	}
+	if space.TTL != nil {
+		if err := space.TTL.Validate(space); err != nil {
+			return nil, err
+		}
+	}
+
 	// For index changes, we need a two-phase approach:
 	// Phase 1: Update partitions first (they will handle index operations)
 	// Phase 2: Update etcd metadata only after successful partition updates
@@ -845,6 +869,11 @@ func (s *SpaceService) arePropertiesEqual(oldProperty, newProperty *entity.Space
 		return false
 	}
 
+	// the full text index is built on write, it can not be added to existing documents
+	if oldProperty.IsFullText() != newProperty.IsFullText() {
+		return false
+	}
+
 	// Compare optional string fields
 	if (oldProperty.Format == nil) != (newProperty.Format == nil) {
 		return false
@@ -1005,20 +1034,22 @@ func (s *SpaceService) waitForPartitionsReady(ctx context.Context, masterClient
 
 func (s *SpaceService) updateSpacePartitonRule(ctx context.Context, dbs *DBService, partitionName *string, partitionOperatorType string, partitionRule *entity.PartitionRule, space *entity.Space) (*entity.Space, error) {
 	masterClient := s.client.Master()
+	rule := space.PartitionRule
+	if rule.Type != entity.RangePartition && rule.Type != entity.ListPartition {
+		// documents of every hash value would move
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partitions of %s partition rule can't be added or dropped", rule.Type))
+	}
 	if partitionOperatorType == entity.Drop {
 		if partitionName == nil || *partitionName == "" {
 			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition name is empty"))
 		}
-		found := false
-		for _, rangeRule := range space.PartitionRule.Ranges {
-			if rangeRule.Name == *partitionName {
-				found = true
-				break
-			}
-		}
-		if !found {
+		names := rule.Names()
+		if !slices.Contains(names, *partitionName) {
 			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition name %s not exist", *partitionName))
 		}
+		if len(names) == 1 {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition %s is the last one of the space, drop the space instead", *partitionName))
+		}
 		remainingPartitions := make([]*entity.Partition, 0)
 		for _, partition := range space.Partitions {
 			if partition.Name != *partitionName {
@@ -1041,23 +1072,48 @@ func (s *SpaceService) updateSpacePartitonRule(ctx context.Context, dbs *DBServi
 			}
 		}
 		space.Partitions = remainingPartitions
-		remainingRangeRules := make([]entity.Range, 0)
-		for _, rangeRule := range space.PartitionRule.Ranges {
-			if rangeRule.Name != *partitionName {
-				remainingRangeRules = append(remainingRangeRules, rangeRule)
+		if rule.Type == entity.ListPartition {
+			remainingListRules := make([]entity.List, 0)
+			for _, listRule := range rule.Lists {
+				if listRule.Name != *partitionName {
+					remainingListRules = append(remainingListRules, listRule)
+				}
+			}
+			rule.Lists = remainingListRules
+		} else {
+			remainingRangeRules := make([]entity.Range, 0)
+			for _, rangeRule := range rule.Ranges {
+				if rangeRule.Name != *partitionName {
+					remainingRangeRules = append(remainingRangeRules, rangeRule)
+				}
 			}
+			rule.Ranges = remainingRangeRules
 		}
-		space.PartitionRule.Ranges = remainingRangeRules
 	}
 
 	if partitionOperatorType == entity.Add {
 		if partitionRule == nil {
 			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition rule is empty"))
 		}
-		_, err := space.PartitionRule.RangeIsSame(partitionRule.Ranges)
+		var newNames []string
+		var err error
+		if rule.Type == entity.ListPartition {
+			_, err = rule.ListIsSame(partitionRule.Lists)
+			for _, listRule := range partitionRule.Lists {
+				newNames = append(newNames, listRule.Name)
+			}
+		} else {
+			_, err = rule.RangeIsSame(partitionRule.Ranges)
+			for _, rangeRule := range partitionRule.Ranges {
+				newNames = append(newNames, rangeRule.Name)
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
+		if len(newNames) == 0 {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition rule has no %s partition to add", rule.Type))
+		}
 
 		// find all servers for update space partition
 		servers, err := masterClient.QueryServers(ctx)
@@ -1077,8 +1133,8 @@ func (s *SpaceService) updateSpacePartitonRule(ctx context.Context, dbs *DBServi
 		}
 
 		newPartitions := make([]*entity.Partition, 0)
-		for _, rangeRule := range partitionRule.Ranges {
-			if rangeRule.Name == "" {
+		for _, name := range newNames {
+			if name == "" {
 				return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition name is empty"))
 			}
 			for j := 0; j < space.PartitionNum; j++ {
@@ -1090,16 +1146,20 @@ func (s *SpaceService) updateSpacePartitonRule(ctx context.Context, dbs *DBServi
 
 				newPartitions = append(newPartitions, &entity.Partition{
 					Id:      entity.PartitionID(partitionID),
-					Name:    rangeRule.Name,
+					Name:    name,
 					SpaceId: space.Id,
 					DBId:    space.DBId,
 				})
 				log.Debug("updateSpacePartitionrule Generate partition id %d", partitionID)
 			}
 		}
-		space.PartitionRule.Ranges, err = space.PartitionRule.AddRanges(partitionRule.Ranges)
-		if err != nil {
-			return nil, err
+		if rule.Type == entity.ListPartition {
+			rule.Lists = append(rule.Lists, partitionRule.Lists...)
+		} else {
+			rule.Ranges, err = rule.AddRanges(partitionRule.Ranges)
+			if err != nil {
+				return nil, err
+			}
 		}
 		log.Debug("updateSpacePartitionrule partition rule %v, add rule %v", space.PartitionRule, partitionRule)
 
@@ -1167,7 +1227,11 @@ func (s *SpaceService) updateSpacePartitonRule(ctx context.Context, dbs *DBServi
 		}
 	}
 
-	space.PartitionRule.Partitions = len(space.PartitionRule.Ranges)
+	if rule.Type == entity.ListPartition {
+		rule.Partitions = len(rule.Lists)
+	} else {
+		rule.Partitions = len(rule.Ranges)
+	}
 	//update space
 	slotWidth := math.MaxUint32 / (space.PartitionNum * space.PartitionRule.Partitions)
 	for i := 0; i < space.PartitionNum*space.PartitionRule.Partitions; i++ {
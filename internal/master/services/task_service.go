@@ -0,0 +1,426 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/client"
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+const (
+	// taskLockTTL is the ttl of the lock of a running task, the task of a
+	// dead master is resumed by another one after it expires
+	taskLockTTL = 30 * time.Second
+	// taskCheckInterval is how often unfinished tasks are resumed and
+	// cancel requests are checked
+	taskCheckInterval = 10 * time.Second
+	// taskRetention is how long finished tasks are kept
+	taskRetention = 7 * 24 * time.Hour
+)
+
+// TaskRunner runs a task. It's called again with the saved steps when the
+// task is resumed, steps done before are skipped by TaskProgress.Run.
+type TaskRunner func(ctx context.Context, task *entity.Task, progress *TaskProgress) error
+
+type TaskService struct {
+	client  *client.Client
+	runners map[entity.TaskType]TaskRunner
+	mu      sync.Mutex
+	// running are the tasks run by this master
+	running map[int64]context.CancelFunc
+}
+
+func NewTaskService(client *client.Client) *TaskService {
+	return &TaskService{
+		client:  client,
+		runners: make(map[entity.TaskType]TaskRunner),
+		running: make(map[int64]context.CancelFunc),
+	}
+}
+
+// RegisterRunner sets the runner of a task type, it should be called before Start.
+func (s *TaskService) RegisterRunner(taskType entity.TaskType, runner TaskRunner) {
+	s.runners[taskType] = runner
+}
+
+// Start resumes the unfinished tasks periodically, so the tasks of a dead
+// master are taken over once their lock expires.
+func (s *TaskService) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(taskCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.resume(ctx)
+			}
+		}
+	}()
+}
+
+func (s *TaskService) resume(ctx context.Context) {
+	tasks, err := s.ListTasks(ctx, "", "")
+	if err != nil {
+		log.Error("list tasks err: %v", err)
+		return
+	}
+	for _, task := range tasks {
+		if !task.Status.Finished() {
+			go s.runTask(task.ID)
+		} else if time.Since(time.Unix(task.UpdateTime, 0)) > taskRetention {
+			if err := s.client.Master().Delete(ctx, entity.TaskKey(task.ID)); err != nil {
+				log.Error("delete task %d err: %v", task.ID, err)
+			}
+		}
+	}
+}
+
+// SubmitTask saves a task and starts it in background.
+func (s *TaskService) SubmitTask(ctx context.Context, user string, req *entity.TaskRequest) (*entity.Task, error) {
+	if err := req.Type.Validate(); err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	if _, ok := s.runners[req.Type]; !ok {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_METHOD_NOT_IMPLEMENT, fmt.Errorf("task type %s has no runner", req.Type))
+	}
+	mc := s.client.Master()
+	id, err := mc.NewIDGenerate(ctx, entity.TaskSequence, 1, 5*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().Unix()
+	task := &entity.Task{
+		ID:         id,
+		Type:       req.Type,
+		Status:     entity.TaskPending,
+		DbName:     req.DbName,
+		SpaceName:  req.SpaceName,
+		Params:     req.Params,
+		User:       user,
+		CreateTime: now,
+		UpdateTime: now,
+	}
+	value, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	if err := mc.Create(ctx, entity.TaskKey(id), value); err != nil {
+		return nil, err
+	}
+	log.Info("submit task %d type %s db %s space %s by %s", id, task.Type, task.DbName, task.SpaceName, user)
+	go s.runTask(id)
+	return task, nil
+}
+
+func (s *TaskService) GetTask(ctx context.Context, id int64) (*entity.Task, error) {
+	value, err := s.client.Master().Get(ctx, entity.TaskKey(id))
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("task %d not exist", id))
+	}
+	task := &entity.Task{}
+	if err := json.Unmarshal(value, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ListTasks returns the tasks of status and type, newest first. Empty
+// status or type matches all.
+func (s *TaskService) ListTasks(ctx context.Context, status entity.TaskStatus, taskType entity.TaskType) ([]*entity.Task, error) {
+	_, values, err := s.client.Master().PrefixScan(ctx, entity.PrefixTask)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*entity.Task, 0, len(values))
+	for _, value := range values {
+		task := &entity.Task{}
+		if err := json.Unmarshal(value, task); err != nil {
+			log.Error("unmarshal task err: %v", err)
+			continue
+		}
+		if (status == "" || task.Status == status) && (taskType == "" || task.Type == taskType) {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].ID > tasks[j].ID
+	})
+	return tasks, nil
+}
+
+// CancelTask requests to cancel a task. The running step is finished and
+// the steps left are not started.
+func (s *TaskService) CancelTask(ctx context.Context, id int64) (*entity.Task, error) {
+	task := &entity.Task{}
+	err := s.client.Master().STM(ctx, func(stm concurrency.STM) error {
+		value := stm.Get(entity.TaskKey(id))
+		if value == "" {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("task %d not exist", id))
+		}
+		if err := json.Unmarshal([]byte(value), task); err != nil {
+			return err
+		}
+		if task.Status.Finished() {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("task %d is already %s", id, task.Status))
+		}
+		task.CancelRequested = true
+		bytes, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		stm.Put(entity.TaskKey(id), string(bytes))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	cancel, ok := s.running[id]
+	s.mu.Unlock()
+	if ok {
+		cancel()
+	} else {
+		// finish it now if no master is running it
+		go s.runTask(id)
+	}
+	return task, nil
+}
+
+// runTask runs a task if no other master holds its lock.
+func (s *TaskService) runTask(id int64) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.mu.Lock()
+	if _, ok := s.running[id]; ok {
+		s.mu.Unlock()
+		return
+	}
+	s.running[id] = cancel
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, id)
+		s.mu.Unlock()
+	}()
+
+	mc := s.client.Master()
+	lock := mc.NewLock(context.Background(), entity.LockTaskKey(id), taskLockTTL)
+	if ok, err := lock.TryLock(); !ok || err != nil {
+		// run by another master
+		return
+	}
+	defer func() {
+		if err := lock.Unlock(); err != nil {
+			log.Error("unlock task %d err: %v", id, err)
+		}
+	}()
+
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		log.Error("get task %d err: %v", id, err)
+		return
+	}
+	if task.Status.Finished() {
+		return
+	}
+	progress := &TaskProgress{service: s, task: task}
+	if task.CancelRequested {
+		progress.finish(entity.TaskCanceled, nil)
+		return
+	}
+	runner, ok := s.runners[task.Type]
+	if !ok {
+		progress.finish(entity.TaskFailed, fmt.Errorf("task type %s has no runner", task.Type))
+		return
+	}
+	if task.Status == entity.TaskRunning {
+		log.Info("resume task %d type %s owned by %s", id, task.Type, task.Owner)
+	}
+	progress.mu.Lock()
+	task.Status = entity.TaskRunning
+	task.Owner = config.Conf().Masters.Self().Name
+	progress.save()
+	progress.mu.Unlock()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(taskLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				lock.KeepAliveOnce()
+				if t, err := s.GetTask(ctx, id); err == nil && t.CancelRequested {
+					cancel()
+				}
+			}
+		}
+	}()
+
+	err = runner(ctx, task, progress)
+	switch {
+	case err == nil:
+		progress.finish(entity.TaskSucceeded, nil)
+	case ctx.Err() != nil:
+		progress.finish(entity.TaskCanceled, nil)
+	default:
+		progress.finish(entity.TaskFailed, err)
+	}
+}
+
+// saveTask puts a task to etcd, keeping the cancel request made meanwhile.
+func (s *TaskService) saveTask(ctx context.Context, task *entity.Task) error {
+	return s.client.Master().STM(ctx, func(stm concurrency.STM) error {
+		if value := stm.Get(entity.TaskKey(task.ID)); value != "" {
+			saved := &entity.Task{}
+			if err := json.Unmarshal([]byte(value), saved); err == nil && saved.CancelRequested {
+				task.CancelRequested = true
+			}
+		}
+		task.UpdateTime = time.Now().Unix()
+		bytes, err := json.Marshal(task)
+		if err != nil {
+			return err
+		}
+		stm.Put(entity.TaskKey(task.ID), string(bytes))
+		return nil
+	})
+}
+
+// TaskProgress saves the steps of a running task, steps may run concurrently.
+type TaskProgress struct {
+	service *TaskService
+	mu      sync.Mutex
+	task    *entity.Task
+}
+
+// Plan adds the steps not planned yet and returns the planned ones, so a
+// resumed task does the steps planned by the master running it before.
+func (p *TaskProgress) Plan(steps ...*entity.TaskStep) []*entity.TaskStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	planned := make([]*entity.TaskStep, 0, len(steps))
+	for _, step := range steps {
+		if exist := p.task.Step(step.Name, step.PartitionID, step.NodeID); exist != nil {
+			planned = append(planned, exist)
+			continue
+		}
+		step.Status = entity.TaskPending
+		p.task.Steps = append(p.task.Steps, step)
+		planned = append(planned, step)
+	}
+	p.save()
+	return planned
+}
+
+// Run runs a step unless it succeeded before or the task is canceled.
+func (p *TaskProgress) Run(ctx context.Context, step *entity.TaskStep, f func() error) error {
+	p.mu.Lock()
+	succeeded := step.Status == entity.TaskSucceeded
+	p.mu.Unlock()
+	if succeeded {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	p.update(step, entity.TaskRunning, nil)
+	err := f()
+	if err != nil {
+		p.update(step, entity.TaskFailed, err)
+		return err
+	}
+	p.update(step, entity.TaskSucceeded, nil)
+	return nil
+}
+
+// Steps returns the planned steps.
+func (p *TaskProgress) Steps() []*entity.TaskStep {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*entity.TaskStep(nil), p.task.Steps...)
+}
+
+// SetResult saves the result of the task, it's kept when the task is resumed.
+func (p *TaskProgress) SetResult(result any) error {
+	value, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.task.Result = value
+	p.save()
+	return nil
+}
+
+func (p *TaskProgress) update(step *entity.TaskStep, status entity.TaskStatus, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	step.Status, step.Error, step.UpdateTime = status, "", time.Now().Unix()
+	if err != nil {
+		step.Error = err.Error()
+	}
+	p.save()
+}
+
+func (p *TaskProgress) finish(status entity.TaskStatus, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.task.Status = status
+	if err != nil {
+		p.task.Error = err.Error()
+	}
+	if status == entity.TaskCanceled {
+		// steps not run are canceled with the task
+		for _, step := range p.task.Steps {
+			if !step.Status.Finished() {
+				step.Status = entity.TaskCanceled
+			}
+		}
+	}
+	p.save()
+	log.Info("task %d type %s %s, err: %v", p.task.ID, p.task.Type, status, err)
+}
+
+// save should be called with mu held. The progress is kept in memory if it
+// fails, and saved again with the next step.
+func (p *TaskProgress) save() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := p.service.saveTask(ctx, p.task); err != nil {
+		log.Error("save task %d err: %v", p.task.ID, err)
+	}
+}
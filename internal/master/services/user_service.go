@@ -52,6 +52,11 @@ func (s *UserService) CreateUser(ctx context.Context, role *RoleService, user *e
 	if user.Password == nil {
 		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("password is empty"))
 	}
+	hash, err := entity.HashPassword(*user.Password)
+	if err != nil {
+		return err
+	}
+	user = &entity.User{Name: user.Name, Password: &hash, RoleName: user.RoleName}
 
 	mc := s.client.Master()
 	mutex := mc.NewLock(ctx, entity.LockUserKey(user.Name), time.Second*30)
@@ -100,9 +105,16 @@ func (s *UserService) DeleteUser(ctx context.Context, role *RoleService, userNam
 		}
 	}()
 
+	keys, err := s.QueryAPIKeys(ctx, userName)
+	if err != nil {
+		return err
+	}
 	err = mc.STM(context.Background(),
 		func(stm concurrency.STM) error {
 			stm.Del(entity.UserKey(user.Name))
+			for _, key := range keys {
+				stm.Del(entity.APIKeyKey(key.ID))
+			}
 			return nil
 		})
 
@@ -204,25 +216,35 @@ func (s *UserService) UpdateUser(ctx context.Context, role *RoleService, user *e
 			if user.Password == nil {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("empty password"))
 			}
-			if old_user.Password != nil && *user.Password == *old_user.Password {
+			if old_user.Password != nil && entity.CheckPassword(*old_user.Password, *user.Password) {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("password is same with old password"))
 			}
 		} else {
 			if user.Password == nil || user.OldPassword == nil {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("empty password or old password"))
 			}
-			if old_user.Password != nil && *user.Password == *old_user.Password {
+			if old_user.Password != nil && entity.CheckPassword(*old_user.Password, *user.Password) {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("password is same with old password"))
 			}
-			if old_user.Password != nil && *user.OldPassword != *old_user.Password {
+			if old_user.Password != nil && !entity.CheckPassword(*old_user.Password, *user.OldPassword) {
 				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("old password is invalid"))
 			}
 		}
+		if *user.Password == "" {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("user password is empty"))
+		}
+		hash, err := entity.HashPassword(*user.Password)
+		if err != nil {
+			return err
+		}
+		user.Password = &hash
 
 		if old_user.RoleName != nil {
 			user.RoleName = old_user.RoleName
 		}
 	}
+	// the old password is only used for checking
+	user.OldPassword = nil
 
 	// it will lock cluster
 	mutex := mc.NewLock(ctx, entity.LockUserKey(user.Name), time.Second*30)
@@ -244,3 +266,120 @@ func (s *UserService) UpdateUser(ctx context.Context, role *RoleService, user *e
 	})
 	return nil
 }
+
+// MigratePassword replaces the plaintext password of a user with its hash.
+func (s *UserService) MigratePassword(ctx context.Context, userName, password string) error {
+	return s.client.Master().MigrateUserPassword(ctx, userName, password)
+}
+
+// CreateAPIKey creates an api key of a user, the token of the returned key
+// can't be got again.
+func (s *UserService) CreateAPIKey(ctx context.Context, role *RoleService, userName string, req *entity.APIKeyRequest) (*entity.APIKey, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.QueryUser(ctx, role, userName, false); err != nil {
+		return nil, err
+	}
+	if req.RoleName != nil {
+		if _, err := role.QueryRole(ctx, *req.RoleName); err != nil {
+			return nil, err
+		}
+	}
+	key, err := entity.NewAPIKey(userName, req)
+	if err != nil {
+		return nil, err
+	}
+
+	mc := s.client.Master()
+	mutex := mc.NewLock(ctx, entity.LockUserKey(userName), time.Second*30)
+	if err = mutex.Lock(); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := mutex.Unlock(); err != nil {
+			log.Error("unlock lock for create api key err %s", err)
+		}
+	}()
+	keys, err := s.QueryAPIKeys(ctx, userName)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if k.Name == key.Name {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("api key %s of user %s already exists", key.Name, userName))
+		}
+	}
+
+	token := key.Token
+	key.Token = ""
+	marshal, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	err = mc.STM(context.Background(), func(stm concurrency.STM) error {
+		stm.Put(entity.APIKeyKey(key.ID), string(marshal))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	key.Hash, key.Token = "", token
+	return key, nil
+}
+
+// QueryAPIKeys returns the api keys of a user without their hashes.
+func (s *UserService) QueryAPIKeys(ctx context.Context, userName string) ([]*entity.APIKey, error) {
+	_, values, err := s.client.Master().PrefixScan(ctx, entity.PrefixAPIKey)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*entity.APIKey, 0)
+	for _, value := range values {
+		key := &entity.APIKey{}
+		if err := json.Unmarshal(value, key); err != nil {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("get api key err:%s", err.Error()))
+		}
+		if key.User != userName {
+			continue
+		}
+		key.Hash = ""
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// DeleteAPIKey deletes an api key of a user by name.
+func (s *UserService) DeleteAPIKey(ctx context.Context, userName, keyName string) error {
+	keys, err := s.QueryAPIKeys(ctx, userName)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if key.Name != keyName {
+			continue
+		}
+		return s.client.Master().STM(context.Background(), func(stm concurrency.STM) error {
+			stm.Del(entity.APIKeyKey(key.ID))
+			return nil
+		})
+	}
+	return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("api key %s of user %s not exist", keyName, userName))
+}
+
+// QueryAPIKeyByToken returns the api key of a bearer token once its secret
+// is checked.
+func (s *UserService) QueryAPIKeyByToken(ctx context.Context, token string) (*entity.APIKey, error) {
+	id, secret, err := entity.ParseAPIKeyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := s.client.Master().QueryAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := key.Check(secret); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
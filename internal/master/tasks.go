@@ -0,0 +1,411 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package master
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
+	"github.com/vearch/vearch/v3/internal/client"
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/master/services"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+const (
+	stepSchema           = "schema"
+	stepAddMember        = "add_member"
+	stepRemoveMember     = "remove_member"
+	stepReplicaNum       = "replica_num"
+	stepRemoveFailServer = "remove_fail_server"
+	stepExpandPartitions = "expand_partitions"
+	stepRebuildIndex     = "rebuild_index"
+	stepRetention        = "retention"
+	stepReshard          = "reshard"
+
+	// backupCheckInterval is how often the backup progress of partitions is queried
+	backupCheckInterval = 5 * time.Second
+	// backupCheckRetry is how many times in a row the backup progress query can fail
+	backupCheckRetry = 10
+	// rebuildIndexTimeout is how long the index of a partition replica can take to rebuild
+	rebuildIndexTimeout = time.Hour
+)
+
+func (ms *masterService) registerTaskRunners() {
+	ms.taskService.RegisterRunner(entity.TaskBackupSpace, ms.runBackupSpace)
+	ms.taskService.RegisterRunner(entity.TaskChangeReplicas, ms.runChangeReplicas)
+	ms.taskService.RegisterRunner(entity.TaskRecoverFailServer, ms.runRecoverFailServer)
+	ms.taskService.RegisterRunner(entity.TaskExpandPartitions, ms.runExpandPartitions)
+	ms.taskService.RegisterRunner(entity.TaskRebuildIndex, ms.runRebuildIndex)
+}
+
+func unmarshalTaskParams(task *entity.Task, params any) error {
+	if len(task.Params) == 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("task %d type %s has no params", task.ID, task.Type))
+	}
+	if err := json.Unmarshal(task.Params, params); err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("task %d params err: %v", task.ID, err))
+	}
+	return nil
+}
+
+// runBackupSpace sends the backup command to the partitions of the space and
+// waits until each of them reports it's done.
+func (ms *masterService) runBackupSpace(ctx context.Context, task *entity.Task, progress *services.TaskProgress) error {
+	req := &entity.BackupSpaceRequest{}
+	if err := unmarshalTaskParams(task, req); err != nil {
+		return err
+	}
+	if req.Command != "create" && req.Command != "export" && req.Command != "restore" {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("backup command %s can't run as task", req.Command))
+	}
+	req.TaskID = task.ID
+	if req.Command == "restore" {
+		req.Source = req.RestoreSource(config.Conf().Global.Name, task.DbName, task.SpaceName)
+	}
+	// the backup id of a resumed task is set by its schema step
+	result := &entity.BackupSpaceResponse{}
+	if len(task.Result) > 0 {
+		if err := json.Unmarshal(task.Result, result); err == nil && result.BackupID > 0 {
+			req.BackupID = result.BackupID
+			req.BaseBackupID = result.BaseBackupID
+		}
+	}
+
+	schema := progress.Plan(&entity.TaskStep{Name: stepSchema})[0]
+	err := progress.Run(ctx, schema, func() error {
+		res, err := ms.Backup().BackupSchema(ctx, ms.DB(), ms.Space(), ms.Config(), task.DbName, task.SpaceName, req)
+		if err != nil {
+			return err
+		}
+		result = res
+		return progress.SetResult(res)
+	})
+	if err != nil {
+		return err
+	}
+
+	// a backup resharded is restored to the reshard space first
+	spaceName := task.SpaceName
+	if result.ReshardSpace != "" {
+		spaceName = result.ReshardSpace
+	}
+	targets, err := ms.Backup().BackupTargets(ctx, task.DbName, spaceName, req)
+	if err != nil {
+		return err
+	}
+	steps := make([]*entity.TaskStep, 0, len(targets))
+	for _, target := range targets {
+		step := &entity.TaskStep{Name: req.Command, PartitionID: target.PartitionID}
+		// the leader may change before the task is resumed
+		if req.Command == "restore" {
+			step.NodeID = target.NodeID
+		}
+		steps = append(steps, step)
+	}
+	steps = progress.Plan(steps...)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(targets))
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			partReq := *req
+			partReq.Part = target.Part
+			errs[i] = progress.Run(ctx, steps[i], func() error {
+				return backupPartition(ctx, &partReq, target)
+			})
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	if result.ReshardSpace != "" {
+		reshard := progress.Plan(&entity.TaskStep{Name: stepReshard})[0]
+		return progress.Run(ctx, reshard, func() error {
+			return ms.Backup().Reshard(ctx, ms.Space(), ms.Alias(), task.DbName, result.ReshardSpace, task.SpaceName)
+		})
+	}
+	if req.Command != "create" || (req.KeepLast == 0 && req.KeepDays == 0) {
+		return nil
+	}
+	retention := progress.Plan(&entity.TaskStep{Name: stepRetention})[0]
+	return progress.Run(ctx, retention, func() error {
+		expired, err := ms.Backup().ApplyRetention(ctx, task.DbName, task.SpaceName, req)
+		if err != nil {
+			return err
+		}
+		log.Info("task %d deleted expired backups %v of space %s/%s", task.ID, expired, task.DbName, task.SpaceName)
+		return nil
+	})
+}
+
+func backupPartition(ctx context.Context, req *entity.BackupSpaceRequest, target *services.BackupTarget) error {
+	// the command may be sent by the master running the task before
+	info, err := client.PartitionInfo(target.Addr, target.PartitionID, false)
+	if err != nil {
+		return err
+	}
+	if p := info.BackupProgress; p == nil || p.TaskID != req.TaskID || p.Status == entity.TaskFailed {
+		log.Debug("invoke nodeID [%v], partition [%v] address [%+v]", target.NodeID, target.PartitionID, target.Addr)
+		if err := client.BackupSpace(target.Addr, req, target.PartitionID); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(backupCheckInterval)
+	defer ticker.Stop()
+	retry := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		info, err := client.PartitionInfo(target.Addr, target.PartitionID, false)
+		if err != nil {
+			if retry++; retry > backupCheckRetry {
+				return err
+			}
+			continue
+		}
+		retry = 0
+		p := info.BackupProgress
+		if p == nil || p.TaskID != req.TaskID {
+			return fmt.Errorf("backup progress of partition %d on %s is lost", target.PartitionID, target.Addr)
+		}
+		switch p.Status {
+		case entity.TaskSucceeded:
+			return nil
+		case entity.TaskFailed:
+			return errors.New(p.Error)
+		}
+	}
+}
+
+func memberStep(cm *entity.ChangeMember) *entity.TaskStep {
+	name := stepAddMember
+	if cm.Method == proto.ConfRemoveNode {
+		name = stepRemoveMember
+	}
+	return &entity.TaskStep{Name: name, PartitionID: cm.PartitionID, NodeID: cm.NodeID}
+}
+
+func stepMember(step *entity.TaskStep) *entity.ChangeMember {
+	cm := &entity.ChangeMember{PartitionID: step.PartitionID, NodeID: step.NodeID, Method: proto.ConfAddNode}
+	if step.Name == stepRemoveMember {
+		cm.Method = proto.ConfRemoveNode
+	}
+	return cm
+}
+
+// runMemberStep applies the member change of a step.
+func (ms *masterService) runMemberStep(ctx context.Context, progress *services.TaskProgress, step *entity.TaskStep) error {
+	cm := stepMember(step)
+	err := progress.Run(ctx, step, func() error {
+		return ms.Member().ApplyChangeMember(ctx, ms.Space(), cm)
+	})
+	if err != nil {
+		return fmt.Errorf("change partition member [%+v] err: %v", cm, err)
+	}
+	return nil
+}
+
+// runChangeReplicas adds or removes a replica of every partition of a space,
+// the member changes are planned once so a resumed task doesn't plan again.
+// It stops at the first failed change like the sync api.
+func (ms *masterService) runChangeReplicas(ctx context.Context, task *entity.Task, progress *services.TaskProgress) error {
+	dbModify := &entity.DBModify{}
+	if err := unmarshalTaskParams(task, dbModify); err != nil {
+		return err
+	}
+	if task.DbName != "" {
+		dbModify.DbName, dbModify.SpaceName = task.DbName, task.SpaceName
+	}
+
+	result := &entity.ChangeReplicasTaskResult{}
+	steps := progress.Steps()
+	if len(steps) == 0 {
+		changeServer, replicaNum, err := ms.Member().PlanChangeReplica(ctx, ms.DB(), dbModify)
+		if err != nil {
+			return err
+		}
+		result.ReplicaNum = replicaNum
+		if err := progress.SetResult(result); err != nil {
+			return err
+		}
+		for _, cm := range changeServer {
+			steps = append(steps, memberStep(cm))
+		}
+		steps = progress.Plan(append(steps, &entity.TaskStep{Name: stepReplicaNum})...)
+	} else if err := json.Unmarshal(task.Result, result); err != nil {
+		return err
+	}
+
+	sleepTime := time.Duration(config.Conf().PS.RaftHeartbeatInterval*10) * time.Millisecond
+	for _, step := range steps {
+		if step.Name == stepReplicaNum {
+			err := progress.Run(ctx, step, func() error {
+				return ms.Member().SetReplicaNum(ctx, ms.Space(), dbModify.DbName, dbModify.SpaceName, result.ReplicaNum)
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		done := step.Status == entity.TaskSucceeded
+		if err := ms.runMemberStep(ctx, progress, step); err != nil {
+			return err
+		}
+		if !done && step.Name == stepRemoveMember {
+			time.Sleep(sleepTime)
+		}
+	}
+	return nil
+}
+
+// runRecoverFailServer moves the partitions of a failed server to a new one.
+func (ms *masterService) runRecoverFailServer(ctx context.Context, task *entity.Task, progress *services.TaskProgress) error {
+	rs := &entity.RecoverFailServer{}
+	if err := unmarshalTaskParams(task, rs); err != nil {
+		return err
+	}
+	mc := ms.Master()
+
+	steps := progress.Steps()
+	if len(steps) == 0 {
+		failServer := mc.QueryServerByIPAddr(ctx, rs.FailNodeAddr)
+		newServer := mc.QueryServerByIPAddr(ctx, rs.NewNodeAddr)
+		if failServer == nil || newServer == nil || newServer.ID <= 0 || failServer.ID <= 0 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_SERVER_ERROR, fmt.Errorf("newServer or targetFailServer is nil"))
+		}
+		for _, pid := range failServer.Node.PartitionIds {
+			steps = append(steps,
+				&entity.TaskStep{Name: stepAddMember, PartitionID: pid, NodeID: newServer.ID},
+				&entity.TaskStep{Name: stepRemoveMember, PartitionID: pid, NodeID: failServer.ID})
+		}
+		steps = progress.Plan(append(steps, &entity.TaskStep{Name: stepRemoveFailServer, NodeID: failServer.ID})...)
+	}
+
+	for _, step := range steps {
+		if step.Name == stepRemoveFailServer {
+			return progress.Run(ctx, step, func() error {
+				if failServer := mc.QueryServerByIPAddr(ctx, rs.FailNodeAddr); failServer != nil && failServer.ID == step.NodeID {
+					mc.TryRemoveFailServer(ctx, failServer.Node)
+				}
+				return nil
+			})
+		}
+		if err := ms.runMemberStep(ctx, progress, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runExpandPartitions adds partitions to a space.
+func (ms *masterService) runExpandPartitions(ctx context.Context, task *entity.Task, progress *services.TaskProgress) error {
+	params := &entity.ExpandPartitionsTaskParams{}
+	if err := unmarshalTaskParams(task, params); err != nil {
+		return err
+	}
+	step := progress.Plan(&entity.TaskStep{Name: stepExpandPartitions})[0]
+	resumed := step.Status != entity.TaskPending
+	return progress.Run(ctx, step, func() error {
+		mc := ms.Master()
+		dbID, err := mc.QueryDBName2ID(ctx, task.DbName)
+		if err != nil {
+			return err
+		}
+		space, err := mc.QuerySpaceByName(ctx, dbID, task.SpaceName)
+		if err != nil {
+			return err
+		}
+		// expanded by the master running the task before
+		if resumed && space.PartitionNum >= params.PartitionNum {
+			return nil
+		}
+		_, err = ms.Space().UpdateSpace(ctx, ms.DB(), task.DbName, task.SpaceName, &entity.Space{Name: task.SpaceName, PartitionNum: params.PartitionNum}, "")
+		return err
+	})
+}
+
+// runRebuildIndex rebuilds the index of every replica of the partitions one
+// by one, a failed replica doesn't stop the others.
+func (ms *masterService) runRebuildIndex(ctx context.Context, task *entity.Task, progress *services.TaskProgress) error {
+	params := &entity.RebuildIndexTaskParams{}
+	if len(task.Params) > 0 {
+		if err := unmarshalTaskParams(task, params); err != nil {
+			return err
+		}
+	}
+	mc := ms.Master()
+
+	steps := progress.Steps()
+	if len(steps) == 0 {
+		dbID, err := mc.QueryDBName2ID(ctx, task.DbName)
+		if err != nil {
+			return err
+		}
+		space, err := mc.QuerySpaceByName(ctx, dbID, task.SpaceName)
+		if err != nil {
+			return err
+		}
+		for _, sp := range space.Partitions {
+			if params.PartitionId != 0 && sp.Id != params.PartitionId {
+				continue
+			}
+			for _, nodeID := range sp.Replicas {
+				steps = append(steps, &entity.TaskStep{Name: stepRebuildIndex, PartitionID: sp.Id, NodeID: nodeID})
+			}
+		}
+		if len(steps) == 0 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_NOT_EXIST, fmt.Errorf("space %s/%s has no partition %d", task.DbName, task.SpaceName, params.PartitionId))
+		}
+		steps = progress.Plan(steps...)
+	}
+
+	indexRequest := &vearchpb.IndexRequest{LimitCpu: int64(params.LimitCPU)}
+	if params.DropBeforeRebuild {
+		indexRequest.DropBeforeRebuild = 1
+	}
+	errs := make([]error, 0)
+	for _, step := range steps {
+		err := progress.Run(ctx, step, func() error {
+			server, err := mc.QueryServer(ctx, step.NodeID)
+			if err != nil {
+				return err
+			}
+			return client.RebuildIndex(server.RpcAddr(), step.PartitionID, indexRequest, rebuildIndexTimeout)
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("partition %d on node %d: %v", step.PartitionID, step.NodeID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
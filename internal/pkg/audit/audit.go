@@ -0,0 +1,275 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/pkg/vearchlog"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+const (
+	// maxBatch is how many records are put to etcd by a transaction, etcd
+	// allows 128 operations in a transaction by default.
+	maxBatch      = 100
+	flushInterval = time.Second
+	queueSize     = 4096
+	// maxCapture is how much of a response is kept to get its code and msg
+	maxCapture = 4096
+)
+
+// Store is the etcd store of the ring of records.
+type Store interface {
+	STM(ctx context.Context, apply func(stm concurrency.STM) error) error
+	PrefixScan(ctx context.Context, prefix string) ([][]byte, [][]byte, error)
+}
+
+// Auditor writes the records of a module to its audit log, and to the ring
+// in etcd if it's enabled.
+type Auditor struct {
+	module             string
+	logger             log.Log
+	store              Store
+	ringSize           int64
+	skipDocumentWrites bool
+	records            chan *entity.AuditRecord
+}
+
+// New returns the auditor of module, or nil if audit is not enabled.
+func New(cfg *config.AuditCfg, module string, store Store) *Auditor {
+	if cfg == nil || !cfg.Enable {
+		return nil
+	}
+	dir := cfg.Log
+	if dir == "" {
+		dir = config.Conf().GetLogDir()
+	}
+	a := &Auditor{
+		module:             module,
+		logger:             vearchlog.NewVearchLog(dir, strings.ToUpper(module)+".AUDIT", vearchlog.InfoLogType, false),
+		skipDocumentWrites: cfg.SkipDocumentWrites,
+	}
+	if cfg.Etcd && store != nil {
+		a.store = store
+		a.ringSize = cfg.RingSize
+		if a.ringSize <= 0 {
+			a.ringSize = entity.DefaultAuditRingSize
+		}
+		a.records = make(chan *entity.AuditRecord, queueSize)
+		go a.run()
+	}
+	log.Info("audit of %s is enabled, log dir: %s, etcd: %v", module, dir, a.store != nil)
+	return a
+}
+
+// Audit writes a record to the audit log. Records are put to etcd in the
+// background, they are dropped from etcd if they come faster than they
+// can be put.
+func (a *Auditor) Audit(record *entity.AuditRecord) {
+	record.Module = a.module
+	if record.Time.IsZero() {
+		record.Time = time.Now()
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		log.Error("marshal audit record err: %v", err)
+		return
+	}
+	a.logger.Info(string(data))
+
+	if a.store == nil {
+		return
+	}
+	select {
+	case a.records <- record:
+	default:
+		log.Warn("audit queue is full, record of %s %s is not put to etcd", record.Method, record.Endpoint)
+	}
+}
+
+func (a *Auditor) run() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	batch := make([]*entity.AuditRecord, 0, maxBatch)
+	for {
+		select {
+		case record := <-a.records:
+			batch = append(batch, record)
+			if len(batch) < maxBatch {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
+		}
+		if err := a.put(batch); err != nil {
+			log.Error("put %d audit records to etcd err: %v", len(batch), err)
+		}
+		batch = batch[:0]
+	}
+}
+
+// put writes records to the next slots of the ring, overwriting the oldest
+// records.
+func (a *Auditor) put(records []*entity.AuditRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	return a.store.STM(ctx, func(stm concurrency.STM) error {
+		seq, _ := strconv.ParseInt(stm.Get(entity.AuditSequence), 10, 64)
+		for _, record := range records {
+			seq++
+			record.Seq = seq
+			data, err := json.Marshal(record)
+			if err != nil {
+				return err
+			}
+			stm.Put(entity.AuditKey(seq%a.ringSize), string(data))
+		}
+		stm.Put(entity.AuditSequence, strconv.FormatInt(seq, 10))
+		return nil
+	})
+}
+
+// Query returns the records in etcd matching q, the latest first.
+func Query(ctx context.Context, store Store, q *entity.AuditQuery) ([]*entity.AuditRecord, error) {
+	_, values, err := store.PrefixScan(ctx, entity.PrefixAudit)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*entity.AuditRecord, 0)
+	for _, value := range values {
+		record := &entity.AuditRecord{}
+		if err := json.Unmarshal(value, record); err != nil {
+			log.Error("unmarshal audit record %s err: %v", string(value), err)
+			continue
+		}
+		if q.Match(record) {
+			records = append(records, record)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Seq > records[j].Seq
+	})
+	if q.Limit > 0 && len(records) > q.Limit {
+		records = records[:q.Limit]
+	}
+	return records, nil
+}
+
+// Middleware audits the mutating calls of the handlers after it, target
+// returns the db and space of a call. It should be before the auth
+// middleware so that calls failing auth are audited too.
+func (a *Auditor) Middleware(target func(c *gin.Context) (string, string)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.FullPath()
+		if _, privilege := entity.ParseResources(path, c.Request.Method); privilege != entity.WriteOnly {
+			c.Next()
+			return
+		}
+		if a.skipDocumentWrites && (path == "/document/upsert" || path == "/document/update") {
+			c.Next()
+			return
+		}
+		db, space := target(c)
+		writer := &responseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		record := &entity.AuditRecord{
+			User:      c.GetString(entity.AuthUserKey),
+			Role:      c.GetString(entity.AuthRoleKey),
+			AuthType:  c.GetString(entity.AuthTypeKey),
+			SourceIP:  c.ClientIP(),
+			Method:    c.Request.Method,
+			Endpoint:  c.Request.URL.Path,
+			DB:        db,
+			Space:     space,
+			Status:    writer.Status(),
+			RequestID: c.GetHeader("X-Request-Id"),
+		}
+		// the user failing auth is the one of the header
+		if record.User == "" {
+			if authType, name, _, err := entity.ParseAuthHeader(c.GetHeader("Authorization")); err == nil {
+				record.User, record.AuthType = name, authType
+			}
+		}
+		record.Code, record.Msg = responseResult(writer.body)
+		a.Audit(record)
+	}
+}
+
+// responseWriter keeps the start of a response.
+type responseWriter struct {
+	gin.ResponseWriter
+	body []byte
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseWriter) capture(b []byte) {
+	if n := maxCapture - len(w.body); n > 0 {
+		if len(b) < n {
+			n = len(b)
+		}
+		w.body = append(w.body, b[:n]...)
+	}
+}
+
+// responseResult returns the code and msg of a response, which may be cut.
+func responseResult(body []byte) (code int, msg string) {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return code, msg
+	}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return code, msg
+		}
+		switch t {
+		case "code":
+			err = dec.Decode(&code)
+		case "msg":
+			err = dec.Decode(&msg)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return code, msg
+		}
+	}
+	return code, msg
+}
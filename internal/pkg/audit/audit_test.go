@@ -0,0 +1,76 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+	"go.etcd.io/etcd/client/v3/concurrency"
+)
+
+type ringStore struct {
+	values [][]byte
+}
+
+func (s *ringStore) STM(ctx context.Context, apply func(stm concurrency.STM) error) error {
+	return nil
+}
+
+func (s *ringStore) PrefixScan(ctx context.Context, prefix string) ([][]byte, [][]byte, error) {
+	return nil, s.values, nil
+}
+
+func TestResponseResult(t *testing.T) {
+	tests := []struct {
+		body string
+		code int
+		msg  string
+	}{
+		{`{"code":0,"request_id":"a","data":{"total":1}}`, 0, ""},
+		{`{"code":401,"request_id":"a","msg":"auth header password is invalid"}`, 401, "auth header password is invalid"},
+		{`{"code":0,"request_id":"a","data":{"documents":[{"_id":`, 0, ""},
+		{`{"code":500,"msg":"space not`, 500, ""},
+		{`not json`, 0, ""},
+	}
+	for _, tt := range tests {
+		if code, msg := responseResult([]byte(tt.body)); code != tt.code || msg != tt.msg {
+			t.Errorf("responseResult(%s) = %d, %s, want %d, %s", tt.body, code, msg, tt.code, tt.msg)
+		}
+	}
+}
+
+func TestQuery(t *testing.T) {
+	now := time.Now()
+	store := &ringStore{}
+	for i, user := range []string{"root", "alice", "root", "bob"} {
+		data, _ := json.Marshal(&entity.AuditRecord{Seq: int64(i + 1), Time: now.Add(time.Duration(i) * time.Minute), User: user})
+		store.values = append(store.values, data)
+	}
+	store.values = append(store.values, []byte("bad"))
+
+	records, err := Query(context.Background(), store, &entity.AuditQuery{User: "root"})
+	if err != nil || len(records) != 2 || records[0].Seq != 3 || records[1].Seq != 1 {
+		t.Fatalf("Query(user) = %v, %v", records, err)
+	}
+	query := &entity.AuditQuery{StartTime: now.Add(time.Minute), EndTime: now.Add(3 * time.Minute), Limit: 1}
+	records, err = Query(context.Background(), store, query)
+	if err != nil || len(records) != 1 || records[0].Seq != 3 {
+		t.Fatalf("Query(time) = %v, %v", records, err)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package backupstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// tmpPrefix is the prefix of files being copied, they aren't listed.
+const tmpPrefix = ".backupstore-"
+
+type localStore struct {
+	root string
+}
+
+// NewLocalStore returns the store of a local dir. Keys are the paths of
+// files relative to root.
+func NewLocalStore(root string) Store {
+	return &localStore{root: root}
+}
+
+func (s *localStore) path(key string) string {
+	return filepath.Join(s.root, filepath.FromSlash(key))
+}
+
+// copyFile copies src to a temp file by dst, then renames it, so a file
+// only exists when it's fully written.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.CreateTemp(filepath.Dir(dst), tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), dst)
+}
+
+func (s *localStore) Put(ctx context.Context, key, file string) error {
+	return copyFile(file, s.path(key))
+}
+
+func (s *localStore) Get(ctx context.Context, key, file string) error {
+	return copyFile(s.path(key), file)
+}
+
+func (s *localStore) List(ctx context.Context, prefix string, recursive bool) ([]string, error) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	dir := s.path(prefix)
+	keys := make([]string, 0)
+
+	if !recursive {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			return keys, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if strings.HasPrefix(entry.Name(), tmpPrefix) {
+				continue
+			}
+			key := path.Join(prefix, entry.Name())
+			if entry.IsDir() {
+				key += "/"
+			}
+			keys = append(keys, key)
+		}
+		return keys, nil
+	}
+
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), tmpPrefix) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, path.Join(prefix, filepath.ToSlash(rel)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (s *localStore) Remove(ctx context.Context, key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
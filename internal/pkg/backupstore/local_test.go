@@ -0,0 +1,134 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package backupstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store := NewLocalStore(filepath.Join(root, "backup"))
+
+	src := filepath.Join(root, "src")
+	if err := os.WriteFile(src, []byte("data"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := store.List(ctx, "c/backup/db/space", false)
+	if err != nil || len(keys) != 0 {
+		t.Fatalf("List() of missing dir = %v, %v", keys, err)
+	}
+
+	for _, key := range []string{"c/backup/db/space/1/1/data/a", "c/backup/db/space/1/1/data/b", "c/backup/db/space/1/space-1.schema"} {
+		if err := store.Put(ctx, key, src); err != nil {
+			t.Fatalf("Put(%s) error = %v", key, err)
+		}
+	}
+
+	keys, err = store.List(ctx, "c/backup/db/space/1/", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(keys)
+	if want := []string{"c/backup/db/space/1/1/", "c/backup/db/space/1/space-1.schema"}; !slices.Equal(keys, want) {
+		t.Fatalf("List() = %v, want %v", keys, want)
+	}
+
+	keys, err = store.List(ctx, "c/backup/db/space/1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(keys)
+	if want := []string{"c/backup/db/space/1/1/data/a", "c/backup/db/space/1/1/data/b", "c/backup/db/space/1/space-1.schema"}; !slices.Equal(keys, want) {
+		t.Fatalf("recursive List() = %v, want %v", keys, want)
+	}
+
+	dst := filepath.Join(root, "restore", "data", "a")
+	if err := store.Get(ctx, "c/backup/db/space/1/1/data/a", dst); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(dst); err != nil || string(data) != "data" {
+		t.Fatalf("Get() wrote %q, %v", data, err)
+	}
+	if err := store.Get(ctx, "c/backup/db/space/1/1/data/c", dst); err == nil {
+		t.Fatal("Get() of missing key should fail")
+	}
+
+	if err := store.Remove(ctx, "c/backup/db/space/1/1/data/a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Remove(ctx, "c/backup/db/space/1/1/data/a"); err != nil {
+		t.Fatalf("Remove() of missing key error = %v", err)
+	}
+	keys, _ = store.List(ctx, "c/backup/db/space/1/1/data", true)
+	if !slices.Equal(keys, []string{"c/backup/db/space/1/1/data/b"}) {
+		t.Fatalf("List() after Remove() = %v", keys)
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		storage entity.BackupStorage
+		path    string
+		wantErr bool
+	}{
+		{"default s3", "", "", false},
+		{"local", entity.BackupStorageLocal, "/mnt/backup", false},
+		{"local without path", entity.BackupStorageLocal, "", true},
+		{"local relative path", entity.BackupStorageLocal, "backup", true},
+		{"unknown", "hdfs", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &entity.BackupSpaceRequest{Storage: tt.storage}
+			req.LocalParam.Path = tt.path
+			req.S3Param.EndPoint = "127.0.0.1:9000"
+			if _, err := New(req); (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	ctx := context.Background()
+	store := NewLocalStore(t.TempDir())
+
+	files := &entity.BackupFiles{DocNum: 10, Files: []*entity.BackupFile{{Path: "data/000010.sst", Size: 100, BackupID: 1}}}
+	if err := PutJSON(ctx, store, "c/backup/db/space/2/1/files.json", files); err != nil {
+		t.Fatal(err)
+	}
+	got := &entity.BackupFiles{}
+	if err := GetJSON(ctx, store, "c/backup/db/space/2/1/files.json", got); err != nil {
+		t.Fatal(err)
+	}
+	if got.DocNum != 10 || got.File("data/000010.sst") == nil || got.File("data/000010.sst").BackupID != 1 {
+		t.Fatalf("GetJSON() = %+v", got)
+	}
+
+	err := GetJSON(ctx, store, "c/backup/db/space/3/1/files.json", got)
+	if !IsNotExist(err) {
+		t.Fatalf("GetJSON() of missing key error = %v, want not exist", err)
+	}
+}
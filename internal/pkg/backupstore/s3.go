@@ -0,0 +1,89 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package backupstore
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+type s3Store struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3Store returns the store of the bucket in s3_param of the request.
+func NewS3Store(req *entity.BackupSpaceRequest) (Store, error) {
+	client, err := minio.New(req.S3Param.EndPoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(req.S3Param.AccessKey, req.S3Param.SecretKey, ""),
+		Secure: req.S3Param.UseSSL,
+		Region: req.S3Param.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %+v", err)
+	}
+	return &s3Store{client: client, bucket: req.S3Param.BucketName}, nil
+}
+
+func contentType(key string) string {
+	switch path.Ext(key) {
+	case ".zst":
+		return "application/zstd"
+	case ".done":
+		return "text/plain"
+	}
+	return "application/octet-stream"
+}
+
+func (s *s3Store) Put(ctx context.Context, key, file string) error {
+	_, err := s.client.FPutObject(ctx, s.bucket, key, file, minio.PutObjectOptions{ContentType: contentType(key)})
+	return err
+}
+
+func (s *s3Store) Get(ctx context.Context, key, file string) error {
+	err := s.client.FGetObject(ctx, s.bucket, key, file, minio.GetObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return fmt.Errorf("%s: %w", key, fs.ErrNotExist)
+	}
+	return err
+}
+
+func (s *s3Store) List(ctx context.Context, prefix string, recursive bool) ([]string, error) {
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	keys := make([]string, 0)
+	for object := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: recursive,
+	}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
+func (s *s3Store) Remove(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
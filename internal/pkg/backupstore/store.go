@@ -0,0 +1,96 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package backupstore is where backups and exports of spaces are kept.
+package backupstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+)
+
+// Store keeps files by keys separated by "/", like
+// cluster/backup/db/space/backup_id/part/file.
+type Store interface {
+	// Put uploads the local file to key.
+	Put(ctx context.Context, key, file string) error
+	// Get downloads key to the local file, the parent dirs are created.
+	// The error is fs.ErrNotExist if key doesn't exist.
+	Get(ctx context.Context, key, file string) error
+	// List returns the keys under the dir prefix. If not recursive, the
+	// sub dirs are returned too, ending with "/".
+	List(ctx context.Context, prefix string, recursive bool) ([]string, error)
+	// Remove deletes key, it's not an error if key doesn't exist.
+	Remove(ctx context.Context, key string) error
+}
+
+// New returns the store of the backup request.
+func New(req *entity.BackupSpaceRequest) (Store, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Storage == entity.BackupStorageLocal {
+		return NewLocalStore(req.LocalParam.Path), nil
+	}
+	return NewS3Store(req)
+}
+
+// IsNotExist tells whether the error is returned as key doesn't exist.
+func IsNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+// PutJSON puts the json of v to key.
+func PutJSON(ctx context.Context, store Store, key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp("", tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return store.Put(ctx, key, file.Name())
+}
+
+// GetJSON gets key and unmarshals it to v.
+func GetJSON(ctx context.Context, store Store, key string, v any) error {
+	file, err := os.CreateTemp("", tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+	if err := store.Get(ctx, key, file.Name()); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package docimport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+)
+
+// parquetBatchSize is how many rows of parquet are read at a time
+const parquetBatchSize = 1024
+
+// recordReader reads the records of a parquet or arrow file.
+type recordReader interface {
+	Next() bool
+	Record() arrow.Record
+	Err() error
+	Release()
+}
+
+// fileRecordReader reads the records of an arrow ipc file in order.
+type fileRecordReader struct {
+	reader *ipc.FileReader
+	index  int
+	record arrow.Record
+	err    error
+}
+
+func (r *fileRecordReader) Next() bool {
+	if r.index >= r.reader.NumRecords() {
+		return false
+	}
+	r.record, r.err = r.reader.Record(r.index)
+	r.index++
+	return r.err == nil
+}
+
+func (r *fileRecordReader) Record() arrow.Record { return r.record }
+func (r *fileRecordReader) Err() error           { return r.err }
+func (r *fileRecordReader) Release()             { r.reader.Close() }
+
+// columnReader converts the rows of records to json documents.
+type columnReader struct {
+	records recordReader
+	mapping columnMapping
+	record  arrow.Record
+	fields  []string
+	row     int
+	num     int64
+}
+
+func newParquetReader(f File, mapping columnMapping) (Reader, error) {
+	pf, err := file.NewParquetReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open parquet file: %v", err)
+	}
+	fr, err := pqarrow.NewFileReader(pf, pqarrow.ArrowReadProperties{BatchSize: parquetBatchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet schema: %v", err)
+	}
+	records, err := fr.GetRecordReader(context.Background(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parquet file: %v", err)
+	}
+	return &columnReader{records: records, mapping: mapping}, nil
+}
+
+// newArrowReader reads the arrow ipc file format, or the stream format if
+// the file has no magic of the file format.
+func newArrowReader(f File, mapping columnMapping) (Reader, error) {
+	if fr, err := ipc.NewFileReader(f); err == nil {
+		return &columnReader{records: &fileRecordReader{reader: fr}, mapping: mapping}, nil
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	sr, err := ipc.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open arrow file: %v", err)
+	}
+	return &columnReader{records: sr, mapping: mapping}, nil
+}
+
+func (r *columnReader) Next() (*Row, error) {
+	for r.record == nil || r.row >= int(r.record.NumRows()) {
+		if !r.records.Next() {
+			if err := r.records.Err(); err != nil && err != io.EOF {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		r.record = r.records.Record()
+		r.row = 0
+		r.fields = make([]string, r.record.NumCols())
+		for i, f := range r.record.Schema().Fields() {
+			if field, ok := r.mapping.field(f.Name); ok {
+				r.fields[i] = field
+			}
+		}
+	}
+
+	r.num++
+	row := &Row{Num: r.num}
+	doc := make(map[string]any, len(r.fields))
+	for i, field := range r.fields {
+		col := r.record.Column(i)
+		if field == "" || col.IsNull(r.row) {
+			continue
+		}
+		value := col.GetOneForMarshal(r.row)
+		if field == idField {
+			// ids of documents are strings
+			value = fmt.Sprint(value)
+		}
+		doc[field] = value
+	}
+	r.row++
+	row.Doc, row.Err = json.Marshal(doc)
+	return row, nil
+}
+
+func (r *columnReader) Close() error {
+	r.records.Release()
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package docimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// maxLineSize limits the size of a document of ndjson
+const maxLineSize = 64 << 20
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	mapping columnMapping
+	num     int64
+	close   func()
+}
+
+func newNDJSONReader(r io.Reader, mapping columnMapping) *ndjsonReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	return &ndjsonReader{scanner: scanner, mapping: mapping}
+}
+
+func newZstdReader(r io.Reader, mapping columnMapping) (*ndjsonReader, error) {
+	zr, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd reader: %v", err)
+	}
+	reader := newNDJSONReader(zr, mapping)
+	reader.close = zr.Close
+	return reader, nil
+}
+
+func (r *ndjsonReader) Next() (*Row, error) {
+	for r.scanner.Scan() {
+		r.num++
+		line := bytes.TrimSpace(r.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		row := &Row{Num: r.num}
+		if !json.Valid(line) {
+			row.Err = fmt.Errorf("invalid json")
+			return row, nil
+		}
+		doc, err := r.mapping.apply(line)
+		if err != nil {
+			row.Err = err
+			return row, nil
+		}
+		// the line is reused by the scanner
+		row.Doc = bytes.Clone(doc)
+		return row, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (r *ndjsonReader) Close() error {
+	if r.close != nil {
+		r.close()
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package docimport reads the documents of files to import into a space.
+package docimport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+type Format string
+
+const (
+	// FormatNDJSON is a json document per line
+	FormatNDJSON Format = "ndjson"
+	// FormatNDJSONZstd is ndjson compressed by zstd, as exported by ps
+	FormatNDJSONZstd Format = "ndjson.zst"
+	FormatParquet    Format = "parquet"
+	// FormatArrow is the arrow ipc file or stream format
+	FormatArrow Format = "arrow"
+
+	// idField is the column of the document id
+	idField = "_id"
+)
+
+// DetectFormat returns the format of a file by the extension of its name.
+func DetectFormat(name string) (Format, error) {
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".json", ".jsonl", ".ndjson", ".txt":
+		return FormatNDJSON, nil
+	case ".zst":
+		return FormatNDJSONZstd, nil
+	case ".parquet":
+		return FormatParquet, nil
+	case ".arrow", ".arrows", ".feather", ".ipc":
+		return FormatArrow, nil
+	default:
+		return "", fmt.Errorf("unknown format of file %s", name)
+	}
+}
+
+// ParseFormat checks the format given by users, it's detected by name if
+// empty.
+func ParseFormat(format, name string) (Format, error) {
+	switch f := Format(strings.ToLower(format)); f {
+	case "":
+		return DetectFormat(name)
+	case FormatNDJSON, FormatNDJSONZstd, FormatParquet, FormatArrow:
+		return f, nil
+	case "json", "jsonl":
+		return FormatNDJSON, nil
+	case "zst", "zstd":
+		return FormatNDJSONZstd, nil
+	case "ipc", "feather":
+		return FormatArrow, nil
+	}
+	return "", fmt.Errorf("unknown format %s", format)
+}
+
+// Row is a document read from a file.
+type Row struct {
+	// Num is the number of the row in the file, from 1
+	Num int64
+	// Doc is the json of the document with the fields of the space
+	Doc []byte
+	// Err is why the row can't be read, the row is rejected
+	Err error
+}
+
+// Reader reads the rows of a file in order.
+type Reader interface {
+	// Next returns the next row, io.EOF after the last one.
+	Next() (*Row, error)
+	Close() error
+}
+
+// File is a file to import, parquet and arrow files are read at offsets.
+type File interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// NewReader returns the reader of the file in format. mapping renames the
+// columns of the file to the fields of the space, only the columns mapped
+// and _id are read if it's not empty.
+func NewReader(file File, format Format, mapping map[string]string) (Reader, error) {
+	m := columnMapping(mapping)
+	switch format {
+	case FormatNDJSON:
+		return newNDJSONReader(file, m), nil
+	case FormatNDJSONZstd:
+		return newZstdReader(file, m)
+	case FormatParquet:
+		return newParquetReader(file, m)
+	case FormatArrow:
+		return newArrowReader(file, m)
+	}
+	return nil, fmt.Errorf("unknown format %s", format)
+}
+
+type columnMapping map[string]string
+
+// field returns the field of a column, false if the column isn't read.
+func (m columnMapping) field(column string) (string, bool) {
+	if len(m) == 0 {
+		return column, true
+	}
+	if field, ok := m[column]; ok {
+		return field, field != ""
+	}
+	return column, column == idField
+}
+
+// apply renames the keys of a json document.
+func (m columnMapping) apply(doc []byte) ([]byte, error) {
+	if len(m) == 0 {
+		return doc, nil
+	}
+	columns := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(doc, &columns); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage, len(columns))
+	for column, value := range columns {
+		if field, ok := m.field(column); ok {
+			fields[field] = value
+		}
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,212 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package docimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/ipc"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+	"github.com/klauspost/compress/zstd"
+)
+
+func readAll(t *testing.T, name string, format Format, mapping map[string]string) []*Row {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	reader, err := NewReader(f, format, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	rows := make([]*Row, 0)
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return rows
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func docOf(t *testing.T, row *Row) map[string]any {
+	t.Helper()
+	if row.Err != nil {
+		t.Fatalf("row %d error = %v", row.Num, row.Err)
+	}
+	doc := make(map[string]any)
+	if err := json.Unmarshal(row.Doc, &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		format, name string
+		want         Format
+		wantErr      bool
+	}{
+		{"", "docs.json", FormatNDJSON, false},
+		{"", "1_0.zst", FormatNDJSONZstd, false},
+		{"", "docs.PARQUET", FormatParquet, false},
+		{"", "docs.arrow", FormatArrow, false},
+		{"", "docs.csv", "", true},
+		{"jsonl", "docs", FormatNDJSON, false},
+		{"parquet", "docs.json", FormatParquet, false},
+		{"csv", "docs.json", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.format, tt.name)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Fatalf("ParseFormat(%q, %q) = %v, %v, want %v", tt.format, tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	dir := t.TempDir()
+	data := []byte("{\"_id\":\"1\",\"id\":1,\"vec\":[1,2]}\n\n{bad json\n{\"_id\":\"3\",\"id\":3,\"vec\":[5,6]}\n")
+
+	name := filepath.Join(dir, "docs.json")
+	if err := os.WriteFile(name, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	rows := readAll(t, name, FormatNDJSON, nil)
+	if len(rows) != 3 || rows[1].Err == nil || rows[1].Num != 3 || rows[2].Num != 4 {
+		t.Fatalf("rows = %+v", rows)
+	}
+	if doc := docOf(t, rows[0]); doc["id"] != 1.0 {
+		t.Fatalf("doc = %v", doc)
+	}
+
+	var buf bytes.Buffer
+	zw, _ := zstd.NewWriter(&buf)
+	zw.Write(data)
+	zw.Close()
+	name = filepath.Join(dir, "1_0.zst")
+	if err := os.WriteFile(name, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rows = readAll(t, name, FormatNDJSONZstd, map[string]string{"id": "field_int", "vec": "field_vector"})
+	if len(rows) != 3 {
+		t.Fatalf("rows = %+v", rows)
+	}
+	doc := docOf(t, rows[2])
+	if doc["_id"] != "3" || doc["field_int"] != 3.0 || doc["field_vector"] == nil || doc["id"] != nil {
+		t.Fatalf("mapped doc = %v", doc)
+	}
+}
+
+func testRecord() arrow.Record {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "vec", Type: arrow.ListOf(arrow.PrimitiveTypes.Float32)},
+	}, nil)
+	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", ""}, []bool{true, false})
+	lb := b.Field(2).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*array.Float32Builder)
+	lb.Append(true)
+	vb.AppendValues([]float32{0.5, 1}, nil)
+	lb.Append(true)
+	vb.AppendValues([]float32{2, 3}, nil)
+	return b.NewRecord()
+}
+
+func checkColumnRows(t *testing.T, rows []*Row) {
+	t.Helper()
+	if len(rows) != 2 {
+		t.Fatalf("rows = %+v", rows)
+	}
+	doc := docOf(t, rows[0])
+	if doc["_id"] != "1" || doc["field_vector"].([]any)[0] != 0.5 || doc["name"] != nil {
+		t.Fatalf("doc = %v", doc)
+	}
+	if doc := docOf(t, rows[1]); rows[1].Num != 2 || doc["_id"] != "2" {
+		t.Fatalf("doc = %v", doc)
+	}
+}
+
+func TestParquet(t *testing.T) {
+	record := testRecord()
+	defer record.Release()
+	table := array.NewTableFromRecords(record.Schema(), []arrow.Record{record})
+	defer table.Release()
+
+	name := filepath.Join(t.TempDir(), "docs.parquet")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pqarrow.WriteTable(table, f, 1, nil, pqarrow.DefaultWriterProps()); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	checkColumnRows(t, readAll(t, name, FormatParquet, map[string]string{"id": "_id", "vec": "field_vector"}))
+}
+
+func TestArrow(t *testing.T) {
+	record := testRecord()
+	defer record.Release()
+	dir := t.TempDir()
+
+	name := filepath.Join(dir, "docs.arrow")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw, err := ipc.NewFileWriter(f, ipc.WithSchema(record.Schema()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fw.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	fw.Close()
+	f.Close()
+	checkColumnRows(t, readAll(t, name, FormatArrow, map[string]string{"id": "_id", "vec": "field_vector"}))
+
+	name = filepath.Join(dir, "docs.arrows")
+	f, err = os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sw := ipc.NewWriter(f, ipc.WithSchema(record.Schema()))
+	if err := sw.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	sw.Close()
+	f.Close()
+	checkColumnRows(t, readAll(t, name, FormatArrow, map[string]string{"id": "_id", "vec": "field_vector"}))
+}
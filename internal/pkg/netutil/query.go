@@ -16,6 +16,7 @@ package netutil
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
@@ -48,6 +49,16 @@ func (p *UriParamsMap) ByName(name string) string {
 	}
 }
 
+// httpClient sends the queries to the master.
+var httpClient = http.DefaultClient
+
+// SetTLSClientConfig makes queries use tlsConfig for https urls.
+func SetTLSClientConfig(tlsConfig *tls.Config) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient = &http.Client{Transport: transport}
+}
+
 func NewMockUriParams(values map[string]string) UriParams {
 	return &UriParamsMap{
 		values: values,
@@ -151,7 +162,7 @@ func (q *query) Do() ([]byte, error) {
 		request = request.WithContext(ctx)
 	}
 	// do request
-	response, err := http.DefaultClient.Do(request)
+	response, err := httpClient.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +198,7 @@ func (q *query) ProxyDo() (respBody []byte, statusCode int, err error) {
 		request = request.WithContext(ctx)
 	}
 	// do request
-	response, err := http.DefaultClient.Do(request)
+	response, err := httpClient.Do(request)
 	if err != nil {
 		return nil, -1, err
 	}
@@ -219,5 +230,5 @@ func (q *query) DoResponse() (*http.Response, error) {
 	}
 	//defer request.Body.Close()
 	// do request
-	return http.DefaultClient.Do(request)
+	return httpClient.Do(request)
 }
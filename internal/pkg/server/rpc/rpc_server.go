@@ -26,6 +26,7 @@ import (
 	"github.com/smallnest/rpcx/share"
 	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/pkg/server/rpc/handler"
+	"github.com/vearch/vearch/v3/internal/pkg/tlsutil"
 	"github.com/vearch/vearch/v3/internal/pkg/vearchlog"
 )
 
@@ -58,7 +59,15 @@ func (r *RpcServer) Run() error {
 	if r.serverAddress == "127.0.0.1" || r.serverAddress == "localhost" {
 		r.serverAddress = ""
 	}
-	r.server = server.NewServer()
+	tlsConfig, err := tlsutil.ServerConfig(config.Conf().TLS.PS)
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		r.server = server.NewServer(server.WithTLSConfig(tlsConfig))
+	} else {
+		r.server = server.NewServer()
+	}
 	vlog := vearchlog.NewVearchLog(config.Conf().GetLogDir(), "PS.RPC", vearchlog.WarnLogType, false)
 	rlog.SetLogger(vlog)
 	r.server.Plugins.Add(client.OpenTracingPlugin{})
@@ -0,0 +1,271 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+)
+
+// reloadInterval is how often the files of a config are checked for changes.
+var reloadInterval = 10 * time.Second
+
+var (
+	storesMu sync.Mutex
+	stores   = make(map[*config.TLSCfg]*certStore)
+)
+
+// certStore keeps the key pair and CA of a config, reloading them when the
+// files change. A failed reload keeps the loaded ones.
+type certStore struct {
+	cfg     *config.TLSCfg
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	pool    *x509.CertPool
+	modTime time.Time
+	checked time.Time
+}
+
+// getStore returns the store of cfg, shared by the listener and clients of
+// a process.
+func getStore(cfg *config.TLSCfg) (*certStore, error) {
+	storesMu.Lock()
+	defer storesMu.Unlock()
+	if s, ok := stores[cfg]; ok {
+		return s, nil
+	}
+	s := &certStore{cfg: cfg}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	stores[cfg] = s
+	return s, nil
+}
+
+func (s *certStore) files() []string {
+	files := make([]string, 0, 3)
+	for _, f := range []string{s.cfg.Cert, s.cfg.Key, s.cfg.CA} {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files
+}
+
+// lastModTime is the latest modification time of the files.
+func (s *certStore) lastModTime() (time.Time, error) {
+	var last time.Time
+	for _, f := range s.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return last, err
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, nil
+}
+
+func (s *certStore) load() error {
+	if (s.cfg.Cert == "") != (s.cfg.Key == "") {
+		return fmt.Errorf("tls cert and key should be set together")
+	}
+	modTime, err := s.lastModTime()
+	if err != nil {
+		return err
+	}
+	var cert *tls.Certificate
+	if s.cfg.Cert != "" {
+		pair, err := tls.LoadX509KeyPair(s.cfg.Cert, s.cfg.Key)
+		if err != nil {
+			return fmt.Errorf("load tls key pair %s err: %v", s.cfg.Cert, err)
+		}
+		cert = &pair
+	}
+	var pool *x509.CertPool
+	if s.cfg.CA != "" {
+		data, err := os.ReadFile(s.cfg.CA)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return fmt.Errorf("tls ca %s has no certificate", s.cfg.CA)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cert, s.pool, s.modTime, s.checked = cert, pool, modTime, time.Now()
+	return nil
+}
+
+// get returns the key pair and CA, reloaded if the files changed.
+func (s *certStore) get() (*tls.Certificate, *x509.CertPool) {
+	s.mu.RLock()
+	cert, pool, checked, modTime := s.cert, s.pool, s.checked, s.modTime
+	s.mu.RUnlock()
+	if time.Since(checked) < reloadInterval {
+		return cert, pool
+	}
+
+	s.mu.Lock()
+	s.checked = time.Now()
+	s.mu.Unlock()
+	if last, err := s.lastModTime(); err != nil || !last.After(modTime) {
+		return cert, pool
+	}
+	if err := s.load(); err != nil {
+		log.Error("reload tls files %v err: %v", s.files(), err)
+		return cert, pool
+	}
+	log.Info("tls files %v are reloaded", s.files())
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cert, s.pool
+}
+
+func minVersion(cfg *config.TLSCfg) (uint16, error) {
+	switch cfg.MinVersion {
+	case "", "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("tls min version %s should be 1.2 or 1.3", cfg.MinVersion)
+}
+
+// ServerConfig returns the TLS config of a listener, nil if cfg is nil.
+func ServerConfig(cfg *config.TLSCfg) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if cfg.Cert == "" {
+		return nil, fmt.Errorf("tls cert and key of a listener should be set")
+	}
+	if cfg.ClientAuth && cfg.CA == "" {
+		return nil, fmt.Errorf("tls ca should be set to verify client certificates")
+	}
+	version, err := minVersion(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := getStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: version,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			cert, _ := s.get()
+			return cert, nil
+		},
+	}
+	if cfg.ClientAuth {
+		// verified by the reloaded CA instead of ClientCAs
+		tlsConfig.ClientAuth = tls.RequireAnyClientCert
+		tlsConfig.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			_, pool := s.get()
+			return verify(rawCerts, pool, "", x509.ExtKeyUsageClientAuth)
+		}
+	}
+	return tlsConfig, nil
+}
+
+// ClientConfig returns the TLS config of the clients of a listener, nil if
+// cfg is nil.
+func ClientConfig(cfg *config.TLSCfg) (*tls.Config, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	version, err := minVersion(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := getStore(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion: version,
+		ServerName: cfg.ServerName,
+		// verified by VerifyConnection with the reloaded CA
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			_, pool := s.get()
+			rawCerts := make([][]byte, 0, len(cs.PeerCertificates))
+			for _, cert := range cs.PeerCertificates {
+				rawCerts = append(rawCerts, cert.Raw)
+			}
+			return verify(rawCerts, pool, cs.ServerName, x509.ExtKeyUsageServerAuth)
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if cert, _ := s.get(); cert != nil {
+				return cert, nil
+			}
+			return &tls.Certificate{}, nil
+		},
+	}, nil
+}
+
+// verify checks the chain of rawCerts against pool, the system roots if
+// pool is nil.
+func verify(rawCerts [][]byte, pool *x509.CertPool, name string, usage x509.ExtKeyUsage) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("tls peer has no certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	opts := x509.VerifyOptions{
+		Roots:         pool,
+		DNSName:       name,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// ListenAndServe serves handler on addr, with TLS if cfg is not nil.
+func ListenAndServe(addr string, handler http.Handler, cfg *config.TLSCfg) error {
+	tlsConfig, err := ServerConfig(cfg)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Addr: addr, Handler: handler, TLSConfig: tlsConfig}
+	if tlsConfig == nil {
+		return server.ListenAndServe()
+	}
+	log.Info("serve https on %s", addr)
+	return server.ListenAndServeTLS("", "")
+}
@@ -0,0 +1,183 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/config"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate of 127.0.0.1 signed by ca and its key.
+func (ca *testCA) issue(t *testing.T, certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "vearch"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+func writeFile(t *testing.T, name string, data []byte) {
+	if err := os.WriteFile(name, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMutualTLSAndReload(t *testing.T) {
+	reloadInterval = 0
+	dir := t.TempDir()
+	ca := newTestCA(t, "ca1")
+	serverCfg := &config.TLSCfg{
+		Cert:       filepath.Join(dir, "server.crt"),
+		Key:        filepath.Join(dir, "server.key"),
+		CA:         filepath.Join(dir, "ca.crt"),
+		ClientAuth: true,
+	}
+	clientCfg := &config.TLSCfg{
+		Cert: filepath.Join(dir, "client.crt"),
+		Key:  filepath.Join(dir, "client.key"),
+		CA:   serverCfg.CA,
+	}
+	writeFile(t, serverCfg.CA, ca.pem)
+	ca.issue(t, serverCfg.Cert, serverCfg.Key)
+	ca.issue(t, clientCfg.Cert, clientCfg.Key)
+
+	serverTLS, err := ServerConfig(serverCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})}
+	go server.Serve(tls.NewListener(lis, serverTLS))
+	defer server.Close()
+	url := "https://" + lis.Addr().String()
+
+	get := func(cfg *config.TLSCfg) error {
+		clientTLS, err := ClientConfig(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+		resp, err := c.Get(url)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	if err := get(clientCfg); err != nil {
+		t.Fatalf("mutual tls err: %v", err)
+	}
+	if err := get(&config.TLSCfg{CA: serverCfg.CA}); err == nil {
+		t.Fatal("client without certificate should be rejected")
+	}
+
+	// rotate everything to a new CA, the old client certificate is rejected
+	// by the reloaded server and the reissued one is accepted
+	ca2 := newTestCA(t, "ca2")
+	time.Sleep(10 * time.Millisecond)
+	writeFile(t, serverCfg.CA, ca2.pem)
+	ca2.issue(t, serverCfg.Cert, serverCfg.Key)
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(serverCfg.CA, future, future)
+
+	stale := &config.TLSCfg{Cert: clientCfg.Cert, Key: clientCfg.Key, CA: serverCfg.CA}
+	if err := get(stale); err == nil {
+		t.Fatal("certificate of the old ca should be rejected after reload")
+	}
+	ca2.issue(t, clientCfg.Cert, clientCfg.Key)
+	os.Chtimes(clientCfg.Cert, future, future)
+	if err := get(clientCfg); err != nil {
+		t.Fatalf("mutual tls after reload err: %v", err)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	if c, err := ServerConfig(nil); c != nil || err != nil {
+		t.Fatalf("nil config should be plaintext, got %v %v", c, err)
+	}
+	if _, err := ServerConfig(&config.TLSCfg{CA: "ca.crt"}); err == nil {
+		t.Fatal("listener without cert should fail")
+	}
+	if _, err := ServerConfig(&config.TLSCfg{Cert: "a.crt", Key: "a.key", ClientAuth: true}); err == nil {
+		t.Fatal("client auth without ca should fail")
+	}
+	if _, err := ClientConfig(&config.TLSCfg{MinVersion: "1.1"}); err == nil {
+		t.Fatal("min version 1.1 should fail")
+	}
+}
@@ -31,7 +31,7 @@ check_protoc_version() {
 }
 
 go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.32.0
-go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.5.1
 check_protoc_version
 
 gen_out_dir=./vearchpb
@@ -46,7 +46,7 @@ if [ "$2" ]; then
 fi
 
 export PATH=$PATH:$GOPATH/bin
-protoc --go_out=$proto_dir *.proto
+protoc --go_out=$proto_dir --go-grpc_out=$proto_dir *.proto
 pushd ../pkg/metrics && protoc --go_out=. *.proto && popd
 protoc --cpp_out=../engine/idl/pb-gen data_model.proto errors.proto router_grpc.proto
 exit $?
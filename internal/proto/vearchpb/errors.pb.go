@@ -104,6 +104,9 @@ const (
 	ErrorEnum_METHOD_NOT_IMPLEMENT    ErrorEnum = 702
 	ErrorEnum_CREATE_RPCCLIENT_FAILED ErrorEnum = 703
 	ErrorEnum_CALL_RPCCLIENT_FAILED   ErrorEnum = 704
+	// limit 800
+	ErrorEnum_RATE_LIMITED   ErrorEnum = 800
+	ErrorEnum_QUOTA_EXCEEDED ErrorEnum = 801
 )
 
 // Enum value maps for ErrorEnum.
@@ -167,6 +170,8 @@ var (
 		702: "METHOD_NOT_IMPLEMENT",
 		703: "CREATE_RPCCLIENT_FAILED",
 		704: "CALL_RPCCLIENT_FAILED",
+		800: "RATE_LIMITED",
+		801: "QUOTA_EXCEEDED",
 	}
 	ErrorEnum_value = map[string]int32{
 		"SUCCESS":                            0,
@@ -227,6 +232,8 @@ var (
 		"METHOD_NOT_IMPLEMENT":        702,
 		"CREATE_RPCCLIENT_FAILED":     703,
 		"CALL_RPCCLIENT_FAILED":       704,
+		"RATE_LIMITED":                800,
+		"QUOTA_EXCEEDED":              801,
 	}
 )
 
@@ -320,7 +327,7 @@ var file_errors_proto_rawDesc = []byte{
 	0x72, 0x12, 0x27, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
 	0x13, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72,
 	0x45, 0x6e, 0x75, 0x6d, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73,
-	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x2a, 0xdb, 0x0d, 0x0a,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x2a, 0x83, 0x0e, 0x0a,
 	0x09, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55,
 	0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x54, 0x45, 0x52,
 	0x4e, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x55,
@@ -430,9 +437,11 @@ var file_errors_proto_rawDesc = []byte{
 	0x12, 0x1c, 0x0a, 0x17, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x50, 0x43, 0x43, 0x4c,
 	0x49, 0x45, 0x4e, 0x54, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0xbf, 0x05, 0x12, 0x1a,
 	0x0a, 0x15, 0x43, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x50, 0x43, 0x43, 0x4c, 0x49, 0x45, 0x4e, 0x54,
-	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0xc0, 0x05, 0x42, 0x0e, 0x48, 0x01, 0x5a, 0x0a,
-	0x2e, 0x2f, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0xc0, 0x05, 0x12, 0x11, 0x0a, 0x0c, 0x52, 0x41,
+	0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x45, 0x44, 0x10, 0xa0, 0x06, 0x12, 0x13, 0x0a,
+	0x0e, 0x51, 0x55, 0x4f, 0x54, 0x41, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10,
+	0xa1, 0x06, 0x42, 0x0e, 0x48, 0x01, 0x5a, 0x0a, 0x2e, 0x2f, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -28,6 +28,10 @@ const (
 	OpType_BULK   OpType = 2
 	OpType_GET    OpType = 3
 	OpType_SEARCH OpType = 4
+	OpType_UPDATE OpType = 5
+	// keys of expired documents with their expire values, a key is only
+	// deleted if its value is unchanged
+	OpType_DELETE_EXPIRED OpType = 6
 )
 
 // Enum value maps for OpType.
@@ -38,13 +42,17 @@ var (
 		2: "BULK",
 		3: "GET",
 		4: "SEARCH",
+		5: "UPDATE",
+		6: "DELETE_EXPIRED",
 	}
 	OpType_value = map[string]int32{
-		"CREATE": 0,
-		"DELETE": 1,
-		"BULK":   2,
-		"GET":    3,
-		"SEARCH": 4,
+		"CREATE":         0,
+		"DELETE":         1,
+		"BULK":           2,
+		"GET":            3,
+		"SEARCH":         4,
+		"UPDATE":         5,
+		"DELETE_EXPIRED": 6,
 	}
 )
 
@@ -144,6 +152,14 @@ type PartitionData struct {
 	IndexRequest       *IndexRequest        `protobuf:"bytes,10,opt,name=index_request,json=indexRequest,proto3" json:"index_request,omitempty"`
 	IndexResponse      *IndexResponse       `protobuf:"bytes,11,opt,name=index_response,json=indexResponse,proto3" json:"index_response,omitempty"`
 	QueryRequest       *QueryRequest        `protobuf:"bytes,12,opt,name=query_request,json=queryRequest,proto3" json:"query_request,omitempty"`
+	ScrollRequest      *ScrollRequest       `protobuf:"bytes,13,opt,name=scroll_request,json=scrollRequest,proto3" json:"scroll_request,omitempty"`
+	ScrollResponse     *ScrollResponse      `protobuf:"bytes,14,opt,name=scroll_response,json=scrollResponse,proto3" json:"scroll_response,omitempty"`
+	// head of gets, for their consistency
+	Head *RequestHead `protobuf:"bytes,15,opt,name=head,proto3" json:"head,omitempty"`
+	// raft index of writes, for the write token
+	WriteIndex      uint64           `protobuf:"varint,16,opt,name=write_index,json=writeIndex,proto3" json:"write_index,omitempty"`
+	ChangesRequest  *ChangesRequest  `protobuf:"bytes,17,opt,name=changes_request,json=changesRequest,proto3" json:"changes_request,omitempty"`
+	ChangesResponse *ChangesResponse `protobuf:"bytes,18,opt,name=changes_response,json=changesResponse,proto3" json:"changes_response,omitempty"`
 }
 
 func (x *PartitionData) Reset() {
@@ -262,6 +278,48 @@ func (x *PartitionData) GetQueryRequest() *QueryRequest {
 	return nil
 }
 
+func (x *PartitionData) GetScrollRequest() *ScrollRequest {
+	if x != nil {
+		return x.ScrollRequest
+	}
+	return nil
+}
+
+func (x *PartitionData) GetScrollResponse() *ScrollResponse {
+	if x != nil {
+		return x.ScrollResponse
+	}
+	return nil
+}
+
+func (x *PartitionData) GetHead() *RequestHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *PartitionData) GetWriteIndex() uint64 {
+	if x != nil {
+		return x.WriteIndex
+	}
+	return 0
+}
+
+func (x *PartitionData) GetChangesRequest() *ChangesRequest {
+	if x != nil {
+		return x.ChangesRequest
+	}
+	return nil
+}
+
+func (x *PartitionData) GetChangesResponse() *ChangesResponse {
+	if x != nil {
+		return x.ChangesResponse
+	}
+	return nil
+}
+
 // *********************** Raft *********************** //
 type UpdateSpace struct {
 	state         protoimpl.MessageState
@@ -323,11 +381,14 @@ type DocCmd struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type    OpType   `protobuf:"varint,1,opt,name=type,proto3,enum=vearchpb.OpType" json:"type,omitempty"`
-	Version int64    `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
-	Slot    uint32   `protobuf:"varint,5,opt,name=slot,proto3" json:"slot,omitempty"`
-	Doc     []byte   `protobuf:"bytes,7,opt,name=doc,proto3" json:"doc,omitempty"`
-	Docs    [][]byte `protobuf:"bytes,8,rep,name=docs,proto3" json:"docs,omitempty"`
+	Type    OpType `protobuf:"varint,1,opt,name=type,proto3,enum=vearchpb.OpType" json:"type,omitempty"`
+	Version int64  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Slot    uint32 `protobuf:"varint,5,opt,name=slot,proto3" json:"slot,omitempty"`
+	Doc     []byte `protobuf:"bytes,7,opt,name=doc,proto3" json:"doc,omitempty"`
+	// docs of a bulk, update or delete of expired documents
+	Docs [][]byte `protobuf:"bytes,8,rep,name=docs,proto3" json:"docs,omitempty"`
+	// unix milliseconds the write is proposed by the leader
+	Timestamp int64 `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (x *DocCmd) Reset() {
@@ -397,6 +458,13 @@ func (x *DocCmd) GetDocs() [][]byte {
 	return nil
 }
 
+func (x *DocCmd) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type RaftCommand struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -522,7 +590,7 @@ var file_raftcmd_proto_rawDesc = []byte{
 	0x08, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x1a, 0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72,
 	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x6d, 0x6f,
 	0x64, 0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x72, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe1, 0x04, 0x0a,
+	0x72, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb9, 0x07, 0x0a,
 	0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x61, 0x12, 0x24,
 	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x76,
 	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
@@ -561,42 +629,67 @@ var file_raftcmd_proto_rawDesc = []byte{
 	0x75, 0x65, 0x73, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61,
 	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x3d, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x53, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05,
-	0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
-	0x82, 0x01, 0x0a, 0x06, 0x44, 0x6f, 0x63, 0x43, 0x6d, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x70, 0x62, 0x2e, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c,
-	0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x10,
-	0x0a, 0x03, 0x64, 0x6f, 0x63, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x64, 0x6f, 0x63,
-	0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04,
-	0x64, 0x6f, 0x63, 0x73, 0x22, 0xa5, 0x01, 0x0a, 0x0b, 0x52, 0x61, 0x66, 0x74, 0x43, 0x6f, 0x6d,
-	0x6d, 0x61, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x43, 0x6d,
-	0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x0d, 0x77,
-	0x72, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44, 0x6f,
-	0x63, 0x43, 0x6d, 0x64, 0x52, 0x0c, 0x77, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x12, 0x38, 0x0a, 0x0c, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x70, 0x61,
-	0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x52,
-	0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x22, 0x32, 0x0a, 0x08,
-	0x53, 0x6e, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x2a, 0x3f, 0x0a, 0x06, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52,
-	0x45, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x55, 0x4c, 0x4b, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03,
-	0x47, 0x45, 0x54, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10,
-	0x04, 0x2a, 0x3f, 0x0a, 0x07, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05,
-	0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x50, 0x44, 0x41, 0x54,
-	0x45, 0x53, 0x50, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x46, 0x4c, 0x55, 0x53,
-	0x48, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x44, 0x45, 0x4c,
-	0x10, 0x03, 0x42, 0x0e, 0x48, 0x01, 0x5a, 0x0a, 0x2e, 0x2f, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x3e, 0x0a, 0x0e, 0x73, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x52, 0x0d, 0x73, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x41, 0x0a, 0x0f, 0x73, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x0e, 0x73, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0a, 0x77, 0x72, 0x69, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x41, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x0e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x44, 0x0a, 0x10, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x5f, 0x72, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x3d, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x70, 0x61, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xa0, 0x01, 0x0a, 0x06, 0x44, 0x6f, 0x63, 0x43,
+	0x6d, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x10, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x4f, 0x70, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x6f, 0x63, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x64, 0x6f, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x63, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0xa5, 0x01, 0x0a, 0x0b, 0x52,
+	0x61, 0x66, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x43, 0x6d, 0x64, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x35, 0x0a, 0x0d, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
+	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x44, 0x6f, 0x63, 0x43, 0x6d, 0x64, 0x52, 0x0c, 0x77, 0x72, 0x69, 0x74,
+	0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x38, 0x0a, 0x0c, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x70, 0x61, 0x63, 0x65, 0x52, 0x0b, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x70, 0x61,
+	0x63, 0x65, 0x22, 0x32, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x44, 0x61, 0x74, 0x61, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x2a, 0x5f, 0x0a, 0x06, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x0a, 0x0a, 0x06, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06,
+	0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x55, 0x4c, 0x4b,
+	0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x47, 0x45, 0x54, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x53,
+	0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x5f, 0x45, 0x58,
+	0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x06, 0x2a, 0x3f, 0x0a, 0x07, 0x43, 0x6d, 0x64, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x57, 0x52, 0x49, 0x54, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x53, 0x50, 0x41, 0x43, 0x45, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x46, 0x4c, 0x55, 0x53, 0x48, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x45, 0x41,
+	0x52, 0x43, 0x48, 0x44, 0x45, 0x4c, 0x10, 0x03, 0x42, 0x0e, 0x48, 0x01, 0x5a, 0x0a, 0x2e, 0x2f,
+	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -629,6 +722,11 @@ var file_raftcmd_proto_goTypes = []interface{}{
 	(*IndexRequest)(nil),        // 12: vearchpb.IndexRequest
 	(*IndexResponse)(nil),       // 13: vearchpb.IndexResponse
 	(*QueryRequest)(nil),        // 14: vearchpb.QueryRequest
+	(*ScrollRequest)(nil),       // 15: vearchpb.ScrollRequest
+	(*ScrollResponse)(nil),      // 16: vearchpb.ScrollResponse
+	(*RequestHead)(nil),         // 17: vearchpb.RequestHead
+	(*ChangesRequest)(nil),      // 18: vearchpb.ChangesRequest
+	(*ChangesResponse)(nil),     // 19: vearchpb.ChangesResponse
 }
 var file_raftcmd_proto_depIdxs = []int32{
 	0,  // 0: vearchpb.PartitionData.type:type_name -> vearchpb.OpType
@@ -640,15 +738,20 @@ var file_raftcmd_proto_depIdxs = []int32{
 	12, // 6: vearchpb.PartitionData.index_request:type_name -> vearchpb.IndexRequest
 	13, // 7: vearchpb.PartitionData.index_response:type_name -> vearchpb.IndexResponse
 	14, // 8: vearchpb.PartitionData.query_request:type_name -> vearchpb.QueryRequest
-	0,  // 9: vearchpb.DocCmd.type:type_name -> vearchpb.OpType
-	1,  // 10: vearchpb.RaftCommand.type:type_name -> vearchpb.CmdType
-	4,  // 11: vearchpb.RaftCommand.write_command:type_name -> vearchpb.DocCmd
-	3,  // 12: vearchpb.RaftCommand.update_space:type_name -> vearchpb.UpdateSpace
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	15, // 9: vearchpb.PartitionData.scroll_request:type_name -> vearchpb.ScrollRequest
+	16, // 10: vearchpb.PartitionData.scroll_response:type_name -> vearchpb.ScrollResponse
+	17, // 11: vearchpb.PartitionData.head:type_name -> vearchpb.RequestHead
+	18, // 12: vearchpb.PartitionData.changes_request:type_name -> vearchpb.ChangesRequest
+	19, // 13: vearchpb.PartitionData.changes_response:type_name -> vearchpb.ChangesResponse
+	0,  // 14: vearchpb.DocCmd.type:type_name -> vearchpb.OpType
+	1,  // 15: vearchpb.RaftCommand.type:type_name -> vearchpb.CmdType
+	4,  // 16: vearchpb.RaftCommand.write_command:type_name -> vearchpb.DocCmd
+	3,  // 17: vearchpb.RaftCommand.update_space:type_name -> vearchpb.UpdateSpace
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_raftcmd_proto_init() }
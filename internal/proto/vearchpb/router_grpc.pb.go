@@ -20,6 +20,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Aggregation_Type int32
+
+const (
+	Aggregation_TERMS     Aggregation_Type = 0
+	Aggregation_RANGE     Aggregation_Type = 1
+	Aggregation_HISTOGRAM Aggregation_Type = 2
+	Aggregation_STATS     Aggregation_Type = 3
+)
+
+// Enum value maps for Aggregation_Type.
+var (
+	Aggregation_Type_name = map[int32]string{
+		0: "TERMS",
+		1: "RANGE",
+		2: "HISTOGRAM",
+		3: "STATS",
+	}
+	Aggregation_Type_value = map[string]int32{
+		"TERMS":     0,
+		"RANGE":     1,
+		"HISTOGRAM": 2,
+		"STATS":     3,
+	}
+)
+
+func (x Aggregation_Type) Enum() *Aggregation_Type {
+	p := new(Aggregation_Type)
+	*p = x
+	return p
+}
+
+func (x Aggregation_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Aggregation_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_grpc_proto_enumTypes[0].Descriptor()
+}
+
+func (Aggregation_Type) Type() protoreflect.EnumType {
+	return &file_router_grpc_proto_enumTypes[0]
+}
+
+func (x Aggregation_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Aggregation_Type.Descriptor instead.
+func (Aggregation_Type) EnumDescriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{19, 0}
+}
+
 type IndexParameters_DistanceMetricType int32
 
 const (
@@ -50,11 +102,11 @@ func (x IndexParameters_DistanceMetricType) String() string {
 }
 
 func (IndexParameters_DistanceMetricType) Descriptor() protoreflect.EnumDescriptor {
-	return file_router_grpc_proto_enumTypes[0].Descriptor()
+	return file_router_grpc_proto_enumTypes[1].Descriptor()
 }
 
 func (IndexParameters_DistanceMetricType) Type() protoreflect.EnumType {
-	return &file_router_grpc_proto_enumTypes[0]
+	return &file_router_grpc_proto_enumTypes[1]
 }
 
 func (x IndexParameters_DistanceMetricType) Number() protoreflect.EnumNumber {
@@ -63,7 +115,56 @@ func (x IndexParameters_DistanceMetricType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IndexParameters_DistanceMetricType.Descriptor instead.
 func (IndexParameters_DistanceMetricType) EnumDescriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{19, 0}
+	return file_router_grpc_proto_rawDescGZIP(), []int{27, 0}
+}
+
+type ChangeEvent_Op int32
+
+const (
+	ChangeEvent_UPSERT ChangeEvent_Op = 0
+	ChangeEvent_UPDATE ChangeEvent_Op = 1
+	ChangeEvent_DELETE ChangeEvent_Op = 2
+)
+
+// Enum value maps for ChangeEvent_Op.
+var (
+	ChangeEvent_Op_name = map[int32]string{
+		0: "UPSERT",
+		1: "UPDATE",
+		2: "DELETE",
+	}
+	ChangeEvent_Op_value = map[string]int32{
+		"UPSERT": 0,
+		"UPDATE": 1,
+		"DELETE": 2,
+	}
+)
+
+func (x ChangeEvent_Op) Enum() *ChangeEvent_Op {
+	p := new(ChangeEvent_Op)
+	*p = x
+	return p
+}
+
+func (x ChangeEvent_Op) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeEvent_Op) Descriptor() protoreflect.EnumDescriptor {
+	return file_router_grpc_proto_enumTypes[2].Descriptor()
+}
+
+func (ChangeEvent_Op) Type() protoreflect.EnumType {
+	return &file_router_grpc_proto_enumTypes[2]
+}
+
+func (x ChangeEvent_Op) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeEvent_Op.Descriptor instead.
+func (ChangeEvent_Op) EnumDescriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{36, 0}
 }
 
 type RequestHead struct {
@@ -79,6 +180,15 @@ type RequestHead struct {
 	ClientType string `protobuf:"bytes,6,opt,name=client_type,json=clientType,proto3" json:"client_type,omitempty"`
 	// from, sort, request_id, partition_id
 	Params map[string]string `protobuf:"bytes,7,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// eventual by default, or bounded_staleness, read_your_writes and
+	// linearizable for reads
+	Consistency string `protobuf:"bytes,8,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	// entries a replica may lag behind the commit of its leader for
+	// bounded_staleness
+	MaxStaleness uint64 `protobuf:"varint,9,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
+	// raft index each partition should have applied for read_your_writes,
+	// decoded from the write token of writes
+	ReadIndex map[uint32]uint64 `protobuf:"bytes,10,rep,name=read_index,json=readIndex,proto3" json:"read_index,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
 func (x *RequestHead) Reset() {
@@ -162,6 +272,27 @@ func (x *RequestHead) GetParams() map[string]string {
 	return nil
 }
 
+func (x *RequestHead) GetConsistency() string {
+	if x != nil {
+		return x.Consistency
+	}
+	return ""
+}
+
+func (x *RequestHead) GetMaxStaleness() uint64 {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return 0
+}
+
+func (x *RequestHead) GetReadIndex() map[uint32]uint64 {
+	if x != nil {
+		return x.ReadIndex
+	}
+	return nil
+}
+
 type ResponseHead struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -398,6 +529,71 @@ func (x *BulkRequest) GetPartitions() []uint32 {
 	return nil
 }
 
+// UpdateRequest applies the fields of docs to existing documents by the
+// update_op of each field, other fields are kept.
+type UpdateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head       *RequestHead `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Docs       []*Document  `protobuf:"bytes,2,rep,name=docs,proto3" json:"docs,omitempty"`
+	Partitions []uint32     `protobuf:"varint,3,rep,packed,name=partitions,proto3" json:"partitions,omitempty"`
+}
+
+func (x *UpdateRequest) Reset() {
+	*x = UpdateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRequest) ProtoMessage() {}
+
+func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRequest) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateRequest) GetHead() *RequestHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetDocs() []*Document {
+	if x != nil {
+		return x.Docs
+	}
+	return nil
+}
+
+func (x *UpdateRequest) GetPartitions() []uint32 {
+	if x != nil {
+		return x.Partitions
+	}
+	return nil
+}
+
 type ForceMergeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -409,7 +605,7 @@ type ForceMergeRequest struct {
 func (x *ForceMergeRequest) Reset() {
 	*x = ForceMergeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[5]
+		mi := &file_router_grpc_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -422,7 +618,7 @@ func (x *ForceMergeRequest) String() string {
 func (*ForceMergeRequest) ProtoMessage() {}
 
 func (x *ForceMergeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[5]
+	mi := &file_router_grpc_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +631,7 @@ func (x *ForceMergeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForceMergeRequest.ProtoReflect.Descriptor instead.
 func (*ForceMergeRequest) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{5}
+	return file_router_grpc_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ForceMergeRequest) GetHead() *RequestHead {
@@ -456,7 +652,7 @@ type FlushRequest struct {
 func (x *FlushRequest) Reset() {
 	*x = FlushRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[6]
+		mi := &file_router_grpc_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -469,7 +665,7 @@ func (x *FlushRequest) String() string {
 func (*FlushRequest) ProtoMessage() {}
 
 func (x *FlushRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[6]
+	mi := &file_router_grpc_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,7 +678,7 @@ func (x *FlushRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlushRequest.ProtoReflect.Descriptor instead.
 func (*FlushRequest) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{6}
+	return file_router_grpc_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FlushRequest) GetHead() *RequestHead {
@@ -507,7 +703,7 @@ type IndexRequest struct {
 func (x *IndexRequest) Reset() {
 	*x = IndexRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[7]
+		mi := &file_router_grpc_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +716,7 @@ func (x *IndexRequest) String() string {
 func (*IndexRequest) ProtoMessage() {}
 
 func (x *IndexRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[7]
+	mi := &file_router_grpc_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +729,7 @@ func (x *IndexRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexRequest.ProtoReflect.Descriptor instead.
 func (*IndexRequest) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{7}
+	return file_router_grpc_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *IndexRequest) GetHead() *RequestHead {
@@ -583,7 +779,7 @@ type GetResponse struct {
 func (x *GetResponse) Reset() {
 	*x = GetResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[8]
+		mi := &file_router_grpc_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -596,7 +792,7 @@ func (x *GetResponse) String() string {
 func (*GetResponse) ProtoMessage() {}
 
 func (x *GetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[8]
+	mi := &file_router_grpc_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -609,7 +805,7 @@ func (x *GetResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetResponse.ProtoReflect.Descriptor instead.
 func (*GetResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{8}
+	return file_router_grpc_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetResponse) GetHead() *ResponseHead {
@@ -638,7 +834,7 @@ type DeleteResponse struct {
 func (x *DeleteResponse) Reset() {
 	*x = DeleteResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[9]
+		mi := &file_router_grpc_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -651,7 +847,7 @@ func (x *DeleteResponse) String() string {
 func (*DeleteResponse) ProtoMessage() {}
 
 func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[9]
+	mi := &file_router_grpc_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -664,7 +860,7 @@ func (x *DeleteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{9}
+	return file_router_grpc_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteResponse) GetHead() *ResponseHead {
@@ -693,7 +889,7 @@ type BulkResponse struct {
 func (x *BulkResponse) Reset() {
 	*x = BulkResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[10]
+		mi := &file_router_grpc_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -706,7 +902,7 @@ func (x *BulkResponse) String() string {
 func (*BulkResponse) ProtoMessage() {}
 
 func (x *BulkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[10]
+	mi := &file_router_grpc_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -719,7 +915,7 @@ func (x *BulkResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkResponse.ProtoReflect.Descriptor instead.
 func (*BulkResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{10}
+	return file_router_grpc_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *BulkResponse) GetHead() *ResponseHead {
@@ -748,7 +944,7 @@ type ForceMergeResponse struct {
 func (x *ForceMergeResponse) Reset() {
 	*x = ForceMergeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[11]
+		mi := &file_router_grpc_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -761,7 +957,7 @@ func (x *ForceMergeResponse) String() string {
 func (*ForceMergeResponse) ProtoMessage() {}
 
 func (x *ForceMergeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[11]
+	mi := &file_router_grpc_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -774,7 +970,7 @@ func (x *ForceMergeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ForceMergeResponse.ProtoReflect.Descriptor instead.
 func (*ForceMergeResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{11}
+	return file_router_grpc_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ForceMergeResponse) GetHead() *ResponseHead {
@@ -804,7 +1000,7 @@ type DelByQueryeResponse struct {
 func (x *DelByQueryeResponse) Reset() {
 	*x = DelByQueryeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[12]
+		mi := &file_router_grpc_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -817,7 +1013,7 @@ func (x *DelByQueryeResponse) String() string {
 func (*DelByQueryeResponse) ProtoMessage() {}
 
 func (x *DelByQueryeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[12]
+	mi := &file_router_grpc_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -830,7 +1026,7 @@ func (x *DelByQueryeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DelByQueryeResponse.ProtoReflect.Descriptor instead.
 func (*DelByQueryeResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{12}
+	return file_router_grpc_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *DelByQueryeResponse) GetHead() *ResponseHead {
@@ -866,7 +1062,7 @@ type FlushResponse struct {
 func (x *FlushResponse) Reset() {
 	*x = FlushResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[13]
+		mi := &file_router_grpc_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -879,7 +1075,7 @@ func (x *FlushResponse) String() string {
 func (*FlushResponse) ProtoMessage() {}
 
 func (x *FlushResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[13]
+	mi := &file_router_grpc_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,7 +1088,7 @@ func (x *FlushResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FlushResponse.ProtoReflect.Descriptor instead.
 func (*FlushResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{13}
+	return file_router_grpc_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *FlushResponse) GetHead() *ResponseHead {
@@ -921,7 +1117,7 @@ type IndexResponse struct {
 func (x *IndexResponse) Reset() {
 	*x = IndexResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[14]
+		mi := &file_router_grpc_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -934,7 +1130,7 @@ func (x *IndexResponse) String() string {
 func (*IndexResponse) ProtoMessage() {}
 
 func (x *IndexResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[14]
+	mi := &file_router_grpc_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -947,7 +1143,7 @@ func (x *IndexResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexResponse.ProtoReflect.Descriptor instead.
 func (*IndexResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{14}
+	return file_router_grpc_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *IndexResponse) GetHead() *ResponseHead {
@@ -977,7 +1173,7 @@ type TermFilter struct {
 func (x *TermFilter) Reset() {
 	*x = TermFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[15]
+		mi := &file_router_grpc_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -990,7 +1186,7 @@ func (x *TermFilter) String() string {
 func (*TermFilter) ProtoMessage() {}
 
 func (x *TermFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[15]
+	mi := &file_router_grpc_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1199,7 @@ func (x *TermFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TermFilter.ProtoReflect.Descriptor instead.
 func (*TermFilter) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{15}
+	return file_router_grpc_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *TermFilter) GetField() string {
@@ -1043,7 +1239,7 @@ type RangeFilter struct {
 func (x *RangeFilter) Reset() {
 	*x = RangeFilter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[16]
+		mi := &file_router_grpc_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1252,7 @@ func (x *RangeFilter) String() string {
 func (*RangeFilter) ProtoMessage() {}
 
 func (x *RangeFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[16]
+	mi := &file_router_grpc_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,7 +1265,7 @@ func (x *RangeFilter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RangeFilter.ProtoReflect.Descriptor instead.
 func (*RangeFilter) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{16}
+	return file_router_grpc_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RangeFilter) GetField() string {
@@ -1114,32 +1310,36 @@ func (x *RangeFilter) GetIsUnion() int32 {
 	return 0
 }
 
-type SortField struct {
+// FilterNode is one group of a nested boolean filter. Its own range and term
+// filters and its children are combined with operator (0 AND, 1 OR, 2 NOT).
+type FilterNode struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
-	Type  bool   `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+	Operator     int32          `protobuf:"varint,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	RangeFilters []*RangeFilter `protobuf:"bytes,2,rep,name=range_filters,json=rangeFilters,proto3" json:"range_filters,omitempty"`
+	TermFilters  []*TermFilter  `protobuf:"bytes,3,rep,name=term_filters,json=termFilters,proto3" json:"term_filters,omitempty"`
+	Children     []*FilterNode  `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
 }
 
-func (x *SortField) Reset() {
-	*x = SortField{}
+func (x *FilterNode) Reset() {
+	*x = FilterNode{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[17]
+		mi := &file_router_grpc_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SortField) String() string {
+func (x *FilterNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SortField) ProtoMessage() {}
+func (*FilterNode) ProtoMessage() {}
 
-func (x *SortField) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[17]
+func (x *FilterNode) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1150,55 +1350,73 @@ func (x *SortField) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SortField.ProtoReflect.Descriptor instead.
-func (*SortField) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use FilterNode.ProtoReflect.Descriptor instead.
+func (*FilterNode) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SortField) GetField() string {
+func (x *FilterNode) GetOperator() int32 {
 	if x != nil {
-		return x.Field
+		return x.Operator
 	}
-	return ""
+	return 0
 }
 
-func (x *SortField) GetType() bool {
+func (x *FilterNode) GetRangeFilters() []*RangeFilter {
 	if x != nil {
-		return x.Type
+		return x.RangeFilters
 	}
-	return false
+	return nil
 }
 
-type VectorQuery struct {
+func (x *FilterNode) GetTermFilters() []*TermFilter {
+	if x != nil {
+		return x.TermFilters
+	}
+	return nil
+}
+
+func (x *FilterNode) GetChildren() []*FilterNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Aggregation is a named aggregation of a search or query, each partition
+// computes it over all its documents matching the filters of the request.
+type Aggregation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name      string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value     []byte  `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	MinScore  float64 `protobuf:"fixed64,3,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
-	MaxScore  float64 `protobuf:"fixed64,4,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
-	Format    string  `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
-	IndexType string  `protobuf:"bytes,6,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`
+	Name  string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type  Aggregation_Type `protobuf:"varint,2,opt,name=type,proto3,enum=vearchpb.Aggregation_Type" json:"type,omitempty"`
+	Field string           `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+	// number of TERMS buckets
+	Size   int32               `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Ranges []*AggregationRange `protobuf:"bytes,5,rep,name=ranges,proto3" json:"ranges,omitempty"`
+	// bucket width of HISTOGRAM, in nanoseconds for date fields
+	Interval float64 `protobuf:"fixed64,6,opt,name=interval,proto3" json:"interval,omitempty"`
 }
 
-func (x *VectorQuery) Reset() {
-	*x = VectorQuery{}
+func (x *Aggregation) Reset() {
+	*x = Aggregation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[18]
+		mi := &file_router_grpc_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *VectorQuery) String() string {
+func (x *Aggregation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VectorQuery) ProtoMessage() {}
+func (*Aggregation) ProtoMessage() {}
 
-func (x *VectorQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[18]
+func (x *Aggregation) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1209,23 +1427,520 @@ func (x *VectorQuery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VectorQuery.ProtoReflect.Descriptor instead.
-func (*VectorQuery) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use Aggregation.ProtoReflect.Descriptor instead.
+func (*Aggregation) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *VectorQuery) GetName() string {
+func (x *Aggregation) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *VectorQuery) GetValue() []byte {
+func (x *Aggregation) GetType() Aggregation_Type {
 	if x != nil {
-		return x.Value
+		return x.Type
 	}
-	return nil
+	return Aggregation_TERMS
+}
+
+func (x *Aggregation) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Aggregation) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Aggregation) GetRanges() []*AggregationRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+func (x *Aggregation) GetInterval() float64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+// AggregationRange is a bucket of a RANGE aggregation, from is inclusive and
+// to exclusive, unbounded ends are infinite.
+type AggregationRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key  string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	From float64 `protobuf:"fixed64,2,opt,name=from,proto3" json:"from,omitempty"`
+	To   float64 `protobuf:"fixed64,3,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *AggregationRange) Reset() {
+	*x = AggregationRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationRange) ProtoMessage() {}
+
+func (x *AggregationRange) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationRange.ProtoReflect.Descriptor instead.
+func (*AggregationRange) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AggregationRange) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AggregationRange) GetFrom() float64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AggregationRange) GetTo() float64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type AggregationBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string  `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Count int64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	From  float64 `protobuf:"fixed64,3,opt,name=from,proto3" json:"from,omitempty"`
+	To    float64 `protobuf:"fixed64,4,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *AggregationBucket) Reset() {
+	*x = AggregationBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationBucket) ProtoMessage() {}
+
+func (x *AggregationBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationBucket.ProtoReflect.Descriptor instead.
+func (*AggregationBucket) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AggregationBucket) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AggregationBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AggregationBucket) GetFrom() float64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AggregationBucket) GetTo() float64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// AggregationResult holds the buckets of TERMS, RANGE and HISTOGRAM, or the
+// stats of a STATS aggregation.
+type AggregationResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Buckets []*AggregationBucket `protobuf:"bytes,2,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	// documents of TERMS not in the buckets
+	OtherCount int64   `protobuf:"varint,3,opt,name=other_count,json=otherCount,proto3" json:"other_count,omitempty"`
+	Count      int64   `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Sum        float64 `protobuf:"fixed64,5,opt,name=sum,proto3" json:"sum,omitempty"`
+	Min        float64 `protobuf:"fixed64,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max        float64 `protobuf:"fixed64,7,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *AggregationResult) Reset() {
+	*x = AggregationResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationResult) ProtoMessage() {}
+
+func (x *AggregationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationResult.ProtoReflect.Descriptor instead.
+func (*AggregationResult) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AggregationResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AggregationResult) GetBuckets() []*AggregationBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *AggregationResult) GetOtherCount() int64 {
+	if x != nil {
+		return x.OtherCount
+	}
+	return 0
+}
+
+func (x *AggregationResult) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AggregationResult) GetSum() float64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *AggregationResult) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *AggregationResult) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// GroupBy collapses the merged results of a search to at most group_size
+// hits for each value of field, and to the best groups values.
+type GroupBy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field     string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	GroupSize int32  `protobuf:"varint,2,opt,name=group_size,json=groupSize,proto3" json:"group_size,omitempty"`
+	Groups    int32  `protobuf:"varint,3,opt,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *GroupBy) Reset() {
+	*x = GroupBy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupBy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupBy) ProtoMessage() {}
+
+func (x *GroupBy) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupBy.ProtoReflect.Descriptor instead.
+func (*GroupBy) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GroupBy) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GroupBy) GetGroupSize() int32 {
+	if x != nil {
+		return x.GroupSize
+	}
+	return 0
+}
+
+func (x *GroupBy) GetGroups() int32 {
+	if x != nil {
+		return x.Groups
+	}
+	return 0
+}
+
+// TextQuery is a BM25 match of query on a text field with a FULLTEXT index.
+type TextQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Query string `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+func (x *TextQuery) Reset() {
+	*x = TextQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TextQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextQuery) ProtoMessage() {}
+
+func (x *TextQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextQuery.ProtoReflect.Descriptor instead.
+func (*TextQuery) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TextQuery) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TextQuery) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+type SortField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Type  bool   `protobuf:"varint,2,opt,name=type,proto3" json:"type,omitempty"`
+}
+
+func (x *SortField) Reset() {
+	*x = SortField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortField) ProtoMessage() {}
+
+func (x *SortField) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortField.ProtoReflect.Descriptor instead.
+func (*SortField) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SortField) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SortField) GetType() bool {
+	if x != nil {
+		return x.Type
+	}
+	return false
+}
+
+type VectorQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value     []byte  `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	MinScore  float64 `protobuf:"fixed64,3,opt,name=min_score,json=minScore,proto3" json:"min_score,omitempty"`
+	MaxScore  float64 `protobuf:"fixed64,4,opt,name=max_score,json=maxScore,proto3" json:"max_score,omitempty"`
+	Format    string  `protobuf:"bytes,5,opt,name=format,proto3" json:"format,omitempty"`
+	IndexType string  `protobuf:"bytes,6,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`
+}
+
+func (x *VectorQuery) Reset() {
+	*x = VectorQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VectorQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VectorQuery) ProtoMessage() {}
+
+func (x *VectorQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VectorQuery.ProtoReflect.Descriptor instead.
+func (*VectorQuery) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *VectorQuery) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VectorQuery) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
 }
 
 func (x *VectorQuery) GetMinScore() float64 {
@@ -1268,7 +1983,7 @@ type IndexParameters struct {
 func (x *IndexParameters) Reset() {
 	*x = IndexParameters{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[19]
+		mi := &file_router_grpc_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1281,7 +1996,7 @@ func (x *IndexParameters) String() string {
 func (*IndexParameters) ProtoMessage() {}
 
 func (x *IndexParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[19]
+	mi := &file_router_grpc_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,7 +2009,7 @@ func (x *IndexParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndexParameters.ProtoReflect.Descriptor instead.
 func (*IndexParameters) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{19}
+	return file_router_grpc_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *IndexParameters) GetMetricType() IndexParameters_DistanceMetricType {
@@ -1331,12 +2046,17 @@ type QueryRequest struct {
 	SortFields    []*SortField      `protobuf:"bytes,13,rep,name=sort_fields,json=sortFields,proto3" json:"sort_fields,omitempty"`
 	Trace         bool              `protobuf:"varint,14,opt,name=trace,proto3" json:"trace,omitempty"`
 	Operator      int32             `protobuf:"varint,15,opt,name=operator,proto3" json:"operator,omitempty"`
+	FilterTree    *FilterNode       `protobuf:"bytes,16,opt,name=filter_tree,json=filterTree,proto3" json:"filter_tree,omitempty"`
+	// documents skipped by the router after merging the partitions, each
+	// partition returns offset + limit documents
+	Offset       int32          `protobuf:"varint,17,opt,name=offset,proto3" json:"offset,omitempty"`
+	Aggregations []*Aggregation `protobuf:"bytes,18,rep,name=aggregations,proto3" json:"aggregations,omitempty"`
 }
 
 func (x *QueryRequest) Reset() {
 	*x = QueryRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[20]
+		mi := &file_router_grpc_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1349,7 +2069,7 @@ func (x *QueryRequest) String() string {
 func (*QueryRequest) ProtoMessage() {}
 
 func (x *QueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[20]
+	mi := &file_router_grpc_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1362,7 +2082,7 @@ func (x *QueryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
 func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{20}
+	return file_router_grpc_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *QueryRequest) GetHead() *RequestHead {
@@ -1432,42 +2152,249 @@ func (x *QueryRequest) GetPageSize() int32 {
 	if x != nil {
 		return x.PageSize
 	}
-	return 0
+	return 0
+}
+
+func (x *QueryRequest) GetLoadBalance() string {
+	if x != nil {
+		return x.LoadBalance
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetSortFieldMap() map[string]string {
+	if x != nil {
+		return x.SortFieldMap
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetSortFields() []*SortField {
+	if x != nil {
+		return x.SortFields
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetTrace() bool {
+	if x != nil {
+		return x.Trace
+	}
+	return false
+}
+
+func (x *QueryRequest) GetOperator() int32 {
+	if x != nil {
+		return x.Operator
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetFilterTree() *FilterNode {
+	if x != nil {
+		return x.FilterTree
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *QueryRequest) GetAggregations() []*Aggregation {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+// ScrollRequest reads up to size documents of one partition in docid order,
+// starting after docid, which is -1 for the first read.
+type ScrollRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head          *RequestHead   `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Docid         int32          `protobuf:"varint,2,opt,name=docid,proto3" json:"docid,omitempty"`
+	Size          int32          `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Fields        []string       `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	IsVectorValue bool           `protobuf:"varint,5,opt,name=is_vector_value,json=isVectorValue,proto3" json:"is_vector_value,omitempty"`
+	RangeFilters  []*RangeFilter `protobuf:"bytes,6,rep,name=range_filters,json=rangeFilters,proto3" json:"range_filters,omitempty"`
+	TermFilters   []*TermFilter  `protobuf:"bytes,7,rep,name=term_filters,json=termFilters,proto3" json:"term_filters,omitempty"`
+	Operator      int32          `protobuf:"varint,8,opt,name=operator,proto3" json:"operator,omitempty"`
+	FilterTree    *FilterNode    `protobuf:"bytes,9,opt,name=filter_tree,json=filterTree,proto3" json:"filter_tree,omitempty"`
+}
+
+func (x *ScrollRequest) Reset() {
+	*x = ScrollRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScrollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrollRequest) ProtoMessage() {}
+
+func (x *ScrollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScrollRequest.ProtoReflect.Descriptor instead.
+func (*ScrollRequest) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ScrollRequest) GetHead() *RequestHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *ScrollRequest) GetDocid() int32 {
+	if x != nil {
+		return x.Docid
+	}
+	return 0
+}
+
+func (x *ScrollRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ScrollRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ScrollRequest) GetIsVectorValue() bool {
+	if x != nil {
+		return x.IsVectorValue
+	}
+	return false
+}
+
+func (x *ScrollRequest) GetRangeFilters() []*RangeFilter {
+	if x != nil {
+		return x.RangeFilters
+	}
+	return nil
+}
+
+func (x *ScrollRequest) GetTermFilters() []*TermFilter {
+	if x != nil {
+		return x.TermFilters
+	}
+	return nil
+}
+
+func (x *ScrollRequest) GetOperator() int32 {
+	if x != nil {
+		return x.Operator
+	}
+	return 0
+}
+
+func (x *ScrollRequest) GetFilterTree() *FilterNode {
+	if x != nil {
+		return x.FilterTree
+	}
+	return nil
+}
+
+// ScrollResponse holds the documents read and the docid to continue after,
+// done is set once the end of the partition is reached.
+type ScrollResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head      *ResponseHead `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Documents []*Document   `protobuf:"bytes,2,rep,name=documents,proto3" json:"documents,omitempty"`
+	Docid     int32         `protobuf:"varint,3,opt,name=docid,proto3" json:"docid,omitempty"`
+	Done      bool          `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+}
+
+func (x *ScrollResponse) Reset() {
+	*x = ScrollResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ScrollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScrollResponse) ProtoMessage() {}
+
+func (x *ScrollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *QueryRequest) GetLoadBalance() string {
-	if x != nil {
-		return x.LoadBalance
-	}
-	return ""
+// Deprecated: Use ScrollResponse.ProtoReflect.Descriptor instead.
+func (*ScrollResponse) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{30}
 }
 
-func (x *QueryRequest) GetSortFieldMap() map[string]string {
+func (x *ScrollResponse) GetHead() *ResponseHead {
 	if x != nil {
-		return x.SortFieldMap
+		return x.Head
 	}
 	return nil
 }
 
-func (x *QueryRequest) GetSortFields() []*SortField {
+func (x *ScrollResponse) GetDocuments() []*Document {
 	if x != nil {
-		return x.SortFields
+		return x.Documents
 	}
 	return nil
 }
 
-func (x *QueryRequest) GetTrace() bool {
+func (x *ScrollResponse) GetDocid() int32 {
 	if x != nil {
-		return x.Trace
+		return x.Docid
 	}
-	return false
+	return 0
 }
 
-func (x *QueryRequest) GetOperator() int32 {
+func (x *ScrollResponse) GetDone() bool {
 	if x != nil {
-		return x.Operator
+		return x.Done
 	}
-	return 0
+	return false
 }
 
 type SearchRequest struct {
@@ -1492,12 +2419,19 @@ type SearchRequest struct {
 	Ranker          string            `protobuf:"bytes,15,opt,name=ranker,proto3" json:"ranker,omitempty"`
 	Trace           bool              `protobuf:"varint,16,opt,name=trace,proto3" json:"trace,omitempty"`
 	Operator        int32             `protobuf:"varint,17,opt,name=operator,proto3" json:"operator,omitempty"`
+	FilterTree      *FilterNode       `protobuf:"bytes,18,opt,name=filter_tree,json=filterTree,proto3" json:"filter_tree,omitempty"`
+	TextQueries     []*TextQuery      `protobuf:"bytes,19,rep,name=text_queries,json=textQueries,proto3" json:"text_queries,omitempty"`
+	// results skipped by the router after merging the partitions, each
+	// partition returns offset + topN results
+	Offset       int32          `protobuf:"varint,20,opt,name=offset,proto3" json:"offset,omitempty"`
+	Aggregations []*Aggregation `protobuf:"bytes,21,rep,name=aggregations,proto3" json:"aggregations,omitempty"`
+	GroupBy      *GroupBy       `protobuf:"bytes,22,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
 }
 
 func (x *SearchRequest) Reset() {
 	*x = SearchRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[21]
+		mi := &file_router_grpc_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1510,7 +2444,7 @@ func (x *SearchRequest) String() string {
 func (*SearchRequest) ProtoMessage() {}
 
 func (x *SearchRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[21]
+	mi := &file_router_grpc_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1523,7 +2457,7 @@ func (x *SearchRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
 func (*SearchRequest) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{21}
+	return file_router_grpc_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *SearchRequest) GetHead() *RequestHead {
@@ -1645,6 +2579,41 @@ func (x *SearchRequest) GetOperator() int32 {
 	return 0
 }
 
+func (x *SearchRequest) GetFilterTree() *FilterNode {
+	if x != nil {
+		return x.FilterTree
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetTextQueries() []*TextQuery {
+	if x != nil {
+		return x.TextQueries
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetAggregations() []*Aggregation {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+func (x *SearchRequest) GetGroupBy() *GroupBy {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
 type ResultItem struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1653,12 +2622,14 @@ type ResultItem struct {
 	Score  float64  `protobuf:"fixed64,1,opt,name=score,proto3" json:"score,omitempty"`
 	Fields []*Field `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
 	PKey   string   `protobuf:"bytes,3,opt,name=p_key,json=pKey,proto3" json:"p_key,omitempty"`
+	// scores of a hybrid search by retriever, fused into score by the router
+	Scores map[string]float64 `protobuf:"bytes,4,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
 }
 
 func (x *ResultItem) Reset() {
 	*x = ResultItem{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[22]
+		mi := &file_router_grpc_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1671,7 +2642,7 @@ func (x *ResultItem) String() string {
 func (*ResultItem) ProtoMessage() {}
 
 func (x *ResultItem) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[22]
+	mi := &file_router_grpc_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1684,7 +2655,7 @@ func (x *ResultItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResultItem.ProtoReflect.Descriptor instead.
 func (*ResultItem) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{22}
+	return file_router_grpc_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ResultItem) GetScore() float64 {
@@ -1708,6 +2679,13 @@ func (x *ResultItem) GetPKey() string {
 	return ""
 }
 
+func (x *ResultItem) GetScores() map[string]float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
 type SearchResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1728,7 +2706,7 @@ type SearchResult struct {
 func (x *SearchResult) Reset() {
 	*x = SearchResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[23]
+		mi := &file_router_grpc_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1741,7 +2719,7 @@ func (x *SearchResult) String() string {
 func (*SearchResult) ProtoMessage() {}
 
 func (x *SearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[23]
+	mi := &file_router_grpc_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1754,7 +2732,7 @@ func (x *SearchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
 func (*SearchResult) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{23}
+	return file_router_grpc_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *SearchResult) GetTotalHits() int32 {
@@ -1832,29 +2810,276 @@ type SearchResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Head      *ResponseHead   `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
-	Results   []*SearchResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
-	Timeout   bool            `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
-	FlatBytes []byte          `protobuf:"bytes,4,opt,name=FlatBytes,proto3" json:"FlatBytes,omitempty"`
+	Head         *ResponseHead        `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Results      []*SearchResult      `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	Timeout      bool                 `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	FlatBytes    []byte               `protobuf:"bytes,4,opt,name=FlatBytes,proto3" json:"FlatBytes,omitempty"`
+	Aggregations []*AggregationResult `protobuf:"bytes,5,rep,name=aggregations,proto3" json:"aggregations,omitempty"`
 }
 
 func (x *SearchResponse) Reset() {
 	*x = SearchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[24]
+		mi := &file_router_grpc_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchResponse) GetHead() *ResponseHead {
+	if x != nil {
+		return x.Head
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetTimeout() bool {
+	if x != nil {
+		return x.Timeout
+	}
+	return false
+}
+
+func (x *SearchResponse) GetFlatBytes() []byte {
+	if x != nil {
+		return x.FlatBytes
+	}
+	return nil
+}
+
+func (x *SearchResponse) GetAggregations() []*AggregationResult {
+	if x != nil {
+		return x.Aggregations
+	}
+	return nil
+}
+
+type SearchStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total      int32  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Failed     int32  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Successful int32  `protobuf:"varint,3,opt,name=successful,proto3" json:"successful,omitempty"`
+	Msg        string `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+}
+
+func (x *SearchStatus) Reset() {
+	*x = SearchStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchStatus) ProtoMessage() {}
+
+func (x *SearchStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchStatus.ProtoReflect.Descriptor instead.
+func (*SearchStatus) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SearchStatus) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchStatus) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *SearchStatus) GetSuccessful() int32 {
+	if x != nil {
+		return x.Successful
+	}
+	return 0
+}
+
+func (x *SearchStatus) GetMsg() string {
+	if x != nil {
+		return x.Msg
+	}
+	return ""
+}
+
+// ChangeEvent is the change of one document by an applied raft entry, the
+// docs of a bulk write share the index of their entry.
+type ChangeEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PartitionId uint32 `protobuf:"varint,1,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
+	Index       uint64 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// unix milliseconds the write is proposed by the leader
+	Timestamp int64          `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Op        ChangeEvent_Op `protobuf:"varint,4,opt,name=op,proto3,enum=vearchpb.ChangeEvent_Op" json:"op,omitempty"`
+	Key       string         `protobuf:"bytes,5,opt,name=key,proto3" json:"key,omitempty"`
+	// fields written, for update only the updated fields with their values
+	// after the update, none for delete
+	Fields []*Field `protobuf:"bytes,6,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ChangeEvent) GetPartitionId() uint32 {
+	if x != nil {
+		return x.PartitionId
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetOp() ChangeEvent_Op {
+	if x != nil {
+		return x.Op
+	}
+	return ChangeEvent_UPSERT
+}
+
+func (x *ChangeEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetFields() []*Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// ChangesRequest reads the changes of each partition after its position,
+// which is the index of the last change read, 0 reads from the oldest
+// change retained.
+type ChangesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Head      *RequestHead      `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Positions map[uint32]uint64 `protobuf:"bytes,2,rep,name=positions,proto3" json:"positions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// max changes of a partition in a response
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// milliseconds to wait for changes if there are none
+	WaitMs int64 `protobuf:"varint,4,opt,name=wait_ms,json=waitMs,proto3" json:"wait_ms,omitempty"`
+}
+
+func (x *ChangesRequest) Reset() {
+	*x = ChangesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_router_grpc_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SearchResponse) String() string {
+func (x *ChangesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchResponse) ProtoMessage() {}
+func (*ChangesRequest) ProtoMessage() {}
 
-func (x *SearchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[24]
+func (x *ChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1865,67 +3090,68 @@ func (x *SearchResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
-func (*SearchResponse) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use ChangesRequest.ProtoReflect.Descriptor instead.
+func (*ChangesRequest) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *SearchResponse) GetHead() *ResponseHead {
+func (x *ChangesRequest) GetHead() *RequestHead {
 	if x != nil {
 		return x.Head
 	}
 	return nil
 }
 
-func (x *SearchResponse) GetResults() []*SearchResult {
+func (x *ChangesRequest) GetPositions() map[uint32]uint64 {
 	if x != nil {
-		return x.Results
+		return x.Positions
 	}
 	return nil
 }
 
-func (x *SearchResponse) GetTimeout() bool {
+func (x *ChangesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Timeout
+		return x.Limit
 	}
-	return false
+	return 0
 }
 
-func (x *SearchResponse) GetFlatBytes() []byte {
+func (x *ChangesRequest) GetWaitMs() int64 {
 	if x != nil {
-		return x.FlatBytes
+		return x.WaitMs
 	}
-	return nil
+	return 0
 }
 
-type SearchStatus struct {
+// ChangesResponse holds the changes read in index order of each partition
+// and the positions to read after next time.
+type ChangesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Total      int32  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
-	Failed     int32  `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
-	Successful int32  `protobuf:"varint,3,opt,name=successful,proto3" json:"successful,omitempty"`
-	Msg        string `protobuf:"bytes,4,opt,name=msg,proto3" json:"msg,omitempty"`
+	Head      *ResponseHead     `protobuf:"bytes,1,opt,name=head,proto3" json:"head,omitempty"`
+	Events    []*ChangeEvent    `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Positions map[uint32]uint64 `protobuf:"bytes,3,rep,name=positions,proto3" json:"positions,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 }
 
-func (x *SearchStatus) Reset() {
-	*x = SearchStatus{}
+func (x *ChangesResponse) Reset() {
+	*x = ChangesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_router_grpc_proto_msgTypes[25]
+		mi := &file_router_grpc_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SearchStatus) String() string {
+func (x *ChangesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchStatus) ProtoMessage() {}
+func (*ChangesResponse) ProtoMessage() {}
 
-func (x *SearchStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_router_grpc_proto_msgTypes[25]
+func (x *ChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_router_grpc_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1936,37 +3162,30 @@ func (x *SearchStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchStatus.ProtoReflect.Descriptor instead.
-func (*SearchStatus) Descriptor() ([]byte, []int) {
-	return file_router_grpc_proto_rawDescGZIP(), []int{25}
-}
-
-func (x *SearchStatus) GetTotal() int32 {
-	if x != nil {
-		return x.Total
-	}
-	return 0
+// Deprecated: Use ChangesResponse.ProtoReflect.Descriptor instead.
+func (*ChangesResponse) Descriptor() ([]byte, []int) {
+	return file_router_grpc_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *SearchStatus) GetFailed() int32 {
+func (x *ChangesResponse) GetHead() *ResponseHead {
 	if x != nil {
-		return x.Failed
+		return x.Head
 	}
-	return 0
+	return nil
 }
 
-func (x *SearchStatus) GetSuccessful() int32 {
+func (x *ChangesResponse) GetEvents() []*ChangeEvent {
 	if x != nil {
-		return x.Successful
+		return x.Events
 	}
-	return 0
+	return nil
 }
 
-func (x *SearchStatus) GetMsg() string {
+func (x *ChangesResponse) GetPositions() map[uint32]uint64 {
 	if x != nil {
-		return x.Msg
+		return x.Positions
 	}
-	return ""
+	return nil
 }
 
 var File_router_grpc_proto protoreflect.FileDescriptor
@@ -1975,7 +3194,7 @@ var file_router_grpc_proto_rawDesc = []byte{
 	0x0a, 0x11, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x12, 0x08, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x1a, 0x10, 0x64,
 	0x61, 0x74, 0x61, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xb5, 0x02,
+	0x0c, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xff, 0x03,
 	0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1e, 0x0a,
 	0x0b, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x75, 0x74, 0x4d, 0x73, 0x12, 0x1b, 0x0a,
@@ -1991,311 +3210,515 @@ var file_router_grpc_proto_rawDesc = []byte{
 	0x39, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
 	0x21, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xc7, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x45, 0x72,
-	0x72, 0x6f, 0x72, 0x52, 0x03, 0x65, 0x72, 0x72, 0x12, 0x3a, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61,
-	0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64,
-	0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73,
+	0x73, 0x12, 0x43, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x2e, 0x52, 0x65, 0x61,
+	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x65, 0x61,
+	0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
 	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x5a, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a,
-	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65,
-	0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d,
-	0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b,
-	0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x5d, 0x0a, 0x0d, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04,
-	0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61,
-	0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61,
-	0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70,
-	0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0b, 0x42,
-	0x75, 0x6c, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65,
-	0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52,
-	0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44,
-	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x12, 0x1e, 0x0a,
-	0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0d, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3e, 0x0a,
-	0x11, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x22, 0x39, 0x0a,
-	0x0c, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a,
-	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65,
-	0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x22, 0xc5, 0x01, 0x0a, 0x0c, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xc7, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x03, 0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x03, 0x65,
+	0x72, 0x72, 0x12, 0x3a, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x22, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x39,
+	0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5a, 0x0a, 0x0a, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
+	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65,
+	0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65,
+	0x79, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72,
+	0x79, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x5d, 0x0a, 0x0d, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79,
+	0x4b, 0x65, 0x79, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0b, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12,
+	0x26, 0x0a, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x82, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
 	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04,
-	0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x62, 0x65, 0x66,
-	0x6f, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x11, 0x64, 0x72, 0x6f, 0x70, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x62,
-	0x75, 0x69, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x63, 0x70,
-	0x75, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x70,
-	0x75, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x21, 0x0a,
-	0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
-	0x22, 0x5f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x68, 0x65, 0x61, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44, 0x6f,
+	0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x04, 0x64, 0x6f, 0x63, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x3e, 0x0a, 0x11,
+	0x46, 0x6f, 0x72, 0x63, 0x65, 0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x22, 0x39, 0x0a, 0x0c,
+	0x46, 0x6c, 0x75, 0x73, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04,
+	0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61,
+	0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x22, 0xc5, 0x01, 0x0a, 0x0c, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68,
+	0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x72, 0x6f, 0x70, 0x5f, 0x62, 0x65, 0x66, 0x6f,
+	0x72, 0x65, 0x5f, 0x72, 0x65, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x64, 0x72, 0x6f, 0x70, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x63, 0x70, 0x75,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x43, 0x70, 0x75,
+	0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22,
+	0x5f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a,
+	0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73,
+	0x22, 0x62, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x24,
+	0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69,
+	0x74, 0x65, 0x6d, 0x73, 0x22, 0x60, 0x0a, 0x0c, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64,
+	0x12, 0x24, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52,
+	0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x70, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x4d,
+	0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04,
+	0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65,
+	0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x72, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x42,
+	0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
 	0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
 	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x24, 0x0a, 0x05, 0x69,
-	0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d,
-	0x73, 0x22, 0x62, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12,
-	0x24, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x05,
-	0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x60, 0x0a, 0x0c, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61,
-	0x64, 0x12, 0x24, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x0e, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x49, 0x74, 0x65, 0x6d,
-	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x70, 0x0a, 0x12, 0x46, 0x6f, 0x72, 0x63, 0x65,
-	0x4d, 0x65, 0x72, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a,
+	0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x44,
+	0x65, 0x6c, 0x4e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x44, 0x65, 0x6c,
+	0x4e, 0x75, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x64, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x64, 0x73, 0x53, 0x74, 0x72, 0x22, 0x6b, 0x0a, 0x0d,
+	0x46, 0x6c, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a,
 	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65,
 	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
 	0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x68, 0x61,
 	0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72,
 	0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x72, 0x0a, 0x13, 0x44, 0x65, 0x6c,
-	0x42, 0x79, 0x51, 0x75, 0x65, 0x72, 0x79, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
-	0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x16, 0x0a, 0x06,
-	0x44, 0x65, 0x6c, 0x4e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x44, 0x65,
-	0x6c, 0x4e, 0x75, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x64, 0x73, 0x5f, 0x73, 0x74, 0x72, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x69, 0x64, 0x73, 0x53, 0x74, 0x72, 0x22, 0x6b, 0x0a,
-	0x0d, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a,
-	0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x68,
-	0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x6b, 0x0a, 0x0d, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68,
-	0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61,
-	0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x53, 0x0a, 0x0a, 0x54, 0x65, 0x72, 0x6d, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x07, 0x69, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0xca, 0x01, 0x0a,
-	0x0b, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x75, 0x70, 0x70, 0x65, 0x72, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
-	0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x4c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x70, 0x70, 0x65, 0x72, 0x12, 0x19,
-	0x0a, 0x08, 0x69, 0x73, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x07, 0x69, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0x35, 0x0a, 0x09, 0x53, 0x6f, 0x72,
-	0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x22, 0xa8, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69,
-	0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d,
-	0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73,
-	0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53,
-	0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
-	0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x22, 0xa8, 0x01, 0x0a, 0x0f,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12,
-	0x4d, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x2e,
-	0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79,
-	0x70, 0x65, 0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x22, 0x2e, 0x0a, 0x12, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c,
-	0x49, 0x6e, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x10, 0x00, 0x12, 0x06,
-	0x0a, 0x02, 0x4c, 0x32, 0x10, 0x01, 0x22, 0x97, 0x05, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x73, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x6b, 0x0a, 0x0d, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65,
+	0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64,
+	0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70,
+	0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
+	0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x53, 0x0a, 0x0a, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x69, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0xca, 0x01, 0x0a, 0x0b,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x75, 0x70, 0x70, 0x65, 0x72, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x4c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x55, 0x70, 0x70, 0x65, 0x72, 0x12, 0x19, 0x0a,
+	0x08, 0x69, 0x73, 0x5f, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x69, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x22, 0xcf, 0x01, 0x0a, 0x0a, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x3a, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x52, 0x0c, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x37, 0x0a, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
+	0x2e, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x74, 0x65, 0x72,
+	0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c,
+	0x64, 0x72, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x22, 0x83, 0x02, 0x0a, 0x0b, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x36, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x09, 0x0a, 0x05, 0x54, 0x45, 0x52, 0x4d, 0x53, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52,
+	0x41, 0x4e, 0x47, 0x45, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x47,
+	0x52, 0x41, 0x4d, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41, 0x54, 0x53, 0x10, 0x03,
+	0x22, 0x48, 0x0a, 0x10, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x5f, 0x0a, 0x11, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xcb, 0x01, 0x0a, 0x11,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70,
+	0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6f, 0x74, 0x68, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x03, 0x73, 0x75, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x56, 0x0a, 0x07, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x42, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x73, 0x22, 0x37, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x22, 0x35, 0x0a, 0x09, 0x53, 0x6f,
+	0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x22, 0xa8, 0x01, 0x0a, 0x0b, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d,
+	0x69, 0x6e, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x6d, 0x69, 0x6e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x54, 0x79, 0x70, 0x65, 0x22, 0xa8, 0x01, 0x0a,
+	0x0f, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x4d, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2c, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
+	0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x2e, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x6e, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x22, 0x2e, 0x0a, 0x12, 0x44, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a,
+	0x0c, 0x49, 0x6e, 0x6e, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x64, 0x75, 0x63, 0x74, 0x10, 0x00, 0x12,
+	0x06, 0x0a, 0x02, 0x4c, 0x32, 0x10, 0x01, 0x22, 0xa1, 0x06, 0x0a, 0x0c, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70,
+	0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68,
+	0x65, 0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x75, 0x6d,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61,
+	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x3a, 0x0a,
+	0x0d, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x74, 0x65, 0x72,
+	0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73,
+	0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x61,
+	0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x6f, 0x61,
+	0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x0e, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x6f, 0x72, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x34, 0x0a, 0x0b, 0x73, 0x6f, 0x72, 0x74,
+	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x52, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74,
+	0x72, 0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x35, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
+	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x54, 0x72, 0x65, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65,
+	0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12,
+	0x39, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
+	0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x6f,
+	0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xec, 0x02, 0x0a, 0x0d,
+	0x53, 0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a,
+	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65,
+	0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x6f, 0x63, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73,
+	0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x3a, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x0c, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x37,
+	0x0a, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x54, 0x65, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x74, 0x65, 0x72, 0x6d,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61,
+	0x74, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x72,
+	0x65, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0a,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x54, 0x72, 0x65, 0x65, 0x22, 0x98, 0x01, 0x0a, 0x0e, 0x53,
+	0x63, 0x72, 0x6f, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a,
+	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x64, 0x6f, 0x63,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x09, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64,
+	0x6f, 0x63, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x6f, 0x63, 0x69,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x6f, 0x6e, 0x65, 0x22, 0xe4, 0x07, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62,
 	0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65,
-	0x61, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69,
-	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x49, 0x64, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x70, 0x61, 0x72,
-	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x12, 0x3a, 0x0a, 0x0d,
-	0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c, 0x72, 0x61, 0x6e, 0x67,
-	0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x74, 0x65, 0x72, 0x6d,
-	0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73, 0x5f,
-	0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f,
-	0x73, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
-	0x53, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x62, 0x61, 0x6c,
-	0x61, 0x6e, 0x63, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6c, 0x6f, 0x61, 0x64,
-	0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x4e, 0x0a, 0x0e, 0x73, 0x6f, 0x72, 0x74, 0x5f,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x28, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x6f, 0x72, 0x74, 0x46,
-	0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x34, 0x0a, 0x0b, 0x73, 0x6f, 0x72, 0x74, 0x5f,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c,
-	0x64, 0x52, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72,
-	0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18,
-	0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x1a,
-	0x3f, 0x0a, 0x11, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x22, 0xf4, 0x05, 0x0a, 0x0d, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x17, 0x0a,
-	0x07, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x72, 0x65, 0x71, 0x4e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73,
-	0x5f, 0x62, 0x72, 0x75, 0x74, 0x65, 0x5f, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0d, 0x69, 0x73, 0x42, 0x72, 0x75, 0x74, 0x65, 0x53, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x12, 0x34, 0x0a, 0x0a, 0x76, 0x65, 0x63, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
-	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70,
-	0x62, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x09, 0x76,
-	0x65, 0x63, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c,
-	0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
-	0x12, 0x3a, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x70, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c,
-	0x72, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x37, 0x0a, 0x0c,
-	0x74, 0x65, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x08, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x54, 0x65,
-	0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x74, 0x65, 0x72, 0x6d, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x70,
-	0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x64,
-	0x65, 0x78, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x75, 0x6c, 0x74,
-	0x69, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x0a, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x52, 0x61, 0x6e, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x32, 0x5f, 0x73, 0x71, 0x72, 0x74, 0x18,
-	0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x32, 0x53, 0x71, 0x72, 0x74, 0x12, 0x26, 0x0a,
-	0x0f, 0x69, 0x73, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e,
-	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x6f, 0x72, 0x74, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x34, 0x0a, 0x0b, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
-	0x52, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x61, 0x6e, 0x6b, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x61,
-	0x6e, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x18, 0x10, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x6f, 0x72, 0x74, 0x46, 0x69,
-	0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x60, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69,
-	0x65, 0x6c, 0x64, 0x73, 0x12, 0x13, 0x0a, 0x05, 0x70, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x4b, 0x65, 0x79, 0x22, 0xc0, 0x02, 0x0a, 0x0c, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x5f, 0x68, 0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x48, 0x69, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
-	0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61,
-	0x78, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f,
-	0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6f,
-	0x6b, 0x12, 0x1e, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6f, 0x6b, 0x49,
-	0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6d, 0x73, 0x67, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x74,
-	0x65, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52,
-	0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03,
-	0x70, 0x49, 0x44, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x49, 0x44, 0x12, 0x18,
-	0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4e,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x22, 0xa6, 0x01, 0x0a,
-	0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x2a, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
-	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x48, 0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x72,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76,
+	0x61, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x5f, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x06, 0x72, 0x65, 0x71, 0x4e, 0x75, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x6f, 0x70, 0x4e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x12,
+	0x26, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x62, 0x72, 0x75, 0x74, 0x65, 0x5f, 0x73, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x69, 0x73, 0x42, 0x72, 0x75, 0x74,
+	0x65, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x34, 0x0a, 0x0a, 0x76, 0x65, 0x63, 0x5f, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x56, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x52, 0x09, 0x76, 0x65, 0x63, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x0c, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x37, 0x0a, 0x0c, 0x74, 0x65, 0x72, 0x6d, 0x5f, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x70, 0x62, 0x2e, 0x54, 0x65, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x74,
+	0x65, 0x72, 0x6d, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x2a, 0x0a,
+	0x11, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f, 0x72, 0x61,
+	0x6e, 0x6b, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x61, 0x6e, 0x6b, 0x12, 0x17, 0x0a, 0x07, 0x6c, 0x32, 0x5f,
+	0x73, 0x71, 0x72, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x32, 0x53, 0x71,
+	0x72, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x69, 0x73, 0x5f, 0x76, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x73, 0x56,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x4f, 0x0a, 0x0e, 0x73, 0x6f,
+	0x72, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x0d, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x6f, 0x72, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73,
+	0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x34, 0x0a, 0x0b, 0x73,
+	0x6f, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x6f, 0x72, 0x74,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0a, 0x73, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x6b, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x6b, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x74, 0x72, 0x61, 0x63, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x0b, 0x66,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x54, 0x72,
+	0x65, 0x65, 0x12, 0x36, 0x0a, 0x0c, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x0b, 0x74,
+	0x65, 0x78, 0x74, 0x51, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66,
+	0x66, 0x73, 0x65, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x12, 0x39, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2c, 0x0a,
+	0x08, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x62, 0x79, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x42, 0x79, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x1a, 0x3f, 0x0a, 0x11, 0x53,
+	0x6f, 0x72, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd5, 0x01, 0x0a,
+	0x0a, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x13, 0x0a, 0x05, 0x70, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x4b, 0x65, 0x79, 0x12,
+	0x38, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x49, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x53, 0x63, 0x6f,
+	0x72, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0xc0, 0x02, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x68,
+	0x69, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x48, 0x69, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53, 0x63, 0x6f, 0x72,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x6b, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6f, 0x6b, 0x12, 0x1e, 0x0a, 0x0b,
+	0x6d, 0x61, 0x78, 0x5f, 0x74, 0x6f, 0x6f, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x54, 0x6f, 0x6f, 0x6b, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x73, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73, 0x67, 0x12, 0x37,
+	0x0a, 0x0c, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x49, 0x44, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x22, 0xe7, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x68, 0x65,
+	0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x65, 0x61, 0x64,
+	0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x30, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x6c, 0x61, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x46, 0x6c, 0x61, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x3f, 0x0a, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70,
+	0x62, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x0c, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x6e, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75, 0x6c, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x73,
+	0x67, 0x22, 0xf3, 0x01, 0x0a, 0x0b, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x28, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x52, 0x02,
+	0x6f, 0x70, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x28, 0x0a,
+	0x02, 0x4f, 0x70, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x53, 0x45, 0x52, 0x54, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x44,
+	0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x02, 0x22, 0xef, 0x01, 0x0a, 0x0e, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x68, 0x65,
+	0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x52,
+	0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x45, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x77, 0x61, 0x69, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x77, 0x61, 0x69, 0x74, 0x4d, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf2, 0x01, 0x0a, 0x0f, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a,
+	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48,
+	0x65, 0x61, 0x64, 0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x2d, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x09, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x1a, 0x3c, 0x0a, 0x0e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x32, 0xf7,
+	0x03, 0x0a, 0x11, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x14, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x06, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e,
+	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x06, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76,
 	0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
-	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x46, 0x6c, 0x61, 0x74, 0x42,
-	0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x46, 0x6c, 0x61, 0x74,
-	0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x0c, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x66,
-	0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61, 0x69,
-	0x6c, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x66, 0x75,
-	0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x66, 0x75, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x73, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6d, 0x73, 0x67, 0x32, 0xf6, 0x02, 0x0a, 0x11, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72,
-	0x47, 0x52, 0x50, 0x43, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x34, 0x0a, 0x03, 0x47,
-	0x65, 0x74, 0x12, 0x14, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x3d, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x76, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x12, 0x3d, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x17, 0x2e, 0x76, 0x65, 0x61,
-	0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x37, 0x0a, 0x04, 0x42, 0x75, 0x6c, 0x6b, 0x12, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
-	0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x05, 0x53, 0x70, 0x61, 0x63,
-	0x65, 0x12, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x1a, 0x0f, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x70, 0x62, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0a, 0x53,
-	0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x12, 0x17, 0x2e, 0x76, 0x65, 0x61, 0x72,
-	0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65,
-	0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x0e,
-	0x48, 0x01, 0x5a, 0x0a, 0x2e, 0x2f, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x04, 0x42, 0x75, 0x6c, 0x6b,
+	0x12, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x70, 0x62, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3b, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x76, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x31,
+	0x0a, 0x05, 0x53, 0x70, 0x61, 0x63, 0x65, 0x12, 0x15, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x70, 0x62, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x48, 0x65, 0x61, 0x64, 0x1a, 0x0f,
+	0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x22,
+	0x00, 0x12, 0x41, 0x0a, 0x0a, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x42, 0x79, 0x49, 0x44, 0x12,
+	0x17, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x70, 0x62, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x07, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12,
+	0x18, 0x2e, 0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x0e, 0x48, 0x01, 0x5a, 0x0a, 0x2e, 0x2f,
+	0x76, 0x65, 0x61, 0x72, 0x63, 0x68, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2310,104 +3733,157 @@ func file_router_grpc_proto_rawDescGZIP() []byte {
 	return file_router_grpc_proto_rawDescData
 }
 
-var file_router_grpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_router_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
+var file_router_grpc_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_router_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_router_grpc_proto_goTypes = []interface{}{
-	(IndexParameters_DistanceMetricType)(0), // 0: vearchpb.IndexParameters.DistanceMetricType
-	(*RequestHead)(nil),                     // 1: vearchpb.RequestHead
-	(*ResponseHead)(nil),                    // 2: vearchpb.ResponseHead
-	(*GetRequest)(nil),                      // 3: vearchpb.GetRequest
-	(*DeleteRequest)(nil),                   // 4: vearchpb.DeleteRequest
-	(*BulkRequest)(nil),                     // 5: vearchpb.BulkRequest
-	(*ForceMergeRequest)(nil),               // 6: vearchpb.ForceMergeRequest
-	(*FlushRequest)(nil),                    // 7: vearchpb.FlushRequest
-	(*IndexRequest)(nil),                    // 8: vearchpb.IndexRequest
-	(*GetResponse)(nil),                     // 9: vearchpb.GetResponse
-	(*DeleteResponse)(nil),                  // 10: vearchpb.DeleteResponse
-	(*BulkResponse)(nil),                    // 11: vearchpb.BulkResponse
-	(*ForceMergeResponse)(nil),              // 12: vearchpb.ForceMergeResponse
-	(*DelByQueryeResponse)(nil),             // 13: vearchpb.DelByQueryeResponse
-	(*FlushResponse)(nil),                   // 14: vearchpb.FlushResponse
-	(*IndexResponse)(nil),                   // 15: vearchpb.IndexResponse
-	(*TermFilter)(nil),                      // 16: vearchpb.TermFilter
-	(*RangeFilter)(nil),                     // 17: vearchpb.RangeFilter
-	(*SortField)(nil),                       // 18: vearchpb.SortField
-	(*VectorQuery)(nil),                     // 19: vearchpb.VectorQuery
-	(*IndexParameters)(nil),                 // 20: vearchpb.IndexParameters
-	(*QueryRequest)(nil),                    // 21: vearchpb.QueryRequest
-	(*SearchRequest)(nil),                   // 22: vearchpb.SearchRequest
-	(*ResultItem)(nil),                      // 23: vearchpb.ResultItem
-	(*SearchResult)(nil),                    // 24: vearchpb.SearchResult
-	(*SearchResponse)(nil),                  // 25: vearchpb.SearchResponse
-	(*SearchStatus)(nil),                    // 26: vearchpb.SearchStatus
-	nil,                                     // 27: vearchpb.RequestHead.ParamsEntry
-	nil,                                     // 28: vearchpb.ResponseHead.ParamsEntry
-	nil,                                     // 29: vearchpb.QueryRequest.SortFieldMapEntry
-	nil,                                     // 30: vearchpb.SearchRequest.SortFieldMapEntry
-	(*Error)(nil),                           // 31: vearchpb.Error
-	(*Document)(nil),                        // 32: vearchpb.Document
-	(*Item)(nil),                            // 33: vearchpb.Item
-	(*Field)(nil),                           // 34: vearchpb.Field
-	(*Table)(nil),                           // 35: vearchpb.Table
+	(Aggregation_Type)(0),                   // 0: vearchpb.Aggregation.Type
+	(IndexParameters_DistanceMetricType)(0), // 1: vearchpb.IndexParameters.DistanceMetricType
+	(ChangeEvent_Op)(0),                     // 2: vearchpb.ChangeEvent.Op
+	(*RequestHead)(nil),                     // 3: vearchpb.RequestHead
+	(*ResponseHead)(nil),                    // 4: vearchpb.ResponseHead
+	(*GetRequest)(nil),                      // 5: vearchpb.GetRequest
+	(*DeleteRequest)(nil),                   // 6: vearchpb.DeleteRequest
+	(*BulkRequest)(nil),                     // 7: vearchpb.BulkRequest
+	(*UpdateRequest)(nil),                   // 8: vearchpb.UpdateRequest
+	(*ForceMergeRequest)(nil),               // 9: vearchpb.ForceMergeRequest
+	(*FlushRequest)(nil),                    // 10: vearchpb.FlushRequest
+	(*IndexRequest)(nil),                    // 11: vearchpb.IndexRequest
+	(*GetResponse)(nil),                     // 12: vearchpb.GetResponse
+	(*DeleteResponse)(nil),                  // 13: vearchpb.DeleteResponse
+	(*BulkResponse)(nil),                    // 14: vearchpb.BulkResponse
+	(*ForceMergeResponse)(nil),              // 15: vearchpb.ForceMergeResponse
+	(*DelByQueryeResponse)(nil),             // 16: vearchpb.DelByQueryeResponse
+	(*FlushResponse)(nil),                   // 17: vearchpb.FlushResponse
+	(*IndexResponse)(nil),                   // 18: vearchpb.IndexResponse
+	(*TermFilter)(nil),                      // 19: vearchpb.TermFilter
+	(*RangeFilter)(nil),                     // 20: vearchpb.RangeFilter
+	(*FilterNode)(nil),                      // 21: vearchpb.FilterNode
+	(*Aggregation)(nil),                     // 22: vearchpb.Aggregation
+	(*AggregationRange)(nil),                // 23: vearchpb.AggregationRange
+	(*AggregationBucket)(nil),               // 24: vearchpb.AggregationBucket
+	(*AggregationResult)(nil),               // 25: vearchpb.AggregationResult
+	(*GroupBy)(nil),                         // 26: vearchpb.GroupBy
+	(*TextQuery)(nil),                       // 27: vearchpb.TextQuery
+	(*SortField)(nil),                       // 28: vearchpb.SortField
+	(*VectorQuery)(nil),                     // 29: vearchpb.VectorQuery
+	(*IndexParameters)(nil),                 // 30: vearchpb.IndexParameters
+	(*QueryRequest)(nil),                    // 31: vearchpb.QueryRequest
+	(*ScrollRequest)(nil),                   // 32: vearchpb.ScrollRequest
+	(*ScrollResponse)(nil),                  // 33: vearchpb.ScrollResponse
+	(*SearchRequest)(nil),                   // 34: vearchpb.SearchRequest
+	(*ResultItem)(nil),                      // 35: vearchpb.ResultItem
+	(*SearchResult)(nil),                    // 36: vearchpb.SearchResult
+	(*SearchResponse)(nil),                  // 37: vearchpb.SearchResponse
+	(*SearchStatus)(nil),                    // 38: vearchpb.SearchStatus
+	(*ChangeEvent)(nil),                     // 39: vearchpb.ChangeEvent
+	(*ChangesRequest)(nil),                  // 40: vearchpb.ChangesRequest
+	(*ChangesResponse)(nil),                 // 41: vearchpb.ChangesResponse
+	nil,                                     // 42: vearchpb.RequestHead.ParamsEntry
+	nil,                                     // 43: vearchpb.RequestHead.ReadIndexEntry
+	nil,                                     // 44: vearchpb.ResponseHead.ParamsEntry
+	nil,                                     // 45: vearchpb.QueryRequest.SortFieldMapEntry
+	nil,                                     // 46: vearchpb.SearchRequest.SortFieldMapEntry
+	nil,                                     // 47: vearchpb.ResultItem.ScoresEntry
+	nil,                                     // 48: vearchpb.ChangesRequest.PositionsEntry
+	nil,                                     // 49: vearchpb.ChangesResponse.PositionsEntry
+	(*Error)(nil),                           // 50: vearchpb.Error
+	(*Document)(nil),                        // 51: vearchpb.Document
+	(*Item)(nil),                            // 52: vearchpb.Item
+	(*Field)(nil),                           // 53: vearchpb.Field
+	(*Table)(nil),                           // 54: vearchpb.Table
 }
 var file_router_grpc_proto_depIdxs = []int32{
-	27, // 0: vearchpb.RequestHead.params:type_name -> vearchpb.RequestHead.ParamsEntry
-	31, // 1: vearchpb.ResponseHead.err:type_name -> vearchpb.Error
-	28, // 2: vearchpb.ResponseHead.params:type_name -> vearchpb.ResponseHead.ParamsEntry
-	1,  // 3: vearchpb.GetRequest.head:type_name -> vearchpb.RequestHead
-	1,  // 4: vearchpb.DeleteRequest.head:type_name -> vearchpb.RequestHead
-	1,  // 5: vearchpb.BulkRequest.head:type_name -> vearchpb.RequestHead
-	32, // 6: vearchpb.BulkRequest.docs:type_name -> vearchpb.Document
-	1,  // 7: vearchpb.ForceMergeRequest.head:type_name -> vearchpb.RequestHead
-	1,  // 8: vearchpb.FlushRequest.head:type_name -> vearchpb.RequestHead
-	1,  // 9: vearchpb.IndexRequest.head:type_name -> vearchpb.RequestHead
-	2,  // 10: vearchpb.GetResponse.head:type_name -> vearchpb.ResponseHead
-	33, // 11: vearchpb.GetResponse.items:type_name -> vearchpb.Item
-	2,  // 12: vearchpb.DeleteResponse.head:type_name -> vearchpb.ResponseHead
-	33, // 13: vearchpb.DeleteResponse.items:type_name -> vearchpb.Item
-	2,  // 14: vearchpb.BulkResponse.head:type_name -> vearchpb.ResponseHead
-	33, // 15: vearchpb.BulkResponse.items:type_name -> vearchpb.Item
-	2,  // 16: vearchpb.ForceMergeResponse.head:type_name -> vearchpb.ResponseHead
-	26, // 17: vearchpb.ForceMergeResponse.shards:type_name -> vearchpb.SearchStatus
-	2,  // 18: vearchpb.DelByQueryeResponse.head:type_name -> vearchpb.ResponseHead
-	2,  // 19: vearchpb.FlushResponse.head:type_name -> vearchpb.ResponseHead
-	26, // 20: vearchpb.FlushResponse.shards:type_name -> vearchpb.SearchStatus
-	2,  // 21: vearchpb.IndexResponse.head:type_name -> vearchpb.ResponseHead
-	26, // 22: vearchpb.IndexResponse.shards:type_name -> vearchpb.SearchStatus
-	0,  // 23: vearchpb.IndexParameters.metric_type:type_name -> vearchpb.IndexParameters.DistanceMetricType
-	1,  // 24: vearchpb.QueryRequest.head:type_name -> vearchpb.RequestHead
-	17, // 25: vearchpb.QueryRequest.range_filters:type_name -> vearchpb.RangeFilter
-	16, // 26: vearchpb.QueryRequest.term_filters:type_name -> vearchpb.TermFilter
-	29, // 27: vearchpb.QueryRequest.sort_field_map:type_name -> vearchpb.QueryRequest.SortFieldMapEntry
-	18, // 28: vearchpb.QueryRequest.sort_fields:type_name -> vearchpb.SortField
-	1,  // 29: vearchpb.SearchRequest.head:type_name -> vearchpb.RequestHead
-	19, // 30: vearchpb.SearchRequest.vec_fields:type_name -> vearchpb.VectorQuery
-	17, // 31: vearchpb.SearchRequest.range_filters:type_name -> vearchpb.RangeFilter
-	16, // 32: vearchpb.SearchRequest.term_filters:type_name -> vearchpb.TermFilter
-	30, // 33: vearchpb.SearchRequest.sort_field_map:type_name -> vearchpb.SearchRequest.SortFieldMapEntry
-	18, // 34: vearchpb.SearchRequest.sort_fields:type_name -> vearchpb.SortField
-	34, // 35: vearchpb.ResultItem.fields:type_name -> vearchpb.Field
-	26, // 36: vearchpb.SearchResult.status:type_name -> vearchpb.SearchStatus
-	23, // 37: vearchpb.SearchResult.result_items:type_name -> vearchpb.ResultItem
-	2,  // 38: vearchpb.SearchResponse.head:type_name -> vearchpb.ResponseHead
-	24, // 39: vearchpb.SearchResponse.results:type_name -> vearchpb.SearchResult
-	3,  // 40: vearchpb.RouterGRPCService.Get:input_type -> vearchpb.GetRequest
-	4,  // 41: vearchpb.RouterGRPCService.Delete:input_type -> vearchpb.DeleteRequest
-	22, // 42: vearchpb.RouterGRPCService.Search:input_type -> vearchpb.SearchRequest
-	5,  // 43: vearchpb.RouterGRPCService.Bulk:input_type -> vearchpb.BulkRequest
-	1,  // 44: vearchpb.RouterGRPCService.Space:input_type -> vearchpb.RequestHead
-	22, // 45: vearchpb.RouterGRPCService.SearchByID:input_type -> vearchpb.SearchRequest
-	9,  // 46: vearchpb.RouterGRPCService.Get:output_type -> vearchpb.GetResponse
-	10, // 47: vearchpb.RouterGRPCService.Delete:output_type -> vearchpb.DeleteResponse
-	25, // 48: vearchpb.RouterGRPCService.Search:output_type -> vearchpb.SearchResponse
-	11, // 49: vearchpb.RouterGRPCService.Bulk:output_type -> vearchpb.BulkResponse
-	35, // 50: vearchpb.RouterGRPCService.Space:output_type -> vearchpb.Table
-	25, // 51: vearchpb.RouterGRPCService.SearchByID:output_type -> vearchpb.SearchResponse
-	46, // [46:52] is the sub-list for method output_type
-	40, // [40:46] is the sub-list for method input_type
-	40, // [40:40] is the sub-list for extension type_name
-	40, // [40:40] is the sub-list for extension extendee
-	0,  // [0:40] is the sub-list for field type_name
+	42, // 0: vearchpb.RequestHead.params:type_name -> vearchpb.RequestHead.ParamsEntry
+	43, // 1: vearchpb.RequestHead.read_index:type_name -> vearchpb.RequestHead.ReadIndexEntry
+	50, // 2: vearchpb.ResponseHead.err:type_name -> vearchpb.Error
+	44, // 3: vearchpb.ResponseHead.params:type_name -> vearchpb.ResponseHead.ParamsEntry
+	3,  // 4: vearchpb.GetRequest.head:type_name -> vearchpb.RequestHead
+	3,  // 5: vearchpb.DeleteRequest.head:type_name -> vearchpb.RequestHead
+	3,  // 6: vearchpb.BulkRequest.head:type_name -> vearchpb.RequestHead
+	51, // 7: vearchpb.BulkRequest.docs:type_name -> vearchpb.Document
+	3,  // 8: vearchpb.UpdateRequest.head:type_name -> vearchpb.RequestHead
+	51, // 9: vearchpb.UpdateRequest.docs:type_name -> vearchpb.Document
+	3,  // 10: vearchpb.ForceMergeRequest.head:type_name -> vearchpb.RequestHead
+	3,  // 11: vearchpb.FlushRequest.head:type_name -> vearchpb.RequestHead
+	3,  // 12: vearchpb.IndexRequest.head:type_name -> vearchpb.RequestHead
+	4,  // 13: vearchpb.GetResponse.head:type_name -> vearchpb.ResponseHead
+	52, // 14: vearchpb.GetResponse.items:type_name -> vearchpb.Item
+	4,  // 15: vearchpb.DeleteResponse.head:type_name -> vearchpb.ResponseHead
+	52, // 16: vearchpb.DeleteResponse.items:type_name -> vearchpb.Item
+	4,  // 17: vearchpb.BulkResponse.head:type_name -> vearchpb.ResponseHead
+	52, // 18: vearchpb.BulkResponse.items:type_name -> vearchpb.Item
+	4,  // 19: vearchpb.ForceMergeResponse.head:type_name -> vearchpb.ResponseHead
+	38, // 20: vearchpb.ForceMergeResponse.shards:type_name -> vearchpb.SearchStatus
+	4,  // 21: vearchpb.DelByQueryeResponse.head:type_name -> vearchpb.ResponseHead
+	4,  // 22: vearchpb.FlushResponse.head:type_name -> vearchpb.ResponseHead
+	38, // 23: vearchpb.FlushResponse.shards:type_name -> vearchpb.SearchStatus
+	4,  // 24: vearchpb.IndexResponse.head:type_name -> vearchpb.ResponseHead
+	38, // 25: vearchpb.IndexResponse.shards:type_name -> vearchpb.SearchStatus
+	20, // 26: vearchpb.FilterNode.range_filters:type_name -> vearchpb.RangeFilter
+	19, // 27: vearchpb.FilterNode.term_filters:type_name -> vearchpb.TermFilter
+	21, // 28: vearchpb.FilterNode.children:type_name -> vearchpb.FilterNode
+	0,  // 29: vearchpb.Aggregation.type:type_name -> vearchpb.Aggregation.Type
+	23, // 30: vearchpb.Aggregation.ranges:type_name -> vearchpb.AggregationRange
+	24, // 31: vearchpb.AggregationResult.buckets:type_name -> vearchpb.AggregationBucket
+	1,  // 32: vearchpb.IndexParameters.metric_type:type_name -> vearchpb.IndexParameters.DistanceMetricType
+	3,  // 33: vearchpb.QueryRequest.head:type_name -> vearchpb.RequestHead
+	20, // 34: vearchpb.QueryRequest.range_filters:type_name -> vearchpb.RangeFilter
+	19, // 35: vearchpb.QueryRequest.term_filters:type_name -> vearchpb.TermFilter
+	45, // 36: vearchpb.QueryRequest.sort_field_map:type_name -> vearchpb.QueryRequest.SortFieldMapEntry
+	28, // 37: vearchpb.QueryRequest.sort_fields:type_name -> vearchpb.SortField
+	21, // 38: vearchpb.QueryRequest.filter_tree:type_name -> vearchpb.FilterNode
+	22, // 39: vearchpb.QueryRequest.aggregations:type_name -> vearchpb.Aggregation
+	3,  // 40: vearchpb.ScrollRequest.head:type_name -> vearchpb.RequestHead
+	20, // 41: vearchpb.ScrollRequest.range_filters:type_name -> vearchpb.RangeFilter
+	19, // 42: vearchpb.ScrollRequest.term_filters:type_name -> vearchpb.TermFilter
+	21, // 43: vearchpb.ScrollRequest.filter_tree:type_name -> vearchpb.FilterNode
+	4,  // 44: vearchpb.ScrollResponse.head:type_name -> vearchpb.ResponseHead
+	51, // 45: vearchpb.ScrollResponse.documents:type_name -> vearchpb.Document
+	3,  // 46: vearchpb.SearchRequest.head:type_name -> vearchpb.RequestHead
+	29, // 47: vearchpb.SearchRequest.vec_fields:type_name -> vearchpb.VectorQuery
+	20, // 48: vearchpb.SearchRequest.range_filters:type_name -> vearchpb.RangeFilter
+	19, // 49: vearchpb.SearchRequest.term_filters:type_name -> vearchpb.TermFilter
+	46, // 50: vearchpb.SearchRequest.sort_field_map:type_name -> vearchpb.SearchRequest.SortFieldMapEntry
+	28, // 51: vearchpb.SearchRequest.sort_fields:type_name -> vearchpb.SortField
+	21, // 52: vearchpb.SearchRequest.filter_tree:type_name -> vearchpb.FilterNode
+	27, // 53: vearchpb.SearchRequest.text_queries:type_name -> vearchpb.TextQuery
+	22, // 54: vearchpb.SearchRequest.aggregations:type_name -> vearchpb.Aggregation
+	26, // 55: vearchpb.SearchRequest.group_by:type_name -> vearchpb.GroupBy
+	53, // 56: vearchpb.ResultItem.fields:type_name -> vearchpb.Field
+	47, // 57: vearchpb.ResultItem.scores:type_name -> vearchpb.ResultItem.ScoresEntry
+	38, // 58: vearchpb.SearchResult.status:type_name -> vearchpb.SearchStatus
+	35, // 59: vearchpb.SearchResult.result_items:type_name -> vearchpb.ResultItem
+	4,  // 60: vearchpb.SearchResponse.head:type_name -> vearchpb.ResponseHead
+	36, // 61: vearchpb.SearchResponse.results:type_name -> vearchpb.SearchResult
+	25, // 62: vearchpb.SearchResponse.aggregations:type_name -> vearchpb.AggregationResult
+	2,  // 63: vearchpb.ChangeEvent.op:type_name -> vearchpb.ChangeEvent.Op
+	53, // 64: vearchpb.ChangeEvent.fields:type_name -> vearchpb.Field
+	3,  // 65: vearchpb.ChangesRequest.head:type_name -> vearchpb.RequestHead
+	48, // 66: vearchpb.ChangesRequest.positions:type_name -> vearchpb.ChangesRequest.PositionsEntry
+	4,  // 67: vearchpb.ChangesResponse.head:type_name -> vearchpb.ResponseHead
+	39, // 68: vearchpb.ChangesResponse.events:type_name -> vearchpb.ChangeEvent
+	49, // 69: vearchpb.ChangesResponse.positions:type_name -> vearchpb.ChangesResponse.PositionsEntry
+	5,  // 70: vearchpb.RouterGRPCService.Get:input_type -> vearchpb.GetRequest
+	6,  // 71: vearchpb.RouterGRPCService.Delete:input_type -> vearchpb.DeleteRequest
+	34, // 72: vearchpb.RouterGRPCService.Search:input_type -> vearchpb.SearchRequest
+	7,  // 73: vearchpb.RouterGRPCService.Bulk:input_type -> vearchpb.BulkRequest
+	8,  // 74: vearchpb.RouterGRPCService.Update:input_type -> vearchpb.UpdateRequest
+	3,  // 75: vearchpb.RouterGRPCService.Space:input_type -> vearchpb.RequestHead
+	34, // 76: vearchpb.RouterGRPCService.SearchByID:input_type -> vearchpb.SearchRequest
+	40, // 77: vearchpb.RouterGRPCService.Changes:input_type -> vearchpb.ChangesRequest
+	12, // 78: vearchpb.RouterGRPCService.Get:output_type -> vearchpb.GetResponse
+	13, // 79: vearchpb.RouterGRPCService.Delete:output_type -> vearchpb.DeleteResponse
+	37, // 80: vearchpb.RouterGRPCService.Search:output_type -> vearchpb.SearchResponse
+	14, // 81: vearchpb.RouterGRPCService.Bulk:output_type -> vearchpb.BulkResponse
+	14, // 82: vearchpb.RouterGRPCService.Update:output_type -> vearchpb.BulkResponse
+	54, // 83: vearchpb.RouterGRPCService.Space:output_type -> vearchpb.Table
+	37, // 84: vearchpb.RouterGRPCService.SearchByID:output_type -> vearchpb.SearchResponse
+	41, // 85: vearchpb.RouterGRPCService.Changes:output_type -> vearchpb.ChangesResponse
+	78, // [78:86] is the sub-list for method output_type
+	70, // [70:78] is the sub-list for method input_type
+	70, // [70:70] is the sub-list for extension type_name
+	70, // [70:70] is the sub-list for extension extendee
+	0,  // [0:70] is the sub-list for field type_name
 }
 
 func init() { file_router_grpc_proto_init() }
@@ -2479,7 +3955,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ForceMergeRequest); i {
+			switch v := v.(*UpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2491,7 +3967,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FlushRequest); i {
+			switch v := v.(*ForceMergeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2503,7 +3979,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexRequest); i {
+			switch v := v.(*FlushRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2515,7 +3991,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResponse); i {
+			switch v := v.(*IndexRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2527,7 +4003,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteResponse); i {
+			switch v := v.(*GetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2539,7 +4015,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BulkResponse); i {
+			switch v := v.(*DeleteResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2551,7 +4027,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ForceMergeResponse); i {
+			switch v := v.(*BulkResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2563,7 +4039,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DelByQueryeResponse); i {
+			switch v := v.(*ForceMergeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2575,7 +4051,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FlushResponse); i {
+			switch v := v.(*DelByQueryeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2587,7 +4063,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexResponse); i {
+			switch v := v.(*FlushResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2599,7 +4075,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TermFilter); i {
+			switch v := v.(*IndexResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2611,7 +4087,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RangeFilter); i {
+			switch v := v.(*TermFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2623,7 +4099,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SortField); i {
+			switch v := v.(*RangeFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2635,7 +4111,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VectorQuery); i {
+			switch v := v.(*FilterNode); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2647,7 +4123,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexParameters); i {
+			switch v := v.(*Aggregation); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2659,7 +4135,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QueryRequest); i {
+			switch v := v.(*AggregationRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2671,7 +4147,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchRequest); i {
+			switch v := v.(*AggregationBucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2683,7 +4159,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResultItem); i {
+			switch v := v.(*AggregationResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2695,7 +4171,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResult); i {
+			switch v := v.(*GroupBy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2707,7 +4183,7 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResponse); i {
+			switch v := v.(*TextQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2719,6 +4195,126 @@ func file_router_grpc_proto_init() {
 			}
 		}
 		file_router_grpc_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VectorQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexParameters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QueryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScrollRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScrollResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResultItem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SearchResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SearchStatus); i {
 			case 0:
 				return &v.state
@@ -2730,14 +4326,50 @@ func file_router_grpc_proto_init() {
 				return nil
 			}
 		}
+		file_router_grpc_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangeEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_router_grpc_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChangesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_router_grpc_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   30,
+			NumEnums:      3,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,391 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.0
+// source: router_grpc.proto
+
+package vearchpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RouterGRPCService_Get_FullMethodName        = "/vearchpb.RouterGRPCService/Get"
+	RouterGRPCService_Delete_FullMethodName     = "/vearchpb.RouterGRPCService/Delete"
+	RouterGRPCService_Search_FullMethodName     = "/vearchpb.RouterGRPCService/Search"
+	RouterGRPCService_Bulk_FullMethodName       = "/vearchpb.RouterGRPCService/Bulk"
+	RouterGRPCService_Update_FullMethodName     = "/vearchpb.RouterGRPCService/Update"
+	RouterGRPCService_Space_FullMethodName      = "/vearchpb.RouterGRPCService/Space"
+	RouterGRPCService_SearchByID_FullMethodName = "/vearchpb.RouterGRPCService/SearchByID"
+	RouterGRPCService_Changes_FullMethodName    = "/vearchpb.RouterGRPCService/Changes"
+)
+
+// RouterGRPCServiceClient is the client API for RouterGRPCService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RouterGRPCServiceClient interface {
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	Bulk(ctx context.Context, in *BulkRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*BulkResponse, error)
+	Space(ctx context.Context, in *RequestHead, opts ...grpc.CallOption) (*Table, error)
+	SearchByID(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	Changes(ctx context.Context, in *ChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangesResponse], error)
+}
+
+type routerGRPCServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRouterGRPCServiceClient(cc grpc.ClientConnInterface) RouterGRPCServiceClient {
+	return &routerGRPCServiceClient{cc}
+}
+
+func (c *routerGRPCServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, RouterGRPCService_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, RouterGRPCService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, RouterGRPCService_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) Bulk(ctx context.Context, in *BulkRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, RouterGRPCService_Bulk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) Update(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*BulkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkResponse)
+	err := c.cc.Invoke(ctx, RouterGRPCService_Update_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) Space(ctx context.Context, in *RequestHead, opts ...grpc.CallOption) (*Table, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Table)
+	err := c.cc.Invoke(ctx, RouterGRPCService_Space_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) SearchByID(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, RouterGRPCService_SearchByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routerGRPCServiceClient) Changes(ctx context.Context, in *ChangesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RouterGRPCService_ServiceDesc.Streams[0], RouterGRPCService_Changes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ChangesRequest, ChangesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RouterGRPCService_ChangesClient = grpc.ServerStreamingClient[ChangesResponse]
+
+// RouterGRPCServiceServer is the server API for RouterGRPCService service.
+// All implementations must embed UnimplementedRouterGRPCServiceServer
+// for forward compatibility.
+type RouterGRPCServiceServer interface {
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	Bulk(context.Context, *BulkRequest) (*BulkResponse, error)
+	Update(context.Context, *UpdateRequest) (*BulkResponse, error)
+	Space(context.Context, *RequestHead) (*Table, error)
+	SearchByID(context.Context, *SearchRequest) (*SearchResponse, error)
+	Changes(*ChangesRequest, grpc.ServerStreamingServer[ChangesResponse]) error
+	mustEmbedUnimplementedRouterGRPCServiceServer()
+}
+
+// UnimplementedRouterGRPCServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRouterGRPCServiceServer struct{}
+
+func (UnimplementedRouterGRPCServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) Bulk(context.Context, *BulkRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Bulk not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) Update(context.Context, *UpdateRequest) (*BulkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) Space(context.Context, *RequestHead) (*Table, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Space not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) SearchByID(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchByID not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) Changes(*ChangesRequest, grpc.ServerStreamingServer[ChangesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Changes not implemented")
+}
+func (UnimplementedRouterGRPCServiceServer) mustEmbedUnimplementedRouterGRPCServiceServer() {}
+func (UnimplementedRouterGRPCServiceServer) testEmbeddedByValue()                           {}
+
+// UnsafeRouterGRPCServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RouterGRPCServiceServer will
+// result in compilation errors.
+type UnsafeRouterGRPCServiceServer interface {
+	mustEmbedUnimplementedRouterGRPCServiceServer()
+}
+
+func RegisterRouterGRPCServiceServer(s grpc.ServiceRegistrar, srv RouterGRPCServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRouterGRPCServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RouterGRPCService_ServiceDesc, srv)
+}
+
+func _RouterGRPCService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_Bulk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).Bulk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_Bulk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).Bulk(ctx, req.(*BulkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_Update_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).Update(ctx, req.(*UpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_Space_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestHead)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).Space(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_Space_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).Space(ctx, req.(*RequestHead))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_SearchByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RouterGRPCServiceServer).SearchByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RouterGRPCService_SearchByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RouterGRPCServiceServer).SearchByID(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RouterGRPCService_Changes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RouterGRPCServiceServer).Changes(m, &grpc.GenericServerStream[ChangesRequest, ChangesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RouterGRPCService_ChangesServer = grpc.ServerStreamingServer[ChangesResponse]
+
+// RouterGRPCService_ServiceDesc is the grpc.ServiceDesc for RouterGRPCService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RouterGRPCService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vearchpb.RouterGRPCService",
+	HandlerType: (*RouterGRPCServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _RouterGRPCService_Get_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _RouterGRPCService_Delete_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _RouterGRPCService_Search_Handler,
+		},
+		{
+			MethodName: "Bulk",
+			Handler:    _RouterGRPCService_Bulk_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _RouterGRPCService_Update_Handler,
+		},
+		{
+			MethodName: "Space",
+			Handler:    _RouterGRPCService_Space_Handler,
+		},
+		{
+			MethodName: "SearchByID",
+			Handler:    _RouterGRPCService_SearchByID_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Changes",
+			Handler:       _RouterGRPCService_Changes_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "router_grpc.proto",
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
 	"github.com/vearch/vearch/v3/internal/ps/engine/mapping"
 )
 
@@ -36,6 +37,13 @@ type Reader interface {
 	Search(ctx context.Context, request *vearchpb.SearchRequest, resp *vearchpb.SearchResponse) error
 
 	Query(ctx context.Context, request *vearchpb.QueryRequest, resp *vearchpb.SearchResponse) error
+
+	// GetDocs gets the documents of the primary keys of docs, the fields of
+	// a document not existing are left nil
+	GetDocs(ctx context.Context, docs []*vearchpb.Document) error
+
+	// BM25 search of a FULLTEXT field, returns at most topN primary keys
+	TextSearch(ctx context.Context, query *vearchpb.TextQuery, topN int) ([]fulltext.Hit, error)
 }
 
 // Writer is the write interface to an engine's data.
@@ -43,8 +51,10 @@ type Writer interface {
 	// use do by single cmd , support create update replace or delete
 	Write(ctx context.Context, docCmd *vearchpb.DocCmd) error
 
-	//this update will merge documents
-	// Update(ctx context.Context, docCmd *vearchpb.DocCmd) error
+	// Update applies the partial updates of docCmd to the existing documents,
+	// the error is the same as Write. It returns each updated document with
+	// the values after the update as a serialized gamma doc, nil if failed.
+	Update(ctx context.Context, docCmd *vearchpb.DocCmd) ([][]byte, error)
 
 	// flush memory to segment, new reader will read the newest data
 	Flush(ctx context.Context, sn int64) error
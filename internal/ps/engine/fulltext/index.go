@@ -0,0 +1,404 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package fulltext is the inverted index of text fields, documents are
+// scored by BM25.
+package fulltext
+
+import (
+	"encoding/gob"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	DefaultK1 = 1.2
+	DefaultB  = 0.75
+)
+
+// Params are the BM25 parameters of a text field.
+type Params struct {
+	K1 float64 `json:"k1,omitempty"`
+	B  float64 `json:"b,omitempty"`
+}
+
+func (p Params) withDefault() Params {
+	if p.K1 <= 0 {
+		p.K1 = DefaultK1
+	}
+	if p.B < 0 || p.B > 1 || p.B == 0 {
+		p.B = DefaultB
+	}
+	return p
+}
+
+// Hit is a document matched by a text query.
+type Hit struct {
+	Key   string
+	Score float64
+}
+
+type fieldIndex struct {
+	Params   Params
+	Postings map[string]map[string]int32 // term -> document key -> term frequency
+	DocTerms map[string][]string         // document key -> distinct terms
+	DocLen   map[string]int32
+	TotalLen int64
+
+	// dirty is set by changes not dumped yet
+	dirty atomic.Bool
+}
+
+func newFieldIndex(params Params) *fieldIndex {
+	fi := &fieldIndex{
+		Params:   params.withDefault(),
+		Postings: make(map[string]map[string]int32),
+		DocTerms: make(map[string][]string),
+		DocLen:   make(map[string]int32),
+	}
+	fi.dirty.Store(true)
+	return fi
+}
+
+func (fi *fieldIndex) remove(key string) {
+	terms, ok := fi.DocTerms[key]
+	if !ok {
+		return
+	}
+	fi.dirty.Store(true)
+	for _, term := range terms {
+		postings := fi.Postings[term]
+		delete(postings, key)
+		if len(postings) == 0 {
+			delete(fi.Postings, term)
+		}
+	}
+	fi.TotalLen -= int64(fi.DocLen[key])
+	delete(fi.DocTerms, key)
+	delete(fi.DocLen, key)
+}
+
+func (fi *fieldIndex) add(key string, text string) {
+	fi.remove(key)
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return
+	}
+	fi.dirty.Store(true)
+	tf := make(map[string]int32)
+	for _, token := range tokens {
+		tf[token]++
+	}
+	terms := make([]string, 0, len(tf))
+	for term, freq := range tf {
+		postings, ok := fi.Postings[term]
+		if !ok {
+			postings = make(map[string]int32)
+			fi.Postings[term] = postings
+		}
+		postings[key] = freq
+		terms = append(terms, term)
+	}
+	fi.DocTerms[key] = terms
+	fi.DocLen[key] = int32(len(tokens))
+	fi.TotalLen += int64(len(tokens))
+}
+
+func (fi *fieldIndex) search(query string) map[string]float64 {
+	scores := make(map[string]float64)
+	n := float64(len(fi.DocLen))
+	if n == 0 {
+		return scores
+	}
+	avgLen := float64(fi.TotalLen) / n
+	k1, b := fi.Params.K1, fi.Params.B
+
+	seen := make(map[string]bool)
+	for _, term := range Tokenize(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		postings := fi.Postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for key, freq := range postings {
+			tf := float64(freq)
+			norm := k1 * (1 - b + b*float64(fi.DocLen[key])/avgLen)
+			scores[key] += idf * tf * (k1 + 1) / (tf + norm)
+		}
+	}
+	return scores
+}
+
+// Index holds the inverted index of every text field of a partition. The
+// whole index is kept in memory, about the size of the postings of all
+// documents. It is dumped as one file per field and only the fields changed
+// since the last dump are rewritten, each in full under the read lock, so
+// writes wait for the encoding of the largest changed field.
+type Index struct {
+	mu     sync.RWMutex
+	fields map[string]*fieldIndex
+	// dumpMu serializes dumps
+	dumpMu sync.Mutex
+}
+
+// New returns an empty index of fields.
+func New(fields map[string]Params) *Index {
+	idx := &Index{fields: make(map[string]*fieldIndex)}
+	idx.SetFields(fields)
+	return idx
+}
+
+// fieldFile is the dump of field in the index dir.
+func fieldFile(dir, field string) string {
+	return filepath.Join(dir, url.PathEscape(field)+fieldFileExt)
+}
+
+const fieldFileExt = ".idx"
+
+// Load reads the index dumped to dir, a missing dir gives an empty index. A
+// single file at dir is the dump of the whole index by older versions.
+func Load(dir string, fields map[string]Params) (*Index, error) {
+	idx := New(fields)
+	info, err := os.Stat(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, err
+	}
+
+	dumped := make(map[string]*fieldIndex)
+	if !info.IsDir() {
+		if err := decodeFile(dir, &dumped); err != nil {
+			return nil, err
+		}
+	} else {
+		for name := range fields {
+			fi := &fieldIndex{}
+			if err := decodeFile(fieldFile(dir, name), fi); err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			dumped[name] = fi
+		}
+	}
+	for name, fi := range dumped {
+		if _, ok := idx.fields[name]; ok {
+			fi.Params = idx.fields[name].Params
+			// fields of an older dump are rewritten to their own files
+			fi.dirty.Store(!info.IsDir())
+			idx.fields[name] = fi
+		}
+	}
+	return idx, nil
+}
+
+func decodeFile(path string, v any) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := gob.NewDecoder(f).Decode(v); err != nil {
+		return fmt.Errorf("decode full text index %s err: %v", path, err)
+	}
+	return nil
+}
+
+// SetFields keeps the index of fields which still exist, adds empty indexes
+// for new fields and drops the others.
+func (idx *Index) SetFields(fields map[string]Params) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for name := range idx.fields {
+		if _, ok := fields[name]; !ok {
+			delete(idx.fields, name)
+		}
+	}
+	for name, params := range fields {
+		if fi, ok := idx.fields[name]; ok {
+			fi.Params = params.withDefault()
+		} else {
+			idx.fields[name] = newFieldIndex(params)
+		}
+	}
+}
+
+// HasField reports whether field is a text field of the index.
+func (idx *Index) HasField(field string) bool {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	_, ok := idx.fields[field]
+	return ok
+}
+
+// Add indexes the texts of document key, texts of other fields are kept.
+func (idx *Index) Add(key string, texts map[string]string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for field, text := range texts {
+		if fi, ok := idx.fields[field]; ok {
+			fi.add(key, text)
+		}
+	}
+}
+
+// Delete removes document key from all fields.
+func (idx *Index) Delete(key string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, fi := range idx.fields {
+		fi.remove(key)
+	}
+}
+
+// Search returns at most topN documents of field matching any term of query,
+// ordered by BM25 score.
+func (idx *Index) Search(field, query string, topN int) ([]Hit, error) {
+	idx.mu.RLock()
+	fi, ok := idx.fields[field]
+	if !ok {
+		idx.mu.RUnlock()
+		return nil, fmt.Errorf("field:[%s] has no full text index", field)
+	}
+	scores := fi.search(query)
+	idx.mu.RUnlock()
+
+	hits := make([]Hit, 0, len(scores))
+	for key, score := range scores {
+		hits = append(hits, Hit{Key: key, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score == hits[j].Score {
+			return hits[i].Key < hits[j].Key
+		}
+		return hits[i].Score > hits[j].Score
+	})
+	if topN > 0 && len(hits) > topN {
+		hits = hits[:topN]
+	}
+	return hits, nil
+}
+
+// Dump writes the fields changed since the last dump to dir, each file is
+// replaced atomically. Files of the fields no longer indexed are removed.
+func (idx *Index) Dump(dir string) error {
+	idx.dumpMu.Lock()
+	defer idx.dumpMu.Unlock()
+
+	// an older dump of the whole index
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		if err := os.Remove(dir); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	idx.mu.RLock()
+	fields := make(map[string]*fieldIndex, len(idx.fields))
+	for name, fi := range idx.fields {
+		fields[name] = fi
+	}
+	idx.mu.RUnlock()
+
+	changed := false
+	for name, fi := range fields {
+		if !fi.dirty.Load() {
+			continue
+		}
+		if err := idx.dumpField(fieldFile(dir, name), fi); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), fieldFileExt)
+		if !ok {
+			continue
+		}
+		if field, err := url.PathUnescape(name); err == nil {
+			if _, ok := fields[field]; ok {
+				continue
+			}
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
+		changed = true
+	}
+	if changed {
+		return syncDir(dir)
+	}
+	return nil
+}
+
+// dumpField writes fi to a temp file and renames it to path.
+func (idx *Index) dumpField(path string, fi *fieldIndex) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	idx.mu.RLock()
+	// cleared before encoding, changes after it are dumped next time
+	fi.dirty.Store(false)
+	err = gob.NewEncoder(f).Encode(fi)
+	idx.mu.RUnlock()
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		fi.dirty.Store(true)
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,173 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package fulltext
+
+import (
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("Hello, World! vearch-3.5 向量检索")
+	expect := []string{"hello", "world", "vearch", "3", "5", "向", "量", "检", "索"}
+	if !reflect.DeepEqual(got, expect) {
+		t.Fatalf("tokenize got %v, expect %v", got, expect)
+	}
+	if len(Tokenize(" ,. ")) != 0 {
+		t.Fatal("tokenize of punctuation should be empty")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	idx := New(map[string]Params{"content": {}})
+	idx.Add("1", map[string]string{"content": "the quick brown fox"})
+	idx.Add("2", map[string]string{"content": "the lazy dog"})
+	idx.Add("3", map[string]string{"content": "quick quick fox jumps over the lazy dog again and again"})
+
+	hits, err := idx.Search("content", "quick fox", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 2 || hits[0].Key != "1" || hits[1].Key != "3" {
+		t.Fatalf("unexpected hits %v", hits)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Fatalf("shorter document should score higher, got %v", hits)
+	}
+
+	hits, _ = idx.Search("content", "quick fox", 1)
+	if len(hits) != 1 {
+		t.Fatalf("topN not applied, got %v", hits)
+	}
+
+	idx.Delete("1")
+	hits, _ = idx.Search("content", "brown", 10)
+	if len(hits) != 0 {
+		t.Fatalf("deleted document matched, got %v", hits)
+	}
+
+	idx.Add("3", map[string]string{"content": "a cat"})
+	hits, _ = idx.Search("content", "dog", 10)
+	if len(hits) != 1 || hits[0].Key != "2" {
+		t.Fatalf("updated document matched old text, got %v", hits)
+	}
+
+	if _, err := idx.Search("title", "dog", 10); err == nil {
+		t.Fatal("search of unknown field should fail")
+	}
+}
+
+func TestDumpLoad(t *testing.T) {
+	fields := map[string]Params{"content": {K1: 1.5, B: 0.5}}
+	idx := New(fields)
+	idx.Add("1", map[string]string{"content": "vector database"})
+	idx.Add("2", map[string]string{"content": "full text search"})
+
+	path := filepath.Join(t.TempDir(), "fulltext")
+	if err := idx.Dump(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect, _ := idx.Search("content", "text database", 10)
+	got, _ := loaded.Search("content", "text database", 10)
+	if !reflect.DeepEqual(expect, got) {
+		t.Fatalf("loaded index got %v, expect %v", got, expect)
+	}
+
+	empty, err := Load(filepath.Join(t.TempDir(), "missing.idx"), fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !empty.HasField("content") {
+		t.Fatal("missing dump should give an empty index")
+	}
+}
+
+func TestDumpChanged(t *testing.T) {
+	fields := map[string]Params{"title": {}, "content": {}}
+	idx := New(fields)
+	idx.Add("1", map[string]string{"title": "vector", "content": "vector database"})
+
+	dir := filepath.Join(t.TempDir(), "fulltext")
+	if err := idx.Dump(dir); err != nil {
+		t.Fatal(err)
+	}
+	for name := range fields {
+		if _, err := os.Stat(fieldFile(dir, name)); err != nil {
+			t.Fatalf("field %s not dumped, err %v", name, err)
+		}
+	}
+
+	// only the changed field is written again
+	os.Remove(fieldFile(dir, "title"))
+	os.Remove(fieldFile(dir, "content"))
+	idx.Add("2", map[string]string{"content": "full text search"})
+	if err := idx.Dump(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fieldFile(dir, "title")); !os.IsNotExist(err) {
+		t.Fatalf("unchanged field dumped again, err %v", err)
+	}
+	if _, err := os.Stat(fieldFile(dir, "content")); err != nil {
+		t.Fatalf("changed field not dumped, err %v", err)
+	}
+
+	// files of dropped fields are removed
+	idx.SetFields(map[string]Params{"content": {}})
+	os.WriteFile(fieldFile(dir, "title"), nil, 0644)
+	if err := idx.Dump(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fieldFile(dir, "title")); !os.IsNotExist(err) {
+		t.Fatalf("dump of dropped field not removed, err %v", err)
+	}
+}
+
+func TestLoadWholeIndexDump(t *testing.T) {
+	fields := map[string]Params{"content": {}}
+	idx := New(fields)
+	idx.Add("1", map[string]string{"content": "vector database"})
+
+	// older versions dump the whole index to one file
+	path := filepath.Join(t.TempDir(), "fulltext")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gob.NewEncoder(f).Encode(idx.fields); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	loaded, err := Load(path, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits, _ := loaded.Search("content", "vector", 10); len(hits) != 1 || hits[0].Key != "1" {
+		t.Fatalf("whole index dump not loaded, got %v", hits)
+	}
+	if err := loaded.Dump(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fieldFile(path, "content")); err != nil {
+		t.Fatalf("whole index dump not rewritten by field, err %v", err)
+	}
+}
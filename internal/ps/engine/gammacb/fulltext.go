@@ -0,0 +1,101 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package gammacb
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
+)
+
+// fullTextFile is the dir of the full text index dump in the engine path
+const fullTextFile = "fulltext"
+
+// fullTextFields returns the BM25 params of the FULLTEXT fields of space.
+func fullTextFields(space *entity.Space) (map[string]fulltext.Params, error) {
+	properties, err := entity.UnmarshalPropertyJSON(space.Fields)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]fulltext.Params)
+	for name, pro := range properties {
+		if !pro.IsFullText() {
+			continue
+		}
+		params := fulltext.Params{}
+		if len(pro.Index.Params) > 0 {
+			if err := json.Unmarshal(pro.Index.Params, &params); err != nil {
+				return nil, fmt.Errorf("field:[%s] full text params:[%s] unmarshal err:[%v]", name, pro.Index.Params, err)
+			}
+		}
+		fields[name] = params
+	}
+	return fields, nil
+}
+
+func loadFullText(path string, space *entity.Space) (*fulltext.Index, error) {
+	fields, err := fullTextFields(space)
+	if err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	index, err := fulltext.Load(filepath.Join(path, fullTextFile), fields)
+	if err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err)
+	}
+	return index, nil
+}
+
+// indexFullText adds the text fields of the documents written successfully,
+// codes are the results of gamma.AddOrUpdateDocs.
+func (ge *gammaEngine) indexFullText(docs [][]byte, codes []int32) {
+	if ge.fullText == nil {
+		return
+	}
+	for i, buffer := range docs {
+		if i >= len(codes) || codes[i] != 0 {
+			continue
+		}
+		doc := &gamma.Doc{}
+		doc.DeSerialize(buffer)
+
+		key := ""
+		texts := make(map[string]string)
+		for _, field := range doc.Fields {
+			if field.Name == entity.IdField {
+				key = string(field.Value)
+			} else if field.Type == vearchpb.FieldType_STRING && ge.fullText.HasField(field.Name) {
+				texts[field.Name] = string(field.Value)
+			}
+		}
+		if key != "" && len(texts) > 0 {
+			ge.fullText.Add(key, texts)
+		}
+	}
+}
+
+func (ge *gammaEngine) dumpFullText(dir string) error {
+	if ge.fullText == nil {
+		return nil
+	}
+	if err := ge.fullText.Dump(filepath.Join(dir, fullTextFile)); err != nil {
+		return fmt.Errorf("dump full text index err:[%v]", err)
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"github.com/vearch/vearch/v3/internal/ps/engine"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
 	"github.com/vearch/vearch/v3/internal/ps/engine/mapping"
 )
 
@@ -126,6 +127,12 @@ func New(cfg EngineConfig) (engine.Engine, error) {
 		}
 	}
 
+	if ge.fullText, err = loadFullText(cfg.Path, cfg.Space); err != nil {
+		log.Error("load full text index err [%v]", err)
+		ge.Close()
+		return nil, err
+	}
+
 	return ge, nil
 }
 
@@ -141,6 +148,9 @@ type gammaEngine struct {
 	reader *readerImpl
 	writer *writerImpl
 
+	// fullText is the BM25 index of the FULLTEXT fields
+	fullText *fulltext.Index
+
 	counter   *atomic.AtomicInt64
 	lock      sync.RWMutex
 	hasClosed bool
@@ -301,6 +311,12 @@ func (ge *gammaEngine) UpdateMapping(updatedSpace *entity.Space) error {
 		log.Info("field index changes completed for space:[%s], partition:[%d]", ge.space.Name, ge.partitionID)
 	}
 
+	textFields, err := fullTextFields(updatedSpace)
+	if err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	ge.fullText.SetFields(textFields)
+
 	// Update the space after all operations are successful
 	ge.space = updatedSpace
 
@@ -441,7 +457,14 @@ func (ge *gammaEngine) Load() error {
 		ge.Close()
 		return vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, fmt.Errorf("load data err code:[%d]", code))
 	}
+	fullText, err := loadFullText(ge.path, ge.space)
+	if err != nil {
+		log.Error("load full text index err [%v]", err)
+		ge.Close()
+		return err
+	}
 	ge.gamma = engineInstance
+	ge.fullText = fullText
 	ge.hasClosed = false
 	return nil
 }
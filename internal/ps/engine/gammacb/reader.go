@@ -27,6 +27,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"github.com/vearch/vearch/v3/internal/ps/engine"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -75,6 +76,22 @@ func (ri *readerImpl) GetDoc(ctx context.Context, doc *vearchpb.Document, getByD
 	return nil
 }
 
+func (ri *readerImpl) GetDocs(ctx context.Context, docs []*vearchpb.Document) error {
+	ri.engine.counter.Incr()
+	defer ri.engine.counter.Decr()
+
+	if ri.engine.gamma == nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_IS_CLOSED, nil)
+	}
+	for _, doc := range docs {
+		docGamma := new(gamma.Doc)
+		if gamma.GetDocByID(ri.engine.gamma, []byte(doc.PKey), docGamma) == 0 {
+			doc.Fields = docGamma.Fields
+		}
+	}
+	return nil
+}
+
 func (ri *readerImpl) ReadSN(ctx context.Context) (int64, error) {
 	ri.engine.lock.RLock()
 	defer ri.engine.lock.RUnlock()
@@ -206,3 +223,18 @@ func (ri *readerImpl) Query(ctx context.Context, request *vearchpb.QueryRequest,
 
 	return nil
 }
+
+func (ri *readerImpl) TextSearch(ctx context.Context, query *vearchpb.TextQuery, topN int) ([]fulltext.Hit, error) {
+	ri.engine.counter.Incr()
+	defer ri.engine.counter.Decr()
+
+	if ri.engine.gamma == nil || ri.engine.fullText == nil {
+		return nil, vearchpb.NewErrorInfo(vearchpb.ErrorEnum_PARTITION_IS_CLOSED, "search engine is null")
+	}
+
+	hits, err := ri.engine.fullText.Search(query.Field, query.Query, topN)
+	if err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	return hits, nil
+}
@@ -118,6 +118,11 @@ func (ge *gammaEngine) NewSnapshot() (proto.Snapshot, error) {
 		return nil, err
 	}
 
+	if err := ge.dumpFullText(backupPath); err != nil {
+		log.Error("create snapshot error:[%v]", err)
+		return nil, err
+	}
+
 	baseSNFile := filepath.Join(ge.path, indexSn)
 	baseSNValue := []byte("0")
 	if b, err := os.ReadFile(baseSNFile); err == nil {
@@ -0,0 +1,100 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package gammacb
+
+import (
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"github.com/vearch/vearch/v3/internal/ps/engine/mapping"
+	"google.golang.org/protobuf/proto"
+)
+
+// updateDocs applies the partial updates of docs, each one a marshaled
+// vearchpb.Document, to the existing documents. It returns the error code
+// of each document and the serialized gamma doc written for it, which has
+// the values of the updated fields after the update.
+func (ge *gammaEngine) updateDocs(docs [][]byte) ([]vearchpb.ErrorEnum, [][]byte) {
+	codes := make([]vearchpb.ErrorEnum, len(docs))
+	applied := make([][]byte, len(docs))
+	writes := make([][]byte, 0, len(docs))
+	written := make([]int, 0, len(docs))
+	// fields updated by earlier documents of the same batch
+	pending := make(map[string]map[string]*vearchpb.Field)
+
+	for i, data := range docs {
+		doc := &vearchpb.Document{}
+		if err := proto.Unmarshal(data, doc); err != nil {
+			log.Error("unmarshal update doc err: %v", err)
+			codes[i] = vearchpb.ErrorEnum_PARAM_ERROR
+			continue
+		}
+
+		current, ok := pending[doc.PKey]
+		if !ok {
+			old := &gamma.Doc{}
+			if gamma.GetDocByID(ge.gamma, []byte(doc.PKey), old) != 0 {
+				codes[i] = vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST
+				continue
+			}
+			current = make(map[string]*vearchpb.Field, len(old.Fields))
+			for _, field := range old.Fields {
+				current[field.Name] = field
+			}
+		}
+
+		fields := make([]*vearchpb.Field, 0, len(doc.Fields))
+		updated := make(map[string]*vearchpb.Field, len(doc.Fields))
+		for _, field := range doc.Fields {
+			if field.Name == entity.IdField {
+				fields = append(fields, field)
+				continue
+			}
+			newField, err := mapping.ApplyUpdate(current[field.Name], field)
+			if err != nil {
+				log.Error("update doc:[%s] err: %v", doc.PKey, err)
+				codes[i] = vearchpb.ErrorEnum_PARAM_ERROR
+				break
+			}
+			fields = append(fields, newField)
+			updated[newField.Name] = newField
+		}
+		if codes[i] != vearchpb.ErrorEnum_SUCCESS {
+			continue
+		}
+
+		for name, field := range updated {
+			current[name] = field
+		}
+		pending[doc.PKey] = current
+		applied[i] = (&gamma.Doc{Fields: fields}).Serialize()
+		writes = append(writes, applied[i])
+		written = append(written, i)
+	}
+
+	if len(writes) > 0 {
+		resp := gamma.AddOrUpdateDocs(ge.gamma, writes)
+		ge.indexFullText(writes, resp)
+		for j, code := range resp {
+			if code != 0 {
+				log.Error("update doc err code:[%d]", code)
+				codes[written[j]] = vearchpb.ErrorEnum_INTERNAL_ERROR
+				applied[written[j]] = nil
+			}
+		}
+	}
+	return codes, applied
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"github.com/vearch/vearch/v3/internal/ps/engine"
+	"google.golang.org/protobuf/proto"
 )
 
 var _ engine.Writer = &writerImpl{}
@@ -60,12 +61,18 @@ func (wi *writerImpl) Write(ctx context.Context, doc *vearchpb.DocCmd) (err erro
 	switch doc.Type {
 	case vearchpb.OpType_BULK:
 		resp := gamma.AddOrUpdateDocs(gammaEngine, doc.Docs)
+		wi.engine.indexFullText(doc.Docs, resp)
 		var buffer bytes.Buffer
 		for _, code := range resp {
 			buffer.WriteString(strconv.Itoa(int(code)) + ",")
 		}
 		err := errors.New(buffer.String())
 		return vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, err)
+	case vearchpb.OpType_UPDATE:
+		_, err := wi.update(doc)
+		return err
+	case vearchpb.OpType_DELETE_EXPIRED:
+		return wi.deleteExpired(doc.Docs)
 	case vearchpb.OpType_DELETE:
 		if resp := gamma.DeleteDoc(gammaEngine, doc.Doc); resp != 0 {
 			if resp == -1 {
@@ -74,6 +81,9 @@ func (wi *writerImpl) Write(ctx context.Context, doc *vearchpb.DocCmd) (err erro
 			err = fmt.Errorf("gamma delete doc err code:[%d]", int(resp))
 			return vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err)
 		}
+		if wi.engine.fullText != nil {
+			wi.engine.fullText.Delete(string(doc.Doc))
+		}
 	default:
 		msg := fmt.Sprintf("type: [%v] not found", doc.Type)
 		err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, errors.New(msg))
@@ -81,6 +91,83 @@ func (wi *writerImpl) Write(ctx context.Context, doc *vearchpb.DocCmd) (err erro
 	return
 }
 
+// deleteExpired deletes the expired documents of docs, each one a marshaled
+// vearchpb.Document of the key and the expire field read by the expiry job.
+// A document whose expire field has changed since is kept and counted as not
+// existing. The codes of the documents are returned in the message of a
+// SUCCESS error.
+func (wi *writerImpl) deleteExpired(docs [][]byte) error {
+	var buffer bytes.Buffer
+	for _, data := range docs {
+		buffer.WriteString(strconv.Itoa(int(wi.deleteIfUnchanged(data))) + ",")
+	}
+	return vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New(buffer.String()))
+}
+
+func (wi *writerImpl) deleteIfUnchanged(data []byte) vearchpb.ErrorEnum {
+	expired := &vearchpb.Document{}
+	if err := proto.Unmarshal(data, expired); err != nil || len(expired.Fields) == 0 {
+		log.Error("unmarshal expired doc err: %v", err)
+		return vearchpb.ErrorEnum_PARAM_ERROR
+	}
+	key := []byte(expired.PKey)
+
+	stored := &gamma.Doc{}
+	if gamma.GetDocByID(wi.engine.gamma, key, stored) != 0 {
+		return vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST
+	}
+	observed := expired.Fields[0]
+	for _, field := range stored.Fields {
+		if field.Name == observed.Name && !bytes.Equal(field.Value, observed.Value) {
+			// written again after the expiry job read it
+			return vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST
+		}
+	}
+
+	switch resp := gamma.DeleteDoc(wi.engine.gamma, key); resp {
+	case 0:
+		if wi.engine.fullText != nil {
+			wi.engine.fullText.Delete(expired.PKey)
+		}
+		return vearchpb.ErrorEnum_SUCCESS
+	case -1:
+		return vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST
+	default:
+		log.Error("gamma delete doc:[%s] err code:[%d]", expired.PKey, int(resp))
+		return vearchpb.ErrorEnum_INTERNAL_ERROR
+	}
+}
+
+func (wi *writerImpl) Update(ctx context.Context, doc *vearchpb.DocCmd) (updated [][]byte, err error) {
+	if doc == nil {
+		return nil, errors.New("doc is nil")
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = vearchpb.NewError(vearchpb.ErrorEnum_RECOVER, fmt.Errorf("%v", r))
+		}
+	}()
+	wi.engine.counter.Incr()
+	defer wi.engine.counter.Decr()
+
+	if wi.engine.gamma == nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_IS_CLOSED, nil)
+	}
+	return wi.update(doc)
+}
+
+// update applies the partial updates of doc, the codes of the documents
+// are returned in the message of a SUCCESS error.
+func (wi *writerImpl) update(doc *vearchpb.DocCmd) ([][]byte, error) {
+	codes, updated := wi.engine.updateDocs(doc.Docs)
+	var buffer bytes.Buffer
+	for _, code := range codes {
+		buffer.WriteString(strconv.Itoa(int(code)) + ",")
+	}
+	return updated, vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New(buffer.String()))
+}
+
 func (wi *writerImpl) Flush(ctx context.Context, sn int64) error {
 	wi.engine.counter.Incr()
 	defer wi.engine.counter.Decr()
@@ -97,6 +184,9 @@ func (wi *writerImpl) Flush(ctx context.Context, sn int64) error {
 	if code := gamma.Dump(gammaEngine); code != 0 {
 		return fmt.Errorf("dump index err response code :[%d]", code)
 	}
+	if err := wi.engine.dumpFullText(wi.engine.path); err != nil {
+		return err
+	}
 
 	fileName := filepath.Join(wi.engine.path, indexSn)
 	err := fileutil.WriteFileAtomic(fileName, []byte(string(strconv.FormatInt(sn, 10))), os.ModePerm)
@@ -143,6 +233,9 @@ func (wi *writerImpl) Commit(ctx context.Context, snx int64) (chan error, error)
 		if code := gamma.Dump(gammaEngine); code != 0 {
 			log.Error("dump index err response code :[%d]", code)
 			fc <- fmt.Errorf("dump index err response code :[%d]", code)
+		} else if err := wi.engine.dumpFullText(wi.engine.path); err != nil {
+			log.Error("%v", err)
+			fc <- err
 		} else {
 			fileName := filepath.Join(wi.engine.path, indexSn)
 			err := fileutil.WriteFileAtomic(fileName, []byte(string(strconv.FormatInt(sn, 10))), os.ModePerm)
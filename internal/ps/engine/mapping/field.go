@@ -88,7 +88,7 @@ func (f *FieldMapping) UnmarshalJSON(data []byte) error {
 	}
 
 	//set index
-	if tmp.Index != nil {
+	if tmp.Index != nil && tmp.Index.Type != entity.FullTextIndexType {
 		fieldMapping.Base().Option |= vearchpb.FieldOption_Index
 	}
 
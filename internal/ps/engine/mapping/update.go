@@ -0,0 +1,142 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mapping
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// stringArraySeparator separates the values of a string array field
+const stringArraySeparator = "\001"
+
+// ApplyUpdate returns the field written by a partial update of old, old is
+// nil if the document has no value of the field. APPEND adds the values not
+// in the array yet and REMOVE drops every occurrence of the values.
+func ApplyUpdate(old, update *vearchpb.Field) (*vearchpb.Field, error) {
+	if old != nil && old.Type != update.Type {
+		return nil, fmt.Errorf("field:[%s] type:[%s] not match update type:[%s]", update.Name, old.Type, update.Type)
+	}
+	field := &vearchpb.Field{Name: update.Name, Type: update.Type, Option: update.Option}
+	switch update.UpdateOp {
+	case vearchpb.UpdateOp_SET:
+		field.Value = update.Value
+	case vearchpb.UpdateOp_INCREMENT:
+		var oldValue []byte
+		if old != nil {
+			oldValue = old.Value
+		}
+		value, err := increment(update.Type, oldValue, update.Value)
+		if err != nil {
+			return nil, fmt.Errorf("field:[%s] %v", update.Name, err)
+		}
+		field.Value = value
+	case vearchpb.UpdateOp_APPEND, vearchpb.UpdateOp_REMOVE:
+		if update.Type != vearchpb.FieldType_STRINGARRAY {
+			return nil, fmt.Errorf("field:[%s] type:[%s] can not %s, only stringArray can", update.Name, update.Type, update.UpdateOp)
+		}
+		var values [][]byte
+		if old != nil {
+			values = splitStringArray(old.Value)
+		}
+		if update.UpdateOp == vearchpb.UpdateOp_APPEND {
+			values = appendStringArray(values, splitStringArray(update.Value))
+		} else {
+			values = removeStringArray(values, splitStringArray(update.Value))
+		}
+		field.Value = bytes.Join(values, []byte(stringArraySeparator))
+	default:
+		return nil, fmt.Errorf("field:[%s] unknown update op:[%d]", update.Name, update.UpdateOp)
+	}
+	return field, nil
+}
+
+func increment(fieldType vearchpb.FieldType, value, delta []byte) ([]byte, error) {
+	size := 0
+	switch fieldType {
+	case vearchpb.FieldType_INT, vearchpb.FieldType_FLOAT:
+		size = 4
+	case vearchpb.FieldType_LONG, vearchpb.FieldType_DOUBLE:
+		size = 8
+	default:
+		return nil, fmt.Errorf("type:[%s] can not increment, only numeric type can", fieldType)
+	}
+	if len(delta) < size {
+		return nil, fmt.Errorf("increment value length:[%d] invalid", len(delta))
+	}
+	if len(value) < size {
+		// no value yet, starts from zero
+		value = make([]byte, size)
+	}
+
+	result := make([]byte, size)
+	switch fieldType {
+	case vearchpb.FieldType_INT:
+		binary.LittleEndian.PutUint32(result, binary.LittleEndian.Uint32(value)+binary.LittleEndian.Uint32(delta))
+	case vearchpb.FieldType_LONG:
+		binary.LittleEndian.PutUint64(result, binary.LittleEndian.Uint64(value)+binary.LittleEndian.Uint64(delta))
+	case vearchpb.FieldType_FLOAT:
+		sum := math.Float32frombits(binary.LittleEndian.Uint32(value)) + math.Float32frombits(binary.LittleEndian.Uint32(delta))
+		binary.LittleEndian.PutUint32(result, math.Float32bits(sum))
+	case vearchpb.FieldType_DOUBLE:
+		sum := math.Float64frombits(binary.LittleEndian.Uint64(value)) + math.Float64frombits(binary.LittleEndian.Uint64(delta))
+		binary.LittleEndian.PutUint64(result, math.Float64bits(sum))
+	}
+	return result, nil
+}
+
+func splitStringArray(value []byte) [][]byte {
+	if len(value) == 0 {
+		return nil
+	}
+	return bytes.Split(value, []byte(stringArraySeparator))
+}
+
+func appendStringArray(values, added [][]byte) [][]byte {
+	for _, value := range added {
+		exist := false
+		for _, v := range values {
+			if bytes.Equal(v, value) {
+				exist = true
+				break
+			}
+		}
+		if !exist {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func removeStringArray(values, removed [][]byte) [][]byte {
+	kept := values[:0]
+	for _, v := range values {
+		drop := false
+		for _, value := range removed {
+			if bytes.Equal(v, value) {
+				drop = true
+				break
+			}
+		}
+		if !drop {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
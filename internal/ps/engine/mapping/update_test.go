@@ -0,0 +1,81 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package mapping
+
+import (
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+func TestApplyUpdateIncrement(t *testing.T) {
+	old := &vearchpb.Field{Name: "stock", Type: vearchpb.FieldType_INT, Value: cbbytes.Int32ToByte(10)}
+	update := &vearchpb.Field{Name: "stock", Type: vearchpb.FieldType_INT, Value: cbbytes.Int32ToByte(-3), UpdateOp: vearchpb.UpdateOp_INCREMENT}
+	field, err := ApplyUpdate(old, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cbbytes.Bytes2Int32(field.Value); got != 7 {
+		t.Fatalf("increment got %d, expect 7", got)
+	}
+
+	old = &vearchpb.Field{Name: "price", Type: vearchpb.FieldType_DOUBLE, Value: cbbytes.Float64ToByteNew(1.5)}
+	update = &vearchpb.Field{Name: "price", Type: vearchpb.FieldType_DOUBLE, Value: cbbytes.Float64ToByteNew(2.25), UpdateOp: vearchpb.UpdateOp_INCREMENT}
+	field, err = ApplyUpdate(old, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cbbytes.ByteToFloat64New(field.Value); got != 3.75 {
+		t.Fatalf("increment got %v, expect 3.75", got)
+	}
+
+	update = &vearchpb.Field{Name: "name", Type: vearchpb.FieldType_STRING, Value: []byte("a"), UpdateOp: vearchpb.UpdateOp_INCREMENT}
+	if _, err := ApplyUpdate(nil, update); err == nil {
+		t.Fatal("increment of string should fail")
+	}
+}
+
+func TestApplyUpdateStringArray(t *testing.T) {
+	old := &vearchpb.Field{Name: "tags", Type: vearchpb.FieldType_STRINGARRAY, Value: []byte("a\001b\001c")}
+	update := &vearchpb.Field{Name: "tags", Type: vearchpb.FieldType_STRINGARRAY, Value: []byte("c\001d"), UpdateOp: vearchpb.UpdateOp_APPEND}
+	field, err := ApplyUpdate(old, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(field.Value) != "a\001b\001c\001d" {
+		t.Fatalf("append got %q", field.Value)
+	}
+
+	update = &vearchpb.Field{Name: "tags", Type: vearchpb.FieldType_STRINGARRAY, Value: []byte("a\001c"), UpdateOp: vearchpb.UpdateOp_REMOVE}
+	field, err = ApplyUpdate(field, update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(field.Value) != "b\001d" {
+		t.Fatalf("remove got %q", field.Value)
+	}
+
+	update = &vearchpb.Field{Name: "tags", Type: vearchpb.FieldType_STRINGARRAY, Value: []byte("x"), UpdateOp: vearchpb.UpdateOp_APPEND}
+	field, err = ApplyUpdate(nil, update)
+	if err != nil || string(field.Value) != "x" {
+		t.Fatalf("append to empty array got %q, err %v", field.Value, err)
+	}
+
+	old = &vearchpb.Field{Name: "tags", Type: vearchpb.FieldType_STRING, Value: []byte("a")}
+	if _, err := ApplyUpdate(old, update); err == nil {
+		t.Fatal("update of mismatched type should fail")
+	}
+}
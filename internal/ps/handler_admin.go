@@ -28,12 +28,12 @@ import (
 	"github.com/cubefs/cubefs/depends/tiglabs/raft"
 	"github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
 	"github.com/klauspost/compress/zstd"
-	"github.com/minio/minio-go/v7"
-	"github.com/minio/minio-go/v7/pkg/credentials"
 
 	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/backupstore"
+	"github.com/vearch/vearch/v3/internal/pkg/fileutil"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/pkg/metrics/mserver"
 	vearch_os "github.com/vearch/vearch/v3/internal/pkg/runtime/os"
@@ -252,6 +252,13 @@ func (pih *PartitionInfoHandler) buildPartitionInfo(store PartitionStore, opType
 		MaxDocid:     int(status.MaxDocid),
 	}
 
+	value.BackupProgress = pih.server.getBackupProgress(store.GetPartition().Id)
+	value.WriteBatch = store.WriteBatchStats()
+	value.Expiry = store.ExpiryStats()
+	if size, err := fileutil.DirSize(store.GetPartition().Path); err == nil {
+		value.Size = size
+	}
+
 	if opType == vearchpb.OpType_GET {
 		value.Path = store.GetPartition().Path
 		value.RaftStatus = store.Status()
@@ -445,76 +452,67 @@ type BackupHandler struct {
 	server *Server
 }
 
-func (bh *BackupHandler) syncBackupFiles(ctx context.Context, minioClient *minio.Client, bucketName, backupPath, s3Path string) error {
+// uploadBackupFiles uploads the files of dir to sub of the partition dir
+// of a backup. The files not changed since the base backup are kept there,
+// except the ones matched by forceUploadPattern.
+func (bh *BackupHandler) uploadBackupFiles(ctx context.Context, store backupstore.Store, backupID int, dir, partPath, sub string, base *entity.BackupFiles) ([]*entity.BackupFile, error) {
 	forceUploadRegex, err := regexp.Compile(forceUploadPattern)
 	if err != nil {
-		return fmt.Errorf("invalid force upload pattern: %v", err)
+		return nil, fmt.Errorf("invalid force upload pattern: %v", err)
+	}
+	baseFiles := make(map[string]*entity.BackupFile, len(base.Files))
+	for _, file := range base.Files {
+		baseFiles[file.Path] = file
 	}
 
-	// Get local file list by walking through the backup directory
-	localFiles := make(map[string]os.FileInfo)
-	err = filepath.Walk(backupPath, func(path string, info os.FileInfo, err error) error {
+	files := make([]*entity.BackupFile, 0)
+	uploaded := make(map[string]bool)
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		// Only store non-directory files
-		if !info.IsDir() {
-			relPath, _ := filepath.Rel(backupPath, path)
-			localFiles[relPath] = info
+		if info.IsDir() {
+			return nil
+		}
+		relPath, _ := filepath.Rel(dir, path)
+		file := &entity.BackupFile{
+			Path:     filepath.ToSlash(filepath.Join(sub, relPath)),
+			Size:     info.Size(),
+			ModTime:  info.ModTime().UnixNano(),
+			BackupID: backupID,
+		}
+		prev := baseFiles[file.Path]
+		if prev != nil && !forceUploadRegex.MatchString(relPath) && prev.Size == file.Size && prev.ModTime == file.ModTime {
+			file.BackupID = prev.BackupID
+		} else {
+			if err := store.Put(ctx, partPath+"/"+file.Path, path); err != nil {
+				return fmt.Errorf("failed to upload file %s: %v", relPath, err)
+			}
+			uploaded[partPath+"/"+file.Path] = true
+			log.Debug("uploaded file: %s", file.Path)
 		}
+		files = append(files, file)
 		return nil
 	})
 	if err != nil {
-		log.Error("failed to walk backup directory: %v", err)
-		return err
-	}
-
-	// Get S3 object list by listing objects in the S3 bucket
-	s3Files := make(map[string]minio.ObjectInfo)
-	objectCh := minioClient.ListObjects(ctx, bucketName, minio.ListObjectsOptions{
-		Prefix:    s3Path,
-		Recursive: true,
-	})
-	for object := range objectCh {
-		if object.Err != nil {
-			log.Error("failed to list S3 objects: %v", object.Err)
-			continue
-		}
-		relPath := strings.TrimPrefix(object.Key, s3Path+"/")
-		s3Files[relPath] = object
+		log.Error("failed to upload backup directory %s: %v", dir, err)
+		return nil, err
 	}
 
-	// Upload files that exist locally but not in S3
-	for localPath := range localFiles {
-		isForceUpload := forceUploadRegex.MatchString(localPath)
-		if _, exists := s3Files[localPath]; isForceUpload || !exists {
-			fullPath := filepath.Join(backupPath, localPath)
-			objectName := filepath.Join(s3Path, localPath)
-
-			_, err := minioClient.FPutObject(ctx, bucketName, objectName, fullPath,
-				minio.PutObjectOptions{ContentType: "application/octet-stream"})
-			if err != nil {
-				log.Error("failed to upload file %s to S3: %v", localPath, err)
-				continue
-			}
-			log.Info("uploaded file to S3: %s", localPath)
-		}
+	// remove the files left by a failed try of the backup
+	keys, err := store.List(ctx, partPath+"/"+sub, true)
+	if err != nil {
+		return nil, err
 	}
-
-	// Delete files that exist in S3 but not locally
-	for file := range s3Files {
-		if _, exists := localFiles[file]; !exists {
-			err := minioClient.RemoveObject(ctx, bucketName,
-				filepath.Join(s3Path, file), minio.RemoveObjectOptions{})
-			if err != nil {
-				log.Error("failed to remove S3 file %s: %v", file, err)
-				continue
+	for _, key := range keys {
+		if !uploaded[key] {
+			if err := store.Remove(ctx, key); err != nil {
+				log.Error("failed to remove backup file %s: %v", key, err)
 			}
-			log.Info("removed file from S3: %s", file)
 		}
 	}
-
-	return nil
+	log.Info("backup %d uploaded %d of %d files in %s", backupID, len(uploaded), len(files), dir)
+	return files, nil
 }
 
 type S3PathBuilder struct {
@@ -548,21 +546,19 @@ func (b *S3PathBuilder) BuildExportPath(dbName, spaceName string, backupID int,
 	return b.BuildObjectPath("export", dbName, spaceName, strconv.Itoa(backupID), fileName)
 }
 
-func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.BackupSpaceRequest, minioClient *minio.Client, dbName string, path string) {
+func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.BackupSpaceRequest, store backupstore.Store, dbName string, path string) error {
 	pathBuilder := NewS3PathBuilder(config.Conf().Global.Name)
 
 	exportDir := fmt.Sprintf("%s/export", path)
 	if _, err := os.Stat(exportDir); os.IsNotExist(err) {
 		if err = os.Mkdir(exportDir, 0644); err != nil {
-			log.Error("failed to create export dir: %s", err)
-			return
+			return fmt.Errorf("failed to create export dir: %s", err)
 		}
 	}
 
 	partitonStore := bh.server.GetPartition(pid)
 	if partitonStore == nil {
-		log.Error("PartitonStore is nil.")
-		return
+		return fmt.Errorf("partition store %d is nil", pid)
 	}
 	space := partitonStore.GetSpace()
 
@@ -579,17 +575,15 @@ func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.
 
 	file, err := os.Create(backupFileName)
 	if err != nil {
-		log.Error("failed to create file: %s", err)
-		return
+		return fmt.Errorf("failed to create file: %s", err)
 	}
 
 	zw, err := zstd.NewWriter(file,
 		zstd.WithEncoderLevel(zstd.SpeedDefault),
 		zstd.WithEncoderConcurrency(2))
 	if err != nil {
-		log.Error("failed to create zstd writer: %s", err)
 		file.Close()
-		return
+		return fmt.Errorf("failed to create zstd writer: %s", err)
 	}
 
 	nextDocid := int32(-1)
@@ -648,18 +642,15 @@ func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.
 			file.Close()
 			file = nil
 
-			_, err = minioClient.FPutObject(ctx, backup.S3Param.BucketName, objectName, backupFileName,
-				minio.PutObjectOptions{ContentType: "application/zstd"})
+			err = store.Put(ctx, objectName, backupFileName)
 			if err != nil {
-				log.Error("failed to upload backup file: %+v", err)
-				return
+				return fmt.Errorf("failed to upload backup file: %+v", err)
 			}
 			log.Info("backup success, file is [%s]", backupFileName)
 
 			// remove old file
 			if err = os.Remove(backupFileName); err != nil {
-				log.Error("failed to remove file: %s", err)
-				return
+				return fmt.Errorf("failed to remove file: %s", err)
 			}
 
 			part++
@@ -669,18 +660,16 @@ func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.
 
 			file, err = os.Create(backupFileName)
 			if err != nil {
-				log.Error("failed to create file: %s", err)
-				return
+				return fmt.Errorf("failed to create file: %s", err)
 			}
 
 			zw, err = zstd.NewWriter(file,
 				zstd.WithEncoderLevel(zstd.SpeedDefault),
 				zstd.WithEncoderConcurrency(2))
 			if err != nil {
-				log.Error("failed to create zstd writer: %s", err)
 				file.Close()
 				file = nil
-				return
+				return fmt.Errorf("failed to create zstd writer: %s", err)
 			}
 		}
 	}
@@ -695,26 +684,21 @@ func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.
 	}
 
 	if total%backupBatchSize != 0 {
-		_, err = minioClient.FPutObject(ctx, backup.S3Param.BucketName, objectName, backupFileName,
-			minio.PutObjectOptions{ContentType: "application/zstd"})
+		err = store.Put(ctx, objectName, backupFileName)
 		if err != nil {
-			log.Error("failed to upload backup file: %+v", err)
-			return
+			return fmt.Errorf("failed to upload backup file: %+v", err)
 		}
 	}
 
 	doneFile := fmt.Sprintf("%s/done_%d", exportDir, backup.Part)
 	if err := os.WriteFile(doneFile, fmt.Appendf(nil, "%d", total), 0644); err != nil {
-		log.Error("failed to create done file: %s", err)
-		return
+		return fmt.Errorf("failed to create done file: %s", err)
 	}
 
-	_, err = minioClient.FPutObject(ctx, backup.S3Param.BucketName, doneName, doneFile,
-		minio.PutObjectOptions{ContentType: "text/plain"})
+	err = store.Put(ctx, doneName, doneFile)
 	if err != nil {
-		log.Error("failed to upload done file: %+v", err)
 		os.Remove(doneFile)
-		return
+		return fmt.Errorf("failed to upload done file: %+v", err)
 	}
 
 	if err := os.Remove(doneFile); err != nil {
@@ -726,9 +710,10 @@ func (bh *BackupHandler) export(ctx context.Context, pid uint32, backup *entity.
 	}
 
 	log.Info("export completed successfully. Total documents: %d", total)
+	return nil
 }
 
-func (bh *BackupHandler) create(ctx context.Context, pid uint32, backup *entity.BackupSpaceRequest, minioClient *minio.Client, dbName, spaceName string, path string) {
+func (bh *BackupHandler) create(ctx context.Context, pid uint32, backup *entity.BackupSpaceRequest, store backupstore.Store, dbName, spaceName string, path string) error {
 	pathBuilder := NewS3PathBuilder(config.Conf().Global.Name)
 
 	bh.server.backupStatus[pid] = 1
@@ -736,21 +721,37 @@ func (bh *BackupHandler) create(ctx context.Context, pid uint32, backup *entity.
 		bh.server.backupStatus[pid] = 0
 	}()
 
+	status := &entity.EngineStatus{}
+	if err := bh.server.GetPartition(pid).GetEngine().GetEngineStatus(status); err != nil {
+		return fmt.Errorf("get engine status error [%+v]", err)
+	}
+
+	partPath := pathBuilder.BuildBackupPath(dbName, spaceName, uint32(backup.BackupID), backup.Part)
+	base := &entity.BackupFiles{}
+	if backup.BaseBackupID > 0 {
+		key := pathBuilder.BuildBackupPath(dbName, spaceName, uint32(backup.BaseBackupID), backup.Part) + "/" + entity.BackupFilesFile
+		if err := backupstore.GetJSON(ctx, store, key, base); err != nil {
+			log.Error("failed to get files of base backup %d, upload all files: %v", backup.BaseBackupID, err)
+			base = &entity.BackupFiles{}
+		}
+	}
+
 	pathes := []string{
 		"data",
 		"bitmap",
 	}
 
+	backupFiles := &entity.BackupFiles{DocNum: uint64(status.DocNum)}
 	for _, p := range pathes {
 		backupLocalPath := strings.Join([]string{
 			path, "backup", p,
 		}, "/")
 
-		s3Path := pathBuilder.BuildBackupPath(dbName, spaceName, uint32(backup.BackupID), backup.Part) + "/" + p
-		if err := bh.syncBackupFiles(ctx, minioClient, backup.S3Param.BucketName, backupLocalPath, s3Path); err != nil {
-			log.Error("failed to sync backup files: %v", err)
-			return
+		files, err := bh.uploadBackupFiles(ctx, store, backup.BackupID, backupLocalPath, partPath, p, base)
+		if err != nil {
+			return fmt.Errorf("failed to upload backup files: %v", err)
 		}
+		backupFiles.Files = append(backupFiles.Files, files...)
 	}
 
 	remoteFiles := []string{
@@ -764,40 +765,37 @@ func (bh *BackupHandler) create(ctx context.Context, pid uint32, backup *entity.
 			path, localFiles[i],
 		}, "/")
 
-		s3Path := pathBuilder.BuildBackupPath(dbName, spaceName, uint32(backup.BackupID), backup.Part) + "/" + file
-		_, err := minioClient.FPutObject(ctx, backup.S3Param.BucketName, s3Path, backupLocalPath,
-			minio.PutObjectOptions{ContentType: "application/octet-stream"})
+		s3Path := partPath + "/" + file
+		err := store.Put(ctx, s3Path, backupLocalPath)
 		if err != nil {
-			log.Error("failed to upload file %s to S3: %v", backupLocalPath, err)
-			continue
+			return fmt.Errorf("failed to upload file %s: %v", backupLocalPath, err)
 		}
 	}
 
+	// the files of the partition are put at last, it marks the partition done
+	if err := backupstore.PutJSON(ctx, store, partPath+"/"+entity.BackupFilesFile, backupFiles); err != nil {
+		return fmt.Errorf("failed to upload backup files list: %v", err)
+	}
+
 	log.Info("backup success")
+	return nil
 }
 
-func (bh *BackupHandler) downloadDirectory(ctx context.Context, minioClient *minio.Client, bucketName, s3Path, localPath string) error {
+func (bh *BackupHandler) downloadDirectory(ctx context.Context, store backupstore.Store, s3Path, localPath string) error {
 	if err := os.MkdirAll(localPath, 0755); err != nil {
 		return fmt.Errorf("can't mkdir: %v", err)
 	}
 
-	opts := minio.ListObjectsOptions{
-		Prefix:    s3Path,
-		Recursive: true,
+	keys, err := store.List(ctx, s3Path, true)
+	if err != nil {
+		return fmt.Errorf("list objects failed: %v", err)
 	}
-
-	objChan := minioClient.ListObjects(ctx, bucketName, opts)
-	for obj := range objChan {
-		if obj.Err != nil {
-			log.Error("list objects failed: %v", obj.Err)
+	for _, key := range keys {
+		if strings.HasSuffix(key, "/") {
 			continue
 		}
 
-		if strings.HasSuffix(obj.Key, "/") {
-			continue
-		}
-
-		relPath := strings.TrimPrefix(obj.Key, s3Path+"/")
+		relPath := strings.TrimPrefix(key, s3Path+"/")
 		destPath := filepath.Join(localPath, relPath)
 
 		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
@@ -805,8 +803,8 @@ func (bh *BackupHandler) downloadDirectory(ctx context.Context, minioClient *min
 			continue
 		}
 
-		if err := minioClient.FGetObject(ctx, bucketName, obj.Key, destPath, minio.GetObjectOptions{}); err != nil {
-			log.Error("failed to download file %s: %v", obj.Key, err)
+		if err := store.Get(ctx, key, destPath); err != nil {
+			log.Error("failed to download file %s: %v", key, err)
 			continue
 		}
 
@@ -816,13 +814,14 @@ func (bh *BackupHandler) downloadDirectory(ctx context.Context, minioClient *min
 	return nil
 }
 
-func (bh *BackupHandler) restore(ctx context.Context, pid uint32, backup *entity.BackupSpaceRequest, minioClient *minio.Client, dbName, spaceName string, path string) {
-	pathBuilder := NewS3PathBuilder(config.Conf().Global.Name)
+func (bh *BackupHandler) restore(ctx context.Context, pid uint32, backup *entity.BackupSpaceRequest, store backupstore.Store, dbName, spaceName string, path string) error {
+	// the backup may be of another space or cluster
+	src := backup.RestoreSource(config.Conf().Global.Name, dbName, spaceName)
+	pathBuilder := NewS3PathBuilder(src.ClusterName)
 
 	engine := bh.server.GetPartition(pid).GetEngine()
 	if engine == nil {
-		log.Error("engine is nil")
-		return
+		return fmt.Errorf("engine is nil")
 	}
 
 	engine.Close()
@@ -832,8 +831,7 @@ func (bh *BackupHandler) restore(ctx context.Context, pid uint32, backup *entity
 		time.Sleep(time.Second * 10)
 		times += 1
 		if times > maxEngineCloseWait {
-			log.Error("engine close timeout")
-			return
+			return fmt.Errorf("engine close timeout")
 		}
 	}
 	bh.server.backupStatus[pid] = 1
@@ -846,24 +844,44 @@ func (bh *BackupHandler) restore(ctx context.Context, pid uint32, backup *entity
 		"bitmap",
 	}
 
+	// backups without files list are downloaded by dirs
+	partPath := pathBuilder.BuildBackupPath(src.DbName, src.SpaceName, uint32(src.BackupID), backup.Part)
+	backupFiles := &entity.BackupFiles{}
+	err := backupstore.GetJSON(ctx, store, partPath+"/"+entity.BackupFilesFile, backupFiles)
+	if err != nil && !backupstore.IsNotExist(err) {
+		return fmt.Errorf("failed to get backup files list: %v", err)
+	}
+	hasFiles := err == nil
+
 	for _, p := range pathes {
 		os.RemoveAll(filepath.Join(path, p))
 		os.Mkdir(filepath.Join(path, p), 0644)
 
+		if hasFiles {
+			continue
+		}
+
 		localPath := strings.Join([]string{
 			path, p,
 		}, "/")
 
-		s3Path := pathBuilder.BuildBackupPath(dbName, spaceName, uint32(backup.BackupID), backup.Part) + "/" + p
-		// Get directory from s3
-		if err := bh.downloadDirectory(ctx, minioClient, backup.S3Param.BucketName, s3Path, localPath); err != nil {
-			log.Error("failed to download backup files: %v", err)
-			return
+		s3Path := partPath + "/" + p
+		// Get directory from the store
+		if err := bh.downloadDirectory(ctx, store, s3Path, localPath); err != nil {
+			return fmt.Errorf("failed to download backup files: %v", err)
+		}
+	}
+
+	for _, file := range backupFiles.Files {
+		// the file may be kept in an earlier backup
+		key := pathBuilder.BuildBackupPath(src.DbName, src.SpaceName, uint32(file.BackupID), backup.Part) + "/" + file.Path
+		if err := store.Get(ctx, key, filepath.Join(path, filepath.FromSlash(file.Path))); err != nil {
+			return fmt.Errorf("failed to download file %s: %v", key, err)
 		}
 	}
 
 	remoteFiles := []string{
-		fmt.Sprintf("%s-%d.schema", spaceName, backup.Part),
+		fmt.Sprintf("%s-%d.schema", src.SpaceName, backup.Part),
 	}
 
 	localFiles := []string{
@@ -874,20 +892,19 @@ func (bh *BackupHandler) restore(ctx context.Context, pid uint32, backup *entity
 		localPath := strings.Join([]string{
 			path, localFiles[i],
 		}, "/")
-		s3Path := pathBuilder.BuildBackupPath(dbName, spaceName, uint32(backup.BackupID), backup.Part) + "/" + file
-		err := minioClient.FGetObject(ctx, backup.S3Param.BucketName, s3Path, localPath, minio.GetObjectOptions{})
+		s3Path := partPath + "/" + file
+		err := store.Get(ctx, s3Path, localPath)
 		if err != nil {
-			log.Error("failed to download file %s: %v", file, err)
-			return
+			return fmt.Errorf("failed to download file %s: %v", file, err)
 		}
 	}
 
-	err := engine.Load()
+	err = engine.Load()
 	if err != nil {
-		log.Error("reload partition error:[%v]", err)
-		return
+		return fmt.Errorf("reload partition error:[%v]", err)
 	}
 	log.Info("restore success")
+	return nil
 }
 
 func (bh *BackupHandler) Execute(ctx context.Context, req *vearchpb.PartitionData, reply *vearchpb.PartitionData) (err error) {
@@ -927,59 +944,65 @@ func (bh *BackupHandler) Execute(ctx context.Context, req *vearchpb.PartitionDat
 		return vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, fmt.Errorf("get engine config error: %s", err.Error()))
 	}
 
-	minioClient, err := minio.New(backup.S3Param.EndPoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(backup.S3Param.AccessKey, backup.S3Param.SecretKey, ""),
-		Secure: backup.S3Param.UseSSL,
-		Region: backup.S3Param.Region,
-	})
+	store, err := backupstore.New(backup)
 	if err != nil {
-		log.Error("failed to create minio client: %+v", err)
-		return vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, fmt.Errorf("failed to create minio client: %s", err.Error()))
+		log.Error("failed to create backup store: %+v", err)
+		return vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, fmt.Errorf("failed to create backup store: %s", err.Error()))
 	}
 
 	if bh.server.backupStatus[pid] != 0 {
 		return vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, fmt.Errorf("backup status %d", bh.server.backupStatus[req.PartitionID]))
 	}
 
-	if backup.Command == "export" {
+	progress := &entity.BackupProgress{
+		TaskID:   backup.TaskID,
+		Command:  backup.Command,
+		BackupID: backup.BackupID,
+		Status:   entity.TaskRunning,
+	}
+	run := func(f func() error) {
+		bh.server.backupProgress.Store(pid, progress)
 		go func() {
-			status := &entity.EngineStatus{}
-			err := e.GetEngineStatus(status)
-
+			err := f()
 			if err != nil {
-				log.Error("get engine status error [%+v]", err)
-				return
-			}
-			for status.BackupStatus != 0 {
-				log.Debug("status.BackupStatus %d", status.BackupStatus)
-				return
+				log.Error("partition %d %s backup %d err: %v", pid, backup.Command, backup.BackupID, err)
 			}
-			bh.export(ctx, pid, backup, minioClient, dbName, *engineConfig.Path)
+			bh.server.finishBackup(pid, progress, err)
 		}()
-	} else if backup.Command == "create" {
-		go func() {
-			status := &entity.EngineStatus{}
-			err := e.GetEngineStatus(status)
+	}
+	// the engine is checked again in the goroutine as it may be busy by then
+	checkEngine := func() error {
+		status := &entity.EngineStatus{}
+		if err := e.GetEngineStatus(status); err != nil {
+			return fmt.Errorf("get engine status error [%+v]", err)
+		}
+		if status.BackupStatus != 0 {
+			return fmt.Errorf("engine backup status %d", status.BackupStatus)
+		}
+		return nil
+	}
 
-			if err != nil {
-				log.Error("get engine status error [%+v]", err)
-				return
+	if backup.Command == "export" {
+		run(func() error {
+			if err := checkEngine(); err != nil {
+				return err
 			}
-			for status.BackupStatus != 0 {
-				log.Debug("status.BackupStatus %d", status.BackupStatus)
-				return
+			return bh.export(ctx, pid, backup, store, dbName, *engineConfig.Path)
+		})
+	} else if backup.Command == "create" {
+		run(func() error {
+			if err := checkEngine(); err != nil {
+				return err
 			}
-			err = e.BackupSpace(backup.Command)
-			if err != nil {
-				log.Error("failed to backup space: %+v", err)
-				return
+			if err := e.BackupSpace(backup.Command); err != nil {
+				return fmt.Errorf("failed to backup space: %+v", err)
 			}
-			bh.create(ctx, pid, backup, minioClient, dbName, space.Name, *engineConfig.Path)
-		}()
+			return bh.create(ctx, pid, backup, store, dbName, space.Name, *engineConfig.Path)
+		})
 	} else if backup.Command == "restore" {
-		go func() {
-			bh.restore(ctx, pid, backup, minioClient, dbName, space.Name, *engineConfig.Path)
-		}()
+		run(func() error {
+			return bh.restore(ctx, pid, backup, store, dbName, space.Name, *engineConfig.Path)
+		})
 	}
 	return nil
 }
@@ -0,0 +1,282 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// maxHistogramBuckets limits the buckets of a histogram on one partition.
+const maxHistogramBuckets = 10000
+
+// termsShardSize is how many terms buckets a partition returns for size, more
+// than size so the merged top buckets are more accurate.
+func termsShardSize(size int32) int {
+	return int(size)*3/2 + 10
+}
+
+// partialAggregation is an aggregation computed over the documents of one
+// partition, the client merges the partials of all partitions.
+type partialAggregation struct {
+	agg       *vearchpb.Aggregation
+	fieldType vearchpb.FieldType
+
+	terms   map[string]int64
+	buckets map[float64]int64
+	ranges  []int64
+
+	count int64
+	sum   float64
+	min   float64
+	max   float64
+}
+
+func newPartialAggregation(agg *vearchpb.Aggregation, proMap map[string]*entity.SpaceProperties) (*partialAggregation, error) {
+	pro := proMap[agg.Field]
+	if pro == nil {
+		return nil, fmt.Errorf("aggregation [%s] field [%s] not found in space", agg.Name, agg.Field)
+	}
+	p := &partialAggregation{agg: agg, fieldType: pro.FieldType, min: math.Inf(1), max: math.Inf(-1)}
+	switch agg.Type {
+	case vearchpb.Aggregation_TERMS:
+		if pro.FieldType != vearchpb.FieldType_STRING && pro.FieldType != vearchpb.FieldType_STRINGARRAY {
+			return nil, fmt.Errorf("terms aggregation [%s] field [%s] should be string or stringArray", agg.Name, agg.Field)
+		}
+		p.terms = make(map[string]int64)
+		return p, nil
+	case vearchpb.Aggregation_HISTOGRAM:
+		if agg.Interval <= 0 {
+			return nil, fmt.Errorf("histogram aggregation [%s] interval should be greater than 0", agg.Name)
+		}
+		p.buckets = make(map[float64]int64)
+	case vearchpb.Aggregation_RANGE:
+		p.ranges = make([]int64, len(agg.Ranges))
+	case vearchpb.Aggregation_STATS:
+	default:
+		return nil, fmt.Errorf("unknown aggregation type %d", agg.Type)
+	}
+	if !isNumericField(pro.FieldType) {
+		return nil, fmt.Errorf("aggregation [%s] field [%s] should be numeric or date", agg.Name, agg.Field)
+	}
+	return p, nil
+}
+
+func isNumericField(fieldType vearchpb.FieldType) bool {
+	switch fieldType {
+	case vearchpb.FieldType_INT, vearchpb.FieldType_LONG, vearchpb.FieldType_FLOAT,
+		vearchpb.FieldType_DOUBLE, vearchpb.FieldType_DATE:
+		return true
+	}
+	return false
+}
+
+// decodeNumber decodes the little endian value of a numeric or date field,
+// dates are nanoseconds.
+func decodeNumber(fieldType vearchpb.FieldType, b []byte) (float64, bool) {
+	switch fieldType {
+	case vearchpb.FieldType_INT, vearchpb.FieldType_LONG, vearchpb.FieldType_DATE:
+		v, ok := decodeInt(b)
+		return float64(v), ok
+	case vearchpb.FieldType_FLOAT, vearchpb.FieldType_DOUBLE:
+		if len(b) != 4 && len(b) != 8 {
+			return 0, false
+		}
+		return cbbytes.ByteToFloat64(b), true
+	}
+	return 0, false
+}
+
+// add counts the value of the field of one document, documents without the
+// field are not counted.
+func (p *partialAggregation) add(value []byte) {
+	if value == nil {
+		return
+	}
+	if p.terms != nil {
+		if p.fieldType == vearchpb.FieldType_STRING {
+			p.terms[string(value)]++
+			return
+		}
+		seen := make(map[string]struct{})
+		for _, term := range bytes.Split(value, []byte{'\001'}) {
+			if _, ok := seen[string(term)]; ok {
+				continue
+			}
+			seen[string(term)] = struct{}{}
+			p.terms[string(term)]++
+		}
+		return
+	}
+
+	v, ok := decodeNumber(p.fieldType, value)
+	if !ok {
+		return
+	}
+	switch p.agg.Type {
+	case vearchpb.Aggregation_HISTOGRAM:
+		p.buckets[math.Floor(v/p.agg.Interval)*p.agg.Interval]++
+	case vearchpb.Aggregation_RANGE:
+		for i, r := range p.agg.Ranges {
+			if v >= r.From && v < r.To {
+				p.ranges[i]++
+			}
+		}
+	case vearchpb.Aggregation_STATS:
+		p.count++
+		p.sum += v
+		p.min = math.Min(p.min, v)
+		p.max = math.Max(p.max, v)
+	}
+}
+
+func (p *partialAggregation) result() (*vearchpb.AggregationResult, error) {
+	result := &vearchpb.AggregationResult{Name: p.agg.Name}
+	switch p.agg.Type {
+	case vearchpb.Aggregation_TERMS:
+		result.Buckets = make([]*vearchpb.AggregationBucket, 0, len(p.terms))
+		for key, count := range p.terms {
+			result.Buckets = append(result.Buckets, &vearchpb.AggregationBucket{Key: key, Count: count})
+		}
+		sortTermsBuckets(result.Buckets)
+		if size := termsShardSize(p.agg.Size); len(result.Buckets) > size {
+			for _, bucket := range result.Buckets[size:] {
+				result.OtherCount += bucket.Count
+			}
+			result.Buckets = result.Buckets[:size]
+		}
+	case vearchpb.Aggregation_HISTOGRAM:
+		if len(p.buckets) > maxHistogramBuckets {
+			return nil, fmt.Errorf("histogram aggregation [%s] has more than %d buckets, use a greater interval", p.agg.Name, maxHistogramBuckets)
+		}
+		result.Buckets = make([]*vearchpb.AggregationBucket, 0, len(p.buckets))
+		for from, count := range p.buckets {
+			result.Buckets = append(result.Buckets, &vearchpb.AggregationBucket{
+				Key:   strconv.FormatFloat(from, 'f', -1, 64),
+				Count: count,
+				From:  from,
+				To:    from + p.agg.Interval,
+			})
+		}
+		sort.Slice(result.Buckets, func(i, j int) bool { return result.Buckets[i].From < result.Buckets[j].From })
+	case vearchpb.Aggregation_RANGE:
+		result.Buckets = make([]*vearchpb.AggregationBucket, 0, len(p.ranges))
+		for i, count := range p.ranges {
+			r := p.agg.Ranges[i]
+			result.Buckets = append(result.Buckets, &vearchpb.AggregationBucket{Key: r.Key, Count: count, From: r.From, To: r.To})
+		}
+	case vearchpb.Aggregation_STATS:
+		result.Count, result.Sum = p.count, p.sum
+		if p.count > 0 {
+			result.Min, result.Max = p.min, p.max
+		}
+	}
+	return result, nil
+}
+
+// sortTermsBuckets orders buckets by count desc then key.
+func sortTermsBuckets(buckets []*vearchpb.AggregationBucket) {
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+		return buckets[i].Key < buckets[j].Key
+	})
+}
+
+// aggregate computes aggs over all the documents of store matching the
+// filters, it reads the whole partition in docid order.
+func aggregate(ctx context.Context, store PartitionStore, head *vearchpb.RequestHead, aggs []*vearchpb.Aggregation,
+	rangeFilters []*vearchpb.RangeFilter, termFilters []*vearchpb.TermFilter, operator int32, filterTree *vearchpb.FilterNode) ([]*vearchpb.AggregationResult, error) {
+	space := store.GetSpace()
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		var err error
+		if proMap, err = entity.UnmarshalPropertyJSON(space.Fields); err != nil {
+			return nil, err
+		}
+	}
+	matcher, err := newDocMatcher(rangeFilters, termFilters, operator, filterTree, proMap)
+	if err != nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	partials := make([]*partialAggregation, 0, len(aggs))
+	for _, agg := range aggs {
+		p, err := newPartialAggregation(agg, proMap)
+		if err != nil {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+		}
+		partials = append(partials, p)
+	}
+
+	readLeader := head != nil && head.ClientType == request.Leader
+	docid := int32(-1)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		doc, next, err := nextDocument(ctx, store, readLeader, docid)
+		if err != nil {
+			return nil, err
+		}
+		if doc == nil {
+			break
+		}
+		docid = next
+		if !matcher.match(doc) {
+			continue
+		}
+		for _, p := range partials {
+			for _, field := range doc.Fields {
+				if field.Name == p.agg.Field {
+					p.add(field.Value)
+					break
+				}
+			}
+		}
+	}
+
+	results := make([]*vearchpb.AggregationResult, 0, len(partials))
+	for _, p := range partials {
+		result, err := p.result()
+		if err != nil {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// setAggregations attaches the aggregations to response. The flat bytes of
+// the engine are deserialized first, as that would reset the response.
+func setAggregations(response *vearchpb.SearchResponse, results []*vearchpb.AggregationResult) {
+	if response.FlatBytes != nil {
+		sr := &vearchpb.SearchResponse{}
+		gamma.DeSerialize(response.FlatBytes, sr)
+		response.Results = sr.Results
+		response.FlatBytes = nil
+	}
+	response.Aggregations = results
+}
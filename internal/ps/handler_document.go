@@ -37,6 +37,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/pkg/server/rpc/handler"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"go.uber.org/atomic"
+	"google.golang.org/protobuf/proto"
 )
 
 type limitPlugin struct {
@@ -137,7 +138,10 @@ func (handler *UnaryHandler) Execute(ctx context.Context, req *vearchpb.Partitio
 		reply.Items = req.Items
 		// reply.SearchRequest = req.SearchRequest
 		reply.SearchResponse = req.SearchResponse
+		reply.ScrollResponse = req.ScrollResponse
 		reply.DelByQueryResponse = req.DelByQueryResponse
+		reply.WriteIndex = req.WriteIndex
+		reply.ChangesResponse = req.ChangesResponse
 		reply.Err = req.Err
 		return
 	case <-time.After(delayTime):
@@ -196,15 +200,17 @@ func (handler *UnaryHandler) execute(ctx context.Context, req *vearchpb.Partitio
 		}
 		switch method {
 		case client.GetDocsHandler:
-			getDocuments(ctx, store, req.Items, false, false)
+			getDocuments(ctx, store, req.Head, req.Items, false, false)
 		case client.GetDocsByPartitionHandler:
-			getDocuments(ctx, store, req.Items, true, false)
+			getDocuments(ctx, store, req.Head, req.Items, true, false)
 		case client.GetNextDocsByPartitionHandler:
-			getDocuments(ctx, store, req.Items, true, true)
+			getDocuments(ctx, store, req.Head, req.Items, true, true)
 		case client.DeleteDocsHandler:
-			deleteDocs(ctx, store, req.Items)
+			req.WriteIndex = deleteDocs(ctx, store, req.Items)
 		case client.BatchHandler:
-			bulk(ctx, store, req.Items)
+			req.WriteIndex = bulk(ctx, store, req.Items)
+		case client.UpdateHandler:
+			req.WriteIndex = update(ctx, store, req.Items)
 		case client.SearchHandler:
 			if req.SearchResponse == nil {
 				req.SearchResponse = &vearchpb.SearchResponse{}
@@ -215,6 +221,11 @@ func (handler *UnaryHandler) execute(ctx context.Context, req *vearchpb.Partitio
 				req.SearchResponse = &vearchpb.SearchResponse{}
 			}
 			query(ctx, store, req.QueryRequest, req.SearchResponse)
+		case client.ScrollHandler:
+			if req.ScrollResponse == nil {
+				req.ScrollResponse = &vearchpb.ScrollResponse{Head: &vearchpb.ResponseHead{}}
+			}
+			scroll(ctx, store, req.ScrollRequest, req.ScrollResponse)
 		case client.ForceMergeHandler:
 			req.Err = forceMerge(store)
 		case client.RebuildIndexHandler:
@@ -223,9 +234,12 @@ func (handler *UnaryHandler) execute(ctx context.Context, req *vearchpb.Partitio
 			if req.DelByQueryResponse == nil {
 				req.DelByQueryResponse = &vearchpb.DelByQueryeResponse{DelNum: 0}
 			}
-			deleteByQuery(ctx, store, req.QueryRequest, req.DelByQueryResponse)
+			req.WriteIndex = deleteByQuery(ctx, store, req.QueryRequest, req.DelByQueryResponse)
 		case client.FlushHandler:
 			req.Err = flush(ctx, store)
+		case client.ChangesHandler:
+			req.ChangesResponse = &vearchpb.ChangesResponse{Head: &vearchpb.ResponseHead{}}
+			changes(ctx, store, req.ChangesRequest, req.ChangesResponse)
 		default:
 			log.Error("method not found, method: [%s]", method)
 			req.Err = vearchpb.NewError(vearchpb.ErrorEnum_METHOD_NOT_IMPLEMENT, nil).GetError()
@@ -234,7 +248,15 @@ func (handler *UnaryHandler) execute(ctx context.Context, req *vearchpb.Partitio
 	}
 }
 
-func getDocuments(ctx context.Context, store PartitionStore, items []*vearchpb.Item, getByDocId bool, next bool) {
+func getDocuments(ctx context.Context, store PartitionStore, head *vearchpb.RequestHead, items []*vearchpb.Item, getByDocId bool, next bool) {
+	if err := store.WaitConsistency(ctx, head); err != nil {
+		vErr := vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+		for _, item := range items {
+			item.Err = vErr
+		}
+		return
+	}
+	ttl, now := store.GetSpace().TTL, time.Now()
 	for _, item := range items {
 		if e := store.GetDocument(ctx, false, item.Doc, getByDocId, next); e != nil {
 			msg := fmt.Sprintf("GetDocument failed, key: [%s], err: [%s]", item.Doc.PKey, e.Error())
@@ -243,11 +265,20 @@ func getDocuments(ctx context.Context, store PartitionStore, items []*vearchpb.I
 			} else {
 				item.Err = &vearchpb.Error{Code: vearchpb.ErrorEnum_INTERNAL_ERROR, Msg: msg}
 			}
+		} else if documentExpired(ttl, item.Doc, now) {
+			// expired documents are gone before the expiry job deletes them
+			item.Doc.Fields = nil
+			item.Err = vearchpb.NewError(vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST, nil).GetError()
 		}
 	}
 }
 
-func deleteDocs(ctx context.Context, store PartitionStore, items []*vearchpb.Item) {
+// deleteDocs returns the largest raft index of the deletes.
+func deleteDocs(ctx context.Context, store PartitionStore, items []*vearchpb.Item) uint64 {
+	var (
+		mu         sync.Mutex
+		writeIndex uint64
+	)
 	wg := sync.WaitGroup{}
 	for _, item := range items {
 		wg.Add(1)
@@ -265,16 +296,22 @@ func deleteDocs(ctx context.Context, store PartitionStore, items []*vearchpb.Ite
 			}
 			dataBytes := item.Doc.Fields[0].Value
 			docCmd := &vearchpb.DocCmd{Type: vearchpb.OpType_DELETE, Doc: dataBytes}
-			if err := store.Write(ctx, docCmd); err != nil {
+			index, err := store.Write(ctx, docCmd)
+			if err != nil {
 				log.Error("delete doc failed, err: [%s]", err.Error())
 				item.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+				return
 			}
+			mu.Lock()
+			writeIndex = max(writeIndex, index)
+			mu.Unlock()
 		}(item)
 	}
 	wg.Wait()
+	return writeIndex
 }
 
-func bulk(ctx context.Context, store PartitionStore, items []*vearchpb.Item) {
+func bulk(ctx context.Context, store PartitionStore, items []*vearchpb.Item) uint64 {
 	wg := sync.WaitGroup{}
 	docBytes := make([][]byte, len(items))
 	for i, item := range items {
@@ -295,14 +332,14 @@ func bulk(ctx context.Context, store PartitionStore, items []*vearchpb.Item) {
 	wg.Wait()
 	docCmd := &vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: docBytes}
 
-	err := store.Write(ctx, docCmd)
+	index, err := store.Write(ctx, docCmd)
 	vErr := vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err)
 	if vErr.GetError().Code != vearchpb.ErrorEnum_SUCCESS {
 		log.Error("Add doc failed, err: [%s]", err.Error())
 		for _, item := range items {
 			item.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
 		}
-		return
+		return 0
 	}
 	msgs := strings.Split(vErr.GetError().Msg, ",")
 	for i, msg := range msgs {
@@ -321,13 +358,64 @@ func bulk(ctx context.Context, store PartitionStore, items []*vearchpb.Item) {
 			}
 		}
 	}
+	return index
+}
+
+// update applies the fields of items to the existing documents by the
+// update op of each field, it returns the raft index of the update.
+func update(ctx context.Context, store PartitionStore, items []*vearchpb.Item) uint64 {
+	docBytes := make([][]byte, len(items))
+	for i, item := range items {
+		data, err := proto.Marshal(item.Doc)
+		if err != nil {
+			for _, item := range items {
+				item.Err = vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err).GetError()
+			}
+			return 0
+		}
+		docBytes[i] = data
+		item.Doc.Fields = nil
+		item.Err = vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, nil).GetError()
+	}
+	docCmd := &vearchpb.DocCmd{Type: vearchpb.OpType_UPDATE, Docs: docBytes}
+
+	index, err := store.Write(ctx, docCmd)
+	vErr := vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err)
+	if vErr.GetError().Code != vearchpb.ErrorEnum_SUCCESS {
+		log.Error("update doc failed, err: [%s]", err.Error())
+		for _, item := range items {
+			item.Err = vErr.GetError()
+		}
+		return 0
+	}
+	// the message is success:code,code,...
+	msg := strings.TrimPrefix(vErr.GetError().Msg, vearchpb.ErrMsg(vearchpb.ErrorEnum_SUCCESS)+":")
+	for i, codeInfo := range strings.Split(msg, ",") {
+		code, codeErr := strconv.Atoi(codeInfo)
+		if codeErr != nil || i >= len(items) || code == int(vearchpb.ErrorEnum_SUCCESS) {
+			continue
+		}
+		items[i].Err = vearchpb.NewError(vearchpb.ErrorEnum(code), nil).GetError()
+	}
+	return index
 }
 
 func query(ctx context.Context, store PartitionStore, request *vearchpb.QueryRequest, response *vearchpb.SearchResponse) {
 	startTime := time.Now()
-	if err := store.Query(ctx, request, response); err != nil {
+	if ttl := store.GetSpace().TTL; ttl != nil {
+		request.RangeFilters, request.TermFilters, request.Operator, request.FilterTree = liveFilters(ttl, startTime, request.RangeFilters, request.TermFilters, request.Operator, request.FilterTree)
+	}
+	if err := storeQuery(ctx, store, request, response); err != nil {
 		log.Error("query doc failed, err: [%s]", err.Error())
 		response.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+	} else if len(request.Aggregations) > 0 {
+		results, err := aggregate(ctx, store, request.Head, request.Aggregations, request.RangeFilters, request.TermFilters, request.Operator, request.FilterTree)
+		if err != nil {
+			log.Error("query aggregations failed, err: [%s]", err.Error())
+			response.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+		} else {
+			setAggregations(response, results)
+		}
 	}
 	partitionIDstr := strconv.FormatUint(uint64(store.GetEngine().GetPartitionID()), 10)
 	storeQuery := (time.Since(startTime).Seconds()) * 1000
@@ -357,11 +445,23 @@ func search(ctx context.Context, store PartitionStore, request *vearchpb.SearchR
 	}()
 
 	startTime := time.Now()
-	if err := store.Search(ctx, request, response); err != nil {
+	if ttl := store.GetSpace().TTL; ttl != nil {
+		request.RangeFilters, request.TermFilters, request.Operator, request.FilterTree = liveFilters(ttl, startTime, request.RangeFilters, request.TermFilters, request.Operator, request.FilterTree)
+	}
+	if err := storeFusedSearch(ctx, store, request, response); err != nil {
 		log.Error("search doc failed, err: [%s]", err.Error())
 		response.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
 		return
 	}
+	if len(request.Aggregations) > 0 {
+		results, err := aggregate(ctx, store, request.Head, request.Aggregations, request.RangeFilters, request.TermFilters, request.Operator, request.FilterTree)
+		if err != nil {
+			log.Error("search aggregations failed, err: [%s]", err.Error())
+			response.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+			return
+		}
+		setAggregations(response, results)
+	}
 
 	partitionIDstr := strconv.FormatUint(uint64(store.GetEngine().GetPartitionID()), 10)
 	storeSearch := (time.Since(startTime).Seconds()) * 1000
@@ -405,13 +505,14 @@ func flush(ctx context.Context, store PartitionStore) *vearchpb.Error {
 	return nil
 }
 
-func deleteByQuery(ctx context.Context, store PartitionStore, req *vearchpb.QueryRequest, resp *vearchpb.DelByQueryeResponse) {
+// deleteByQuery returns the largest raft index of the deletes.
+func deleteByQuery(ctx context.Context, store PartitionStore, req *vearchpb.QueryRequest, resp *vearchpb.DelByQueryeResponse) uint64 {
 	searchResponse := &vearchpb.SearchResponse{}
-	if err := store.Query(ctx, req, searchResponse); err != nil {
+	if err := storeQuery(ctx, store, req, searchResponse); err != nil {
 		log.Error("deleteByQuery search doc failed, err: [%s]", err.Error())
 		head := &vearchpb.ResponseHead{Err: &vearchpb.Error{Code: vearchpb.ErrorEnum_DELETE_BY_QUERY_SERACH_ERR, Msg: "deleteByQuery search doc failed"}}
 		resp.Head = head
-		return
+		return 0
 	}
 	flatBytes := searchResponse.FlatBytes
 	if flatBytes != nil {
@@ -422,7 +523,7 @@ func deleteByQuery(ctx context.Context, store PartitionStore, req *vearchpb.Quer
 	if len(results) == 0 {
 		head := &vearchpb.ResponseHead{Err: &vearchpb.Error{Code: vearchpb.ErrorEnum_DELETE_BY_QUERY_SEARCH_ID_IS_0, Msg: "deleteByQuery search id is 0"}}
 		resp.Head = head
-		return
+		return 0
 	}
 	docs := make([]*vearchpb.Item, 0)
 	for _, result := range results {
@@ -453,13 +554,38 @@ func deleteByQuery(ctx context.Context, store PartitionStore, req *vearchpb.Quer
 	if len(docs) == 0 {
 		head := &vearchpb.ResponseHead{Err: &vearchpb.Error{Code: vearchpb.ErrorEnum_DELETE_BY_QUERY_SEARCH_ID_IS_0, Msg: "deleteByQuery document id is empty"}}
 		resp.Head = head
-		return
+		return 0
 	}
-	deleteDocs(ctx, store, docs)
+	writeIndex := deleteDocs(ctx, store, docs)
 	for _, item := range docs {
 		if item.Err == nil {
 			resp.IdsStr = append(resp.IdsStr, item.Doc.PKey)
 			resp.DelNum++
 		}
 	}
+	return writeIndex
+}
+
+// defaultChangesLimit is the changes of a partition read at a time if the
+// limit is not set.
+const defaultChangesLimit = 100
+
+// changes reads the changes of store after its position in req.
+func changes(ctx context.Context, store PartitionStore, req *vearchpb.ChangesRequest, resp *vearchpb.ChangesResponse) {
+	pid := store.GetPartition().Id
+	after := req.GetPositions()[pid]
+	resp.Positions = map[uint32]uint64{pid: after}
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultChangesLimit
+	}
+	events, err := store.Changes(ctx, after, limit, time.Duration(req.GetWaitMs())*time.Millisecond)
+	if err != nil {
+		resp.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+		return
+	}
+	resp.Events = events
+	if len(events) > 0 {
+		resp.Positions[pid] = events[len(events)-1].Index
+	}
 }
@@ -0,0 +1,368 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// filter operators of vearchpb.FilterNode, same as the engine FilterOperator
+const (
+	filterOperatorAnd int32 = 0
+	filterOperatorOr  int32 = 1
+	filterOperatorNot int32 = 2
+)
+
+// is_union of a range or term filter, a NOT IN filter excludes its values
+const (
+	filterUnionIn    int32 = 1
+	filterUnionNotIn int32 = 2
+)
+
+// maxFilterConjunctions limits how many engine calls one filter tree may
+// expand to, every conjunction is one search or query on the engine.
+const maxFilterConjunctions = 64
+
+// filterConjunction is a set of range and term filters which must all match,
+// it is what the engine evaluates with the AND operator.
+type filterConjunction struct {
+	rangeFilters []*vearchpb.RangeFilter
+	termFilters  []*vearchpb.TermFilter
+}
+
+func (c *filterConjunction) and(other *filterConjunction) *filterConjunction {
+	conj := &filterConjunction{
+		rangeFilters: make([]*vearchpb.RangeFilter, 0, len(c.rangeFilters)+len(other.rangeFilters)),
+		termFilters:  make([]*vearchpb.TermFilter, 0, len(c.termFilters)+len(other.termFilters)),
+	}
+	conj.rangeFilters = append(append(conj.rangeFilters, c.rangeFilters...), other.rangeFilters...)
+	conj.termFilters = append(append(conj.termFilters, c.termFilters...), other.termFilters...)
+	return conj
+}
+
+func negateUnion(isUnion int32) int32 {
+	if isUnion == filterUnionNotIn {
+		return filterUnionIn
+	}
+	return filterUnionNotIn
+}
+
+// filterAtoms returns the range and term filters of node, negated if negate,
+// each as its own conjunction.
+func filterAtoms(node *vearchpb.FilterNode, negate bool) []*filterConjunction {
+	atoms := make([]*filterConjunction, 0, len(node.RangeFilters)+len(node.TermFilters))
+	for _, rf := range node.RangeFilters {
+		if negate {
+			rf = &vearchpb.RangeFilter{
+				Field:        rf.Field,
+				LowerValue:   rf.LowerValue,
+				UpperValue:   rf.UpperValue,
+				IncludeLower: rf.IncludeLower,
+				IncludeUpper: rf.IncludeUpper,
+				IsUnion:      negateUnion(rf.IsUnion),
+			}
+		}
+		atoms = append(atoms, &filterConjunction{rangeFilters: []*vearchpb.RangeFilter{rf}})
+	}
+	for _, tf := range node.TermFilters {
+		if negate {
+			tf = &vearchpb.TermFilter{
+				Field:   tf.Field,
+				Value:   tf.Value,
+				IsUnion: negateUnion(tf.IsUnion),
+			}
+		}
+		atoms = append(atoms, &filterConjunction{termFilters: []*vearchpb.TermFilter{tf}})
+	}
+	return atoms
+}
+
+// expandFilterTree rewrites the filter tree into disjunctive normal form,
+// NOT is pushed down to the range and term filters by De Morgan's laws.
+func expandFilterTree(node *vearchpb.FilterNode, negate bool) ([]*filterConjunction, error) {
+	operator := node.Operator
+	switch operator {
+	case filterOperatorAnd, filterOperatorOr:
+	case filterOperatorNot:
+		operator = filterOperatorAnd
+		negate = !negate
+	default:
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_FILTER_OPERATOR_TYPE_ERR, fmt.Errorf("unknown filter operator %d", node.Operator))
+	}
+	if negate {
+		if operator == filterOperatorAnd {
+			operator = filterOperatorOr
+		} else {
+			operator = filterOperatorAnd
+		}
+	}
+
+	atoms := filterAtoms(node, negate)
+	var conjs []*filterConjunction
+	if operator == filterOperatorAnd {
+		conj := &filterConjunction{}
+		for _, atom := range atoms {
+			conj = conj.and(atom)
+		}
+		conjs = []*filterConjunction{conj}
+		for _, child := range node.Children {
+			childConjs, err := expandFilterTree(child, negate)
+			if err != nil {
+				return nil, err
+			}
+			if len(conjs)*len(childConjs) > maxFilterConjunctions {
+				return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("filter expands to more than %d conjunctions", maxFilterConjunctions))
+			}
+			product := make([]*filterConjunction, 0, len(conjs)*len(childConjs))
+			for _, left := range conjs {
+				for _, right := range childConjs {
+					product = append(product, left.and(right))
+				}
+			}
+			conjs = product
+		}
+	} else {
+		conjs = atoms
+		for _, child := range node.Children {
+			childConjs, err := expandFilterTree(child, negate)
+			if err != nil {
+				return nil, err
+			}
+			conjs = append(conjs, childConjs...)
+		}
+		if len(conjs) > maxFilterConjunctions {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("filter expands to more than %d conjunctions", maxFilterConjunctions))
+		}
+	}
+	return conjs, nil
+}
+
+func resultItemID(item *vearchpb.ResultItem) (string, bool) {
+	for _, field := range item.Fields {
+		if field != nil && field.Name == entity.IdField {
+			return string(field.Value), true
+		}
+	}
+	return "", false
+}
+
+// unionResults appends the items of src to dest, result by result.
+func unionResults(dest []*vearchpb.SearchResult, src []*vearchpb.SearchResult) []*vearchpb.SearchResult {
+	if dest == nil {
+		return src
+	}
+	for i := range dest {
+		if i >= len(src) {
+			break
+		}
+		dest[i].ResultItems = append(dest[i].ResultItems, src[i].ResultItems...)
+		if src[i].MaxScore > dest[i].MaxScore {
+			dest[i].MaxScore = src[i].MaxScore
+		}
+	}
+	return dest
+}
+
+// dedupResultItems keeps the first item of each document and at most limit items.
+func dedupResultItems(items []*vearchpb.ResultItem, limit int) []*vearchpb.ResultItem {
+	seen := make(map[string]struct{}, len(items))
+	deduped := items[:0]
+	for _, item := range items {
+		if id, ok := resultItemID(item); ok {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+		}
+		deduped = append(deduped, item)
+		if limit > 0 && len(deduped) >= limit {
+			break
+		}
+	}
+	return deduped
+}
+
+// sortQueryItems orders the documents merged from the conjunctions of a
+// query by sortFields and then by _id, so the same documents are kept by limit
+// whatever conjunction they come from.
+func sortQueryItems(items []*vearchpb.ResultItem, sortFields []*vearchpb.SortField, proMap map[string]*entity.SpaceProperties) {
+	values := func(item *vearchpb.ResultItem) map[string][]byte {
+		m := make(map[string][]byte, len(item.Fields))
+		for _, field := range item.Fields {
+			if field != nil {
+				m[field.Name] = field.Value
+			}
+		}
+		return m
+	}
+	keys := make(map[*vearchpb.ResultItem]map[string][]byte, len(items))
+	for _, item := range items {
+		keys[item] = values(item)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := keys[items[i]], keys[items[j]]
+		for _, sf := range sortFields {
+			if sf == nil || sf.Field == entity.IdField {
+				continue
+			}
+			c, ok := 0, false
+			if pro := proMap[sf.Field]; pro != nil {
+				c, ok = compareNumeric(pro.FieldType, a[sf.Field], b[sf.Field])
+			}
+			if !ok {
+				c = bytes.Compare(a[sf.Field], b[sf.Field])
+			}
+			if c != 0 {
+				return (c > 0) == sf.Type
+			}
+		}
+		c := bytes.Compare(a[entity.IdField], b[entity.IdField])
+		for _, sf := range sortFields {
+			if sf != nil && sf.Field == entity.IdField && sf.Type {
+				return c > 0
+			}
+		}
+		return c < 0
+	})
+}
+
+// waitConsistencyOnce waits for the read consistency of head once for a
+// request run as several store reads, the reads then skip the wait until
+// restore is called.
+func waitConsistencyOnce(ctx context.Context, store PartitionStore, head *vearchpb.RequestHead) (restore func(), err error) {
+	if head == nil {
+		return func() {}, nil
+	}
+	if err := store.WaitConsistency(ctx, head); err != nil {
+		return nil, err
+	}
+	consistency := head.Consistency
+	head.Consistency = request.Eventual
+	return func() { head.Consistency = consistency }, nil
+}
+
+// storeSearch searches store, the filter tree of request if any is evaluated
+// as one engine search per conjunction and the hits are merged by score.
+func storeSearch(ctx context.Context, store PartitionStore, request *vearchpb.SearchRequest, response *vearchpb.SearchResponse) error {
+	if request.FilterTree == nil {
+		return store.Search(ctx, request, response)
+	}
+
+	conjs, err := expandFilterTree(request.FilterTree, false)
+	if err != nil {
+		return err
+	}
+
+	restore, err := waitConsistencyOnce(ctx, store, request.Head)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	tree, operator := request.FilterTree, request.Operator
+	defer func() {
+		request.FilterTree, request.Operator = tree, operator
+		request.RangeFilters, request.TermFilters = nil, nil
+	}()
+	request.FilterTree = nil
+	request.Operator = filterOperatorAnd
+
+	var results []*vearchpb.SearchResult
+	for _, conj := range conjs {
+		request.RangeFilters, request.TermFilters = conj.rangeFilters, conj.termFilters
+		if err := store.Search(ctx, request, response); err != nil {
+			return err
+		}
+		sr := &vearchpb.SearchResponse{}
+		if response.FlatBytes != nil {
+			gamma.DeSerialize(response.FlatBytes, sr)
+		}
+		results = unionResults(results, sr.Results)
+	}
+
+	desc := true
+	if len(request.SortFields) > 0 {
+		desc = request.SortFields[0].Type
+	}
+	for _, result := range results {
+		sort.SliceStable(result.ResultItems, func(i, j int) bool {
+			if desc {
+				return result.ResultItems[i].Score > result.ResultItems[j].Score
+			}
+			return result.ResultItems[i].Score < result.ResultItems[j].Score
+		})
+		result.ResultItems = dedupResultItems(result.ResultItems, int(request.TopN))
+	}
+	response.FlatBytes = nil
+	response.Results = results
+	return nil
+}
+
+// storeQuery queries store, the filter tree of request if any is evaluated
+// as one engine query per conjunction and the documents are merged.
+func storeQuery(ctx context.Context, store PartitionStore, request *vearchpb.QueryRequest, response *vearchpb.SearchResponse) error {
+	if request.FilterTree == nil {
+		return store.Query(ctx, request, response)
+	}
+
+	conjs, err := expandFilterTree(request.FilterTree, false)
+	if err != nil {
+		return err
+	}
+
+	restore, err := waitConsistencyOnce(ctx, store, request.Head)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	tree, operator := request.FilterTree, request.Operator
+	defer func() {
+		request.FilterTree, request.Operator = tree, operator
+		request.RangeFilters, request.TermFilters = nil, nil
+	}()
+	request.FilterTree = nil
+	request.Operator = filterOperatorAnd
+
+	var results []*vearchpb.SearchResult
+	for _, conj := range conjs {
+		request.RangeFilters, request.TermFilters = conj.rangeFilters, conj.termFilters
+		if err := store.Query(ctx, request, response); err != nil {
+			return err
+		}
+		sr := &vearchpb.SearchResponse{}
+		if response.FlatBytes != nil {
+			gamma.DeSerialize(response.FlatBytes, sr)
+		}
+		results = unionResults(results, sr.Results)
+	}
+
+	proMap := store.GetSpace().SpaceProperties
+	for _, result := range results {
+		sortQueryItems(result.ResultItems, request.SortFields, proMap)
+		result.ResultItems = dedupResultItems(result.ResultItems, int(request.Limit))
+	}
+	response.FlatBytes = nil
+	response.Results = results
+	return nil
+}
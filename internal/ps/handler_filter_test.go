@@ -0,0 +1,215 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+func termNode(operator int32, fields ...string) *vearchpb.FilterNode {
+	node := &vearchpb.FilterNode{Operator: operator}
+	for _, field := range fields {
+		node.TermFilters = append(node.TermFilters, &vearchpb.TermFilter{Field: field, Value: []byte(field), IsUnion: filterUnionIn})
+	}
+	return node
+}
+
+// conjString formats conjs as sorted "a&!b" terms, ! marks NOT IN and * a
+// conjunction without filters, which matches every document.
+func conjString(conjs []*filterConjunction) string {
+	terms := make([]string, 0, len(conjs))
+	for _, conj := range conjs {
+		atoms := make([]string, 0, len(conj.termFilters)+len(conj.rangeFilters))
+		for _, tf := range conj.termFilters {
+			atom := tf.Field
+			if tf.IsUnion == filterUnionNotIn {
+				atom = "!" + atom
+			}
+			atoms = append(atoms, atom)
+		}
+		for _, rf := range conj.rangeFilters {
+			atom := rf.Field
+			if rf.IsUnion == filterUnionNotIn {
+				atom = "!" + atom
+			}
+			atoms = append(atoms, atom)
+		}
+		if len(atoms) == 0 {
+			atoms = append(atoms, "*")
+		}
+		sort.Strings(atoms)
+		terms = append(terms, strings.Join(atoms, "&"))
+	}
+	sort.Strings(terms)
+	return strings.Join(terms, "|")
+}
+
+func TestExpandFilterTree(t *testing.T) {
+	tests := []struct {
+		name string
+		tree *vearchpb.FilterNode
+		want string
+	}{
+		{"and", termNode(filterOperatorAnd, "a", "b"), "a&b"},
+		{"or", termNode(filterOperatorOr, "a", "b"), "a|b"},
+		{
+			"and of or",
+			&vearchpb.FilterNode{
+				Operator:    filterOperatorAnd,
+				TermFilters: termNode(filterOperatorAnd, "a").TermFilters,
+				Children:    []*vearchpb.FilterNode{termNode(filterOperatorOr, "b", "c")},
+			},
+			"a&b|a&c",
+		},
+		// NOT (a AND b) = !a OR !b
+		{"not", &vearchpb.FilterNode{Operator: filterOperatorNot, Children: []*vearchpb.FilterNode{termNode(filterOperatorAnd, "a", "b")}}, "!a|!b"},
+		// NOT (a OR NOT b) = !a AND b
+		{
+			"double not",
+			&vearchpb.FilterNode{
+				Operator: filterOperatorNot,
+				Children: []*vearchpb.FilterNode{{
+					Operator:    filterOperatorOr,
+					TermFilters: termNode(filterOperatorAnd, "a").TermFilters,
+					Children:    []*vearchpb.FilterNode{{Operator: filterOperatorNot, Children: []*vearchpb.FilterNode{termNode(filterOperatorAnd, "b")}}},
+				}},
+			},
+			"!a&b",
+		},
+		{
+			"not range",
+			&vearchpb.FilterNode{Operator: filterOperatorNot, RangeFilters: []*vearchpb.RangeFilter{{Field: "r", IsUnion: filterUnionIn}}},
+			"!r",
+		},
+		// an empty AND group matches everything, an empty OR group nothing
+		{"empty and", termNode(filterOperatorAnd), "*"},
+		{"empty or", termNode(filterOperatorOr), ""},
+		{
+			"and with empty or",
+			&vearchpb.FilterNode{Operator: filterOperatorAnd, TermFilters: termNode(filterOperatorAnd, "a").TermFilters, Children: []*vearchpb.FilterNode{termNode(filterOperatorOr)}},
+			"",
+		},
+		{
+			"or with empty and",
+			&vearchpb.FilterNode{Operator: filterOperatorOr, TermFilters: termNode(filterOperatorAnd, "a").TermFilters, Children: []*vearchpb.FilterNode{termNode(filterOperatorAnd)}},
+			"*|a",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conjs, err := expandFilterTree(tt.tree, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := conjString(conjs); got != tt.want {
+				t.Fatalf("expandFilterTree() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// the filters of the request are not changed by NOT
+	tree := &vearchpb.FilterNode{Operator: filterOperatorNot, Children: []*vearchpb.FilterNode{termNode(filterOperatorAnd, "a")}}
+	if _, err := expandFilterTree(tree, false); err != nil || tree.Children[0].TermFilters[0].IsUnion != filterUnionIn {
+		t.Fatalf("filter of the tree negated in place, err %v", err)
+	}
+
+	if _, err := expandFilterTree(&vearchpb.FilterNode{Operator: 3}, false); err == nil {
+		t.Fatal("unknown operator should fail")
+	}
+}
+
+func TestExpandFilterTreeLimit(t *testing.T) {
+	or := func(prefix string, n int) *vearchpb.FilterNode {
+		fields := make([]string, n)
+		for i := range fields {
+			fields[i] = fmt.Sprintf("%s%d", prefix, i)
+		}
+		return termNode(filterOperatorOr, fields...)
+	}
+
+	// (a0|..|a7) & (b0|..|b7) is 64 conjunctions
+	tree := &vearchpb.FilterNode{Operator: filterOperatorAnd, Children: []*vearchpb.FilterNode{or("a", 8), or("b", 8)}}
+	if conjs, err := expandFilterTree(tree, false); err != nil || len(conjs) != maxFilterConjunctions {
+		t.Fatalf("expandFilterTree() = %d conjunctions, err %v", len(conjs), err)
+	}
+
+	tests := []struct {
+		name string
+		tree *vearchpb.FilterNode
+	}{
+		{"and", &vearchpb.FilterNode{Operator: filterOperatorAnd, Children: []*vearchpb.FilterNode{or("a", 8), or("b", 8), or("c", 2)}}},
+		{"or", or("a", maxFilterConjunctions+1)},
+		{"or of children", &vearchpb.FilterNode{Operator: filterOperatorOr, Children: []*vearchpb.FilterNode{or("a", 40), or("b", 40)}}},
+		// NOT (a0&..&a64) = !a0|..|!a64
+		{"not", &vearchpb.FilterNode{Operator: filterOperatorNot, TermFilters: or("a", maxFilterConjunctions+1).TermFilters}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := expandFilterTree(tt.tree, false)
+			if vErr, ok := err.(*vearchpb.VearchErr); !ok || vErr.GetError().Code != vearchpb.ErrorEnum_PARAM_ERROR {
+				t.Fatalf("expandFilterTree() err = %v, want PARAM_ERROR", err)
+			}
+		})
+	}
+}
+
+func TestSortQueryItems(t *testing.T) {
+	item := func(id string, count int64) *vearchpb.ResultItem {
+		return &vearchpb.ResultItem{Fields: []*vearchpb.Field{
+			{Name: entity.IdField, Value: []byte(id)},
+			{Name: "count", Value: cbbytes.Int64ToByte(count)},
+		}}
+	}
+	ids := func(items []*vearchpb.ResultItem) string {
+		out := make([]string, 0, len(items))
+		for _, item := range items {
+			id, _ := resultItemID(item)
+			out = append(out, id)
+		}
+		return strings.Join(out, ",")
+	}
+	proMap := map[string]*entity.SpaceProperties{"count": {FieldType: vearchpb.FieldType_LONG}}
+
+	// two conjunctions merged, each in docid order
+	items := []*vearchpb.ResultItem{item("c", 2), item("a", 10), item("b", 1), item("a", 10)}
+	sortQueryItems(items, nil, proMap)
+	if got := ids(items); got != "a,a,b,c" {
+		t.Fatalf("sortQueryItems() = %s, want by _id", got)
+	}
+	if got := ids(dedupResultItems(items, 2)); got != "a,b" {
+		t.Fatalf("dedupResultItems() = %s", got)
+	}
+
+	items = []*vearchpb.ResultItem{item("c", 2), item("a", 10), item("b", 2), item("d", 1)}
+	sortQueryItems(items, []*vearchpb.SortField{{Field: "count", Type: true}}, proMap)
+	if got := ids(items); got != "a,b,c,d" {
+		t.Fatalf("sortQueryItems() = %s, want by count desc", got)
+	}
+	sortQueryItems(items, []*vearchpb.SortField{{Field: "count"}}, proMap)
+	if got := ids(items); got != "d,b,c,a" {
+		t.Fatalf("sortQueryItems() = %s, want by count asc", got)
+	}
+	sortQueryItems(items, []*vearchpb.SortField{{Field: entity.IdField, Type: true}}, proMap)
+	if got := ids(items); got != "d,c,b,a" {
+		t.Fatalf("sortQueryItems() = %s, want by _id desc", got)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ps
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// maxScrollScan limits how many documents one scroll request reads from the
+// engine, so a selective filter can't hold the partition for long.
+const maxScrollScan = 10000
+
+// docidField is added by the engine to documents read in docid order.
+const docidField = "_docid"
+
+// scroll reads the documents of store after request.Docid in docid order,
+// the documents not matching the filters are skipped.
+func scroll(ctx context.Context, store PartitionStore, req *vearchpb.ScrollRequest, resp *vearchpb.ScrollResponse) {
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Head.Err = &vearchpb.Error{Code: vearchpb.ErrorEnum_INTERNAL_ERROR, Msg: cast.ToString(r)}
+		}
+	}()
+	if req == nil || req.Size <= 0 {
+		resp.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("scroll size should be greater than 0")).GetError()
+		return
+	}
+
+	space := store.GetSpace()
+	if space.TTL != nil {
+		req.RangeFilters, req.TermFilters, req.Operator, req.FilterTree = liveFilters(space.TTL, time.Now(), req.RangeFilters, req.TermFilters, req.Operator, req.FilterTree)
+	}
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		var err error
+		if proMap, err = entity.UnmarshalPropertyJSON(space.Fields); err != nil {
+			resp.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+			return
+		}
+	}
+	matcher, err := newDocMatcher(req.RangeFilters, req.TermFilters, req.Operator, req.FilterTree, proMap)
+	if err != nil {
+		resp.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err).GetError()
+		return
+	}
+
+	fields := make(map[string]bool, len(req.Fields))
+	for _, name := range req.Fields {
+		fields[name] = true
+	}
+	readLeader := req.Head != nil && req.Head.ClientType == request.Leader
+
+	docid := req.Docid
+	for scanned := 0; scanned < maxScrollScan && len(resp.Documents) < int(req.Size); scanned++ {
+		doc, next, err := nextDocument(ctx, store, readLeader, docid)
+		if err != nil {
+			resp.Head.Err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError()
+			return
+		}
+		if doc == nil {
+			resp.Done = true
+			break
+		}
+		docid = next
+
+		if !matcher.match(doc) {
+			continue
+		}
+		out := &vearchpb.Document{PKey: doc.PKey, Fields: make([]*vearchpb.Field, 0, len(doc.Fields))}
+		for _, field := range doc.Fields {
+			if len(fields) > 0 && !fields[field.Name] && field.Name != entity.IdField {
+				continue
+			}
+			if pro := proMap[field.Name]; pro != nil && pro.FieldType == vearchpb.FieldType_VECTOR && !req.IsVectorValue {
+				continue
+			}
+			out.Fields = append(out.Fields, field)
+		}
+		resp.Documents = append(resp.Documents, out)
+	}
+	resp.Docid = docid
+}
+
+// nextDocument reads the first document of store after docid, the docid of
+// the document is returned as next. doc is nil at the end of the partition.
+func nextDocument(ctx context.Context, store PartitionStore, readLeader bool, docid int32) (doc *vearchpb.Document, next int32, err error) {
+	doc = &vearchpb.Document{PKey: strconv.Itoa(int(docid))}
+	if err := store.GetDocument(ctx, readLeader, doc, true, true); err != nil {
+		if vErr := vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err); vErr.GetError().Code == vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST {
+			return nil, docid, nil
+		}
+		return nil, docid, err
+	}
+
+	next = -1
+	fields := doc.Fields[:0]
+	for _, field := range doc.Fields {
+		switch field.Name {
+		case docidField:
+			next = cbbytes.Bytes2Int32(field.Value)
+			continue
+		case entity.IdField:
+			doc.PKey = string(field.Value)
+		}
+		fields = append(fields, field)
+	}
+	doc.Fields = fields
+	if next <= docid {
+		return nil, docid, fmt.Errorf("no docid returned after docid [%d]", docid)
+	}
+	return doc, next, nil
+}
+
+// docMatcher evaluates the range and term filters of a scroll on documents,
+// filters are in the same disjunctive normal form the engine is queried by.
+type docMatcher struct {
+	proMap map[string]*entity.SpaceProperties
+	conjs  []*filterConjunction
+}
+
+func newDocMatcher(rangeFilters []*vearchpb.RangeFilter, termFilters []*vearchpb.TermFilter, operator int32, filterTree *vearchpb.FilterNode, proMap map[string]*entity.SpaceProperties) (*docMatcher, error) {
+	m := &docMatcher{proMap: proMap}
+	if filterTree != nil {
+		conjs, err := expandFilterTree(filterTree, false)
+		if err != nil {
+			return nil, err
+		}
+		m.conjs = conjs
+	} else if len(rangeFilters) > 0 || len(termFilters) > 0 {
+		node := &vearchpb.FilterNode{Operator: operator, RangeFilters: rangeFilters, TermFilters: termFilters}
+		conjs, err := expandFilterTree(node, false)
+		if err != nil {
+			return nil, err
+		}
+		m.conjs = conjs
+	}
+	for _, conj := range m.conjs {
+		for _, rf := range conj.rangeFilters {
+			if pro := proMap[rf.Field]; pro == nil {
+				return nil, fmt.Errorf("range filter field [%s] not found in space", rf.Field)
+			}
+		}
+		for _, tf := range conj.termFilters {
+			if pro := proMap[tf.Field]; pro == nil {
+				return nil, fmt.Errorf("term filter field [%s] not found in space", tf.Field)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *docMatcher) match(doc *vearchpb.Document) bool {
+	if len(m.conjs) == 0 {
+		return true
+	}
+	values := make(map[string][]byte, len(doc.Fields))
+	for _, field := range doc.Fields {
+		values[field.Name] = field.Value
+	}
+	for _, conj := range m.conjs {
+		if m.matchConjunction(conj, values) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *docMatcher) matchConjunction(conj *filterConjunction, values map[string][]byte) bool {
+	for _, rf := range conj.rangeFilters {
+		in := inRange(m.proMap[rf.Field].FieldType, values[rf.Field], rf)
+		if in == (rf.IsUnion == filterUnionNotIn) {
+			return false
+		}
+	}
+	for _, tf := range conj.termFilters {
+		in := inTerms(values[tf.Field], tf.Value)
+		if in == (tf.IsUnion == filterUnionNotIn) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRange reports whether value is between the bounds of rf.
+func inRange(fieldType vearchpb.FieldType, value []byte, rf *vearchpb.RangeFilter) bool {
+	lower, ok := compareNumeric(fieldType, value, rf.LowerValue)
+	if !ok || lower < 0 || (lower == 0 && !rf.IncludeLower) {
+		return false
+	}
+	upper, ok := compareNumeric(fieldType, value, rf.UpperValue)
+	if !ok || upper > 0 || (upper == 0 && !rf.IncludeUpper) {
+		return false
+	}
+	return true
+}
+
+// compareNumeric compares the little endian numbers a and b of fieldType,
+// ok is false if they can't be decoded.
+func compareNumeric(fieldType vearchpb.FieldType, a, b []byte) (int, bool) {
+	switch fieldType {
+	case vearchpb.FieldType_INT, vearchpb.FieldType_LONG, vearchpb.FieldType_DATE:
+		x, ok := decodeInt(a)
+		if !ok {
+			return 0, false
+		}
+		y, ok := decodeInt(b)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	case vearchpb.FieldType_FLOAT, vearchpb.FieldType_DOUBLE:
+		if (len(a) != 4 && len(a) != 8) || (len(b) != 4 && len(b) != 8) {
+			return 0, false
+		}
+		x, y := cbbytes.ByteToFloat64(a), cbbytes.ByteToFloat64(b)
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func decodeInt(b []byte) (int64, bool) {
+	switch len(b) {
+	case 4:
+		return int64(cbbytes.Bytes2Int32(b)), true
+	case 8:
+		return cbbytes.Bytes2Int(b), true
+	}
+	return 0, false
+}
+
+// inTerms reports whether any of the \001 separated values of a string or
+// string array field is one of the \001 separated terms.
+func inTerms(value []byte, terms []byte) bool {
+	if value == nil {
+		return false
+	}
+	for _, v := range bytes.Split(value, []byte{'\001'}) {
+		for _, term := range bytes.Split(terms, []byte{'\001'}) {
+			if bytes.Equal(v, term) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,318 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ps
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
+)
+
+// termDelimiter separates the values of a term filter and of a string array
+const termDelimiter = "\001"
+
+func compareFieldValue(fieldType vearchpb.FieldType, value, other []byte) (int, bool) {
+	switch fieldType {
+	case vearchpb.FieldType_INT:
+		if len(value) < 4 || len(other) < 4 {
+			return 0, false
+		}
+		a, b := int32(binary.LittleEndian.Uint32(value)), int32(binary.LittleEndian.Uint32(other))
+		return compareOrdered(a, b), true
+	case vearchpb.FieldType_LONG, vearchpb.FieldType_DATE:
+		if len(value) < 8 || len(other) < 8 {
+			return 0, false
+		}
+		a, b := int64(binary.LittleEndian.Uint64(value)), int64(binary.LittleEndian.Uint64(other))
+		return compareOrdered(a, b), true
+	case vearchpb.FieldType_FLOAT:
+		if len(value) < 4 || len(other) < 4 {
+			return 0, false
+		}
+		a, b := math.Float32frombits(binary.LittleEndian.Uint32(value)), math.Float32frombits(binary.LittleEndian.Uint32(other))
+		return compareOrdered(a, b), true
+	case vearchpb.FieldType_DOUBLE:
+		if len(value) < 8 || len(other) < 8 {
+			return 0, false
+		}
+		a, b := math.Float64frombits(binary.LittleEndian.Uint64(value)), math.Float64frombits(binary.LittleEndian.Uint64(other))
+		return compareOrdered(a, b), true
+	}
+	return 0, false
+}
+
+func compareOrdered[T int32 | int64 | float32 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func matchRangeFilter(rf *vearchpb.RangeFilter, fields map[string]*vearchpb.Field) bool {
+	in := false
+	if field, ok := fields[rf.Field]; ok {
+		lower, okLower := compareFieldValue(field.Type, field.Value, rf.LowerValue)
+		upper, okUpper := compareFieldValue(field.Type, field.Value, rf.UpperValue)
+		in = okLower && okUpper &&
+			(lower > 0 || (lower == 0 && rf.IncludeLower)) &&
+			(upper < 0 || (upper == 0 && rf.IncludeUpper))
+	}
+	if rf.IsUnion == filterUnionNotIn {
+		return !in
+	}
+	return in
+}
+
+func matchTermFilter(tf *vearchpb.TermFilter, fields map[string]*vearchpb.Field) bool {
+	in := false
+	if field, ok := fields[tf.Field]; ok {
+		values := [][]byte{field.Value}
+		if field.Type == vearchpb.FieldType_STRINGARRAY {
+			values = bytes.Split(field.Value, []byte(termDelimiter))
+		}
+		for _, term := range bytes.Split(tf.Value, []byte(termDelimiter)) {
+			for _, value := range values {
+				if bytes.Equal(term, value) {
+					in = true
+				}
+			}
+		}
+	}
+	if tf.IsUnion == filterUnionNotIn {
+		return !in
+	}
+	return in
+}
+
+// searchConjunctions returns the filters of req as conjunctions of which
+// a document has to match one, nil if req has no filter.
+func searchConjunctions(req *vearchpb.SearchRequest) ([]*filterConjunction, error) {
+	if req.FilterTree != nil {
+		return expandFilterTree(req.FilterTree, false)
+	}
+	if len(req.RangeFilters) == 0 && len(req.TermFilters) == 0 {
+		return nil, nil
+	}
+	conj := &filterConjunction{rangeFilters: req.RangeFilters, termFilters: req.TermFilters}
+	if req.Operator == filterOperatorAnd {
+		return []*filterConjunction{conj}, nil
+	}
+	// a NOT IN filter of an OR matches documents out of its values, the same
+	// as it does alone
+	return filterAtoms(&vearchpb.FilterNode{RangeFilters: conj.rangeFilters, TermFilters: conj.termFilters}, false), nil
+}
+
+func matchConjunctions(conjs []*filterConjunction, fields []*vearchpb.Field) bool {
+	if conjs == nil {
+		return true
+	}
+	fieldMap := make(map[string]*vearchpb.Field, len(fields))
+	for _, field := range fields {
+		fieldMap[field.Name] = field
+	}
+	for _, conj := range conjs {
+		matched := true
+		for _, rf := range conj.rangeFilters {
+			if !matchRangeFilter(rf, fieldMap) {
+				matched = false
+				break
+			}
+		}
+		for _, tf := range conj.termFilters {
+			if !matched {
+				break
+			}
+			matched = matchTermFilter(tf, fieldMap)
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// storeFusedSearch searches store by each vector and text query of req on
+// its own when the engine can not rank the results of req. Items keep the
+// score of every retriever in Scores, the router fuses them into the score
+// of the item.
+func storeFusedSearch(ctx context.Context, store PartitionStore, req *vearchpb.SearchRequest, response *vearchpb.SearchResponse) error {
+	if request.EngineRanked(req) {
+		return storeSearch(ctx, store, req, response)
+	}
+
+	conjs, err := searchConjunctions(req)
+	if err != nil {
+		return err
+	}
+
+	restore, err := waitConsistencyOnce(ctx, store, req.Head)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	result := &vearchpb.SearchResult{Status: &vearchpb.SearchStatus{}}
+	items := make(map[string]*vearchpb.ResultItem)
+	addItem := func(id string, item *vearchpb.ResultItem) {
+		items[id] = item
+		result.ResultItems = append(result.ResultItems, item)
+		if item.Score > result.MaxScore {
+			result.MaxScore = item.Score
+		}
+	}
+
+	vecFields, ranker, textQueries := req.VecFields, req.Ranker, req.TextQueries
+	for i, vecField := range vecFields {
+		req.VecFields, req.Ranker, req.TextQueries = []*vearchpb.VectorQuery{vecField}, "", nil
+		err := storeSearch(ctx, store, req, response)
+		req.VecFields, req.Ranker, req.TextQueries = vecFields, ranker, textQueries
+		if err != nil {
+			return err
+		}
+
+		results := response.Results
+		if response.FlatBytes != nil {
+			sr := &vearchpb.SearchResponse{}
+			gamma.DeSerialize(response.FlatBytes, sr)
+			results = sr.Results
+		}
+		if len(results) == 0 || results[0] == nil {
+			continue
+		}
+		retriever := request.VectorRetriever(i)
+		for _, item := range results[0].ResultItems {
+			id, ok := resultItemID(item)
+			if !ok {
+				continue
+			}
+			if exist, ok := items[id]; ok {
+				exist.Scores[retriever] = item.Score
+				continue
+			}
+			item.Scores = map[string]float64{retriever: item.Score}
+			addItem(id, item)
+		}
+	}
+
+	fieldSet := make(map[string]bool, len(req.Fields))
+	for _, field := range req.Fields {
+		fieldSet[field] = true
+	}
+	readLeader := req.Head.ClientType == "leader"
+	for i, textQuery := range req.TextQueries {
+		hits, docs, err := filteredTextHits(ctx, store, readLeader, textQuery, int(req.TopN), conjs, items)
+		if err != nil {
+			return err
+		}
+		retriever := request.TextRetriever(i)
+		for _, hit := range hits {
+			if item, ok := items[hit.Key]; ok {
+				item.Scores[retriever] = hit.Score
+				continue
+			}
+
+			item := &vearchpb.ResultItem{
+				Score:  hit.Score,
+				Fields: make([]*vearchpb.Field, 0, len(req.Fields)),
+				Scores: map[string]float64{retriever: hit.Score},
+			}
+			for _, field := range docs[hit.Key].Fields {
+				if fieldSet[field.Name] {
+					item.Fields = append(item.Fields, field)
+				}
+			}
+			if _, ok := resultItemID(item); !ok {
+				item.Fields = append(item.Fields, &vearchpb.Field{Name: entity.IdField, Type: vearchpb.FieldType_STRING, Value: []byte(hit.Key)})
+			}
+			addItem(hit.Key, item)
+		}
+	}
+
+	result.TotalHits = int32(len(result.ResultItems))
+	result.Status.Total = int32(len(result.ResultItems))
+	result.Status.Successful = int32(len(result.ResultItems))
+
+	response.FlatBytes = nil
+	response.Results = []*vearchpb.SearchResult{result}
+	return nil
+}
+
+// filteredTextHits returns the topN hits of query which match conjs, with
+// the documents of the ones not in items. The hits are searched again with
+// twice the topN until enough of them match or no more are left, the
+// documents of each round are got at once.
+func filteredTextHits(ctx context.Context, store PartitionStore, readLeader bool, query *vearchpb.TextQuery, topN int, conjs []*filterConjunction, items map[string]*vearchpb.ResultItem) ([]fulltext.Hit, map[string]*vearchpb.Document, error) {
+	var matched []fulltext.Hit
+	docs := make(map[string]*vearchpb.Document)
+	seen := make(map[string]bool)
+	for want := topN; ; want *= 2 {
+		hits, err := store.TextSearch(ctx, readLeader, query, want)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		candidates := make([]fulltext.Hit, 0, len(hits))
+		loads := make([]*vearchpb.Document, 0, len(hits))
+		for _, hit := range hits {
+			if seen[hit.Key] {
+				continue
+			}
+			seen[hit.Key] = true
+			candidates = append(candidates, hit)
+			if _, ok := items[hit.Key]; !ok {
+				loads = append(loads, &vearchpb.Document{PKey: hit.Key})
+			}
+		}
+		if err := store.GetDocuments(ctx, readLeader, loads); err != nil {
+			return nil, nil, err
+		}
+		loaded := make(map[string]*vearchpb.Document, len(loads))
+		for _, doc := range loads {
+			loaded[doc.PKey] = doc
+		}
+
+		for _, hit := range candidates {
+			// items of the vector queries are filtered by the engine
+			if _, ok := items[hit.Key]; !ok {
+				doc := loaded[hit.Key]
+				// deleted after the text search
+				if doc.Fields == nil || !matchConjunctions(conjs, doc.Fields) {
+					continue
+				}
+				docs[hit.Key] = doc
+			}
+			matched = append(matched, hit)
+		}
+
+		// a topN of 0 searches all the hits at once
+		if len(matched) >= topN || len(hits) < want {
+			break
+		}
+	}
+	if topN > 0 && len(matched) > topN {
+		matched = matched[:topN]
+	}
+	return matched, docs, nil
+}
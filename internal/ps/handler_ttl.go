@@ -0,0 +1,69 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ps
+
+import (
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// liveFilters returns the filters of a read which also exclude the documents
+// expired at now, so they are hidden before the expiry job deletes them.
+func liveFilters(ttl *entity.SpaceTTL, now time.Time, rangeFilters []*vearchpb.RangeFilter, termFilters []*vearchpb.TermFilter, operator int32, tree *vearchpb.FilterNode) ([]*vearchpb.RangeFilter, []*vearchpb.TermFilter, int32, *vearchpb.FilterNode) {
+	live := ttl.ExpiredFilter(now, true)
+	if tree != nil {
+		tree = &vearchpb.FilterNode{
+			Operator:     filterOperatorAnd,
+			RangeFilters: []*vearchpb.RangeFilter{live},
+			Children:     []*vearchpb.FilterNode{tree},
+		}
+		return rangeFilters, termFilters, operator, tree
+	}
+	if len(rangeFilters) == 0 && len(termFilters) == 0 {
+		return []*vearchpb.RangeFilter{live}, nil, filterOperatorAnd, nil
+	}
+	if operator == filterOperatorAnd {
+		filters := make([]*vearchpb.RangeFilter, 0, len(rangeFilters)+1)
+		filters = append(append(filters, rangeFilters...), live)
+		return filters, termFilters, operator, nil
+	}
+	// the filters of an OR become a child of the AND with the live filter
+	tree = &vearchpb.FilterNode{
+		Operator:     filterOperatorAnd,
+		RangeFilters: []*vearchpb.RangeFilter{live},
+		Children: []*vearchpb.FilterNode{{
+			Operator:     operator,
+			RangeFilters: rangeFilters,
+			TermFilters:  termFilters,
+		}},
+	}
+	return nil, nil, filterOperatorAnd, tree
+}
+
+// documentExpired reports whether doc of a space with ttl is expired at now.
+func documentExpired(ttl *entity.SpaceTTL, doc *vearchpb.Document, now time.Time) bool {
+	if ttl == nil || doc == nil {
+		return false
+	}
+	name := ttl.ExpiryField()
+	for _, field := range doc.Fields {
+		if field != nil && field.Name == name {
+			return ttl.Expired(field.Value, now)
+		}
+	}
+	return false
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cubefs/cubefs/depends/tiglabs/raft"
 	"github.com/cubefs/cubefs/depends/tiglabs/raft/proto"
@@ -26,6 +27,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/pkg/runtime/os"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"github.com/vearch/vearch/v3/internal/ps/engine"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
 	"github.com/vearch/vearch/v3/internal/ps/storage/raftstore"
 )
 
@@ -76,13 +78,32 @@ type PartitionStore interface {
 
 	GetDocument(ctx context.Context, readLeader bool, doc *vearchpb.Document, getByDocId bool, next bool) (err error)
 
-	Write(ctx context.Context, request *vearchpb.DocCmd) (err error)
+	// GetDocuments gets the documents of the primary keys of docs at once,
+	// the fields of a document not existing are left nil
+	GetDocuments(ctx context.Context, readLeader bool, docs []*vearchpb.Document) error
+
+	Write(ctx context.Context, request *vearchpb.DocCmd) (index uint64, err error)
+
+	// WaitConsistency waits until the store can serve a read of the
+	// consistency in head
+	WaitConsistency(ctx context.Context, head *vearchpb.RequestHead) error
+
+	WriteBatchStats() *entity.WriteBatchStats
+
+	// ExpiryStats returns the documents deleted by the ttl of the space
+	ExpiryStats() *entity.ExpiryStats
+
+	// Changes returns up to limit changes after the raft index, it waits for
+	// changes if there are none
+	Changes(ctx context.Context, after uint64, limit int, wait time.Duration) ([]*vearchpb.ChangeEvent, error)
 
 	Flush(ctx context.Context) error
 
 	Search(ctx context.Context, query *vearchpb.SearchRequest, response *vearchpb.SearchResponse) error
 
 	Query(ctx context.Context, query *vearchpb.QueryRequest, response *vearchpb.SearchResponse) error
+
+	TextSearch(ctx context.Context, readLeader bool, query *vearchpb.TextQuery, topN int) ([]fulltext.Hit, error)
 }
 
 func (s *Server) GetPartition(id entity.PartitionID) (partition PartitionStore) {
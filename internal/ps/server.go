@@ -63,6 +63,8 @@ type Server struct {
 	concurrentNum   int
 	rpcTimeOut      int
 	backupStatus    map[uint32]int
+	// backupProgress is the last backup command of each partition
+	backupProgress sync.Map
 }
 
 // NewServer creates a server instance
@@ -110,6 +112,9 @@ func (s *Server) Start() error {
 	}()
 
 	var err error
+	if err = raftstore.CheckTransportTLS(); err != nil {
+		return err
+	}
 
 	s.stopping = false // set start flag for all jobs; if false, all jobs will end
 
@@ -340,3 +345,22 @@ func (s *Server) changeReplicas(pStatus *raftstore.ReplicasStatusEntry) {
 func (s *Server) HandleRaftFatalEvent(event *raftstore.RaftFatalEvent) {
 	log.Error("error in raft: [%s]", event.Cause.Error())
 }
+
+// finishBackup records the outcome of the backup command of a partition.
+func (s *Server) finishBackup(pid entity.PartitionID, progress *entity.BackupProgress, err error) {
+	done := *progress
+	done.Status = entity.TaskSucceeded
+	if err != nil {
+		done.Status, done.Error = entity.TaskFailed, err.Error()
+	}
+	s.backupProgress.Store(pid, &done)
+}
+
+// getBackupProgress returns the last backup command of a partition, nil if
+// it has none since the server started.
+func (s *Server) getBackupProgress(pid entity.PartitionID) *entity.BackupProgress {
+	if v, ok := s.backupProgress.Load(pid); ok {
+		return v.(*entity.BackupProgress)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"fmt"
+
+	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// The first byte of a raft entry is its format. Entries written before the
+// format byte are json, which always begin with '{'.
+const (
+	raftCommandJSON  byte = '{'
+	raftCommandProto byte = 1
+)
+
+// encodeRaftCommand encodes cmd as json, or as protobuf after the format
+// byte if protobuf. PS of older versions only decode json, so protobuf is
+// enabled by raft_protobuf_command once every PS of the cluster is upgraded.
+func encodeRaftCommand(cmd *vearchpb.RaftCommand, protobuf bool) ([]byte, error) {
+	if !protobuf {
+		return json.Marshal(cmd)
+	}
+	data := make([]byte, 1, 1+proto.Size(cmd))
+	data[0] = raftCommandProto
+	return proto.MarshalOptions{}.MarshalAppend(data, cmd)
+}
+
+// decodeRaftCommand decodes an entry of either format, so old logs and
+// snapshots still replay.
+func decodeRaftCommand(data []byte, cmd *vearchpb.RaftCommand) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty raft command")
+	}
+	switch data[0] {
+	case raftCommandProto:
+		return proto.Unmarshal(data[1:], cmd)
+	case raftCommandJSON:
+		return json.Unmarshal(data, cmd)
+	}
+	return fmt.Errorf("unknown raft command format %d", data[0])
+}
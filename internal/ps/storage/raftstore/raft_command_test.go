@@ -0,0 +1,126 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	json "github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// bulkCommand is a bulk write of num documents with a vector of dimension.
+func bulkCommand(num, dimension int) *vearchpb.RaftCommand {
+	r := rand.New(rand.NewSource(1))
+	docs := make([][]byte, num)
+	for i := range docs {
+		// a vector of float32 and a few scalar fields
+		docs[i] = make([]byte, dimension*4+64)
+		r.Read(docs[i])
+	}
+	return &vearchpb.RaftCommand{
+		Type:         vearchpb.CmdType_WRITE,
+		WriteCommand: &vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: docs},
+	}
+}
+
+func TestRaftCommandCodec(t *testing.T) {
+	cmd := bulkCommand(3, 8)
+
+	data, err := encodeRaftCommand(cmd, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data[0] != raftCommandProto {
+		t.Fatalf("format = %d", data[0])
+	}
+	decoded := &vearchpb.RaftCommand{}
+	if err := decodeRaftCommand(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Type != vearchpb.CmdType_WRITE || len(decoded.WriteCommand.Docs) != 3 || !bytes.Equal(decoded.WriteCommand.Docs[2], cmd.WriteCommand.Docs[2]) {
+		t.Fatalf("decoded = %v", decoded)
+	}
+
+	// entries of old logs and of PS not writing protobuf yet
+	legacy, err := encodeRaftCommand(cmd, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expect, _ := json.Marshal(cmd); !bytes.Equal(legacy, expect) {
+		t.Fatal("json entries should be the same as older versions")
+	}
+	decoded = &vearchpb.RaftCommand{}
+	if err := decodeRaftCommand(legacy, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.WriteCommand.Type != vearchpb.OpType_BULK || !bytes.Equal(decoded.WriteCommand.Docs[0], cmd.WriteCommand.Docs[0]) {
+		t.Fatalf("decoded = %v", decoded)
+	}
+
+	flush, err := encodeRaftCommand(&vearchpb.RaftCommand{Type: vearchpb.CmdType_FLUSH}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded = &vearchpb.RaftCommand{}
+	if err := decodeRaftCommand(flush, decoded); err != nil || decoded.Type != vearchpb.CmdType_FLUSH {
+		t.Fatalf("decoded = %v, err = %v", decoded, err)
+	}
+
+	for _, data := range [][]byte{nil, {2, 0}} {
+		if err := decodeRaftCommand(data, &vearchpb.RaftCommand{}); err == nil {
+			t.Fatalf("decode %v should fail", data)
+		}
+	}
+}
+
+// BenchmarkRaftCommand compares the json and protobuf entries of a bulk
+// write of 100 documents with 128 dimension vectors.
+func BenchmarkRaftCommand(b *testing.B) {
+	cmd := bulkCommand(100, 128)
+	codecs := []struct {
+		name   string
+		encode func(*vearchpb.RaftCommand) ([]byte, error)
+	}{
+		{"json", func(cmd *vearchpb.RaftCommand) ([]byte, error) { return encodeRaftCommand(cmd, false) }},
+		{"proto", func(cmd *vearchpb.RaftCommand) ([]byte, error) { return encodeRaftCommand(cmd, true) }},
+	}
+	for _, codec := range codecs {
+		data, err := codec.encode(cmd)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(codec.name+"/encode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(len(data)), "bytes/entry")
+			for i := 0; i < b.N; i++ {
+				if _, err := codec.encode(cmd); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(codec.name+"/decode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				if err := decodeRaftCommand(data, &vearchpb.RaftCommand{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -92,7 +92,7 @@ func (s *Store) ReplicasStatusChange() bool {
 func (s *Store) Apply(command []byte, index uint64) (resp any, err error) {
 	raftCmd := &vearchpb.RaftCommand{}
 
-	if err = json.Unmarshal(command, raftCmd); err != nil {
+	if err = decodeRaftCommand(command, raftCmd); err != nil {
 		log.Error(err)
 		return nil, err
 	}
@@ -125,7 +125,14 @@ func (s *Store) innerApply(index uint64, raftCmd *vearchpb.RaftCommand) any {
 	resp := new(RaftApplyResponse)
 	switch raftCmd.Type {
 	case vearchpb.CmdType_WRITE:
-		resp.Err = s.Engine.Writer().Write(s.Ctx, raftCmd.WriteCommand)
+		cmd := raftCmd.WriteCommand
+		var updated [][]byte
+		if cmd.Type == vearchpb.OpType_UPDATE {
+			updated, resp.Err = s.Engine.Writer().Update(s.Ctx, cmd)
+		} else {
+			resp.Err = s.Engine.Writer().Write(s.Ctx, cmd)
+		}
+		s.changes.append(index, cmd, updated, resp.Err)
 	case vearchpb.CmdType_UPDATESPACE:
 		resp = s.updateSchemaBySpace(raftCmd.UpdateSpace.Space)
 	case vearchpb.CmdType_FLUSH:
@@ -139,6 +146,7 @@ func (s *Store) innerApply(index uint64, raftCmd *vearchpb.RaftCommand) any {
 
 	// set current index to store
 	s.Sn = int64(index)
+	resp.Index = index
 
 	return resp
 }
@@ -28,6 +28,19 @@ import (
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 )
 
+// CheckTransportTLS fails if the ps has tls but raft_plaintext is not set,
+// the raft transports listen and dial plain tcp and can't use tls.
+func CheckTransportTLS() error {
+	if config.Conf().TLS == nil || config.Conf().TLS.PS == nil {
+		return nil
+	}
+	if !config.Conf().PS.RaftPlaintext {
+		return fmt.Errorf("raft heartbeat and replicate ports don't support tls, set raft_plaintext of ps to run them in plaintext on a trusted network")
+	}
+	log.Warn("raft heartbeat and replicate ports are plaintext, they should be on a trusted network")
+	return nil
+}
+
 func StartRaftServer(nodeId entity.NodeID, ip string, resolver raft.SocketResolver) (*raft.RaftServer, error) {
 	rc := raft.DefaultConfig()
 	rc.NodeID = uint64(nodeId)
@@ -64,6 +64,9 @@ type Store struct {
 	raftDiffCount uint64
 	RsStatusC     chan *ReplicasStatusEntry
 	RsStatusMap   sync.Map
+	writeBatcher  *writeBatcher
+	changes       *changeLog
+	expiry        expiryStats
 }
 
 // CreateStore create an instance of Store.
@@ -86,12 +89,16 @@ func CreateStore(ctx context.Context, pID entity.PartitionID, nodeID entity.Node
 		EventListener: eventListener,
 		Client:        client,
 		RsStatusMap:   sync.Map{},
+		changes:       newChangeLog(config.Conf().PS.ChangeLogSize),
 	}
 	if config.Conf().PS.RaftDiffCount > 0 {
 		s.raftDiffCount = config.Conf().PS.RaftDiffCount
 	} else {
 		s.raftDiffCount = 10000
 	}
+	if window := config.Conf().PS.WriteBatchWindow; window > 0 {
+		s.writeBatcher = newWriteBatcher(time.Duration(window)*time.Microsecond, config.Conf().PS.WriteBatchMaxDocs, config.Conf().PS.WriteBatchMaxBytes, s.submitWrite)
+	}
 	return s, nil
 }
 
@@ -112,6 +119,7 @@ func (s *Store) ReBuildEngine() (err error) {
 		s.Engine.Close()
 		return err
 	}
+	s.changes.reset(uint64(apply))
 	// sn - 1
 	s.LastFlushSn = apply - 1
 	s.LastFlushTime = time.Now()
@@ -138,6 +146,7 @@ func (s *Store) Start() (err error) {
 	}
 	s.LastFlushSn = apply
 	s.LastFlushTime = time.Now()
+	s.changes.reset(uint64(apply))
 
 	s.Partition.SetStatus(entity.PA_READONLY)
 
@@ -183,6 +192,8 @@ func (s *Store) Start() (err error) {
 	s.startFlushJob()
 	// Start Raft Truncate Worker
 	s.startTruncateJob(apply)
+	// Start Expire Worker of ttl
+	s.startExpireJob()
 
 	return nil
 }
@@ -0,0 +1,226 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"google.golang.org/protobuf/proto"
+)
+
+// defaultChangeLogSize is the changes retained of a partition if
+// change_log_size is not set.
+const defaultChangeLogSize = 10000
+
+// change is a document written by an applied raft entry, a serialized
+// gamma doc for upserts and updates, it's decoded once it's read.
+type change struct {
+	index     uint64
+	timestamp int64
+	op        vearchpb.OpType
+	doc       []byte
+}
+
+// changeLog retains the latest changes applied to a partition in memory.
+// Changes are kept on every replica, so the indexes are the same whichever
+// replica is read.
+type changeLog struct {
+	mu      sync.Mutex
+	size    int
+	changes []change
+	// truncated is the index of the latest change dropped, positions
+	// before it can't be resumed
+	truncated uint64
+	// appended is closed once changes are appended
+	appended chan struct{}
+}
+
+func newChangeLog(size int) *changeLog {
+	if size < 0 {
+		return nil
+	}
+	if size == 0 {
+		size = defaultChangeLogSize
+	}
+	return &changeLog{size: size, appended: make(chan struct{})}
+}
+
+// reset drops all changes, for the engine is loaded at the index.
+func (l *changeLog) reset(index uint64) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.changes = nil
+	l.truncated = index
+}
+
+// append adds the docs written by the command at index, docs failed to
+// write are skipped by the codes of err. updated is the docs written by an
+// update, with the values after the update.
+func (l *changeLog) append(index uint64, cmd *vearchpb.DocCmd, updated [][]byte, err error) {
+	if l == nil {
+		return
+	}
+	timestamp := cmd.Timestamp
+	if timestamp == 0 {
+		// entries written before the timestamp
+		timestamp = time.Now().UnixMilli()
+	}
+
+	var added []change
+	switch cmd.Type {
+	case vearchpb.OpType_DELETE:
+		if err == nil {
+			added = append(added, change{index: index, timestamp: timestamp, op: cmd.Type, doc: cmd.Doc})
+		}
+	case vearchpb.OpType_BULK, vearchpb.OpType_UPDATE, vearchpb.OpType_DELETE_EXPIRED:
+		vErr, ok := err.(*vearchpb.VearchErr)
+		if !ok || vErr.GetError().Code != vearchpb.ErrorEnum_SUCCESS {
+			return
+		}
+		// the message is success:code,code,..., of the docs in order
+		msg := strings.TrimPrefix(vErr.GetError().Msg, vearchpb.ErrMsg(vearchpb.ErrorEnum_SUCCESS)+":")
+		codes := strings.Split(strings.TrimSuffix(msg, ","), ",")
+		op, docs := cmd.Type, cmd.Docs
+		switch cmd.Type {
+		case vearchpb.OpType_UPDATE:
+			docs = updated
+		case vearchpb.OpType_DELETE_EXPIRED:
+			op, docs = vearchpb.OpType_DELETE, expiredKeys(cmd.Docs)
+		}
+		for i, doc := range docs {
+			if i < len(codes) && codes[i] == "0" && doc != nil {
+				added = append(added, change{index: index, timestamp: timestamp, op: op, doc: doc})
+			}
+		}
+	}
+	if len(added) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.changes = append(l.changes, added...)
+	if drop := len(l.changes) - l.size; drop > 0 {
+		l.truncated = l.changes[drop-1].index
+		l.changes = l.changes[drop:]
+	}
+	close(l.appended)
+	l.appended = make(chan struct{})
+}
+
+// read returns up to limit changes after the index, the changes of the last
+// index are never split. appended is returned to wait for changes if there
+// expiredKeys returns the keys of the documents of a delete of expired
+// documents, nil for a document which fails to unmarshal.
+func expiredKeys(docs [][]byte) [][]byte {
+	keys := make([][]byte, len(docs))
+	for i, data := range docs {
+		doc := &vearchpb.Document{}
+		if proto.Unmarshal(data, doc) == nil {
+			keys[i] = []byte(doc.PKey)
+		}
+	}
+	return keys
+}
+
+// are none.
+func (l *changeLog) read(after uint64, limit int) (changes []change, appended <-chan struct{}, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if after < l.truncated && after != 0 {
+		return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("changes after %d are truncated, the oldest position is %d", after, l.truncated))
+	}
+	i := sort.Search(len(l.changes), func(i int) bool { return l.changes[i].index > after })
+	j := min(i+limit, len(l.changes))
+	for j > i && j < len(l.changes) && l.changes[j].index == l.changes[j-1].index {
+		j++
+	}
+	if i == j {
+		return nil, l.appended, nil
+	}
+	return append([]change(nil), l.changes[i:j]...), nil, nil
+}
+
+// Changes returns up to limit changes of the partition after the index, it
+// waits for changes if there are none.
+func (s *Store) Changes(ctx context.Context, after uint64, limit int, wait time.Duration) ([]*vearchpb.ChangeEvent, error) {
+	if s.changes == nil {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_METHOD_NOT_IMPLEMENT, fmt.Errorf("changes of partition %d are disabled by change_log_size", s.Partition.Id))
+	}
+	if err := s.checkReadable(false); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	for {
+		changes, appended, err := s.changes.read(after, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(changes) > 0 {
+			return s.decodeChanges(changes)
+		}
+		select {
+		case <-appended:
+		case <-timer.C:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, nil
+		}
+	}
+}
+
+func (s *Store) decodeChanges(changes []change) ([]*vearchpb.ChangeEvent, error) {
+	events := make([]*vearchpb.ChangeEvent, len(changes))
+	for i, c := range changes {
+		event := &vearchpb.ChangeEvent{
+			PartitionId: s.Partition.Id,
+			Index:       c.index,
+			Timestamp:   c.timestamp,
+		}
+		switch c.op {
+		case vearchpb.OpType_BULK, vearchpb.OpType_UPDATE:
+			event.Op = vearchpb.ChangeEvent_UPSERT
+			if c.op == vearchpb.OpType_UPDATE {
+				event.Op = vearchpb.ChangeEvent_UPDATE
+			}
+			doc := &gamma.Doc{}
+			doc.DeSerialize(c.doc)
+			for _, field := range doc.Fields {
+				if field.Name == entity.IdField {
+					event.Key = string(field.Value)
+				} else {
+					event.Fields = append(event.Fields, field)
+				}
+			}
+		case vearchpb.OpType_DELETE:
+			event.Op = vearchpb.ChangeEvent_DELETE
+			event.Key = string(c.doc)
+		}
+		events[i] = event
+	}
+	return events, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"google.golang.org/protobuf/proto"
+)
+
+func bulkCmd(docs ...string) *vearchpb.DocCmd {
+	cmd := &vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Timestamp: 1}
+	for _, doc := range docs {
+		cmd.Docs = append(cmd.Docs, []byte(doc))
+	}
+	return cmd
+}
+
+func bulkErr(codes string) error {
+	return vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New(codes))
+}
+
+func TestChangeLogAppend(t *testing.T) {
+	l := newChangeLog(10)
+	l.append(1, bulkCmd("a", "b", "c"), nil, bulkErr("0,1,0,"))
+	l.append(2, &vearchpb.DocCmd{Type: vearchpb.OpType_DELETE, Doc: []byte("a")}, nil, nil)
+	// failed writes add no changes
+	l.append(3, &vearchpb.DocCmd{Type: vearchpb.OpType_DELETE, Doc: []byte("b")}, nil, vearchpb.NewError(vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST, nil))
+	l.append(4, bulkCmd("d"), nil, vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, nil))
+
+	changes, appended, err := l.read(0, 100)
+	if err != nil || appended != nil {
+		t.Fatalf("read: %v", err)
+	}
+	want := []struct {
+		index uint64
+		doc   string
+	}{{1, "a"}, {1, "c"}, {2, "a"}}
+	if len(changes) != len(want) {
+		t.Fatalf("got %d changes, want %d", len(changes), len(want))
+	}
+	for i, w := range want {
+		if changes[i].index != w.index || string(changes[i].doc) != w.doc {
+			t.Errorf("change %d is %d %s, want %d %s", i, changes[i].index, changes[i].doc, w.index, w.doc)
+		}
+	}
+
+	changes, _, _ = l.read(1, 100)
+	if len(changes) != 1 || changes[0].op != vearchpb.OpType_DELETE {
+		t.Errorf("changes after 1 are %v", changes)
+	}
+
+	// an update adds the docs after the update, not the update ops
+	update := &vearchpb.DocCmd{Type: vearchpb.OpType_UPDATE, Docs: [][]byte{[]byte("+1"), []byte("+2"), []byte("+3")}}
+	l.append(5, update, [][]byte{[]byte("a=2"), nil, []byte("c=3")}, bulkErr("0,3,0,"))
+	changes, _, _ = l.read(2, 100)
+	if len(changes) != 2 || string(changes[0].doc) != "a=2" || string(changes[1].doc) != "c=3" || changes[0].op != vearchpb.OpType_UPDATE {
+		t.Errorf("changes of the update are %v", changes)
+	}
+
+	// a delete of expired documents adds deletes of the keys deleted
+	del := &vearchpb.DocCmd{Type: vearchpb.OpType_DELETE_EXPIRED}
+	for _, key := range []string{"a", "b", "c"} {
+		data, _ := proto.Marshal(&vearchpb.Document{PKey: key, Fields: []*vearchpb.Field{{Name: "_expire_at"}}})
+		del.Docs = append(del.Docs, data)
+	}
+	l.append(6, del, nil, bulkErr("0,260,0,"))
+	changes, _, _ = l.read(5, 100)
+	if len(changes) != 2 || string(changes[0].doc) != "a" || string(changes[1].doc) != "c" || changes[0].op != vearchpb.OpType_DELETE {
+		t.Errorf("changes of the delete are %v", changes)
+	}
+}
+
+func TestChangeLogRead(t *testing.T) {
+	l := newChangeLog(4)
+	l.append(1, bulkCmd("a", "b"), nil, bulkErr("0,0,"))
+	l.append(2, bulkCmd("c", "d"), nil, bulkErr("0,0,"))
+
+	// the changes of an index are never split by the limit
+	changes, _, _ := l.read(0, 1)
+	if len(changes) != 2 || changes[1].index != 1 {
+		t.Fatalf("read limit 1 got %v", changes)
+	}
+
+	_, appended, err := l.read(2, 10)
+	if err != nil || appended == nil {
+		t.Fatalf("read at the end should wait, err %v", err)
+	}
+	l.append(3, bulkCmd("e"), nil, bulkErr("0,"))
+	select {
+	case <-appended:
+	default:
+		t.Fatal("append doesn't wake up readers")
+	}
+
+	// the changes of index 1 are dropped, reads after it lose nothing
+	l.append(4, bulkCmd("f"), nil, bulkErr("0,"))
+	if _, _, err := l.read(0, 10); err != nil {
+		t.Errorf("read from the oldest: %v", err)
+	}
+	if _, _, err := l.read(1, 10); err != nil {
+		t.Errorf("read after the truncated: %v", err)
+	}
+	l.append(5, bulkCmd("g"), nil, bulkErr("0,"))
+	if _, _, err := l.read(1, 10); err == nil {
+		t.Error("read before the truncated should fail")
+	}
+
+	l.reset(10)
+	if _, _, err := l.read(4, 10); err == nil {
+		t.Error("read before the reset should fail")
+	}
+	if _, appended, err := l.read(10, 10); err != nil || appended == nil {
+		t.Errorf("read after the reset: %v", err)
+	}
+}
+
+func TestChangeLogDisabled(t *testing.T) {
+	l := newChangeLog(-1)
+	if l != nil {
+		t.Fatal("negative size should disable the change log")
+	}
+	l.append(1, bulkCmd("a"), nil, bulkErr("0,"))
+	l.reset(1)
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// defaultMaxStaleness is the max entries a replica of bounded_staleness
+// lags behind the commit if max_staleness is not set.
+const defaultMaxStaleness = 1000
+
+// WaitConsistency waits until this store can serve a read of the
+// consistency in head.
+func (s *Store) WaitConsistency(ctx context.Context, head *vearchpb.RequestHead) error {
+	switch head.GetConsistency() {
+	case "", request.Eventual:
+		return nil
+	case request.BoundedStaleness:
+		if leader, _ := s.GetLeader(); leader == 0 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_NO_LEADER, nil)
+		}
+		maxStaleness := head.MaxStaleness
+		if maxStaleness == 0 {
+			maxStaleness = defaultMaxStaleness
+		}
+		committed := s.RaftServer.CommittedIndex(uint64(s.Partition.Id))
+		return s.waitApplied(ctx, committed-min(maxStaleness, committed))
+	case request.ReadYourWrites:
+		return s.waitApplied(ctx, head.ReadIndex[uint32(s.Partition.Id)])
+	case request.Linearizable:
+		if !s.IsLeader() {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_NOT_LEADER, nil)
+		}
+		// the future responds after the read index is applied
+		respCh, errCh := s.RaftServer.ReadIndex(uint64(s.Partition.Id)).AsyncResponse()
+		select {
+		case <-respCh:
+			return nil
+		case err := <-errCh:
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_NOT_LEADER, err)
+		case <-ctx.Done():
+			return vearchpb.NewError(vearchpb.ErrorEnum_TIMEOUT, fmt.Errorf("partition %d wait read index: %v", s.Partition.Id, ctx.Err()))
+		}
+	}
+	return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("unknown consistency [%s]", head.GetConsistency()))
+}
+
+// waitApplied waits until the raft index is applied to this store.
+func (s *Store) waitApplied(ctx context.Context, index uint64) error {
+	backoff := time.Millisecond
+	for s.RaftServer.AppliedIndex(uint64(s.Partition.Id)) < index {
+		select {
+		case <-ctx.Done():
+			return vearchpb.NewError(vearchpb.ErrorEnum_TIMEOUT, fmt.Errorf("partition %d wait applied index %d: %v", s.Partition.Id, index, ctx.Err()))
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 50*time.Millisecond)
+	}
+	return nil
+}
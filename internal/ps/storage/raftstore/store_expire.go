@@ -0,0 +1,240 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/engine/sdk/go/gamma"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultTTLCheckInterval is the seconds between checks of expired
+	// documents if ttl_check_interval is not set
+	DefaultTTLCheckInterval = 60
+	// expireBatchSize is the expired documents queried at a time
+	expireBatchSize = 1000
+	// maxExpireBatches limits the batches deleted by one check, the rest
+	// are left to the next
+	maxExpireBatches = 100
+)
+
+// expiryStats counts the documents deleted by the expiry job.
+type expiryStats struct {
+	expired   atomic.Uint64
+	failed    atomic.Uint64
+	lastCheck atomic.Int64
+}
+
+// startExpireJob deletes the expired documents of a space with ttl. It only
+// runs on the leader and deletes through raft, so the replicas stay the same.
+func (s *Store) startExpireJob() {
+	interval := config.Conf().PS.TTLCheckInterval
+	if interval < 0 {
+		log.Info("expire job of partition[%d] is disabled", s.Partition.Id)
+		return
+	}
+	if interval == 0 {
+		interval = DefaultTTLCheckInterval
+	}
+	go func() {
+		defer func() {
+			if i := recover(); i != nil {
+				log.Error(string(debug.Stack()))
+				log.Error(cast.ToString(i))
+			}
+		}()
+
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.Ctx.Done():
+				return
+			case <-ticker.C:
+				ttl := s.GetSpace().TTL
+				if ttl == nil || !s.IsLeader() {
+					continue
+				}
+				if expired := s.expire(ttl, time.Now()); expired > 0 {
+					log.Info("partition[%d] deleted %d expired documents", s.Partition.Id, expired)
+				}
+			}
+		}
+	}()
+}
+
+// expire deletes the documents expired at now batch by batch, it returns
+// how many are deleted.
+func (s *Store) expire(ttl *entity.SpaceTTL, now time.Time) uint64 {
+	s.expiry.lastCheck.Store(now.Unix())
+	var total uint64
+	for range maxExpireBatches {
+		if s.Ctx.Err() != nil || !s.IsLeader() {
+			break
+		}
+		docs, err := s.expiredDocs(ttl, now)
+		if err != nil {
+			log.Error("query expired documents of partition[%d] failed, err: [%s]", s.Partition.Id, err.Error())
+			break
+		}
+		if len(docs) == 0 {
+			break
+		}
+		deleted := s.deleteExpired(docs)
+		total += deleted
+		// the documents which fail to delete are found again by the next query
+		if deleted == 0 || len(docs) < expireBatchSize {
+			break
+		}
+	}
+	return total
+}
+
+// expiredDocs returns a batch of the documents expired at now, each with
+// its id and expire field only.
+func (s *Store) expiredDocs(ttl *entity.SpaceTTL, now time.Time) ([]*vearchpb.Document, error) {
+	request := &vearchpb.QueryRequest{
+		Head:         &vearchpb.RequestHead{},
+		RangeFilters: []*vearchpb.RangeFilter{ttl.ExpiredFilter(now, false)},
+		Fields:       []string{entity.IdField, ttl.ExpiryField()},
+		Limit:        expireBatchSize,
+	}
+	response := &vearchpb.SearchResponse{}
+	if err := s.Engine.Reader().Query(s.Ctx, request, response); err != nil {
+		return nil, err
+	}
+	if response.FlatBytes != nil {
+		gamma.DeSerialize(response.FlatBytes, response)
+	}
+	docs := make([]*vearchpb.Document, 0, expireBatchSize)
+	for _, result := range response.Results {
+		if result == nil {
+			continue
+		}
+		for _, item := range result.ResultItems {
+			doc := &vearchpb.Document{}
+			for _, field := range item.Fields {
+				switch field.Name {
+				case entity.IdField:
+					doc.PKey = string(field.Value)
+				case ttl.ExpiryField():
+					doc.Fields = []*vearchpb.Field{field}
+				}
+			}
+			if doc.PKey != "" && len(doc.Fields) > 0 {
+				docs = append(docs, doc)
+			}
+		}
+	}
+	return docs, nil
+}
+
+// deleteExpired deletes the expired docs by one raft entry, a document is
+// kept if its expire field has changed when the entry is applied. It returns
+// how many are deleted.
+func (s *Store) deleteExpired(docs []*vearchpb.Document) uint64 {
+	if !config.Conf().PS.RaftProtobufCommand {
+		return s.deleteExpiredByKey(docs)
+	}
+
+	entries := make([][]byte, len(docs))
+	for i, doc := range docs {
+		data, err := proto.Marshal(doc)
+		if err != nil {
+			log.Error("marshal expired document [%s] of partition[%d] failed, err: [%s]", doc.PKey, s.Partition.Id, err.Error())
+			s.expiry.failed.Add(uint64(len(docs)))
+			return 0
+		}
+		entries[i] = data
+	}
+	_, err := s.Write(s.Ctx, &vearchpb.DocCmd{Type: vearchpb.OpType_DELETE_EXPIRED, Docs: entries})
+	vErr, ok := err.(*vearchpb.VearchErr)
+	if !ok || vErr.GetError().Code != vearchpb.ErrorEnum_SUCCESS {
+		s.expiry.failed.Add(uint64(len(docs)))
+		log.Error("delete %d expired documents of partition[%d] failed, err: [%v]", len(docs), s.Partition.Id, err)
+		return 0
+	}
+
+	// the message is success:code,code,..., of the docs in order
+	msg := strings.TrimPrefix(vErr.GetError().Msg, vearchpb.ErrMsg(vearchpb.ErrorEnum_SUCCESS)+":")
+	codes := strings.Split(strings.TrimSuffix(msg, ","), ",")
+	var deleted, failed uint64
+	for i, doc := range docs {
+		code := strconv.Itoa(int(vearchpb.ErrorEnum_INTERNAL_ERROR))
+		if i < len(codes) {
+			code = codes[i]
+		}
+		switch code {
+		case strconv.Itoa(int(vearchpb.ErrorEnum_SUCCESS)):
+			deleted++
+		case strconv.Itoa(int(vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST)):
+		default:
+			failed++
+			log.Error("delete expired document [%s] of partition[%d] failed, code: [%s]", doc.PKey, s.Partition.Id, code)
+		}
+	}
+	s.expiry.expired.Add(deleted)
+	s.expiry.failed.Add(failed)
+	return deleted
+}
+
+// deleteExpiredByKey deletes the expired docs one raft entry each, for the
+// clusters which may have ps older than OpType_DELETE_EXPIRED, the ones
+// without raft_protobuf_command. The delete is not conditional, a document
+// written again between the query and the delete is lost.
+func (s *Store) deleteExpiredByKey(docs []*vearchpb.Document) uint64 {
+	var deleted, failed uint64
+	for _, doc := range docs {
+		if s.Ctx.Err() != nil || !s.IsLeader() {
+			break
+		}
+		_, err := s.Write(s.Ctx, &vearchpb.DocCmd{Type: vearchpb.OpType_DELETE, Doc: []byte(doc.PKey)})
+		switch {
+		case err == nil:
+			deleted++
+		case vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError().Code == vearchpb.ErrorEnum_DOCUMENT_NOT_EXIST:
+		default:
+			failed++
+			log.Error("delete expired document [%s] of partition[%d] failed, err: [%v]", doc.PKey, s.Partition.Id, err)
+		}
+	}
+	s.expiry.expired.Add(deleted)
+	s.expiry.failed.Add(failed)
+	return deleted
+}
+
+// ExpiryStats returns the documents deleted by the expiry job, nil if the
+// space has no ttl.
+func (s *Store) ExpiryStats() *entity.ExpiryStats {
+	if s.GetSpace().TTL == nil {
+		return nil
+	}
+	return &entity.ExpiryStats{
+		Expired:   s.expiry.expired.Load(),
+		Failed:    s.expiry.failed.Load(),
+		LastCheck: s.expiry.lastCheck.Load(),
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"github.com/vearch/vearch/v3/internal/ps/engine/fulltext"
 )
 
 func (s *Store) GetDocument(ctx context.Context, readLeader bool, doc *vearchpb.Document, getByDocId bool, next bool) (err error) {
@@ -29,6 +30,13 @@ func (s *Store) GetDocument(ctx context.Context, readLeader bool, doc *vearchpb.
 	return s.Engine.Reader().GetDoc(ctx, doc, getByDocId, next)
 }
 
+func (s *Store) GetDocuments(ctx context.Context, readLeader bool, docs []*vearchpb.Document) error {
+	if err := s.checkReadable(readLeader); err != nil {
+		return err
+	}
+	return s.Engine.Reader().GetDocs(ctx, docs)
+}
+
 // check this store can read
 func (s *Store) checkReadable(readLeader bool) error {
 	status := s.Partition.GetStatus()
@@ -57,6 +65,9 @@ func (s *Store) Search(ctx context.Context, request *vearchpb.SearchRequest, res
 	if err = s.checkReadable(leader); err != nil {
 		return err
 	}
+	if err = s.WaitConsistency(ctx, request.Head); err != nil {
+		return err
+	}
 	err = s.Engine.Reader().Search(ctx, request, response)
 	return err
 }
@@ -69,6 +80,16 @@ func (s *Store) Query(ctx context.Context, request *vearchpb.QueryRequest, respo
 	if err = s.checkReadable(leader); err != nil {
 		return err
 	}
+	if err = s.WaitConsistency(ctx, request.Head); err != nil {
+		return err
+	}
 	err = s.Engine.Reader().Query(ctx, request, response)
 	return err
 }
+
+func (s *Store) TextSearch(ctx context.Context, readLeader bool, query *vearchpb.TextQuery, topN int) ([]fulltext.Hit, error) {
+	if err := s.checkReadable(readLeader); err != nil {
+		return nil, err
+	}
+	return s.Engine.Reader().TextSearch(ctx, query, topN)
+}
@@ -17,7 +17,9 @@ package raftstore
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	vearch_os "github.com/vearch/vearch/v3/internal/pkg/runtime/os"
@@ -28,6 +30,8 @@ import (
 type RaftApplyResponse struct {
 	FlushC chan error
 	Err    error
+	// Index the raft index of the applied command
+	Index uint64
 }
 
 func (r *RaftApplyResponse) SetErr(err error) *RaftApplyResponse {
@@ -54,7 +58,7 @@ func (s *Store) UpdateSpace(ctx context.Context, space *entity.Space) error {
 		},
 	}
 
-	data, err := json.Marshal(raftCmd)
+	data, err := encodeRaftCommand(raftCmd, config.Conf().PS.RaftProtobufCommand)
 
 	if err != nil {
 		return err
@@ -74,56 +78,68 @@ func (s *Store) UpdateSpace(ctx context.Context, space *entity.Space) error {
 	return nil
 }
 
-func (s *Store) Write(ctx context.Context, request *vearchpb.DocCmd) (err error) {
+// Write submits the write to raft, it returns the raft index of the write
+// for reads of read_your_writes.
+func (s *Store) Write(ctx context.Context, request *vearchpb.DocCmd) (index uint64, err error) {
 	if err = s.checkWritable(); err != nil {
-		return err
+		return 0, err
 	}
 
 	if request.Type == vearchpb.OpType_BULK {
 		if s.Partition.ResourceExhausted {
 			err = vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_RESOURCE_EXHAUSTED, fmt.Errorf("partition %d resource exhausted", s.Partition.Id))
-			return err
+			return 0, err
 		}
 		s.Partition.AddNum += int64(len(request.Docs))
 		if s.Partition.AddNum >= 50000 {
 			s.Partition.AddNum = 0
 			s.Partition.ResourceExhausted, err = vearch_os.CheckResource(s.RaftPath)
 			if s.Partition.ResourceExhausted {
-				return err
+				return 0, err
 			}
 		}
 	}
 
+	if request.Type == vearchpb.OpType_BULK && s.writeBatcher != nil {
+		return s.writeBatcher.write(request)
+	}
+	return s.submitWrite(request)
+}
+
+func (s *Store) submitWrite(request *vearchpb.DocCmd) (index uint64, err error) {
+	request.Timestamp = time.Now().UnixMilli()
 	raftCmd := &vearchpb.RaftCommand{
 		Type:         vearchpb.CmdType_WRITE,
 		WriteCommand: request,
 	}
 
-	data, err := json.Marshal(raftCmd)
+	data, err := encodeRaftCommand(raftCmd, config.Conf().PS.RaftProtobufCommand)
 	if err != nil {
-		return err
+		return 0, err
 	}
 
 	// sumbit raft
-	err = s.RaftSubmit(data)
-	if err != nil {
-		return err
-	}
+	return s.RaftSubmit(data)
+}
 
-	return nil
+// WriteBatchStats returns the batching of bulk writes, nil if disabled.
+func (s *Store) WriteBatchStats() *entity.WriteBatchStats {
+	if s.writeBatcher == nil {
+		return nil
+	}
+	return s.writeBatcher.stats()
 }
 
 // raft submit do
-func (s *Store) RaftSubmit(data []byte) (err error) {
+func (s *Store) RaftSubmit(data []byte) (index uint64, err error) {
 	future := s.RaftServer.Submit(uint64(s.Partition.Id), data)
 	resp, err := future.Response()
 	if err != nil {
-		return err
-	}
-	if resp.(*RaftApplyResponse).Err != nil {
-		return resp.(*RaftApplyResponse).Err
+		return 0, err
 	}
-	return nil
+	// the error of bulk and update carries the code of each doc even if
+	// success, so the index is returned along with it
+	return resp.(*RaftApplyResponse).Index, resp.(*RaftApplyResponse).Err
 }
 
 func (s *Store) Flush(ctx context.Context) error {
@@ -139,7 +155,7 @@ func (s *Store) Flush(ctx context.Context) error {
 	raftCmd := &vearchpb.RaftCommand{
 		Type: vearchpb.CmdType_FLUSH,
 	}
-	data, err := json.Marshal(raftCmd)
+	data, err := encodeRaftCommand(raftCmd, config.Conf().PS.RaftProtobufCommand)
 	if err != nil {
 		return err
 	}
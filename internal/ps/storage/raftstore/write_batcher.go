@@ -0,0 +1,177 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"go.uber.org/atomic"
+)
+
+const (
+	defaultWriteBatchMaxDocs  = 1000
+	defaultWriteBatchMaxBytes = 8 << 20
+)
+
+// writeBatch is the bulk writes merged into one raft entry, the first
+// write of a batch submits it after the window or once it's full. Only
+// writes of the same version and slot are merged.
+type writeBatch struct {
+	version int64
+	slot    uint32
+
+	docs  [][]byte
+	bytes int
+	// writes is the number of bulk writes merged
+	writes int
+	full   chan struct{}
+	done   chan struct{}
+
+	index uint64
+	err   error
+	// codes is the code of each doc if the batch is applied
+	codes []string
+}
+
+// writeBatcher merges concurrent bulk writes of a partition into a single
+// raft entry, so small writes don't serialize on raft round trips.
+type writeBatcher struct {
+	window   time.Duration
+	maxDocs  int
+	maxBytes int
+	submit   func(cmd *vearchpb.DocCmd) (uint64, error)
+
+	mu      sync.Mutex
+	pending *writeBatch
+
+	batches     atomic.Uint64
+	writes      atomic.Uint64
+	docs        atomic.Uint64
+	fullBatches atomic.Uint64
+	waitMicros  atomic.Uint64
+}
+
+func newWriteBatcher(window time.Duration, maxDocs, maxBytes int, submit func(cmd *vearchpb.DocCmd) (uint64, error)) *writeBatcher {
+	if maxDocs <= 0 {
+		maxDocs = defaultWriteBatchMaxDocs
+	}
+	if maxBytes <= 0 {
+		maxBytes = defaultWriteBatchMaxBytes
+	}
+	return &writeBatcher{window: window, maxDocs: maxDocs, maxBytes: maxBytes, submit: submit}
+}
+
+// write adds the docs of a bulk write to the pending batch and returns the
+// raft index of the batch and the result of these docs, the same as the
+// write was submitted alone.
+func (b *writeBatcher) write(cmd *vearchpb.DocCmd) (uint64, error) {
+	start := time.Now()
+	size := 0
+	for _, doc := range cmd.Docs {
+		size += len(doc)
+	}
+
+	b.mu.Lock()
+	batch := b.pending
+	if batch != nil && (batch.version != cmd.Version || batch.slot != cmd.Slot ||
+		len(batch.docs)+len(cmd.Docs) > b.maxDocs || batch.bytes+size > b.maxBytes) {
+		// the pending batch is submitted now and this write starts a new one
+		close(batch.full)
+		b.pending, batch = nil, nil
+	}
+	first := batch == nil
+	if first {
+		batch = &writeBatch{version: cmd.Version, slot: cmd.Slot, full: make(chan struct{}), done: make(chan struct{})}
+		b.pending = batch
+	}
+	offset := len(batch.docs)
+	batch.docs = append(batch.docs, cmd.Docs...)
+	batch.bytes += size
+	batch.writes++
+	if len(batch.docs) >= b.maxDocs || batch.bytes >= b.maxBytes {
+		close(batch.full)
+		b.pending = nil
+	}
+	b.mu.Unlock()
+
+	if first {
+		b.commit(batch)
+	} else {
+		<-batch.done
+	}
+	b.waitMicros.Add(uint64(time.Since(start).Microseconds()))
+
+	if batch.codes == nil {
+		return batch.index, batch.err
+	}
+	codes := strings.Join(batch.codes[offset:offset+len(cmd.Docs)], ",") + ","
+	return batch.index, vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New(codes))
+}
+
+// commit waits for the window and submits the batch.
+func (b *writeBatcher) commit(batch *writeBatch) {
+	timer := time.NewTimer(b.window)
+	select {
+	case <-timer.C:
+	case <-batch.full:
+		timer.Stop()
+		b.fullBatches.Inc()
+	}
+	b.mu.Lock()
+	if b.pending == batch {
+		b.pending = nil
+	}
+	b.mu.Unlock()
+
+	b.batches.Inc()
+	b.writes.Add(uint64(batch.writes))
+	b.docs.Add(uint64(len(batch.docs)))
+
+	batch.index, batch.err = b.submit(&vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Version: batch.version, Slot: batch.slot, Docs: batch.docs})
+	if vErr, ok := batch.err.(*vearchpb.VearchErr); ok && vErr.GetError().Code == vearchpb.ErrorEnum_SUCCESS {
+		// the message is success:code,code,..., of the docs in order
+		msg := strings.TrimPrefix(vErr.GetError().Msg, vearchpb.ErrMsg(vearchpb.ErrorEnum_SUCCESS)+":")
+		codes := strings.Split(strings.TrimSuffix(msg, ","), ",")
+		if len(codes) == len(batch.docs) {
+			batch.codes = codes
+		} else {
+			// the codes can't be split among the writes
+			batch.err = vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, fmt.Errorf("bulk of %d docs returned %d codes", len(batch.docs), len(codes)))
+		}
+	}
+	close(batch.done)
+}
+
+func (b *writeBatcher) stats() *entity.WriteBatchStats {
+	stats := &entity.WriteBatchStats{
+		Batches:     b.batches.Load(),
+		Writes:      b.writes.Load(),
+		Docs:        b.docs.Load(),
+		FullBatches: b.fullBatches.Load(),
+	}
+	if stats.Batches > 0 {
+		stats.AvgWritesPerBatch = float64(stats.Writes) / float64(stats.Batches)
+	}
+	if stats.Writes > 0 {
+		stats.AvgWaitMicros = float64(b.waitMicros.Load()) / float64(stats.Writes)
+	}
+	return stats
+}
@@ -0,0 +1,183 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package raftstore
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// applyBulk answers a bulk write like the engine, the code of a doc is its
+// content.
+func applyBulk(submitted *[][][]byte, mu *sync.Mutex) func(cmd *vearchpb.DocCmd) (uint64, error) {
+	return func(cmd *vearchpb.DocCmd) (uint64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		*submitted = append(*submitted, cmd.Docs)
+		var codes strings.Builder
+		for _, doc := range cmd.Docs {
+			codes.WriteString(string(doc) + ",")
+		}
+		return uint64(len(*submitted)), vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New(codes.String()))
+	}
+}
+
+func TestWriteBatcher(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		submitted [][][]byte
+	)
+	b := newWriteBatcher(50*time.Millisecond, 0, 0, applyBulk(&submitted, &mu))
+
+	wg := sync.WaitGroup{}
+	results := make([]string, 10)
+	indexes := make([]uint64, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cmd := &vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: [][]byte{[]byte(strconv.Itoa(i)), []byte(strconv.Itoa(i + 100))}}
+			index, err := b.write(cmd)
+			indexes[i] = index
+			results[i] = err.Error()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(submitted) != 1 || len(submitted[0]) != 20 {
+		t.Fatalf("submitted = %d batches", len(submitted))
+	}
+	for i, result := range results {
+		// each write gets the codes of its own docs
+		if want := "success:" + strconv.Itoa(i) + "," + strconv.Itoa(i+100) + ","; result != want || indexes[i] != 1 {
+			t.Fatalf("result of write %d = %s, index %d", i, result, indexes[i])
+		}
+	}
+	stats := b.stats()
+	if stats.Batches != 1 || stats.Writes != 10 || stats.Docs != 20 || stats.AvgWritesPerBatch != 10 {
+		t.Fatalf("stats = %+v", stats)
+	}
+}
+
+func TestWriteBatcherCap(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		submitted [][][]byte
+	)
+	// the window is long enough that batches are only submitted by the cap
+	b := newWriteBatcher(time.Hour, 4, 0, applyBulk(&submitted, &mu))
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := b.write(&vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: [][]byte{[]byte(strconv.Itoa(i))}}); err == nil {
+				t.Error("bulk should return the codes")
+			}
+		}(i)
+	}
+	wg.Wait()
+	if len(submitted) != 2 || b.stats().FullBatches != 2 {
+		t.Fatalf("submitted = %d batches, stats = %+v", len(submitted), b.stats())
+	}
+
+	// a write larger than the cap is submitted alone
+	if _, err := b.write(&vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: make([][]byte, 5)}); err == nil {
+		t.Fatal("bulk should return the codes")
+	}
+	if len(submitted) != 3 || len(submitted[2]) != 5 {
+		t.Fatalf("submitted = %d batches", len(submitted))
+	}
+
+	// errors of the batch are returned to every write
+	b = newWriteBatcher(time.Millisecond, 0, 0, func(cmd *vearchpb.DocCmd) (uint64, error) {
+		return 0, vearchpb.NewError(vearchpb.ErrorEnum_PARTITION_NOT_LEADER, nil)
+	})
+	if _, err := b.write(&vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: [][]byte{[]byte("0")}}); vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err).GetError().Code != vearchpb.ErrorEnum_PARTITION_NOT_LEADER {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestWriteBatcherVersionSlot(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		cmds []*vearchpb.DocCmd
+	)
+	b := newWriteBatcher(50*time.Millisecond, 0, 0, func(cmd *vearchpb.DocCmd) (uint64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		cmds = append(cmds, cmd)
+		return uint64(len(cmds)), vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New(strings.Repeat("0,", len(cmd.Docs))))
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// writes of another version or slot are not merged
+			cmd := &vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Version: int64(i % 2), Slot: 7, Docs: [][]byte{[]byte(strconv.Itoa(i))}}
+			if i == 3 {
+				cmd.Slot = 8
+			}
+			b.write(cmd)
+		}(i)
+	}
+	wg.Wait()
+
+	docs := 0
+	for _, cmd := range cmds {
+		for _, doc := range cmd.Docs {
+			i, _ := strconv.Atoi(string(doc))
+			slot := uint32(7)
+			if i == 3 {
+				slot = 8
+			}
+			if cmd.Version != int64(i%2) || cmd.Slot != slot {
+				t.Fatalf("doc %d submitted with version %d slot %d", i, cmd.Version, cmd.Slot)
+			}
+			docs++
+		}
+	}
+	if len(cmds) < 3 || docs != 4 {
+		t.Fatalf("submitted %d batches of %d docs", len(cmds), docs)
+	}
+}
+
+func TestWriteBatcherCodesMismatch(t *testing.T) {
+	b := newWriteBatcher(50*time.Millisecond, 0, 0, func(cmd *vearchpb.DocCmd) (uint64, error) {
+		return 1, vearchpb.NewError(vearchpb.ErrorEnum_SUCCESS, errors.New("0,0,0,"))
+	})
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := b.write(&vearchpb.DocCmd{Type: vearchpb.OpType_BULK, Docs: [][]byte{[]byte("0")}})
+			if vErr, ok := err.(*vearchpb.VearchErr); !ok || vErr.GetError().Code != vearchpb.ErrorEnum_INTERNAL_ERROR {
+				t.Errorf("err = %v, want INTERNAL_ERROR", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
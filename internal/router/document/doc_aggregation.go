@@ -0,0 +1,282 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+const (
+	// maxAggregations is how many aggregations a request can ask for, every
+	// aggregation reads all matching documents of each partition.
+	maxAggregations = 16
+	// maxAggregationRanges is how many buckets a range aggregation can have.
+	maxAggregationRanges = 100
+	// maxTermsSize is the largest number of buckets of a terms aggregation.
+	maxTermsSize     = 1000
+	defaultTermsSize = 10
+)
+
+var aggregationTypes = map[string]vearchpb.Aggregation_Type{
+	"terms":     vearchpb.Aggregation_TERMS,
+	"range":     vearchpb.Aggregation_RANGE,
+	"histogram": vearchpb.Aggregation_HISTOGRAM,
+	"stats":     vearchpb.Aggregation_STATS,
+}
+
+// parseAggregations converts the aggregations of a search or query, they are
+// ordered by name so every partition returns them in the same order.
+func parseAggregations(aggs map[string]*request.Aggregation, space *entity.Space) ([]*vearchpb.Aggregation, error) {
+	if len(aggs) == 0 {
+		return nil, nil
+	}
+	if len(aggs) > maxAggregations {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("at most %d aggregations can be asked for", maxAggregations))
+	}
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		proMap, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+
+	names := make([]string, 0, len(aggs))
+	for name := range aggs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pbAggs := make([]*vearchpb.Aggregation, 0, len(aggs))
+	for _, name := range names {
+		pbAgg, err := parseAggregation(name, aggs[name], proMap)
+		if err != nil {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+		}
+		pbAggs = append(pbAggs, pbAgg)
+	}
+	return pbAggs, nil
+}
+
+func parseAggregation(name string, agg *request.Aggregation, proMap map[string]*entity.SpaceProperties) (*vearchpb.Aggregation, error) {
+	if name == "" {
+		return nil, fmt.Errorf("aggregation name should not be empty")
+	}
+	if agg == nil {
+		return nil, fmt.Errorf("aggregation [%s] is empty", name)
+	}
+	aggType, ok := aggregationTypes[strings.ToLower(agg.Type)]
+	if !ok {
+		return nil, fmt.Errorf("aggregation [%s] type [%s] should be one of terms, range, histogram and stats", name, agg.Type)
+	}
+	pro := proMap[agg.Field]
+	if pro == nil {
+		return nil, fmt.Errorf("aggregation [%s] field [%s] is not exist in the space", name, agg.Field)
+	}
+	pbAgg := &vearchpb.Aggregation{Name: name, Type: aggType, Field: agg.Field}
+
+	if aggType == vearchpb.Aggregation_TERMS {
+		if pro.FieldType != vearchpb.FieldType_STRING && pro.FieldType != vearchpb.FieldType_STRINGARRAY {
+			return nil, fmt.Errorf("terms aggregation [%s] field [%s] should be string or stringArray", name, agg.Field)
+		}
+		pbAgg.Size = agg.Size
+		if pbAgg.Size == 0 {
+			pbAgg.Size = defaultTermsSize
+		}
+		if pbAgg.Size < 0 || pbAgg.Size > maxTermsSize {
+			return nil, fmt.Errorf("terms aggregation [%s] size [%d] should be between 1 and %d", name, agg.Size, maxTermsSize)
+		}
+		return pbAgg, nil
+	}
+
+	switch pro.FieldType {
+	case vearchpb.FieldType_INT, vearchpb.FieldType_LONG, vearchpb.FieldType_FLOAT,
+		vearchpb.FieldType_DOUBLE, vearchpb.FieldType_DATE:
+	default:
+		return nil, fmt.Errorf("%s aggregation [%s] field [%s] should be numeric or date", strings.ToLower(agg.Type), name, agg.Field)
+	}
+	isDate := pro.FieldType == vearchpb.FieldType_DATE
+
+	switch aggType {
+	case vearchpb.Aggregation_RANGE:
+		if len(agg.Ranges) == 0 || len(agg.Ranges) > maxAggregationRanges {
+			return nil, fmt.Errorf("range aggregation [%s] should have 1 to %d ranges", name, maxAggregationRanges)
+		}
+		keys := make(map[string]bool, len(agg.Ranges))
+		for _, r := range agg.Ranges {
+			from, err := parseAggregationValue(r.From, isDate, math.Inf(-1))
+			if err != nil {
+				return nil, fmt.Errorf("range aggregation [%s] from: %v", name, err)
+			}
+			to, err := parseAggregationValue(r.To, isDate, math.Inf(1))
+			if err != nil {
+				return nil, fmt.Errorf("range aggregation [%s] to: %v", name, err)
+			}
+			if from >= to {
+				return nil, fmt.Errorf("range aggregation [%s] from should be less than to", name)
+			}
+			key := r.Key
+			if key == "" {
+				key = rangeValueKey(r.From) + "-" + rangeValueKey(r.To)
+			}
+			if keys[key] {
+				return nil, fmt.Errorf("range aggregation [%s] has duplicate range key [%s]", name, key)
+			}
+			keys[key] = true
+			pbAgg.Ranges = append(pbAgg.Ranges, &vearchpb.AggregationRange{Key: key, From: from, To: to})
+		}
+	case vearchpb.Aggregation_HISTOGRAM:
+		interval, err := parseAggregationInterval(agg.Interval, isDate)
+		if err != nil {
+			return nil, fmt.Errorf("histogram aggregation [%s] interval: %v", name, err)
+		}
+		pbAgg.Interval = interval
+	}
+	return pbAgg, nil
+}
+
+// parseAggregationValue parses a bound of a range, dates are seconds or date
+// strings and are returned in nanoseconds. An omitted bound is unbounded.
+func parseAggregationValue(data json.RawMessage, isDate bool, unbounded float64) (float64, error) {
+	if len(data) == 0 || string(data) == "null" {
+		return unbounded, nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err == nil {
+		if isDate {
+			v *= 1e9
+		}
+		return v, nil
+	}
+	var s string
+	if !isDate || json.Unmarshal(data, &s) != nil {
+		return 0, fmt.Errorf("invalid value %s", string(data))
+	}
+	t, err := cast.ToTimeE(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date %s: %v", string(data), err)
+	}
+	return float64(t.UnixNano()), nil
+}
+
+// parseAggregationInterval parses the interval of a histogram, for dates it
+// is seconds or a duration such as "24h" and is returned in nanoseconds.
+func parseAggregationInterval(data json.RawMessage, isDate bool) (float64, error) {
+	var interval float64
+	if err := json.Unmarshal(data, &interval); err == nil {
+		if isDate {
+			interval *= 1e9
+		}
+	} else {
+		var s string
+		if !isDate || json.Unmarshal(data, &s) != nil {
+			return 0, fmt.Errorf("invalid interval %s", string(data))
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid interval %s: %v", string(data), err)
+		}
+		interval = float64(d)
+	}
+	if interval <= 0 {
+		return 0, fmt.Errorf("interval should be greater than 0")
+	}
+	return interval, nil
+}
+
+func rangeValueKey(data json.RawMessage) string {
+	if len(data) == 0 || string(data) == "null" {
+		return "*"
+	}
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	return string(data)
+}
+
+// aggregationValue returns a number of a numeric field, or the time of a
+// date field.
+func aggregationValue(v float64, isDate bool) any {
+	if isDate {
+		return time.Unix(0, int64(v))
+	}
+	return v
+}
+
+// documentAggregationsResponse formats the merged aggregations by name.
+func documentAggregationsResponse(results []*vearchpb.AggregationResult, aggs map[string]*request.Aggregation, space *entity.Space) map[string]any {
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		proMap, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+
+	response := make(map[string]any, len(results))
+	for _, result := range results {
+		agg := aggs[result.Name]
+		if agg == nil {
+			continue
+		}
+		isDate := false
+		if pro := proMap[agg.Field]; pro != nil {
+			isDate = pro.FieldType == vearchpb.FieldType_DATE
+		}
+
+		aggType := aggregationTypes[strings.ToLower(agg.Type)]
+		if aggType == vearchpb.Aggregation_STATS {
+			stats := map[string]any{"count": result.Count}
+			if !isDate {
+				stats["sum"] = result.Sum
+			}
+			if result.Count > 0 {
+				stats["min"] = aggregationValue(result.Min, isDate)
+				stats["max"] = aggregationValue(result.Max, isDate)
+				stats["avg"] = aggregationValue(result.Sum/float64(result.Count), isDate)
+			}
+			response[result.Name] = stats
+			continue
+		}
+
+		buckets := make([]map[string]any, 0, len(result.Buckets))
+		for _, b := range result.Buckets {
+			bucket := map[string]any{"key": b.Key, "count": b.Count}
+			switch aggType {
+			case vearchpb.Aggregation_RANGE:
+				if !math.IsInf(b.From, 0) {
+					bucket["from"] = aggregationValue(b.From, isDate)
+				}
+				if !math.IsInf(b.To, 0) {
+					bucket["to"] = aggregationValue(b.To, isDate)
+				}
+			case vearchpb.Aggregation_HISTOGRAM:
+				bucket["key"] = aggregationValue(b.From, isDate)
+			}
+			buckets = append(buckets, bucket)
+		}
+		aggResponse := map[string]any{"buckets": buckets}
+		if aggType == vearchpb.Aggregation_TERMS {
+			aggResponse["other_count"] = result.OtherCount
+		}
+		response[result.Name] = aggResponse
+	}
+	return response
+}
@@ -0,0 +1,268 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/errors"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/entity/response"
+	"github.com/vearch/vearch/v3/internal/monitor"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+const (
+	// defaultChangesWaitMs is how long a stream waits for changes at a time
+	defaultChangesWaitMs = 10000
+	// changesDeadlineMargin is left before the deadline of a request, so
+	// the changes are returned before it times out
+	changesDeadlineMargin = time.Second
+)
+
+// changesWait returns how long a read of ctx can wait for changes, it's
+// clamped so the read returns before the deadline of ctx.
+func changesWait(ctx context.Context, waitMs int64) int64 {
+	if deadline, ok := ctx.Deadline(); ok {
+		left := time.Until(deadline) - changesDeadlineMargin
+		waitMs = min(waitMs, left.Milliseconds())
+	}
+	return max(waitMs, 0)
+}
+
+// changeEventSerialize returns the json of a change event, the fields are
+// in the types of the space.
+func changeEventSerialize(event *vearchpb.ChangeEvent, space *entity.Space) (map[string]any, error) {
+	fields := make(map[string]any)
+	if _, err := DocFieldSerialize(&vearchpb.Document{Fields: event.Fields}, space, nil, true, fields); err != nil {
+		return nil, err
+	}
+	result := map[string]any{
+		"partition_id": event.PartitionId,
+		"index":        event.Index,
+		"timestamp":    event.Timestamp,
+		"op":           strings.ToLower(event.Op.String()),
+		"_id":          event.Key,
+	}
+	if event.Op != vearchpb.ChangeEvent_DELETE {
+		result["fields"] = fields
+	}
+	return result, nil
+}
+
+// parseChangesRequest returns the changes request of the query of a stream.
+// The positions of the Last-Event-ID header of a reconnected stream win
+// over the query.
+func parseChangesRequest(c *gin.Context) (*request.ChangesRequest, error) {
+	req := &request.ChangesRequest{
+		DbName:    c.Query(URLParamDbName),
+		SpaceName: c.Query(URLParamSpaceName),
+		WaitMs:    defaultChangesWaitMs,
+	}
+	positions := c.Query("positions")
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		positions = lastEventID
+	}
+	var err error
+	if req.Positions, err = request.ParsePositions(positions); err != nil {
+		return nil, err
+	}
+	if limit := c.Query("limit"); limit != "" {
+		if req.Limit, err = cast.ToInt32E(limit); err != nil {
+			return nil, fmt.Errorf("limit %s should be an integer", limit)
+		}
+	}
+	if waitMs := c.Query("wait_ms"); waitMs != "" {
+		if req.WaitMs, err = cast.ToInt64E(waitMs); err != nil {
+			return nil, fmt.Errorf("wait_ms %s should be an integer", waitMs)
+		}
+	}
+	return req, req.Validate()
+}
+
+// handleDocumentChanges returns the changes of a space after the positions,
+// it waits up to wait_ms if there are none.
+func (handler *DocumentHandler) handleDocumentChanges(c *gin.Context) {
+	startTime := time.Now()
+	operateName := "handleDocumentChanges"
+	defer monitor.Profiler(operateName, startTime)
+	span, _ := opentracing.StartSpanFromContext(c.Request.Context(), operateName)
+	defer span.Finish()
+
+	head, err := setRequestHeadFromGin(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	req := &request.ChangesRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	if err := req.Validate(); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	head.DbName = req.DbName
+	head.SpaceName = req.SpaceName
+	ctx := c.Request.Context()
+	space, err := handler.docService.getSpace(ctx, head)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+
+	args := &vearchpb.ChangesRequest{Head: head, Positions: req.Positions, Limit: req.Limit, WaitMs: changesWait(ctx, req.WaitMs)}
+	reply := handler.docService.changes(ctx, args)
+	if err := changesError(reply); err != nil {
+		response.New(c).JsonError(changesHttpError(err))
+		return
+	}
+	events := make([]map[string]any, 0, len(reply.Events))
+	for _, event := range reply.Events {
+		result, err := changeEventSerialize(event, space)
+		if err != nil {
+			response.New(c).JsonError(errors.NewErrInternal(err))
+			return
+		}
+		events = append(events, result)
+	}
+	response.New(c).JsonSuccess(map[string]any{
+		"events":    events,
+		"positions": reply.Positions,
+	})
+}
+
+// handleDocumentChangesStream streams the changes of a space as server sent
+// events. The id of an event is the positions after it, a client resumes
+// from it by Last-Event-ID. The stream ends before the request times out,
+// and clients reconnect to go on.
+func (handler *DocumentHandler) handleDocumentChangesStream(c *gin.Context) {
+	startTime := time.Now()
+	operateName := "handleDocumentChangesStream"
+	defer monitor.Profiler(operateName, startTime)
+
+	head, err := setRequestHeadFromGin(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	req, err := parseChangesRequest(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	head.DbName = req.DbName
+	head.SpaceName = req.SpaceName
+	ctx := c.Request.Context()
+	space, err := handler.docService.getSpace(ctx, head)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+
+	positions := req.Positions
+	started := false
+	for {
+		waitMs := changesWait(ctx, req.WaitMs)
+		if started && waitMs == 0 {
+			return
+		}
+		args := &vearchpb.ChangesRequest{Head: head, Positions: positions, Limit: req.Limit, WaitMs: waitMs}
+		reply := handler.docService.changes(ctx, args)
+		if err := changesError(reply); err != nil {
+			if !started {
+				response.New(c).JsonError(changesHttpError(err))
+			} else {
+				log.Error("stream changes of %s/%s: %v", head.DbName, head.SpaceName, err)
+			}
+			return
+		}
+		if !started {
+			c.Header("Content-Type", "text/event-stream")
+			c.Header("Cache-Control", "no-cache")
+			c.Header("Connection", "keep-alive")
+			c.Status(http.StatusOK)
+			started = true
+		}
+		if err := writeChangeEvents(c, reply, space, positions); err != nil {
+			log.Error("stream changes of %s/%s: %v", head.DbName, head.SpaceName, err)
+			return
+		}
+		c.Writer.Flush()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// writeChangeEvents writes the events of reply and moves positions past
+// them. Only the last event of an index has an id, so a stream resumed by
+// it never splits the changes of an index.
+func writeChangeEvents(c *gin.Context, reply *vearchpb.ChangesResponse, space *entity.Space, positions map[uint32]uint64) error {
+	if len(reply.Events) == 0 {
+		for pid, position := range reply.Positions {
+			positions[pid] = position
+		}
+		_, err := fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		return err
+	}
+	for i, event := range reply.Events {
+		result, err := changeEventSerialize(event, space)
+		if err != nil {
+			return err
+		}
+		data, err := vjson.Marshal(result)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(c.Writer, "event: change\ndata: %s\n", data); err != nil {
+			return err
+		}
+		if i+1 < len(reply.Events) {
+			next := reply.Events[i+1]
+			if next.PartitionId == event.PartitionId && next.Index == event.Index {
+				if _, err := fmt.Fprint(c.Writer, "\n"); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		positions[event.PartitionId] = event.Index
+		if _, err := fmt.Fprintf(c.Writer, "id: %s\n\n", request.FormatPositions(positions)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// changesHttpError returns a bad request for positions which can't be
+// resumed, other errors are internal.
+func changesHttpError(err error) *errors.ErrRequest {
+	if vErr, ok := err.(*vearchpb.VearchErr); ok && vErr.GetError().Code == vearchpb.ErrorEnum_PARAM_ERROR {
+		return errors.NewErrBadRequest(err)
+	}
+	return errors.NewErrInternal(err)
+}
@@ -15,8 +15,8 @@
 package document
 
 import (
+	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +25,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/opentracing/opentracing-go"
+	"github.com/spf13/cast"
 	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/entity"
@@ -35,6 +36,7 @@ import (
 	"github.com/vearch/vearch/v3/internal/monitor"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/pkg/netutil"
+	"github.com/vearch/vearch/v3/internal/pkg/vjson"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 )
 
@@ -50,73 +52,47 @@ const (
 	URLQueryTimeout     = "timeout"
 	URLParamAliasName   = "alias_name"
 	URLParamUserName    = "user_name"
+	URLParamAPIKeyName  = "api_key_name"
 	URLParamRoleName    = "role_name"
 	URLParamMemberId    = "member_id"
+	URLParamTaskID      = "task_id"
+	URLParamImportID    = "import_id"
 	NodeID              = "node_id"
 	defaultTimeout      = 10 * time.Second
+	// requestTargetKey is the context key of the db and space of a request
+	requestTargetKey = "request_target"
 )
 
 type DocumentHandler struct {
 	httpServer *gin.Engine
 	docService docService
 	client     *client.Client
+	imports    *importJobs
 }
 
 func BasicAuthMiddleware(docService docService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			err := fmt.Errorf("auth header is empty")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
-
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || parts[0] != "Basic" {
-			err := fmt.Errorf("auth header type is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
-
-		decoded, err := base64.StdEncoding.DecodeString(parts[1])
+		authType, name, secret, err := entity.ParseAuthHeader(c.GetHeader("Authorization"))
 		if err != nil {
 			response.New(c).JsonError(errors.NewErrUnauthorized(err))
 			c.Abort()
 			return
 		}
-
-		credentials := strings.SplitN(string(decoded), ":", 2)
-		if len(credentials) != 2 {
-			err := fmt.Errorf("auth header credentials is invalid")
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
-		}
-
-		user, err := docService.getUser(c, credentials[0])
+		user, roles, err := docService.authenticate(c, authType, name, secret)
 		if err != nil {
-			ferr := fmt.Errorf("auth header user %s is invalid", credentials[0])
-			response.New(c).JsonError(errors.NewErrUnauthorized(ferr))
-			c.Abort()
-			return
-		}
-		if *user.Password != credentials[1] {
-			err := fmt.Errorf("auth header password is invalid")
 			response.New(c).JsonError(errors.NewErrUnauthorized(err))
 			c.Abort()
 			return
 		}
-		role, err := docService.getRole(c, *user.RoleName)
-		if err != nil {
-			response.New(c).JsonError(errors.NewErrUnauthorized(err))
-			c.Abort()
-			return
+		c.Set(entity.AuthUserKey, user)
+		c.Set(entity.AuthTypeKey, authType)
+		c.Set(entity.AuthRoleKey, roles[0].Name)
+
+		resource, privilege := entity.ParseResources(c.FullPath(), c.Request.Method)
+		target := func() (string, string) {
+			return requestTarget(c, docService)
 		}
-		endpoint := c.FullPath()
-		method := c.Request.Method
-		if err := role.HasPermissionForResources(endpoint, method); err != nil {
+		if err := entity.Authorize(roles, resource, privilege, target); err != nil {
 			response.New(c).JsonError(errors.NewErrUnauthorized(err))
 			c.Abort()
 			return
@@ -126,27 +102,83 @@ func BasicAuthMiddleware(docService docService) gin.HandlerFunc {
 	}
 }
 
+// requestTarget returns the db and space a request is on, from its path or
+// its body. An alias is resolved to its space.
+func requestTarget(c *gin.Context, docService docService) (string, string) {
+	if db := c.Param(URLParamDbName); db != "" {
+		return db, c.Param(URLParamSpaceName)
+	}
+	// got by both auth and audit
+	if target, ok := c.Get(requestTargetKey); ok {
+		t := target.([2]string)
+		return t[0], t[1]
+	}
+	db, space := requestBodyTarget(c)
+	db, space = docService.resolveTarget(c, db, space)
+	c.Set(requestTargetKey, [2]string{db, space})
+	return db, space
+}
+
+// requestBodyTarget returns the db_name and space_name of the body of a
+// document or index request.
+func requestBodyTarget(c *gin.Context) (db, space string) {
+	// files of imports are uploaded, changes are streamed and imports are
+	// got or canceled with the target in the query
+	if c.Request.Method == http.MethodGet || c.ContentType() == gin.MIMEMultipartPOSTForm || c.Param(URLParamImportID) != "" {
+		return c.Query(URLParamDbName), c.Query(URLParamSpaceName)
+	}
+	if c.Request.Body == nil {
+		return "", ""
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return "", ""
+	}
+	// the handler reads the body again
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	target := struct {
+		DbName    string `json:"db_name"`
+		SpaceName string `json:"space_name"`
+	}{}
+	if err := vjson.Unmarshal(body, &target); err != nil {
+		return "", ""
+	}
+	return target.DbName, target.SpaceName
+}
+
 func HttpLimitMiddleware(docService docService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		Request := strings.TrimPrefix(c.Request.RequestURI, "/document/")
+		Request := strings.TrimPrefix(c.Request.URL.Path, "/document/")
 
+		write := false
 		switch Request {
-		case "upsert", "delete":
+		case "upsert", "update", "delete", "import":
+			write = true
 			if !entity.WriteLimiter.Allow() {
 				msg := fmt.Sprintf("document write request too frequency, have reached limit %d", entity.WriteLimiter.Burst())
 				log.Error(msg)
-				response.New(c).JsonError(errors.NewErrInternal(fmt.Errorf(msg)))
+				response.New(c).JsonError(errors.NewErrTooManyRequests(vearchpb.NewErrorInfo(vearchpb.ErrorEnum_RATE_LIMITED, msg)))
 				c.Abort()
 				return
 			}
-		case "query", "search":
+		case "query", "search", "scroll", "changes":
 			if !entity.ReadLimiter.Allow() {
 				msg := fmt.Sprintf("document read request too frequency, have reached limit %d", entity.ReadLimiter.Burst())
 				log.Error(msg)
-				response.New(c).JsonError(errors.NewErrInternal(fmt.Errorf(msg)))
+				response.New(c).JsonError(errors.NewErrTooManyRequests(vearchpb.NewErrorInfo(vearchpb.ErrorEnum_RATE_LIMITED, msg)))
 				c.Abort()
 				return
 			}
+		default:
+			c.Next()
+			return
+		}
+		db, space := requestTarget(c, docService)
+		if err := entity.AllowRequest(c.GetString(entity.AuthUserKey), db, space, write); err != nil {
+			log.Error(err.Error())
+			response.New(c).JsonError(errors.NewErrTooManyRequests(err))
+			c.Abort()
+			return
 		}
 
 		c.Next()
@@ -160,20 +192,27 @@ func ExportDocumentHandler(httpServer *gin.Engine, client *client.Client) {
 		httpServer: httpServer,
 		docService: *docService,
 		client:     client,
+		imports:    newImportJobs(),
 	}
 
-	var group *gin.RouterGroup
-	var groupProxy *gin.RouterGroup
+	// calls failing auth are audited too, calls proxied to master are
+	// audited by master
+	handlers := make([]gin.HandlerFunc, 0, 2)
+	if auditor := documentHandler.docService.auditor; auditor != nil {
+		handlers = append(handlers, auditor.Middleware(func(c *gin.Context) (string, string) {
+			return requestTarget(c, documentHandler.docService)
+		}))
+	}
 	if !config.Conf().Global.SkipAuth {
-		group = documentHandler.httpServer.Group("", BasicAuthMiddleware(documentHandler.docService))
-		// auth by master
-		groupProxy = documentHandler.httpServer.Group("")
-	} else {
-		group = documentHandler.httpServer.Group("")
-		groupProxy = documentHandler.httpServer.Group("")
+		handlers = append(handlers, BasicAuthMiddleware(documentHandler.docService))
 	}
+	group := documentHandler.httpServer.Group("", handlers...)
+	// auth by master
+	groupProxy := documentHandler.httpServer.Group("")
 
 	documentHandler.proxyMaster(groupProxy)
+	// imports upload files and run in background, not bound by the timeout
+	documentHandler.exportImportInterfaces(group)
 	group.Use(master.TimeoutMiddleware(defaultTimeout))
 	// open router api
 	if err := documentHandler.ExportInterfacesToServer(group); err != nil {
@@ -210,6 +249,10 @@ func (handler *DocumentHandler) proxyMaster(group *gin.RouterGroup) error {
 	group.PUT(fmt.Sprintf("/dbs/:%s", URLParamDbName), handler.handleMasterRequest)
 	group.POST(fmt.Sprintf("/backup/dbs/:%s", URLParamDbName), handler.handleMasterRequest)
 	group.POST(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s", URLParamDbName, URLParamSpaceName), handler.handleMasterRequest)
+	group.GET("/backup/schedules", handler.handleMasterRequest)
+	group.GET(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s/schedule", URLParamDbName, URLParamSpaceName), handler.handleMasterRequest)
+	group.PUT(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s/schedule", URLParamDbName, URLParamSpaceName), handler.handleMasterRequest)
+	group.DELETE(fmt.Sprintf("/backup/dbs/:%s/spaces/:%s/schedule", URLParamDbName, URLParamSpaceName), handler.handleMasterRequest)
 	// space handler
 	group.POST(fmt.Sprintf("/dbs/:%s/spaces", URLParamDbName), handler.handleMasterRequest)
 	group.GET(fmt.Sprintf("/dbs/:%s/spaces/:%s", URLParamDbName, URLParamSpaceName), handler.handleMasterRequest)
@@ -230,6 +273,9 @@ func (handler *DocumentHandler) proxyMaster(group *gin.RouterGroup) error {
 	group.GET("/users", handler.handleMasterRequest)
 	group.DELETE(fmt.Sprintf("/users/:%s", URLParamUserName), handler.handleMasterRequest)
 	group.PUT("/users", handler.handleMasterRequest)
+	group.POST(fmt.Sprintf("/users/:%s/api_keys", URLParamUserName), handler.handleMasterRequest)
+	group.GET(fmt.Sprintf("/users/:%s/api_keys", URLParamUserName), handler.handleMasterRequest)
+	group.DELETE(fmt.Sprintf("/users/:%s/api_keys/:%s", URLParamUserName, URLParamAPIKeyName), handler.handleMasterRequest)
 
 	// role handler
 	group.POST("/roles", handler.handleMasterRequest)
@@ -254,6 +300,15 @@ func (handler *DocumentHandler) proxyMaster(group *gin.RouterGroup) error {
 	group.GET("/members/stats", handler.handleMasterRequest)
 	group.DELETE("/members", handler.handleMasterRequest)
 	group.POST("/members", handler.handleMasterRequest)
+
+	// audit handler
+	group.GET("/audit", handler.handleMasterRequest)
+
+	// task handler
+	group.GET("/tasks", handler.handleMasterRequest)
+	group.POST("/tasks", handler.handleMasterRequest)
+	group.GET(fmt.Sprintf("/tasks/:%s", URLParamTaskID), handler.handleMasterRequest)
+	group.POST(fmt.Sprintf("/tasks/:%s/cancel", URLParamTaskID), handler.handleMasterRequest)
 	return nil
 }
 
@@ -264,8 +319,13 @@ func (handler *DocumentHandler) handleMasterRequest(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrBadRequest(err))
 		return
 	}
-	authHeader := c.GetHeader("Authorization")
-	res, err := handler.client.Master().ProxyHTTPRequest(method, c.Request.RequestURI, string(bodyBytes), authHeader)
+	// the master audits the call with the client address
+	header := map[string]string{
+		"Authorization":   c.GetHeader("Authorization"),
+		"X-Forwarded-For": c.ClientIP(),
+		"X-Request-Id":    c.GetHeader("X-Request-Id"),
+	}
+	res, err := handler.client.Master().ProxyHTTPRequest(method, c.Request.RequestURI, string(bodyBytes), header)
 	if err != nil {
 		log.Error("handleMasterRequest %v, response %s", err, string(res))
 		if string(res) != "" {
@@ -278,6 +338,15 @@ func (handler *DocumentHandler) handleMasterRequest(c *gin.Context) {
 	response.New(c).SendJsonBytes(res)
 }
 
+func (handler *DocumentHandler) exportImportInterfaces(group *gin.RouterGroup) {
+	groupImport := group.Group("/document/import")
+	groupImport.Use(HttpLimitMiddleware(handler.docService))
+
+	groupImport.POST("", handler.handleDocumentImport)
+	groupImport.GET("/:"+URLParamImportID, handler.handleDocumentImportProgress)
+	groupImport.POST(fmt.Sprintf("/:%s/cancel", URLParamImportID), handler.handleDocumentImportCancel)
+}
+
 func (handler *DocumentHandler) ExportInterfacesToServer(group *gin.RouterGroup) error {
 	// router info
 	group.GET("/", handler.handleRouterInfo)
@@ -287,9 +356,13 @@ func (handler *DocumentHandler) ExportInterfacesToServer(group *gin.RouterGroup)
 	groupdoc.Use(HttpLimitMiddleware(handler.docService))
 
 	groupdoc.POST("/upsert", handler.handleDocumentUpsert)
+	groupdoc.POST("/update", handler.handleDocumentUpdate)
 	groupdoc.POST("/query", handler.handleDocumentQuery)
+	groupdoc.POST("/scroll", handler.handleDocumentScroll)
 	groupdoc.POST("/search", handler.handleDocumentSearch)
 	groupdoc.POST("/delete", handler.handleDocumentDelete)
+	groupdoc.POST("/changes", handler.handleDocumentChanges)
+	groupdoc.GET("/changes", handler.handleDocumentChangesStream)
 
 	// index
 	group.POST("/index/flush", handler.handleIndexFlush)
@@ -447,6 +520,49 @@ func (handler *DocumentHandler) handleDocumentUpsert(c *gin.Context) {
 	response.New(c).JsonSuccess(result)
 }
 
+func (handler *DocumentHandler) handleDocumentUpdate(c *gin.Context) {
+	startTime := time.Now()
+	operateName := "handleDocumentUpdate"
+	defer monitor.Profiler(operateName, startTime)
+	span, _ := opentracing.StartSpanFromContext(c.Request.Context(), operateName)
+	defer span.Finish()
+
+	args := &vearchpb.UpdateRequest{}
+	var err error
+	args.Head, err = setRequestHeadFromGin(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	docRequest := &request.UpdateDocumentRequest{}
+	err = c.ShouldBindJSON(docRequest)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+
+	args.Head.DbName = docRequest.DbName
+	args.Head.SpaceName = docRequest.SpaceName
+	space, err := handler.docService.getSpace(c.Request.Context(), args.Head)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+
+	err = documentUpdateParse(docRequest, space, args)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	reply := handler.docService.update(c.Request.Context(), args)
+	result, err := documentUpsertResponse(reply)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrUnprocessable(err))
+		return
+	}
+	response.New(c).JsonSuccess(result)
+}
+
 func (handler *DocumentHandler) handleDocumentQuery(c *gin.Context) {
 	startTime := time.Now()
 	operateName := "handleDocumentQuery"
@@ -492,7 +608,7 @@ func (handler *DocumentHandler) handleDocumentQuery(c *gin.Context) {
 	}
 
 	if searchDoc.DocumentIds != nil && len(*searchDoc.DocumentIds) != 0 {
-		if args.TermFilters != nil || args.RangeFilters != nil {
+		if args.TermFilters != nil || args.RangeFilters != nil || args.FilterTree != nil {
 			err := vearchpb.NewError(vearchpb.ErrorEnum_QUERY_INVALID_PARAMS_BOTH_DOCUMENT_IDS_AND_FILTER, nil)
 			response.New(c).JsonError(errors.NewErrBadRequest(err))
 			return
@@ -507,7 +623,7 @@ func (handler *DocumentHandler) handleDocumentQuery(c *gin.Context) {
 			return
 		}
 	} else {
-		if args.TermFilters == nil && args.RangeFilters == nil {
+		if args.TermFilters == nil && args.RangeFilters == nil && args.FilterTree == nil {
 			err := vearchpb.NewError(vearchpb.ErrorEnum_QUERY_INVALID_PARAMS_SHOULD_HAVE_ONE_OF_DOCUMENT_IDS_OR_FILTER, nil)
 			response.New(c).JsonError(errors.NewErrBadRequest(err))
 			return
@@ -523,6 +639,12 @@ func (handler *DocumentHandler) handleDocumentQuery(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrUnprocessable(err))
 		return
 	}
+	if len(searchResp.Aggregations) > 0 {
+		result["aggregations"] = documentAggregationsResponse(searchResp.Aggregations, searchDoc.Aggregations, space)
+	}
+	if pruned, ok := searchResp.Head.Params[client.PrunedPartitions]; ok {
+		result[client.PrunedPartitions] = cast.ToInt(pruned)
+	}
 	response.New(c).JsonSuccess(result)
 	if trace {
 		log.Trace("handleDocumentQuery total use :[%.4f] service use :[%.4f] detail use :[%v]", time.Since(startTime).Seconds()*1000, serviceCost.Seconds()*1000, searchResp.Head.Params)
@@ -540,6 +662,10 @@ func (handler *DocumentHandler) handleDocumentGet(c *gin.Context, searchDoc *req
 	args.Head.DbName = searchDoc.DbName
 	args.Head.SpaceName = searchDoc.SpaceName
 	args.PrimaryKeys = *searchDoc.DocumentIds
+	if err := setConsistency(searchDoc, args.Head); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
 
 	var queryFieldsParam map[string]string
 	if searchDoc.Fields != nil {
@@ -577,6 +703,109 @@ func (handler *DocumentHandler) handleDocumentGet(c *gin.Context, searchDoc *req
 	}
 }
 
+// defaultScrollKeepAlive is the keep alive of a scroll without keep_alive
+// if scroll_keep_alive is not set in the router config.
+const defaultScrollKeepAlive = 5 * time.Minute
+
+func (handler *DocumentHandler) handleDocumentScroll(c *gin.Context) {
+	startTime := time.Now()
+	operateName := "handleDocumentScroll"
+	defer monitor.Profiler(operateName, startTime)
+	span, _ := opentracing.StartSpanFromContext(c.Request.Context(), operateName)
+	defer span.Finish()
+
+	args := &vearchpb.ScrollRequest{}
+	var err error
+	args.Head, err = setRequestHeadFromGin(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+
+	scrollDoc := &request.ScrollDocumentRequest{}
+	err = c.ShouldBindJSON(scrollDoc)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+
+	defaultKeepAlive := defaultScrollKeepAlive
+	if config.Conf().Router.ScrollKeepAlive > 0 {
+		defaultKeepAlive = time.Duration(config.Conf().Router.ScrollKeepAlive) * time.Second
+	}
+	keepAlive, err := request.ParseKeepAlive(scrollDoc.KeepAlive, defaultKeepAlive)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)))
+		return
+	}
+	size := scrollDoc.Limit
+	if size == 0 {
+		size = DefaultSize
+	}
+	if size < 0 || size > maxScrollSize {
+		err := fmt.Errorf("scroll limit should be in (0, %d]", maxScrollSize)
+		response.New(c).JsonError(errors.NewErrBadRequest(vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)))
+		return
+	}
+
+	// a new scroll is described by the request, a scroll is continued by
+	// the scroll_id only
+	var cursor *request.ScrollCursor
+	if scrollDoc.ScrollID != "" {
+		cursor, err = request.DecodeScrollCursor(scrollDoc.ScrollID, startTime)
+		if err != nil {
+			response.New(c).JsonError(errors.NewErrBadRequest(vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)))
+			return
+		}
+		args.Head.DbName = cursor.DbName
+		args.Head.SpaceName = cursor.SpaceName
+	} else {
+		args.Head.DbName = scrollDoc.DbName
+		args.Head.SpaceName = scrollDoc.SpaceName
+	}
+
+	space, err := handler.docService.getSpace(c.Request.Context(), args.Head)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	if cursor == nil {
+		scrollDoc.DbName = args.Head.DbName
+		cursor = request.NewScrollCursor(scrollDoc, space)
+	} else if cursor.SpaceID != space.Id {
+		err := fmt.Errorf("space [%s] was recreated after the scroll started", space.Name)
+		response.New(c).JsonError(errors.NewErrBadRequest(vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)))
+		return
+	}
+
+	if err = scrollRequestToPb(cursor, space, args); err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+
+	docs, err := handler.docService.scroll(c.Request.Context(), args, cursor, size)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+
+	var scrollID string
+	if !cursor.Done() {
+		cursor.ExpireAt = time.Now().Add(keepAlive).Unix()
+		if scrollID, err = cursor.Encode(); err != nil {
+			response.New(c).JsonError(errors.NewErrInternal(err))
+			return
+		}
+	}
+
+	result, err := documentScrollResponse(space, docs, cursor.VectorValue, scrollID)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrUnprocessable(err))
+		return
+	}
+	response.New(c).JsonSuccess(result)
+}
+
 func (handler *DocumentHandler) handleDocumentSearch(c *gin.Context) {
 	startTime := time.Now()
 	operateName := "handleDocumentSearch"
@@ -622,7 +851,7 @@ func (handler *DocumentHandler) handleDocumentSearch(c *gin.Context) {
 		return
 	}
 
-	if searchReq.VecFields == nil {
+	if searchReq.VecFields == nil && len(searchReq.TextQueries) == 0 {
 		err := vearchpb.NewError(vearchpb.ErrorEnum_SEARCH_INVALID_PARAMS_SHOULD_HAVE_VECTOR_FIELD, nil)
 		response.New(c).JsonError(errors.NewErrInternal(err))
 		return
@@ -638,6 +867,12 @@ func (handler *DocumentHandler) handleDocumentSearch(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrInternal(err))
 		return
 	}
+	if len(searchResp.Aggregations) > 0 {
+		result["aggregations"] = documentAggregationsResponse(searchResp.Aggregations, searchDoc.Aggregations, space)
+	}
+	if pruned, ok := searchResp.Head.Params[client.PrunedPartitions]; ok {
+		result[client.PrunedPartitions] = cast.ToInt(pruned)
+	}
 	response.New(c).JsonSuccess(result)
 	if trace {
 		log.Trace("handleDocumentSearch %s total: [%.4f] getSpace: [%.4f] service: [%.4f] detail: [%v]",
@@ -686,9 +921,19 @@ func (handler *DocumentHandler) handleDocumentDelete(c *gin.Context) {
 		response.New(c).JsonError(errors.NewErrBadRequest(err))
 		return
 	}
+	if args.Offset != 0 {
+		err := vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("offset is not supported by delete"))
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	if len(args.Aggregations) > 0 {
+		err := vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("aggregations are not supported by delete"))
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
 
 	if searchDoc.DocumentIds != nil && len(*searchDoc.DocumentIds) != 0 {
-		if args.TermFilters != nil || args.RangeFilters != nil {
+		if args.TermFilters != nil || args.RangeFilters != nil || args.FilterTree != nil {
 			err := vearchpb.NewError(vearchpb.ErrorEnum_DELETE_INVALID_PARAMS_BOTH_DOCUMENT_IDS_AND_VECTOR, nil)
 			response.New(c).JsonError(errors.NewErrBadRequest(err))
 			return
@@ -699,7 +944,7 @@ func (handler *DocumentHandler) handleDocumentDelete(c *gin.Context) {
 			return
 		}
 	} else {
-		if args.TermFilters == nil && args.RangeFilters == nil {
+		if args.TermFilters == nil && args.RangeFilters == nil && args.FilterTree == nil {
 			err := vearchpb.NewError(vearchpb.ErrorEnum_DELETE_INVALID_PARAMS_SHOULD_HAVE_ONE_OF_DOCUMENT_IDS_OR_FILTER, nil)
 			response.New(c).JsonError(errors.NewErrBadRequest(err))
 			return
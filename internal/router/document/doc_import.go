@@ -0,0 +1,628 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/opentracing/opentracing-go"
+	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/errors"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/entity/response"
+	"github.com/vearch/vearch/v3/internal/monitor"
+	"github.com/vearch/vearch/v3/internal/pkg/backupstore"
+	"github.com/vearch/vearch/v3/internal/pkg/docimport"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/pkg/vjson"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+const (
+	defaultImportBatchSize = 100
+	// importConcurrency is how many batches of a file are upserting at a
+	// time, reading the file blocks when all of them are busy
+	importConcurrency = 4
+	importMaxRetries  = 5
+	// importRetryInterval is the first backoff of a batch rejected by
+	// limits or failed by timeout, doubled on each retry
+	importRetryInterval    = 200 * time.Millisecond
+	importMaxRetryInterval = 5 * time.Second
+	importProgressInterval = 10 * time.Second
+	// importBatchTimeout bounds the upsert of a batch, imports run in
+	// background without a deadline
+	importBatchTimeout = defaultTimeout
+	// maxRejectedRows is how many rejected rows of a file are returned
+	maxRejectedRows = 100
+)
+
+type importRejectedRow struct {
+	Row   int64  `json:"row"`
+	ID    string `json:"_id,omitempty"`
+	Error string `json:"error"`
+}
+
+// importFileResult is the progress of a file imported.
+type importFileResult struct {
+	mu           sync.Mutex
+	File         string               `json:"file"`
+	Format       string               `json:"format,omitempty"`
+	Total        int64                `json:"total"`
+	Imported     int64                `json:"imported"`
+	Rejected     int64                `json:"rejected"`
+	RejectedRows []*importRejectedRow `json:"rejected_rows,omitempty"`
+	// Error is why the file stopped importing
+	Error string `json:"error,omitempty"`
+}
+
+func (r *importFileResult) reject(row int64, id string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Rejected++
+	if len(r.RejectedRows) < maxRejectedRows {
+		r.RejectedRows = append(r.RejectedRows, &importRejectedRow{Row: row, ID: id, Error: err.Error()})
+	}
+}
+
+// fail sets why the file stopped importing, the first error is kept.
+func (r *importFileResult) fail(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.Error == "" {
+		r.Error = err.Error()
+	}
+}
+
+func (r *importFileResult) imported(num int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Imported += num
+}
+
+func (r *importFileResult) progress() (total, imported, rejected int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Total, r.Imported, r.Rejected
+}
+
+// importBatch is the documents of the rows upserted together.
+type importBatch struct {
+	rows []int64
+	docs []*vearchpb.Document
+}
+
+// importer upserts the rows of files into a space.
+type importer struct {
+	docService     *docService
+	head           *vearchpb.RequestHead
+	user           string
+	space          *entity.Space
+	properties     map[string]*entity.SpaceProperties
+	vectorFieldNum int
+	req            *request.ImportRequest
+
+	mu      sync.Mutex
+	results []*importFileResult
+}
+
+func newImporter(docService *docService, head *vearchpb.RequestHead, user string, space *entity.Space, req *request.ImportRequest) *importer {
+	properties := space.SpaceProperties
+	if properties == nil {
+		properties, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+	if req.BatchSize == 0 {
+		req.BatchSize = defaultImportBatchSize
+	}
+	return &importer{
+		docService:     docService,
+		head:           head,
+		user:           user,
+		space:          space,
+		properties:     properties,
+		vectorFieldNum: vectorFieldNum(properties),
+		req:            req,
+	}
+}
+
+// newResult adds the progress of file to the import.
+func (im *importer) newResult(file string, err error) *importFileResult {
+	result := &importFileResult{File: file}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	im.results = append(im.results, result)
+	return result
+}
+
+// progress returns a copy of the progress of the files imported so far.
+func (im *importer) progress() (results []*importFileResult, total, imported, rejected int64) {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+	results = make([]*importFileResult, 0, len(im.results))
+	for _, r := range im.results {
+		r.mu.Lock()
+		results = append(results, &importFileResult{
+			File:         r.File,
+			Format:       r.Format,
+			Total:        r.Total,
+			Imported:     r.Imported,
+			Rejected:     r.Rejected,
+			RejectedRows: r.RejectedRows[:len(r.RejectedRows):len(r.RejectedRows)],
+			Error:        r.Error,
+		})
+		total += r.Total
+		imported += r.Imported
+		rejected += r.Rejected
+		r.mu.Unlock()
+	}
+	return results, total, imported, rejected
+}
+
+// importFile reads the rows of file and upserts them batch by batch. Rows
+// which can't be parsed or upserted are rejected, the others go on.
+func (im *importer) importFile(ctx context.Context, name string, file docimport.File) *importFileResult {
+	result := im.newResult(name, nil)
+	format, err := docimport.ParseFormat(im.req.Format, name)
+	if err != nil {
+		result.fail(err)
+		return result
+	}
+	result.mu.Lock()
+	result.Format = string(format)
+	result.mu.Unlock()
+	reader, err := docimport.NewReader(file, format, im.req.Mapping)
+	if err != nil {
+		result.fail(err)
+		return result
+	}
+	defer reader.Close()
+
+	startTime := time.Now()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(importProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				total, imported, rejected := result.progress()
+				log.Infof("importing %s into %s/%s, read %d, imported %d, rejected %d", name, im.head.DbName, im.head.SpaceName, total, imported, rejected)
+			}
+		}
+	}()
+
+	batches := make(chan *importBatch)
+	var wg sync.WaitGroup
+	for i := 0; i < importConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				im.upsert(ctx, batch, result)
+			}
+		}()
+	}
+
+	send := func(batch *importBatch) bool {
+		select {
+		case batches <- batch:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	batch := &importBatch{}
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			if len(batch.docs) > 0 {
+				send(batch)
+			}
+			break
+		}
+		if err != nil {
+			result.fail(err)
+			break
+		}
+		result.mu.Lock()
+		result.Total++
+		result.mu.Unlock()
+		if row.Err != nil {
+			result.reject(row.Num, "", row.Err)
+			continue
+		}
+		doc, err := parseDocument(row.Doc, im.space, im.properties, im.vectorFieldNum)
+		if err != nil {
+			result.reject(row.Num, "", err)
+			continue
+		}
+		batch.rows = append(batch.rows, row.Num)
+		batch.docs = append(batch.docs, doc)
+		if len(batch.docs) >= im.req.BatchSize {
+			if !send(batch) {
+				break
+			}
+			batch = &importBatch{}
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		result.fail(err)
+	}
+	total, imported, rejected := result.progress()
+	log.Infof("import %s into %s/%s finished, read %d, imported %d, rejected %d, cost %v",
+		name, im.head.DbName, im.head.SpaceName, total, imported, rejected, time.Since(startTime))
+	return result
+}
+
+// importRetryable tells whether a batch may succeed later, after the limits
+// or the partitions recover.
+func importRetryable(code vearchpb.ErrorEnum) bool {
+	switch code {
+	case vearchpb.ErrorEnum_RATE_LIMITED, vearchpb.ErrorEnum_TIMEOUT, vearchpb.ErrorEnum_PARTITION_NOT_LEADER,
+		vearchpb.ErrorEnum_PARTITION_NO_LEADER, vearchpb.ErrorEnum_PARTITION_RESOURCE_EXHAUSTED:
+		return true
+	}
+	return false
+}
+
+// wait blocks until the write limits of the router, the user and the space
+// allow a batch, it's the back pressure of imports.
+func (im *importer) wait(ctx context.Context) error {
+	for {
+		if err := entity.WriteLimiter.Wait(ctx); err != nil {
+			return err
+		}
+		if entity.AllowRequest(im.user, im.head.DbName, im.head.SpaceName, true) == nil {
+			return nil
+		}
+		select {
+		case <-time.After(importRetryInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// upsert upserts a batch, the documents failed for limits or timeout are
+// retried with backoff.
+func (im *importer) upsert(ctx context.Context, batch *importBatch, result *importFileResult) {
+	pending := make([]int, len(batch.docs))
+	for i := range pending {
+		pending[i] = i
+	}
+	rejectPending := func(err error) {
+		for _, i := range pending {
+			result.reject(batch.rows[i], batch.docs[i].PKey, err)
+		}
+	}
+
+	interval := importRetryInterval
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				rejectPending(ctx.Err())
+				return
+			}
+			interval = min(interval*2, importMaxRetryInterval)
+		}
+		if err := im.wait(ctx); err != nil {
+			rejectPending(err)
+			return
+		}
+
+		// the fields of the documents are appended with _id on each upsert
+		docs := make([]*vearchpb.Document, len(pending))
+		for j, i := range pending {
+			doc := batch.docs[i]
+			docs[j] = &vearchpb.Document{PKey: doc.PKey, Fields: doc.Fields[:len(doc.Fields):len(doc.Fields)]}
+		}
+		head := &vearchpb.RequestHead{DbName: im.head.DbName, SpaceName: im.head.SpaceName, Params: im.head.Params}
+		bulkCtx, cancel := context.WithTimeout(ctx, importBatchTimeout)
+		reply := im.docService.bulk(bulkCtx, &vearchpb.BulkRequest{Head: head, Docs: docs})
+		cancel()
+
+		// keep the keys generated for documents without _id when retrying
+		index := make(map[string]int, len(pending))
+		for j, i := range pending {
+			batch.docs[i].PKey = docs[j].PKey
+			index[docs[j].PKey] = i
+		}
+
+		if headErr := reply.GetHead().GetErr(); headErr != nil && headErr.Code != vearchpb.ErrorEnum_SUCCESS {
+			if importRetryable(headErr.Code) && attempt < importMaxRetries {
+				continue
+			}
+			rejectPending(vearchpb.NewErrorInfo(headErr.Code, headErr.Msg))
+			return
+		}
+
+		retry := make([]int, 0)
+		rejected := 0
+		for _, item := range reply.Items {
+			if item == nil || item.Doc == nil || item.Err == nil || item.Err.Code == vearchpb.ErrorEnum_SUCCESS {
+				continue
+			}
+			i, ok := index[item.Doc.PKey]
+			if !ok {
+				continue
+			}
+			if importRetryable(item.Err.Code) && attempt < importMaxRetries {
+				retry = append(retry, i)
+				continue
+			}
+			rejected++
+			result.reject(batch.rows[i], item.Doc.PKey, vearchpb.NewErrorInfo(item.Err.Code, item.Err.Msg))
+		}
+		result.imported(int64(len(pending) - len(retry) - rejected))
+		pending = retry
+	}
+}
+
+// importStore imports the files of keys in the storage of the request, the
+// files are downloaded one by one.
+func (im *importer) importStore(ctx context.Context) error {
+	store, err := backupstore.New(im.req.Source)
+	if err != nil {
+		return err
+	}
+	dir, err := os.MkdirTemp("", "vearch-import-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	for _, key := range im.req.Keys {
+		keys := []string{key}
+		if strings.HasSuffix(key, "/") {
+			if keys, err = store.List(ctx, key, true); err != nil {
+				return fmt.Errorf("list %s failed: %v", key, err)
+			}
+		}
+		for _, key := range keys {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			im.importKey(ctx, store, dir, key)
+		}
+	}
+	return nil
+}
+
+func (im *importer) importKey(ctx context.Context, store backupstore.Store, dir, key string) {
+	name := filepath.Join(dir, path.Base(key))
+	if err := store.Get(ctx, key, name); err != nil {
+		im.newResult(key, err)
+		return
+	}
+	defer os.Remove(name)
+	im.importPath(ctx, key, name)
+}
+
+// importPath imports the local file at path as the file name.
+func (im *importer) importPath(ctx context.Context, name, path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		im.newResult(name, err)
+		return
+	}
+	defer f.Close()
+	im.importFile(ctx, name, f)
+}
+
+// parseImportRequest reads the request of the json body, or of the query
+// if files are uploaded by a multipart form.
+func parseImportRequest(c *gin.Context) (*request.ImportRequest, bool, error) {
+	req := &request.ImportRequest{}
+	if c.ContentType() != gin.MIMEMultipartPOSTForm {
+		if err := c.ShouldBindJSON(req); err != nil {
+			return nil, false, err
+		}
+		return req, false, req.Validate(false)
+	}
+
+	req.DbName = c.Query(URLParamDbName)
+	req.SpaceName = c.Query(URLParamSpaceName)
+	req.Format = c.Query("format")
+	if batchSize := c.Query("batch_size"); batchSize != "" {
+		size, err := cast.ToIntE(batchSize)
+		if err != nil {
+			return nil, true, fmt.Errorf("batch_size %s should be an integer", batchSize)
+		}
+		req.BatchSize = size
+	}
+	if mapping := c.Query("mapping"); mapping != "" {
+		if err := vjson.Unmarshal([]byte(mapping), &req.Mapping); err != nil {
+			return nil, true, fmt.Errorf("mapping should be a json object of columns to fields: %v", err)
+		}
+	}
+	return req, true, req.Validate(true)
+}
+
+// handleDocumentImport starts importing the documents of files into a space
+// in background, its progress is got by the import id returned. Uploaded
+// files are saved to a temp dir first.
+func (handler *DocumentHandler) handleDocumentImport(c *gin.Context) {
+	startTime := time.Now()
+	operateName := "handleDocumentImport"
+	defer monitor.Profiler(operateName, startTime)
+	span, _ := opentracing.StartSpanFromContext(c.Request.Context(), operateName)
+	defer span.Finish()
+
+	head, err := setRequestHeadFromGin(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+	req, upload, err := parseImportRequest(c)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrBadRequest(err))
+		return
+	}
+	if !upload {
+		routerConf := config.Conf().Router
+		if err := req.CheckSource(routerConf.ImportRoot, routerConf.ImportS3Endpoints); err != nil {
+			response.New(c).JsonError(errors.NewErrBadRequest(err))
+			return
+		}
+	}
+	head.DbName = req.DbName
+	head.SpaceName = req.SpaceName
+	space, err := handler.docService.getSpace(c.Request.Context(), head)
+	if err != nil {
+		response.New(c).JsonError(errors.NewErrInternal(err))
+		return
+	}
+
+	im := newImporter(&handler.docService, head, c.GetString(entity.AuthUserKey), space, req)
+	var files []*importUpload
+	dir := ""
+	if upload {
+		form, err := c.MultipartForm()
+		if err != nil {
+			response.New(c).JsonError(errors.NewErrBadRequest(err))
+			return
+		}
+		if len(form.File["file"]) == 0 {
+			response.New(c).JsonError(errors.NewErrBadRequest(fmt.Errorf("no file is uploaded")))
+			return
+		}
+		if dir, err = os.MkdirTemp("", "vearch-import-"); err != nil {
+			response.New(c).JsonError(errors.NewErrInternal(err))
+			return
+		}
+		files, err = saveUploads(dir, form.File["file"])
+		if err != nil {
+			os.RemoveAll(dir)
+			response.New(c).JsonError(errors.NewErrInternal(err))
+			return
+		}
+	}
+
+	job := handler.imports.start(im, func(ctx context.Context) error {
+		if !upload {
+			return im.importStore(ctx)
+		}
+		defer os.RemoveAll(dir)
+		for _, f := range files {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if f.err != nil {
+				im.newResult(f.name, f.err)
+				continue
+			}
+			im.importPath(ctx, f.name, f.path)
+		}
+		return nil
+	})
+	response.New(c).JsonSuccess(job.progress())
+}
+
+// importUpload is an uploaded file saved to path.
+type importUpload struct {
+	name string
+	path string
+	err  error
+}
+
+// saveUploads copies the uploaded files to dir, the request removes them
+// when it ends. A file which can't be read is imported with its error.
+func saveUploads(dir string, headers []*multipart.FileHeader) ([]*importUpload, error) {
+	files := make([]*importUpload, 0, len(headers))
+	for i, fh := range headers {
+		upload := &importUpload{name: fh.Filename, path: filepath.Join(dir, strconv.Itoa(i))}
+		files = append(files, upload)
+		src, err := fh.Open()
+		if err != nil {
+			upload.err = err
+			continue
+		}
+		err = copyFile(upload.path, src)
+		src.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func copyFile(path string, src io.Reader) error {
+	dst, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	return dst.Close()
+}
+
+// importJobFromGin returns the import of the request, which must be on the
+// db and space of the query, the same target the request is authorized on.
+func (handler *DocumentHandler) importJobFromGin(c *gin.Context) *importJob {
+	job := handler.imports.get(c.Param(URLParamImportID))
+	if job != nil {
+		db, space := requestTarget(c, handler.docService)
+		jobDb, jobSpace := handler.docService.resolveTarget(c, job.im.req.DbName, job.im.req.SpaceName)
+		if db != jobDb || space != jobSpace {
+			job = nil
+		}
+	}
+	if job == nil {
+		response.New(c).JsonError(errors.NewErrNotFound(fmt.Errorf("import %s of %s/%s not found", c.Param(URLParamImportID), c.Query(URLParamDbName), c.Query(URLParamSpaceName))))
+		return nil
+	}
+	return job
+}
+
+// handleDocumentImportProgress returns the progress of an import.
+func (handler *DocumentHandler) handleDocumentImportProgress(c *gin.Context) {
+	if job := handler.importJobFromGin(c); job != nil {
+		response.New(c).JsonSuccess(job.progress())
+	}
+}
+
+// handleDocumentImportCancel cancels an import, the batches upserting are
+// finished first.
+func (handler *DocumentHandler) handleDocumentImportCancel(c *gin.Context) {
+	if job := handler.importJobFromGin(c); job != nil {
+		job.cancel()
+		response.New(c).JsonSuccess(job.progress())
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+)
+
+// importJobRetention is how long a finished import is kept for its
+// progress to be got.
+const importJobRetention = 24 * time.Hour
+
+// importJob is an import run by this router in background, its progress is
+// only known by this router.
+type importJob struct {
+	id        string
+	im        *importer
+	cancel    context.CancelFunc
+	startTime time.Time
+
+	mu      sync.Mutex
+	status  entity.TaskStatus
+	err     string
+	endTime time.Time
+}
+
+func (job *importJob) finish(err error, canceled bool) {
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	job.endTime = time.Now()
+	switch {
+	case canceled:
+		job.status = entity.TaskCanceled
+	case err != nil:
+		job.status = entity.TaskFailed
+	default:
+		job.status = entity.TaskSucceeded
+	}
+	if err != nil {
+		job.err = err.Error()
+	}
+}
+
+func (job *importJob) progress() map[string]any {
+	files, total, imported, rejected := job.im.progress()
+	job.mu.Lock()
+	defer job.mu.Unlock()
+	res := map[string]any{
+		"import_id":  job.id,
+		"db_name":    job.im.req.DbName,
+		"space_name": job.im.req.SpaceName,
+		"status":     job.status,
+		"start_time": job.startTime.UnixMilli(),
+		"total":      total,
+		"imported":   imported,
+		"rejected":   rejected,
+		"files":      files,
+	}
+	if job.status.Finished() {
+		res["end_time"] = job.endTime.UnixMilli()
+	}
+	if job.err != "" {
+		res["error"] = job.err
+	}
+	return res
+}
+
+// importJobs are the imports of this router.
+type importJobs struct {
+	mu   sync.Mutex
+	jobs map[string]*importJob
+}
+
+func newImportJobs() *importJobs {
+	return &importJobs{jobs: make(map[string]*importJob)}
+}
+
+// start runs fn of im in background until it returns or is canceled.
+func (jobs *importJobs) start(im *importer, fn func(ctx context.Context) error) *importJob {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &importJob{
+		id:        uuid.NewString(),
+		im:        im,
+		cancel:    cancel,
+		startTime: time.Now(),
+		status:    entity.TaskRunning,
+	}
+
+	jobs.mu.Lock()
+	for id, j := range jobs.jobs {
+		j.mu.Lock()
+		expired := j.status.Finished() && time.Since(j.endTime) > importJobRetention
+		j.mu.Unlock()
+		if expired {
+			delete(jobs.jobs, id)
+		}
+	}
+	jobs.jobs[job.id] = job
+	jobs.mu.Unlock()
+
+	log.Info("import %s into %s/%s started", job.id, im.req.DbName, im.req.SpaceName)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Error("import %s panic: %s, %s", job.id, cast.ToString(r), string(debug.Stack()))
+				job.finish(fmt.Errorf("import panic: %v", r), false)
+			}
+		}()
+		defer cancel()
+		err := fn(ctx)
+		job.finish(err, ctx.Err() != nil)
+		_, total, imported, rejected := im.progress()
+		log.Info("import %s into %s/%s finished, read %d, imported %d, rejected %d, cost %v",
+			job.id, im.req.DbName, im.req.SpaceName, total, imported, rejected, time.Since(job.startTime))
+	}()
+	return job
+}
+
+func (jobs *importJobs) get(id string) *importJob {
+	jobs.mu.Lock()
+	defer jobs.mu.Unlock()
+	return jobs.jobs[id]
+}
@@ -77,7 +77,11 @@ func parseJSON(path []string, v *fastjson.Value, space *entity.Space, proMap map
 
 	obj.Visit(func(key []byte, val *fastjson.Value) {
 		fieldName := string(key)
-		if fieldName == IDField {
+		if fieldName == IDField || fieldName == entity.TTLField {
+			return
+		}
+		if fieldName == entity.ExpireAtField && space.TTL != nil && space.TTL.Field == "" {
+			log.Warnf("field name [%s] is set by %s that cannot be used", fieldName, entity.TTLField)
 			return
 		}
 		pro, ok := proMap[fieldName]
@@ -336,7 +340,7 @@ func processString(pro *entity.SpaceProperties, fieldName, val string) (*vearchp
 	switch pro.FieldType {
 	case vearchpb.FieldType_STRING:
 		isIndex := false
-		if pro.Index != nil {
+		if pro.Index != nil && !pro.IsFullText() {
 			isIndex = true
 		}
 		if isIndex && len(val) > maxIndexedStrLen {
@@ -535,56 +539,211 @@ func configTraceParse(r *http.Request) (printSwitch bool, err error) {
 	return temp.Trace, nil
 }
 
-func documentParse(ctx context.Context, handler *DocumentHandler, r *http.Request, docRequest *request.DocumentRequest, space *entity.Space, args *vearchpb.BulkRequest) error {
-	spaceProperties := space.SpaceProperties
-	if spaceProperties == nil {
-		spaceProperties, _ = entity.UnmarshalPropertyJSON(space.Fields)
+func parsePartitions(partitions *[]entity.PartitionID, space *entity.Space) ([]uint32, error) {
+	if partitions == nil || len(*partitions) == 0 {
+		return nil, nil
+	}
+	// check partition
+	for _, pid := range *partitions {
+		found := false
+		for _, partition := range space.Partitions {
+			if pid == partition.Id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition id %d not belong to space[%s]", pid, space.Name))
+		}
 	}
+	return *partitions, nil
+}
 
-	vectorFieldNum := 0
+func vectorFieldNum(spaceProperties map[string]*entity.SpaceProperties) int {
+	num := 0
 	for _, value := range spaceProperties {
 		if value.FieldType == vearchpb.FieldType_VECTOR {
-			vectorFieldNum++
+			num++
 		}
 	}
+	return num
+}
 
-	if docRequest.Partitions != nil && len(*docRequest.Partitions) != 0 {
-		// check partition
-		for _, pid := range *docRequest.Partitions {
-			found := false
-			for _, partition := range space.Partitions {
-				if pid == partition.Id {
-					found = true
-					break
-				}
-			}
-			if !found {
-				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("partition id %d not belong to space[%s]", pid, space.Name))
-			}
+// parseDocument parses the json of a document to upsert, all vector fields
+// are required if it has no _id.
+func parseDocument(docJson []byte, space *entity.Space, spaceProperties map[string]*entity.SpaceProperties, vectorFieldNum int) (*vearchpb.Document, error) {
+	jsonMap, err := vjson.ByteToJsonMap(docJson)
+	if err != nil {
+		return nil, err
+	}
+	primaryKey := jsonMap.GetJsonValString(IDField)
+
+	fields, haveVector, err := MapDocument(docJson, space, spaceProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	if haveVector != vectorFieldNum {
+		if primaryKey == "" {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("vector field num:%d is not equal to vector num of space fields:%d and document_id is empty", haveVector, vectorFieldNum))
 		}
-		args.Partitions = *docRequest.Partitions
 	}
 
+	expireField, err := parseExpireField(space, jsonMap.GetJsonVal(entity.TTLField), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if expireField != nil {
+		fields = append(fields, expireField)
+	}
+
+	return &vearchpb.Document{PKey: primaryKey, Fields: fields}, nil
+}
+
+// parseExpireField returns the expiry field of a document written at now
+// whose _ttl is ttl, nil if the document never expires. Without _ttl the
+// document expires after the seconds of the ttl of its space.
+func parseExpireField(space *entity.Space, ttl any, now time.Time) (*vearchpb.Field, error) {
+	if space.TTL == nil || space.TTL.Field != "" {
+		if ttl != nil {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s can not be used, space %s has no ttl without field", entity.TTLField, space.Name))
+		}
+		return nil, nil
+	}
+	var seconds int64
+	if ttl != nil {
+		var err error
+		if seconds, err = cast.ToInt64E(ttl); err != nil || seconds <= 0 {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s %v should be a positive integer", entity.TTLField, ttl))
+		}
+	}
+	expireAt := space.TTL.ExpireAt(now, seconds)
+	if expireAt == 0 {
+		return nil, nil
+	}
+	return processField(entity.ExpireAtField, vearchpb.FieldType_DATE, cbbytes.Int64ToByte(expireAt), vearchpb.FieldOption_Index)
+}
+
+func documentParse(ctx context.Context, handler *DocumentHandler, r *http.Request, docRequest *request.DocumentRequest, space *entity.Space, args *vearchpb.BulkRequest) error {
+	spaceProperties := space.SpaceProperties
+	if spaceProperties == nil {
+		spaceProperties, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+
+	vectorFieldNum := vectorFieldNum(spaceProperties)
+
+	partitions, err := parsePartitions(docRequest.Partitions, space)
+	if err != nil {
+		return err
+	}
+	args.Partitions = partitions
+
 	docs := make([]*vearchpb.Document, 0, len(docRequest.Documents))
 	for _, docJson := range docRequest.Documents {
-		jsonMap, err := vjson.ByteToJsonMap(docJson)
+		doc, err := parseDocument(docJson, space, spaceProperties, vectorFieldNum)
 		if err != nil {
 			return err
 		}
-		primaryKey := jsonMap.GetJsonValString(IDField)
+		docs = append(docs, doc)
+	}
 
-		fields, haveVector, err := MapDocument(docJson, space, spaceProperties)
-		if err != nil {
-			return err
+	if len(docs) == 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("empty documents, should set at least one document"))
+	}
+
+	args.Docs = docs
+	return nil
+}
+
+func checkUpdateOp(op vearchpb.UpdateOp, field *vearchpb.Field) error {
+	switch op {
+	case vearchpb.UpdateOp_INCREMENT:
+		switch field.Type {
+		case vearchpb.FieldType_INT, vearchpb.FieldType_LONG, vearchpb.FieldType_FLOAT, vearchpb.FieldType_DOUBLE:
+			return nil
 		}
+	case vearchpb.UpdateOp_APPEND, vearchpb.UpdateOp_REMOVE:
+		if field.Type == vearchpb.FieldType_STRINGARRAY {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("field:[%s] type:[%s] not support update operation %s", field.Name, field.Type, strings.ToLower(op.String())))
+}
+
+// documentUpdateParse parses the partial updates of docRequest, the fields
+// of a document carry their update operation.
+func documentUpdateParse(docRequest *request.UpdateDocumentRequest, space *entity.Space, args *vearchpb.UpdateRequest) error {
+	spaceProperties := space.SpaceProperties
+	if spaceProperties == nil {
+		spaceProperties, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+
+	partitions, err := parsePartitions(docRequest.Partitions, space)
+	if err != nil {
+		return err
+	}
+	args.Partitions = partitions
 
-		if haveVector != vectorFieldNum {
-			if primaryKey == "" {
-				return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("vector field num:%d is not equal to vector num of space fields:%d and document_id is empty", haveVector, vectorFieldNum))
+	docs := make([]*vearchpb.Document, 0, len(docRequest.Documents))
+	for _, updateDoc := range docRequest.Documents {
+		if updateDoc == nil || updateDoc.ID == "" {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s of document to update should be set", IDField))
+		}
+
+		operations := []struct {
+			op   vearchpb.UpdateOp
+			data json.RawMessage
+		}{
+			{vearchpb.UpdateOp_SET, updateDoc.Set},
+			{vearchpb.UpdateOp_INCREMENT, updateDoc.Increment},
+			{vearchpb.UpdateOp_APPEND, updateDoc.Append},
+			{vearchpb.UpdateOp_REMOVE, updateDoc.Remove},
+		}
+		fields := make([]*vearchpb.Field, 0)
+		updated := make(map[string]bool)
+		for _, operation := range operations {
+			if len(operation.data) == 0 {
+				continue
+			}
+			opFields, _, err := MapDocument(operation.data, space, spaceProperties)
+			if err != nil {
+				return err
+			}
+			// a _ttl set restarts the expiry of the document
+			if operation.op == vearchpb.UpdateOp_SET {
+				jsonMap, err := vjson.ByteToJsonMap(operation.data)
+				if err != nil {
+					return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+				}
+				if ttl := jsonMap.GetJsonVal(entity.TTLField); ttl != nil {
+					expireField, err := parseExpireField(space, ttl, time.Now())
+					if err != nil {
+						return err
+					}
+					if expireField != nil {
+						opFields = append(opFields, expireField)
+					}
+				}
 			}
+			for _, field := range opFields {
+				if updated[field.Name] {
+					return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("field:[%s] of document:[%s] updated more than once", field.Name, updateDoc.ID))
+				}
+				if err := checkUpdateOp(operation.op, field); err != nil {
+					return err
+				}
+				updated[field.Name] = true
+				field.UpdateOp = operation.op
+				fields = append(fields, field)
+			}
+		}
+		if len(fields) == 0 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("document:[%s] has no field to update", updateDoc.ID))
 		}
 
-		docs = append(docs, &vearchpb.Document{PKey: primaryKey, Fields: fields})
+		docs = append(docs, &vearchpb.Document{PKey: updateDoc.ID, Fields: fields})
 	}
 
 	if len(docs) == 0 {
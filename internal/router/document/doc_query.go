@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cast"
+	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/entity/request"
 	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
@@ -39,9 +40,36 @@ const (
 	UrlQueryOpType   = "op_type"
 	UrlQueryTimeout  = "timeout"
 	DefaultSize      = 50
-	WeightedRanker   = "WeightedRanker"
 )
 
+// DefaultMaxResultWindow is the max offset + limit of a search or query if
+// max_result_window is not set in the router config.
+const DefaultMaxResultWindow int32 = 10000
+
+func maxResultWindow() int32 {
+	if config.Conf().Router.MaxResultWindow > 0 {
+		return config.Conf().Router.MaxResultWindow
+	}
+	return DefaultMaxResultWindow
+}
+
+// checkResultWindow checks offset and limit of a search or query, every
+// partition returns offset + limit results to the router.
+func checkResultWindow(offset, limit int32) error {
+	if offset < 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("offset [%d] should not be negative", offset))
+	}
+	window := maxResultWindow()
+	if int64(offset)+int64(limit) > int64(window) {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("offset + limit [%d] is greater than the max result window [%d], use scroll to read deeper", int64(offset)+int64(limit), window))
+	}
+	return nil
+}
+
+// ConditionOperatorMatch scores documents by BM25 of a text field instead of
+// filtering them.
+const ConditionOperatorMatch = "MATCH"
+
 const (
 	ConditionOperatorIN    int32 = 1
 	ConditionOperatorNOTIN int32 = 2
@@ -50,6 +78,7 @@ const (
 const (
 	FilterOperatorAnd int32 = 0
 	FilterOperatorOr  int32 = 1
+	FilterOperatorNot int32 = 2
 )
 
 type VectorQuery struct {
@@ -93,61 +122,132 @@ func parseFilter(filters *request.Filter, space *entity.Space) ([]*vearchpb.Rang
 		} else {
 			return nil, nil, operator, vearchpb.NewError(vearchpb.ErrorEnum_FILTER_OPERATOR_TYPE_ERR, nil)
 		}
-		rangeConditionMap := make(map[string][]*request.Condition)
-		termConditionMap := make(map[string][]*Term)
-		for _, condition := range filters.Conditions {
-			if condition.Operator == "<" || condition.Operator == "<=" ||
-				condition.Operator == ">" || condition.Operator == ">=" ||
-				condition.Operator == "=" || condition.Operator == "<>" ||
-				condition.Operator == "!=" {
-				rangeConditionMap[condition.Field] = append(rangeConditionMap[condition.Field], &condition)
-			} else if condition.Operator == "IN" {
-				tmp := make([]string, 0)
-				err := json.Unmarshal(condition.Value, &tmp)
-				if err != nil {
-					log.Error(err)
-					return nil, nil, operator, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
-				}
+		rfs, tfs, err = parseConditions(filters.Operator, filters.Conditions, proMap)
+		if err != nil {
+			return nil, nil, operator, err
+		}
+	}
 
-				tm := &Term{
-					Value:    condition.Value,
-					Operator: ConditionOperatorIN,
-				}
-				termConditionMap[condition.Field] = append(termConditionMap[condition.Field], tm)
-			} else if condition.Operator == "NOT IN" {
-				tmp := make([]string, 0)
-				err := json.Unmarshal(condition.Value, &tmp)
-				if err != nil {
-					log.Error(err)
-					return nil, nil, operator, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
-				}
+	return rfs, tfs, operator, nil
+}
 
-				tm := &Term{
-					Value:    condition.Value,
-					Operator: ConditionOperatorNOTIN,
-				}
-				termConditionMap[condition.Field] = append(termConditionMap[condition.Field], tm)
-			} else {
-				return nil, nil, operator, vearchpb.NewError(vearchpb.ErrorEnum_FILTER_CONDITION_OPERATOR_TYPE_ERR, nil)
+func parseConditions(operator string, conditions []request.Condition, proMap map[string]*entity.SpaceProperties) ([]*vearchpb.RangeFilter, []*vearchpb.TermFilter, error) {
+	rfs := make([]*vearchpb.RangeFilter, 0)
+	tfs := make([]*vearchpb.TermFilter, 0)
+
+	rangeConditionMap := make(map[string][]*request.Condition)
+	termConditionMap := make(map[string][]*Term)
+	for _, condition := range conditions {
+		if condition.Operator == "<" || condition.Operator == "<=" ||
+			condition.Operator == ">" || condition.Operator == ">=" ||
+			condition.Operator == "=" || condition.Operator == "<>" ||
+			condition.Operator == "!=" {
+			rangeConditionMap[condition.Field] = append(rangeConditionMap[condition.Field], &condition)
+		} else if condition.Operator == "IN" {
+			tmp := make([]string, 0)
+			err := json.Unmarshal(condition.Value, &tmp)
+			if err != nil {
+				log.Error(err)
+				return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+			}
+
+			tm := &Term{
+				Value:    condition.Value,
+				Operator: ConditionOperatorIN,
+			}
+			termConditionMap[condition.Field] = append(termConditionMap[condition.Field], tm)
+		} else if condition.Operator == "NOT IN" {
+			tmp := make([]string, 0)
+			err := json.Unmarshal(condition.Value, &tmp)
+			if err != nil {
+				log.Error(err)
+				return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+			}
+
+			tm := &Term{
+				Value:    condition.Value,
+				Operator: ConditionOperatorNOTIN,
 			}
+			termConditionMap[condition.Field] = append(termConditionMap[condition.Field], tm)
+		} else if condition.Operator == ConditionOperatorMatch {
+			return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s condition of field:[%s] is only supported in the top level AND filter of search", ConditionOperatorMatch, condition.Field))
+		} else {
+			return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_FILTER_CONDITION_OPERATOR_TYPE_ERR, nil)
 		}
-		filter, err := parseRange(filters.Operator, rangeConditionMap, proMap)
+	}
+	filter, err := parseRange(operator, rangeConditionMap, proMap)
+	if err != nil {
+		return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("parseRange err %s", err.Error()))
+	}
+	if len(filter) != 0 {
+		rfs = append(rfs, filter...)
+	}
+	tmFilter, err := parseTerm(termConditionMap, proMap)
+	if err != nil {
+		return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("parseTerm err %s", err.Error()))
+	}
+	if len(tmFilter) != 0 {
+		tfs = append(tfs, tmFilter...)
+	}
+	return rfs, tfs, nil
+}
+
+// isFilterTree reports whether filters can't be expressed by the flat
+// range and term filters and has to be sent to ps as a filter tree.
+func isFilterTree(filters *request.Filter) bool {
+	return filters != nil && (len(filters.Filters) > 0 || filters.Operator == "NOT")
+}
+
+func parseFilterTree(filters *request.Filter, space *entity.Space) (*vearchpb.FilterNode, error) {
+	var err error
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		proMap, err = entity.UnmarshalPropertyJSON(space.Fields)
 		if err != nil {
-			return nil, nil, operator, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("parseRange err %s", err.Error()))
-		}
-		if len(filter) != 0 {
-			rfs = append(rfs, filter...)
+			return nil, err
 		}
-		tmFilter, err := parseTerm(termConditionMap, proMap)
+	}
+	return parseFilterNode(filters, proMap)
+}
+
+func parseFilterNode(filters *request.Filter, proMap map[string]*entity.SpaceProperties) (*vearchpb.FilterNode, error) {
+	node := &vearchpb.FilterNode{}
+	// conditions of a NOT group are negated as a whole, so they are ANDed first
+	conditionOperator := filters.Operator
+	switch filters.Operator {
+	case "AND":
+		node.Operator = FilterOperatorAnd
+	case "OR":
+		node.Operator = FilterOperatorOr
+	case "NOT":
+		node.Operator = FilterOperatorNot
+		conditionOperator = "AND"
+	default:
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_FILTER_OPERATOR_TYPE_ERR, nil)
+	}
+	if len(filters.Conditions) == 0 && len(filters.Filters) == 0 {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("filter group with operator %s should have conditions or filters", filters.Operator))
+	}
+
+	rfs, tfs, err := parseConditions(conditionOperator, filters.Conditions, proMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(rfs) > 0 {
+		node.RangeFilters = rfs
+	}
+	if len(tfs) > 0 {
+		node.TermFilters = tfs
+	}
+
+	for i := range filters.Filters {
+		child, err := parseFilterNode(&filters.Filters[i], proMap)
 		if err != nil {
-			return nil, nil, operator, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("parseTerm err %s", err.Error()))
-		}
-		if len(tmFilter) != 0 {
-			tfs = append(tfs, tmFilter...)
+			return nil, err
 		}
+		node.Children = append(node.Children, child)
 	}
-
-	return rfs, tfs, operator, nil
+	return node, nil
 }
 
 func parseSearch(vectors []json.RawMessage, filters *request.Filter, req *vearchpb.SearchRequest, space *entity.Space) error {
@@ -166,17 +266,36 @@ func parseSearch(vectors []json.RawMessage, filters *request.Filter, req *vearch
 		req.VecFields = vqs
 	}
 
-	rfs, tfs, operator, err := parseFilter(filters, space)
+	textQueries, filters, err := parseTextQueries(filters, space)
 	if err != nil {
 		return err
 	}
-	if len(rfs) > 0 {
-		req.RangeFilters = rfs
+	if len(textQueries) > 0 {
+		if reqNum > 1 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s condition not support batch vector search", ConditionOperatorMatch))
+		}
+		req.TextQueries = textQueries
 	}
-	if len(tfs) > 0 {
-		req.TermFilters = tfs
+
+	if isFilterTree(filters) {
+		tree, err := parseFilterTree(filters, space)
+		if err != nil {
+			return err
+		}
+		req.FilterTree = tree
+	} else {
+		rfs, tfs, operator, err := parseFilter(filters, space)
+		if err != nil {
+			return err
+		}
+		if len(rfs) > 0 {
+			req.RangeFilters = rfs
+		}
+		if len(tfs) > 0 {
+			req.TermFilters = tfs
+		}
+		req.Operator = operator
 	}
-	req.Operator = operator
 
 	if reqNum <= 0 {
 		reqNum = 1
@@ -186,19 +305,66 @@ func parseSearch(vectors []json.RawMessage, filters *request.Filter, req *vearch
 	return nil
 }
 
+// parseTextQueries takes the MATCH conditions out of the top level AND group
+// of filters and returns them with the rest of filters.
+func parseTextQueries(filters *request.Filter, space *entity.Space) ([]*vearchpb.TextQuery, *request.Filter, error) {
+	if filters == nil || filters.Operator != "AND" {
+		return nil, filters, nil
+	}
+
+	var err error
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		proMap, err = entity.UnmarshalPropertyJSON(space.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	textQueries := make([]*vearchpb.TextQuery, 0)
+	conditions := make([]request.Condition, 0, len(filters.Conditions))
+	for _, condition := range filters.Conditions {
+		if condition.Operator != ConditionOperatorMatch {
+			conditions = append(conditions, condition)
+			continue
+		}
+		pro := proMap[condition.Field]
+		if pro == nil {
+			return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("field:[%s] not found in space fields", condition.Field))
+		}
+		if !pro.IsFullText() {
+			return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("field:[%s] not set %s index, can not %s", condition.Field, entity.FullTextIndexType, ConditionOperatorMatch))
+		}
+		var query string
+		if err := vjson.Unmarshal(condition.Value, &query); err != nil || strings.TrimSpace(query) == "" {
+			return nil, nil, vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("%s value of field:[%s] should be a non empty string", ConditionOperatorMatch, condition.Field))
+		}
+		textQueries = append(textQueries, &vearchpb.TextQuery{Field: condition.Field, Query: query})
+	}
+	if len(textQueries) == 0 {
+		return nil, filters, nil
+	}
+
+	if len(conditions) == 0 && len(filters.Filters) == 0 {
+		return textQueries, nil, nil
+	}
+	return textQueries, &request.Filter{Operator: filters.Operator, Conditions: conditions, Filters: filters.Filters}, nil
+}
+
+// parseRanker checks the ranker of a search by the registered rankers, the
+// retrievers are the vectors and then the text queries. Without a ranker a
+// search with text queries is fused by RRF.
 func parseRanker(data json.RawMessage, req *vearchpb.SearchRequest) error {
-	ranker := &request.Ranker{}
-	err := vjson.Unmarshal(data, ranker)
+	ranker, err := request.ParseRanker(data)
 	if err != nil {
-		err = vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("ranker param convert json %s err: %v", string(data), err))
-		return err
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
 	}
-	if ranker.Type != WeightedRanker {
-		err = vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("unsupport ranker type: %s, now only support %s", ranker.Type, WeightedRanker))
-		return err
+	if ranker == nil {
+		return nil
+	}
+	if err := ranker.Check(len(req.VecFields) + len(req.TextQueries)); err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
 	}
-	// TODO
-	// check ranker.Params
 	req.Ranker = string(data)
 	return nil
 }
@@ -1291,7 +1457,13 @@ func queryRequestToPb(searchDoc *request.SearchDocumentRequest, space *entity.Sp
 
 	queryReq.SortFields = sortFieldArr
 
-	if searchDoc.Filters != nil {
+	if isFilterTree(searchDoc.Filters) {
+		tree, err := parseFilterTree(searchDoc.Filters, space)
+		if err != nil {
+			return err
+		}
+		queryReq.FilterTree = tree
+	} else if searchDoc.Filters != nil {
 		rfs, tfs, operator, err := parseFilter(searchDoc.Filters, space)
 		if err != nil {
 			return err
@@ -1305,6 +1477,12 @@ func queryRequestToPb(searchDoc *request.SearchDocumentRequest, space *entity.Sp
 		queryReq.Operator = operator
 	}
 	if searchDoc.DocumentIds != nil && len(*searchDoc.DocumentIds) > 0 {
+		if searchDoc.Offset != 0 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("offset can't be used with document_ids"))
+		}
+		if len(searchDoc.Aggregations) > 0 {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("aggregations can't be used with document_ids"))
+		}
 		queryReq.DocumentIds = *searchDoc.DocumentIds
 		queryReq.Limit = int32(len(queryReq.DocumentIds))
 	}
@@ -1318,8 +1496,136 @@ func queryRequestToPb(searchDoc *request.SearchDocumentRequest, space *entity.Sp
 	if queryReq.Limit <= 0 {
 		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("query limit[topN] is zero"))
 	}
+	queryReq.Offset = searchDoc.Offset
+	if err := checkResultWindow(queryReq.Offset, queryReq.Limit); err != nil {
+		return err
+	}
+	if queryReq.Aggregations, err = parseAggregations(searchDoc.Aggregations, space); err != nil {
+		return err
+	}
 
 	queryReq.Head.ClientType = searchDoc.LoadBalance
+	return setConsistency(searchDoc, queryReq.Head)
+}
+
+// setConsistency sets the consistency of a read to its head, linearizable
+// reads go to the leaders.
+func setConsistency(searchDoc *request.SearchDocumentRequest, head *vearchpb.RequestHead) error {
+	switch searchDoc.Consistency {
+	case "", request.Eventual:
+	case request.BoundedStaleness:
+		head.MaxStaleness = searchDoc.MaxStaleness
+	case request.ReadYourWrites:
+		if searchDoc.WriteToken == "" {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("write_token is required by consistency %s", request.ReadYourWrites))
+		}
+		readIndex, err := request.DecodeWriteToken(strings.Split(searchDoc.WriteToken, ",")...)
+		if err != nil {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+		}
+		head.ReadIndex = readIndex
+	case request.Linearizable:
+		head.ClientType = request.Leader
+	default:
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("unknown consistency %s, should be one of %s, %s, %s and %s",
+			searchDoc.Consistency, request.Eventual, request.BoundedStaleness, request.ReadYourWrites, request.Linearizable))
+	}
+	head.Consistency = searchDoc.Consistency
+	return nil
+}
+
+const (
+	// maxGroupSize is the most hits a group of a grouped search can have.
+	maxGroupSize = 100
+	// groupByFetchFactor is how many times groups * group_size hits each
+	// partition returns for a grouped search, hits of the same value are
+	// collapsed after the partitions are merged.
+	groupByFetchFactor = 4
+)
+
+// parseGroupBy sets the group by of a search, topN becomes the hits read
+// from each partition.
+func parseGroupBy(groupBy *request.GroupBy, space *entity.Space, searchReq *vearchpb.SearchRequest) error {
+	proMap := space.SpaceProperties
+	if proMap == nil {
+		proMap, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+	pro := proMap[groupBy.Field]
+	if pro == nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("group_by field [%s] is not exist in the space", groupBy.Field))
+	}
+	switch pro.FieldType {
+	case vearchpb.FieldType_STRING, vearchpb.FieldType_INT, vearchpb.FieldType_LONG,
+		vearchpb.FieldType_FLOAT, vearchpb.FieldType_DOUBLE, vearchpb.FieldType_DATE:
+	default:
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("group_by field [%s] should be string, numeric or date", groupBy.Field))
+	}
+	if searchReq.Offset != 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("offset can't be used with group_by"))
+	}
+
+	groupSize := groupBy.GroupSize
+	if groupSize == 0 {
+		groupSize = 1
+	}
+	if groupSize < 0 || groupSize > maxGroupSize {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("group_by group_size [%d] should be between 1 and %d", groupBy.GroupSize, maxGroupSize))
+	}
+	groups := groupBy.Groups
+	if groups == 0 {
+		groups = searchReq.TopN
+	}
+	if groups <= 0 {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("group_by groups [%d] should be greater than 0", groupBy.Groups))
+	}
+	if err := checkResultWindow(0, groups*groupSize); err != nil {
+		return err
+	}
+
+	searchReq.GroupBy = &vearchpb.GroupBy{Field: groupBy.Field, GroupSize: groupSize, Groups: groups}
+	searchReq.TopN = int32(min(int64(groups)*int64(groupSize)*groupByFetchFactor, int64(maxResultWindow())))
+	for _, field := range searchReq.Fields {
+		if field == groupBy.Field {
+			return nil
+		}
+	}
+	searchReq.Fields = append(searchReq.Fields, groupBy.Field)
+	return nil
+}
+
+// maxScrollSize is the largest page a scroll can ask for.
+const maxScrollSize = 10000
+
+func scrollRequestToPb(cursor *request.ScrollCursor, space *entity.Space, scrollReq *vearchpb.ScrollRequest) error {
+	scrollReq.IsVectorValue = cursor.VectorValue
+	scrollReq.Fields = cursor.Fields
+	scrollReq.Head.ClientType = cursor.LoadBalance
+
+	spaceProKeyMap := space.SpaceProperties
+	if spaceProKeyMap == nil {
+		spaceProKeyMap, _ = entity.UnmarshalPropertyJSON(space.Fields)
+	}
+	for _, field := range scrollReq.Fields {
+		if field != entity.IdField && spaceProKeyMap[field] == nil {
+			return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, fmt.Errorf("field [%s] is not exist in the space", field))
+		}
+	}
+
+	if isFilterTree(cursor.Filters) {
+		tree, err := parseFilterTree(cursor.Filters, space)
+		if err != nil {
+			return err
+		}
+		scrollReq.FilterTree = tree
+	} else if cursor.Filters != nil {
+		rfs, tfs, operator, err := parseFilter(cursor.Filters, space)
+		if err != nil {
+			return err
+		}
+		scrollReq.RangeFilters = rfs
+		scrollReq.TermFilters = tfs
+		scrollReq.Operator = operator
+	}
 	return nil
 }
 
@@ -1337,6 +1643,10 @@ func requestToPb(searchDoc *request.SearchDocumentRequest, space *entity.Space,
 	if searchReq.TopN == 0 {
 		searchReq.TopN = DefaultSize
 	}
+	searchReq.Offset = searchDoc.Offset
+	if err := checkResultWindow(searchReq.Offset, searchReq.TopN); err != nil {
+		return err
+	}
 
 	if searchReq.Head.Params != nil && searchReq.Head.Params["queryOnlyId"] != "" {
 		searchReq.Fields = []string{entity.IdField}
@@ -1442,13 +1752,22 @@ func requestToPb(searchDoc *request.SearchDocumentRequest, space *entity.Space,
 		return err
 	}
 
-	if searchDoc.Ranker != nil && string(searchDoc.Ranker) != "" && len(searchDoc.Vectors) > 1 {
+	if len(searchReq.TextQueries) > 0 || len(searchDoc.Vectors) > 1 {
 		err = parseRanker(searchDoc.Ranker, searchReq)
 		if err != nil {
 			return err
 		}
 	}
 
+	if searchReq.Aggregations, err = parseAggregations(searchDoc.Aggregations, space); err != nil {
+		return err
+	}
+	if searchDoc.GroupBy != nil {
+		if err = parseGroupBy(searchDoc.GroupBy, space, searchReq); err != nil {
+			return err
+		}
+	}
+
 	searchReq.Head.ClientType = searchDoc.LoadBalance
-	return nil
+	return setConsistency(searchDoc, searchReq.Head)
 }
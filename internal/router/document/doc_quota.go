@@ -0,0 +1,245 @@
+// Copyright 2019 The Vearch Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package document
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vearch/vearch/v3/internal/client"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/pkg/log"
+	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+)
+
+// usageRefreshInterval is how long the usage of a space is used before it's
+// queried from the partition leaders again.
+const usageRefreshInterval = 10 * time.Second
+
+// spaceUsage is the storage used by a space when it was queried, and the
+// documents and bytes accepted by this router since.
+type spaceUsage struct {
+	docNum        int64
+	size          int64
+	acceptedDocs  int64
+	acceptedBytes int64
+	updateTime    time.Time
+	refreshing    bool
+}
+
+// exceeded returns a QUOTA_EXCEEDED error if accepting docs and bytes more
+// exceeds quota. Other routers accept writes to the space too, so a router
+// only takes an even share of what was left of the quota at the query, the
+// check is conservative and doesn't need the routers to coordinate.
+func (u *spaceUsage) exceeded(key string, quota *entity.SpaceQuota, docs, bytes int64) error {
+	routers := entity.RouterCount()
+	if quota.MaxDocuments > 0 && u.docNum+(u.acceptedDocs+docs)*routers > quota.MaxDocuments {
+		return vearchpb.NewError(vearchpb.ErrorEnum_QUOTA_EXCEEDED, fmt.Errorf("space %s has about %d documents, writing %d exceeds max_documents %d", key, u.docNum+u.acceptedDocs, docs, quota.MaxDocuments))
+	}
+	if quota.MaxBytes > 0 && u.size+(u.acceptedBytes+bytes)*routers > quota.MaxBytes {
+		return vearchpb.NewError(vearchpb.ErrorEnum_QUOTA_EXCEEDED, fmt.Errorf("space %s has about %d bytes, writing %d exceeds max_bytes %d", key, u.size+u.acceptedBytes, bytes, quota.MaxBytes))
+	}
+	return nil
+}
+
+// quotaChecker enforces the quotas of spaces on upsert.
+type quotaChecker struct {
+	client *client.Client
+	mu     sync.Mutex
+	usages map[string]*spaceUsage
+}
+
+func newQuotaChecker(client *client.Client) *quotaChecker {
+	return &quotaChecker{client: client, usages: make(map[string]*spaceUsage)}
+}
+
+// usage returns the quota of the space and its usage, nil if the space has
+// no quota.
+func (q *quotaChecker) usage(ctx context.Context, db, spaceName string) (*entity.SpaceQuota, *spaceUsage, error) {
+	if !entity.HasSpaceQuota() {
+		return nil, nil, nil
+	}
+	quota := entity.GetSpaceQuota(db, spaceName)
+	if quota == nil {
+		return nil, nil, nil
+	}
+	key := entity.LimitSpaceKey(db, spaceName)
+
+	q.mu.Lock()
+	usage, ok := q.usages[key]
+	q.mu.Unlock()
+	if !ok {
+		// the first write waits for the usage, later ones refresh it in background
+		num, size, err := q.query(ctx, db, spaceName)
+		if err != nil {
+			return nil, nil, err
+		}
+		usage = &spaceUsage{docNum: num, size: size, updateTime: time.Now()}
+		q.mu.Lock()
+		q.usages[key] = usage
+		q.mu.Unlock()
+	}
+
+	q.mu.Lock()
+	if time.Since(usage.updateTime) > usageRefreshInterval && !usage.refreshing {
+		usage.refreshing = true
+		go q.refresh(usage, db, spaceName)
+	}
+	q.mu.Unlock()
+	return quota, usage, nil
+}
+
+// check returns a QUOTA_EXCEEDED error if upserting docs to the space
+// exceeds its quota. Near the quota, documents whose _id is already in the
+// space are not counted, upserting them doesn't add documents.
+func (q *quotaChecker) check(ctx context.Context, head *vearchpb.RequestHead, db, spaceName string, docs []*vearchpb.Document) error {
+	quota, usage, err := q.usage(ctx, db, spaceName)
+	if quota == nil || err != nil {
+		return err
+	}
+	key := entity.LimitSpaceKey(db, spaceName)
+
+	q.mu.Lock()
+	err = usage.exceeded(key, quota, int64(len(docs)), docBytes(docs))
+	q.mu.Unlock()
+	if err != nil {
+		newDocs, getErr := q.newDocs(ctx, head, docs)
+		if getErr != nil {
+			log.Error("get documents of space %s for quota err: %v", key, getErr)
+			return err
+		}
+		docs = newDocs
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	num, bytes := int64(len(docs)), docBytes(docs)
+	if err := usage.exceeded(key, quota, num, bytes); err != nil {
+		return err
+	}
+	usage.acceptedDocs += num
+	usage.acceptedBytes += bytes
+	return nil
+}
+
+// checkUpdate returns a QUOTA_EXCEEDED error if the partial updates of docs
+// exceed the quota of the space. An update adds no documents, the values of
+// its fields are counted as bytes, an append grows the document by them.
+func (q *quotaChecker) checkUpdate(ctx context.Context, db, spaceName string, docs []*vearchpb.Document) error {
+	quota, usage, err := q.usage(ctx, db, spaceName)
+	if quota == nil || err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	bytes := docBytes(docs)
+	if err := usage.exceeded(entity.LimitSpaceKey(db, spaceName), quota, 0, bytes); err != nil {
+		return err
+	}
+	usage.acceptedBytes += bytes
+	return nil
+}
+
+// newDocs returns the documents of docs not in the space yet.
+func (q *quotaChecker) newDocs(ctx context.Context, head *vearchpb.RequestHead, docs []*vearchpb.Document) ([]*vearchpb.Document, error) {
+	keys := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if doc.PKey != "" {
+			keys = append(keys, doc.PKey)
+		}
+	}
+	if len(keys) == 0 {
+		return docs, nil
+	}
+	request := client.NewRouterRequest(ctx, q.client)
+	request.SetMsgID(head.Params["request_id"]).SetMethod(client.GetDocsHandler).SetHead(head).SetSpace().SetDocsByKey(keys).PartitionDocs()
+	if request.Err != nil {
+		return nil, request.Err
+	}
+	exist := make(map[string]struct{}, len(keys))
+	for _, item := range request.Execute() {
+		if item == nil || item.Doc == nil {
+			continue
+		}
+		if item.Err == nil || item.Err.Code == vearchpb.ErrorEnum_SUCCESS {
+			exist[item.Doc.PKey] = struct{}{}
+		}
+	}
+	newDocs := make([]*vearchpb.Document, 0, len(docs))
+	for _, doc := range docs {
+		if _, ok := exist[doc.PKey]; !ok || doc.PKey == "" {
+			newDocs = append(newDocs, doc)
+		}
+	}
+	return newDocs, nil
+}
+
+// docBytes is the size of the field values of docs.
+func docBytes(docs []*vearchpb.Document) int64 {
+	var size int64
+	for _, doc := range docs {
+		for _, field := range doc.Fields {
+			if field != nil {
+				size += int64(len(field.Value))
+			}
+		}
+	}
+	return size
+}
+
+func (q *quotaChecker) refresh(usage *spaceUsage, db, spaceName string) {
+	ctx, cancel := context.WithTimeout(context.Background(), usageRefreshInterval)
+	defer cancel()
+	docs, size, err := q.query(ctx, db, spaceName)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	usage.refreshing = false
+	if err != nil {
+		log.Error("refresh usage of space %s/%s err: %v", db, spaceName, err)
+		return
+	}
+	usage.docNum, usage.size, usage.updateTime = docs, size, time.Now()
+	usage.acceptedDocs, usage.acceptedBytes = 0, 0
+}
+
+// query sums the documents and data size of the partition leaders of a space.
+func (q *quotaChecker) query(ctx context.Context, db, spaceName string) (docNum int64, size int64, err error) {
+	cache := q.client.Master().Cache()
+	space, err := cache.SpaceByCache(ctx, db, spaceName)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, sp := range space.Partitions {
+		partition, err := cache.PartitionByCache(ctx, space.Name, sp.Id)
+		if err != nil {
+			return 0, 0, err
+		}
+		server, err := cache.ServerByCache(ctx, partition.LeaderID)
+		if err != nil {
+			return 0, 0, err
+		}
+		info, err := client.PartitionInfo(server.RpcAddr(), partition.Id, false)
+		if err != nil {
+			return 0, 0, err
+		}
+		docNum += int64(info.DocNum)
+		size += info.Size
+	}
+	return docNum, size, nil
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/entity"
 	"github.com/vearch/vearch/v3/internal/pkg/cbbytes"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
@@ -67,6 +68,9 @@ func documentUpsertResponse(reply *vearchpb.BulkResponse) (map[string]any, error
 	}
 
 	response["document_ids"] = documentIDs
+	if token, ok := reply.Head.GetParams()[client.WriteToken]; ok {
+		response[client.WriteToken] = token
+	}
 
 	return response, nil
 }
@@ -151,6 +155,27 @@ func documentGetResponse(space *entity.Space, reply *vearchpb.GetResponse, retur
 	return response, nil
 }
 
+func documentScrollResponse(space *entity.Space, docs []*vearchpb.Document, vectorValue bool, scrollID string) (map[string]any, error) {
+	response := make(map[string]any)
+	response["total"] = len(docs)
+
+	documents := make([]map[string]any, 0, len(docs))
+	for _, item := range docs {
+		doc := make(map[string]any)
+		doc["_id"] = item.PKey
+		if _, err := DocFieldSerialize(item, space, map[string]string{}, vectorValue, doc); err != nil {
+			return nil, vearchpb.NewError(vearchpb.ErrorEnum_QUERY_RESPONSE_PARSE_ERR, errors.New("get data err:"+err.Error()))
+		}
+		documents = append(documents, doc)
+	}
+	response["documents"] = documents
+	// the scroll is finished when no scroll_id is returned
+	if scrollID != "" {
+		response["scroll_id"] = scrollID
+	}
+	return response, nil
+}
+
 func documentQueryResponse(srs []*vearchpb.SearchResult, head *vearchpb.ResponseHead, space *entity.Space) (map[string]any, error) {
 	response := make(map[string]any)
 
@@ -425,6 +450,9 @@ func deleteByQueryResult(resp *vearchpb.DelByQueryeResponse) (map[string]any, er
 	} else {
 		result["document_ids"] = []string{}
 	}
+	if token, ok := resp.Head.GetParams()[client.WriteToken]; ok {
+		result[client.WriteToken] = token
+	}
 
 	return result, nil
 }
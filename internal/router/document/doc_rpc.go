@@ -24,8 +24,13 @@ import (
 
 	"github.com/spf13/cast"
 	"github.com/vearch/vearch/v3/internal/client"
+	"github.com/vearch/vearch/v3/internal/config"
+	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 )
 
 const defaultTimeOutMs = 1 * 1000
@@ -35,20 +40,21 @@ type Request interface {
 }
 
 type RpcHandler struct {
+	vearchpb.UnimplementedRouterGRPCServiceServer
 	client     *client.Client
 	docService docService
 }
 
-// func ExportRpcHandler(rpcServer *grpc.Server, client *client.Client) {
-// 	docService := newDocService(client)
+func ExportRpcHandler(rpcServer *grpc.Server, client *client.Client) {
+	docService := newDocService(client)
 
-// 	rpcHandler := &RpcHandler{
-// 		client:     client,
-// 		docService: *docService,
-// 	}
+	rpcHandler := &RpcHandler{
+		client:     client,
+		docService: *docService,
+	}
 
-// 	vearchpb.RegisterRouterGRPCServiceServer(rpcServer, rpcHandler)
-// }
+	vearchpb.RegisterRouterGRPCServiceServer(rpcServer, rpcHandler)
+}
 
 func (handler *RpcHandler) Space(ctx context.Context, req *vearchpb.RequestHead) (reply *vearchpb.Table, err error) {
 	defer func() {
@@ -56,6 +62,9 @@ func (handler *RpcHandler) Space(ctx context.Context, req *vearchpb.RequestHead)
 			err = vearchpb.NewError(vearchpb.ErrorEnum_RECOVER, errors.New(cast.ToString(r)))
 		}
 	}()
+	if _, _, err := handler.auth(ctx, req, entity.ResourceSpace, entity.ReadOnly); err != nil {
+		return nil, err
+	}
 	space, err := handler.client.Space(ctx, req.DbName, req.SpaceName)
 
 	reply = &vearchpb.Table{}
@@ -227,12 +236,45 @@ func (handler *RpcHandler) Bulk(ctx context.Context, req *vearchpb.BulkRequest)
 	return reply, nil
 }
 
+func (handler *RpcHandler) Update(ctx context.Context, req *vearchpb.UpdateRequest) (reply *vearchpb.BulkResponse, err error) {
+	defer Cost("update", time.Now())
+	res, err := handler.deal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	reply, ok := res.(*vearchpb.BulkResponse)
+	if !ok {
+		return nil, vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, nil)
+	}
+	return reply, nil
+}
+
 func (handler *RpcHandler) deal(ctx context.Context, req Request) (reply interface{}, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			err = vearchpb.NewError(vearchpb.ErrorEnum_RECOVER, errors.New(cast.ToString(r)))
 		}
 	}()
+	privilege := entity.WriteOnly
+	switch req.(type) {
+	case *vearchpb.GetRequest, *vearchpb.SearchRequest:
+		privilege = entity.ReadOnly
+	}
+	user, role, err := handler.auth(ctx, req.GetHead(), entity.ResourceDocument, privilege)
+	if privilege == entity.WriteOnly && handler.docService.auditor != nil {
+		defer func() {
+			handler.audit(ctx, req, user, role, reply, err)
+		}()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if head := req.GetHead(); head != nil {
+		db, space := handler.docService.resolveTarget(ctx, head.DbName, head.SpaceName)
+		if err = entity.AllowRequest(user, db, space, privilege == entity.WriteOnly); err != nil {
+			return nil, err
+		}
+	}
 	ctx, cancel := handler.setTimeout(ctx, req.GetHead())
 	defer func() {
 		if cancel != nil {
@@ -246,6 +288,8 @@ func (handler *RpcHandler) deal(ctx context.Context, req Request) (reply interfa
 		reply = handler.docService.deleteDocs(ctx, v)
 	case *vearchpb.BulkRequest:
 		reply = handler.docService.bulk(ctx, v)
+	case *vearchpb.UpdateRequest:
+		reply = handler.docService.update(ctx, v)
 	case *vearchpb.SearchRequest:
 		reply = handler.docService.search(ctx, v)
 	default:
@@ -254,6 +298,110 @@ func (handler *RpcHandler) deal(ctx context.Context, req Request) (reply interfa
 	return reply, nil
 }
 
+// auth checks the user of the head of a request has privilege on resource
+// of its space, and returns the user and its role. A head without user name
+// has the token of an api key as password.
+func (handler *RpcHandler) auth(ctx context.Context, head *vearchpb.RequestHead, resource entity.Resource, privilege entity.Privilege) (string, string, error) {
+	if config.Conf().Global.SkipAuth {
+		return "", "", nil
+	}
+	if head == nil {
+		return "", "", vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, fmt.Errorf("request head is empty"))
+	}
+	authType := entity.BasicAuth
+	if head.UserName == "" {
+		authType = entity.BearerAuth
+	}
+	user, roles, err := handler.docService.authenticate(ctx, authType, head.UserName, head.Password)
+	if err != nil {
+		return head.UserName, "", vearchpb.NewError(vearchpb.ErrorEnum_AUTHENTICATION_FAILED, err)
+	}
+	target := func() (string, string) {
+		return handler.docService.resolveTarget(ctx, head.DbName, head.SpaceName)
+	}
+	return user, roles[0].Name, entity.Authorize(roles, resource, privilege, target)
+}
+
+// audit records a mutating request, its outcome is err or the error of the
+// head of reply.
+func (handler *RpcHandler) audit(ctx context.Context, req Request, user, role string, reply interface{}, err error) {
+	record := &entity.AuditRecord{
+		User:     user,
+		Role:     role,
+		Method:   "RPC",
+		Endpoint: fmt.Sprintf("%T", req),
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		record.SourceIP = p.Addr.String()
+	}
+	if head := req.GetHead(); head != nil {
+		record.DB, record.Space = head.DbName, head.SpaceName
+		record.RequestID = head.Params["request_id"]
+	}
+	if err != nil {
+		vErr := vearchpb.NewError(vearchpb.ErrorEnum_INTERNAL_ERROR, err)
+		record.Code, record.Msg = int(vErr.GetError().Code), vErr.GetError().Msg
+	} else if r, ok := reply.(interface{ GetHead() *vearchpb.ResponseHead }); ok && r.GetHead().GetErr() != nil {
+		record.Code, record.Msg = int(r.GetHead().GetErr().Code), r.GetHead().GetErr().Msg
+	}
+	handler.docService.auditor.Audit(record)
+}
+
+// Changes streams the changes of a space after the positions of req, until
+// the stream is closed.
+func (handler *RpcHandler) Changes(req *vearchpb.ChangesRequest, stream grpc.ServerStreamingServer[vearchpb.ChangesResponse]) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = vearchpb.NewError(vearchpb.ErrorEnum_RECOVER, errors.New(cast.ToString(r)))
+		}
+	}()
+	ctx := stream.Context()
+	user, _, err := handler.auth(ctx, req.GetHead(), entity.ResourceDocument, entity.ReadOnly)
+	if err != nil {
+		return err
+	}
+	if req.Head == nil {
+		req.Head = &vearchpb.RequestHead{}
+	}
+	r := &request.ChangesRequest{DbName: req.Head.DbName, SpaceName: req.Head.SpaceName, Limit: req.Limit, WaitMs: req.WaitMs}
+	if err := r.Validate(); err != nil {
+		return vearchpb.NewError(vearchpb.ErrorEnum_PARAM_ERROR, err)
+	}
+	db, space := handler.docService.resolveTarget(ctx, req.Head.DbName, req.Head.SpaceName)
+	if err := entity.AllowRequest(user, db, space, false); err != nil {
+		return err
+	}
+	req.Head.DbName, req.Head.SpaceName = db, space
+	positions := req.Positions
+	if positions == nil {
+		positions = make(map[uint32]uint64)
+	}
+	waitMs := req.WaitMs
+	if waitMs == 0 {
+		waitMs = defaultChangesWaitMs
+	}
+	for ctx.Err() == nil {
+		args := &vearchpb.ChangesRequest{Head: req.Head, Positions: positions, Limit: req.Limit, WaitMs: waitMs}
+		reply := handler.docService.changes(ctx, args)
+		if err := changesError(reply); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for pid, position := range reply.Positions {
+			positions[pid] = position
+		}
+		if len(reply.Events) == 0 {
+			continue
+		}
+		if err := stream.Send(reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Cost record how long the function use
 func Cost(name string, t time.Time) {
 	engTime := time.Now()
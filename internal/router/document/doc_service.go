@@ -16,11 +16,16 @@ package document
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/entity"
+	"github.com/vearch/vearch/v3/internal/entity/request"
+	"github.com/vearch/vearch/v3/internal/pkg/audit"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/proto/vearchpb"
 	"github.com/vearch/vearch/v3/internal/ps/engine/sortorder"
@@ -29,12 +34,16 @@ import (
 const defaultRpcTimeOut int64 = 10 * 1000 // 10 second
 
 type docService struct {
-	client *client.Client
+	client  *client.Client
+	auditor *audit.Auditor
+	quota   *quotaChecker
 }
 
 func newDocService(client *client.Client) *docService {
 	return &docService{
-		client: client,
+		client:  client,
+		auditor: audit.New(config.Conf().Audit, "router", client.Master().Store),
+		quota:   newQuotaChecker(client),
 	}
 }
 
@@ -103,6 +112,10 @@ func (docService *docService) deleteDocs(ctx context.Context, args *vearchpb.Del
 
 func (docService *docService) bulk(ctx context.Context, args *vearchpb.BulkRequest) *vearchpb.BulkResponse {
 	reply := &vearchpb.BulkResponse{Head: newOkHead()}
+	db, space := docService.resolveTarget(ctx, args.Head.DbName, args.Head.SpaceName)
+	if err := docService.quota.check(ctx, args.Head, db, space, args.Docs); err != nil {
+		return &vearchpb.BulkResponse{Head: setErrHead(err)}
+	}
 	request := client.NewRouterRequest(ctx, docService.client)
 	request.SetMsgID(args.Head.Params["request_id"]).SetMethod(client.BatchHandler).SetHead(args.Head).SetSpace().SetDocs(args.Docs).SetDocsField().UpsertByPartitions(args.Partitions)
 	if request.Err != nil {
@@ -114,6 +127,58 @@ func (docService *docService) bulk(ctx context.Context, args *vearchpb.BulkReque
 	return reply
 }
 
+func (docService *docService) update(ctx context.Context, args *vearchpb.UpdateRequest) *vearchpb.BulkResponse {
+	reply := &vearchpb.BulkResponse{Head: newOkHead()}
+	db, space := docService.resolveTarget(ctx, args.Head.DbName, args.Head.SpaceName)
+	if err := docService.quota.checkUpdate(ctx, db, space, args.Docs); err != nil {
+		return &vearchpb.BulkResponse{Head: setErrHead(err)}
+	}
+	request := client.NewRouterRequest(ctx, docService.client)
+	request.SetMsgID(args.Head.Params["request_id"]).SetMethod(client.UpdateHandler).SetHead(args.Head).SetSpace().SetDocs(args.Docs).SetDocsField().UpsertByPartitions(args.Partitions)
+	if request.Err != nil {
+		log.Errorf("update args:[%v] error: [%s]", args, request.Err)
+		return &vearchpb.BulkResponse{Head: setErrHead(request.Err)}
+	}
+	reply.Items = request.Execute()
+	reply.Head.Params = request.GetMD()
+	return reply
+}
+
+// maxScrollRequests limits the partition reads of one scroll page, a page
+// with a selective filter may hold fewer documents than asked for.
+const maxScrollRequests = 64
+
+// scroll reads up to size documents from the positions of cursor, partition
+// by partition, and moves the positions past the documents read.
+func (docService *docService) scroll(ctx context.Context, args *vearchpb.ScrollRequest, cursor *request.ScrollCursor, size int32) ([]*vearchpb.Document, error) {
+	request := client.NewRouterRequest(ctx, docService.client)
+	request.SetMsgID(args.Head.Params["request_id"]).SetMethod(client.ScrollHandler).SetHead(args.Head).SetSpace()
+	if request.Err != nil {
+		log.Errorf("scroll args:[%v] error: [%s]", args, request.Err)
+		return nil, request.Err
+	}
+
+	docs := make([]*vearchpb.Document, 0, size)
+	requests := 0
+	for _, pos := range cursor.Positions {
+		for !pos.Done && len(docs) < int(size) && requests < maxScrollRequests {
+			args.Docid = pos.Docid
+			args.Size = size - int32(len(docs))
+			resp := request.ScrollExecute(pos.PartitionID, args)
+			requests++
+			if resp.Head.Err != nil && resp.Head.Err.Code != vearchpb.ErrorEnum_SUCCESS {
+				return nil, vearchpb.NewError(resp.Head.Err.Code, errors.New(resp.Head.Err.Msg))
+			}
+			docs = append(docs, resp.Documents...)
+			pos.Docid, pos.Done = resp.Docid, resp.Done
+		}
+		if len(docs) >= int(size) || requests >= maxScrollRequests {
+			break
+		}
+	}
+	return docs, nil
+}
+
 // utils
 func setErrHead(err error) *vearchpb.ResponseHead {
 	vErr, ok := err.(*vearchpb.VearchErr)
@@ -139,6 +204,91 @@ func (docService *docService) getUser(ctx context.Context, userName string) (*en
 	return docService.client.Master().Cache().UserByCache(ctx, userName)
 }
 
+// authenticate checks the credentials of a request and returns its user and
+// the roles it must be allowed by: the role of the user, and the role of
+// the api key if it has one.
+func (docService *docService) authenticate(ctx context.Context, authType, name, secret string) (string, []*entity.Role, error) {
+	var keyRoleName *string
+	if authType == entity.BearerAuth {
+		key, err := docService.getAPIKey(ctx, secret)
+		if err != nil {
+			return "", nil, err
+		}
+		name, keyRoleName = key.User, key.RoleName
+	}
+
+	user, err := docService.getUser(ctx, name)
+	if err != nil {
+		return "", nil, fmt.Errorf("auth user %s is invalid", name)
+	}
+	if authType == entity.BasicAuth {
+		if user.Password == nil || !entity.CheckPassword(*user.Password, secret) {
+			return "", nil, fmt.Errorf("auth password is invalid")
+		}
+		if !entity.IsPasswordHashed(*user.Password) {
+			docService.migrateUserPassword(name, secret)
+		}
+	}
+	role, err := docService.getRole(ctx, *user.RoleName)
+	if err != nil {
+		return "", nil, err
+	}
+	roles := []*entity.Role{role}
+	// an api key has at most the privileges of its user
+	if keyRoleName != nil {
+		keyRole, err := docService.getRole(ctx, *keyRoleName)
+		if err != nil {
+			return "", nil, err
+		}
+		roles = append(roles, keyRole)
+	}
+	return name, roles, nil
+}
+
+// resolveTarget returns the space a request on db and space reads or
+// writes, space may be an alias.
+func (docService *docService) resolveTarget(ctx context.Context, db, space string) (string, string) {
+	if space == "" {
+		return db, space
+	}
+	if alias, err := docService.client.Master().Cache().AliasByCache(ctx, space); err == nil {
+		space = alias.SpaceName
+	}
+	return db, space
+}
+
+// getAPIKey returns the api key of a bearer token once its secret is checked.
+func (docService *docService) getAPIKey(ctx context.Context, token string) (*entity.APIKey, error) {
+	id, secret, err := entity.ParseAPIKeyToken(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := docService.client.Master().Cache().APIKeyByCache(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := key.Check(secret); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// migratingUsers are the users whose password is being hashed
+var migratingUsers sync.Map
+
+// migrateUserPassword hashes the plaintext password of a user in background.
+func (docService *docService) migrateUserPassword(userName, password string) {
+	if _, ok := migratingUsers.LoadOrStore(userName, struct{}{}); ok {
+		return
+	}
+	go func() {
+		defer migratingUsers.Delete(userName)
+		if err := docService.client.Master().MigrateUserPassword(context.Background(), userName, password); err != nil {
+			log.Error("migrate password of user %s err: %v", userName, err)
+		}
+	}()
+}
+
 func (docService *docService) getRole(ctx context.Context, roleName string) (*entity.Role, error) {
 	if value, exists := entity.RoleMap[roleName]; exists {
 		role := &value
@@ -280,3 +430,27 @@ func (docService *docService) deleteByQuery(ctx context.Context, args *vearchpb.
 
 	return delByQueryResponse
 }
+
+// changes reads the changes of each partition of the space after its
+// position, it waits up to wait_ms if there are none.
+func (docService *docService) changes(ctx context.Context, args *vearchpb.ChangesRequest) *vearchpb.ChangesResponse {
+	t := time.Duration(args.WaitMs+defaultRpcTimeOut) * time.Millisecond
+	ctx = context.WithValue(ctx, entity.RPC_TIME_OUT, time.Now().Add(t))
+	ctx, cancel := context.WithTimeout(ctx, t)
+	defer cancel()
+
+	request := client.NewRouterRequest(ctx, docService.client)
+	request.SetMsgID(args.Head.Params["request_id"]).SetMethod(client.ChangesHandler).SetHead(args.Head).SetSpace().ChangesByPartitions(args)
+	if request.Err != nil {
+		return &vearchpb.ChangesResponse{Head: setErrHead(request.Err)}
+	}
+	return request.ChangesExecute()
+}
+
+// changesError returns the error of the head of reply, nil if it's success.
+func changesError(reply *vearchpb.ChangesResponse) error {
+	if err := reply.GetHead().GetErr(); err != nil && err.Code != vearchpb.ErrorEnum_SUCCESS {
+		return vearchpb.NewError(err.Code, errors.New(err.Msg))
+	}
+	return nil
+}
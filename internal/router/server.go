@@ -24,15 +24,16 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/spf13/cast"
 	"github.com/vearch/vearch/v3/internal/client"
 	"github.com/vearch/vearch/v3/internal/config"
 	"github.com/vearch/vearch/v3/internal/monitor"
 	"github.com/vearch/vearch/v3/internal/pkg/log"
 	"github.com/vearch/vearch/v3/internal/pkg/metrics/mserver"
 	"github.com/vearch/vearch/v3/internal/pkg/netutil"
+	"github.com/vearch/vearch/v3/internal/pkg/tlsutil"
 	"github.com/vearch/vearch/v3/internal/router/document"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type Server struct {
@@ -90,17 +91,25 @@ func NewServer(ctx context.Context) (*Server, error) {
 
 	var rpcServer *grpc.Server
 	if config.Conf().Router.RpcPort > 0 {
+		tlsConfig, err := tlsutil.ServerConfig(config.Conf().TLS.RouterRpc)
+		if err != nil {
+			return nil, err
+		}
 		lis, err := net.Listen("tcp", fmt.Sprintf("%s:%d", addr, config.Conf().Router.RpcPort))
 		if err != nil {
 			panic(fmt.Errorf("start rpc server failed to listen: %v", err))
 		}
-		rpcServer = grpc.NewServer()
+		opts := make([]grpc.ServerOption, 0, 1)
+		if tlsConfig != nil {
+			opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+		}
+		rpcServer = grpc.NewServer(opts...)
 		go func() {
 			if err := rpcServer.Serve(lis); err != nil {
 				panic(fmt.Errorf("start rpc server failed to start: %v", err))
 			}
 		}()
-		// document.ExportRpcHandler(rpcServer, cli)
+		document.ExportRpcHandler(rpcServer, cli)
 	}
 
 	routerCtx, routerCancel := context.WithCancel(ctx)
@@ -136,7 +145,7 @@ func (server *Server) Start() error {
 		monitor.Register(nil, nil, config.Conf().Router.MonitorPort)
 	}
 
-	if err := server.httpServer.Run(cast.ToString(fmt.Sprintf("0.0.0.0:%d", config.Conf().Router.Port))); err != nil {
+	if err := tlsutil.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", config.Conf().Router.Port), server.httpServer, config.Conf().TLS.Router); err != nil {
 		return fmt.Errorf("fail to start http Server, %v", err)
 	}
 	log.Info("router exited!")
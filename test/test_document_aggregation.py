@@ -0,0 +1,187 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for aggregations of search and query """
+
+total = 100
+categories = ["a", "b", "c", "d"]
+
+
+def create_aggregation_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {
+            "name": "field_int",
+            "type": "integer",
+            "index": {"name": "field_int", "type": "SCALAR"},
+        },
+        {
+            "name": "field_float",
+            "type": "float",
+            "index": {"name": "field_float", "type": "SCALAR"},
+        },
+        {
+            "name": "field_string",
+            "type": "string",
+            "index": {"name": "field_string", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=3)
+
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {
+                "_id": str(i),
+                "field_int": i,
+                "field_float": float(i) / 2,
+                "field_string": categories[i % 7 % len(categories)],
+                "field_vector": xb[i].tolist(),
+            }
+            for i in range(total)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+
+def post(interface, data):
+    data["db_name"] = db_name
+    data["space_name"] = space_name
+    rs = requests.post(
+        router_url + "/document/" + interface, auth=(username, password), json=data
+    )
+    return rs.json()
+
+
+aggregations = {
+    "categories": {"type": "terms", "field": "field_string", "size": 2},
+    "ranges": {
+        "type": "range",
+        "field": "field_int",
+        "ranges": [{"to": 50}, {"key": "high", "from": 50}],
+    },
+    "histogram": {"type": "histogram", "field": "field_int", "interval": 25},
+    "stats": {"type": "stats", "field": "field_float"},
+}
+
+
+def check_aggregations(aggs, ids):
+    counts = {}
+    for i in ids:
+        key = categories[i % 7 % len(categories)]
+        counts[key] = counts.get(key, 0) + 1
+    top = sorted(counts.items(), key=lambda kv: (-kv[1], kv[0]))[:2]
+    buckets = aggs["categories"]["buckets"]
+    assert [(b["key"], b["count"]) for b in buckets] == top
+    assert aggs["categories"]["other_count"] == len(ids) - sum(c for _, c in top)
+
+    buckets = aggs["ranges"]["buckets"]
+    assert buckets[0]["key"] == "*-50"
+    assert buckets[0]["count"] == len([i for i in ids if i < 50])
+    assert buckets[1]["key"] == "high"
+    assert buckets[1]["count"] == len([i for i in ids if i >= 50])
+
+    buckets = aggs["histogram"]["buckets"]
+    for b in buckets:
+        key = int(b["key"])
+        assert b["count"] == len([i for i in ids if key <= i < key + 25])
+
+    stats = aggs["stats"]
+    assert stats["count"] == len(ids)
+    assert stats["min"] == min(ids) / 2
+    assert stats["max"] == max(ids) / 2
+    assert abs(stats["sum"] - sum(ids) / 2) < 1e-6
+
+
+def test_document_aggregation():
+    create_aggregation_space()
+
+    filters = {
+        "operator": "AND",
+        "conditions": [{"field": "field_int", "operator": ">=", "value": 10}],
+    }
+    ids = list(range(10, total))
+
+    rs = post("query", {"filters": filters, "limit": 1, "aggregations": aggregations})
+    assert rs["code"] == 0
+    assert len(rs["data"]["documents"]) == 1
+    check_aggregations(rs["data"]["aggregations"], ids)
+
+    # aggregations of a search are over all documents matching the filters
+    vectors = [{"field": "field_vector", "feature": xb[0].tolist()}]
+    rs = post(
+        "search",
+        {
+            "vectors": vectors,
+            "filters": filters,
+            "limit": 3,
+            "aggregations": aggregations,
+        },
+    )
+    assert rs["code"] == 0
+    assert len(rs["data"]["documents"][0]) == 3
+    check_aggregations(rs["data"]["aggregations"], ids)
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_aggregation_badcase():
+    create_aggregation_space()
+
+    filters = {
+        "operator": "AND",
+        "conditions": [{"field": "field_int", "operator": ">=", "value": 0}],
+    }
+    bad_aggregations = [
+        {"x": {"type": "unknown", "field": "field_int"}},
+        {"x": {"type": "terms", "field": "field_int"}},
+        {"x": {"type": "terms", "field": "field_string", "size": 100000}},
+        {"x": {"type": "stats", "field": "field_string"}},
+        {"x": {"type": "stats", "field": "field_unknown"}},
+        {"x": {"type": "histogram", "field": "field_int", "interval": 0}},
+        {"x": {"type": "range", "field": "field_int", "ranges": []}},
+        {"x": {"type": "range", "field": "field_int", "ranges": [{"from": 5, "to": 1}]}},
+    ]
+    for aggs in bad_aggregations:
+        rs = post("query", {"filters": filters, "aggregations": aggs})
+        assert rs["code"] != 0
+
+    rs = post("query", {"document_ids": ["1"], "aggregations": aggregations})
+    assert rs["code"] != 0
+
+    destroy(router_url, db_name, space_name)
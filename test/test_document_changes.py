@@ -0,0 +1,193 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import json
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for changes of documents """
+
+
+sift10k = DatasetSift10K()
+xb = sift10k.get_database()
+
+
+def create_changes_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {"name": "field_int", "type": "integer"},
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=2)
+
+
+def upsert(start, end):
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {"_id": str(i), "field_int": i, "field_vector": xb[i].tolist()}
+            for i in range(start, end)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+
+def changes(**params):
+    data = {"db_name": db_name, "space_name": space_name}
+    data.update(params)
+    rs = requests.post(
+        router_url + "/document/changes", auth=(username, password), json=data
+    )
+    logger.info(rs.json())
+    return rs.json()
+
+
+def read_all(positions=None, limit=0):
+    events = []
+    positions = positions or {}
+    while True:
+        rs = changes(positions=positions, limit=limit)
+        assert rs["code"] == 0
+        positions = {int(k): v for k, v in rs["data"]["positions"].items()}
+        if len(rs["data"]["events"]) == 0:
+            return events, positions
+        events.extend(rs["data"]["events"])
+
+
+def test_document_changes():
+    create_changes_space()
+    upsert(0, 20)
+    data = {"db_name": db_name, "space_name": space_name, "document_ids": ["0", "1"]}
+    rs = requests.post(
+        router_url + "/document/delete", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+    events, positions = read_all(limit=3)
+    upserts = [e for e in events if e["op"] == "upsert"]
+    deletes = [e for e in events if e["op"] == "delete"]
+    assert len(upserts) == 20
+    assert sorted(e["_id"] for e in deletes) == ["0", "1"]
+    for e in upserts:
+        assert e["fields"]["field_int"] == int(e["_id"])
+        assert e["index"] > 0 and e["timestamp"] > 0
+    assert len(positions) == 2
+
+    # the positions resume after the events read
+    upsert(20, 25)
+    events, _ = read_all(positions)
+    assert sorted(e["_id"] for e in events) == [str(i) for i in range(20, 25)]
+
+    # a read waits for changes after the positions
+    rs = changes(positions=positions, wait_ms=100)
+    assert rs["code"] == 0
+
+    # an update event has the values after the update
+    _, positions = read_all(positions)
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [{"_id": "5", "increment": {"field_int": 10}}],
+    }
+    rs = requests.post(
+        router_url + "/document/update", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    events, _ = read_all(positions)
+    assert len(events) == 1 and events[0]["op"] == "update"
+    assert events[0]["_id"] == "5" and events[0]["fields"]["field_int"] == 15
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_changes_stream():
+    create_changes_space()
+    upsert(0, 10)
+
+    params = {"db_name": db_name, "space_name": space_name, "wait_ms": 100}
+    rs = requests.get(
+        router_url + "/document/changes",
+        auth=(username, password),
+        params=params,
+        headers={"Accept": "text/event-stream"},
+        stream=True,
+        timeout=10,
+    )
+    assert rs.status_code == 200
+    ids, last_id = [], ""
+    for line in rs.iter_lines(decode_unicode=True):
+        if line.startswith("data: "):
+            ids.append(json.loads(line[len("data: "):])["_id"])
+        elif line.startswith("id: "):
+            last_id = line[len("id: "):]
+        if len(ids) == 10 and last_id != "":
+            break
+    rs.close()
+    assert sorted(ids) == sorted(str(i) for i in range(10))
+
+    # a reconnected stream resumes from the last event id
+    upsert(10, 11)
+    rs = requests.get(
+        router_url + "/document/changes",
+        auth=(username, password),
+        params=params,
+        headers={"Last-Event-ID": last_id},
+        stream=True,
+        timeout=10,
+    )
+    for line in rs.iter_lines(decode_unicode=True):
+        if line.startswith("data: "):
+            assert json.loads(line[len("data: "):])["_id"] == "10"
+            break
+    rs.close()
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_changes_badcase():
+    create_changes_space()
+    upsert(0, 10)
+
+    assert changes(limit=100000)["code"] != 0
+    assert changes(wait_ms=-1)["code"] != 0
+    assert changes(space_name="not_exist_space")["code"] != 0
+
+    rs = requests.get(
+        router_url + "/document/changes",
+        auth=(username, password),
+        params={"db_name": db_name, "space_name": space_name, "positions": "x"},
+    )
+    assert rs.json()["code"] != 0
+
+    destroy(router_url, db_name, space_name)
@@ -0,0 +1,163 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for read consistency """
+
+
+sift10k = DatasetSift10K()
+xb = sift10k.get_database()
+
+
+def create_consistency_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {"name": "field_int", "type": "integer"},
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=2)
+
+
+def upsert(start, end):
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {"_id": str(i), "field_int": i, "field_vector": xb[i].tolist()}
+            for i in range(start, end)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    return rs.json()["data"]
+
+
+def query(ids, **params):
+    data = {"db_name": db_name, "space_name": space_name, "document_ids": ids}
+    data.update(params)
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    logger.info(rs.json())
+    return rs.json()
+
+
+def search(**params):
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "vectors": [{"field": "field_vector", "feature": xb[0].tolist()}],
+        "limit": 10,
+    }
+    data.update(params)
+    rs = requests.post(
+        router_url + "/document/search", auth=(username, password), json=data
+    )
+    logger.info(rs.json())
+    return rs.json()
+
+
+def test_document_read_your_writes():
+    create_consistency_space()
+
+    tokens = []
+    for i in range(5):
+        rs = upsert(i * 10, (i + 1) * 10)
+        assert rs["write_token"] != ""
+        tokens.append(rs["write_token"])
+
+        # the write is visible to the read with its token
+        rs = query([str(i * 10)], consistency="read_your_writes", write_token=rs["write_token"])
+        assert rs["code"] == 0
+        assert rs["data"]["documents"][0]["field_int"] == i * 10
+
+    rs = query(
+        [str(i) for i in range(50)],
+        consistency="read_your_writes",
+        write_token=",".join(tokens),
+    )
+    assert rs["code"] == 0
+    assert rs["data"]["total"] == 50
+
+    rs = search(consistency="read_your_writes", write_token=tokens[-1])
+    assert rs["code"] == 0
+    assert rs["data"]["documents"][0][0]["_id"] == "0"
+
+    # delete returns a token too
+    data = {"db_name": db_name, "space_name": space_name, "document_ids": ["0"]}
+    rs = requests.post(
+        router_url + "/document/delete", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    rs = query(["0"], consistency="read_your_writes", write_token=rs.json()["data"]["write_token"])
+    assert rs["data"]["total"] == 0
+
+    destroy(router_url, db_name, space_name)
+
+
+@pytest.mark.parametrize(
+    ["consistency", "params"],
+    [
+        ["eventual", {}],
+        ["bounded_staleness", {}],
+        ["bounded_staleness", {"max_staleness": 1}],
+        ["linearizable", {}],
+    ],
+)
+def test_document_consistency_levels(consistency, params):
+    create_consistency_space()
+    upsert(0, 20)
+
+    rs = query([str(i) for i in range(20)], consistency=consistency, **params)
+    assert rs["code"] == 0
+    assert rs["data"]["total"] == 20
+
+    rs = search(consistency=consistency, **params)
+    assert rs["code"] == 0
+    assert len(rs["data"]["documents"][0]) == 10
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_consistency_badcase():
+    create_consistency_space()
+    upsert(0, 10)
+
+    assert query(["1"], consistency="strong")["code"] != 0
+    assert search(consistency="strong")["code"] != 0
+    # read_your_writes requires a write token
+    assert query(["1"], consistency="read_your_writes")["code"] != 0
+    assert query(["1"], consistency="read_your_writes", write_token="!")["code"] != 0
+
+    destroy(router_url, db_name, space_name)
@@ -0,0 +1,129 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for group_by of search """
+
+total = 200
+chunks = 5
+
+
+def create_group_by_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {
+            "name": "field_source",
+            "type": "string",
+            "index": {"name": "field_source", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=3)
+
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {
+                "_id": str(i),
+                "field_source": "source_" + str(i // chunks),
+                "field_vector": xb[i].tolist(),
+            }
+            for i in range(total)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+
+def search(data):
+    data["db_name"] = db_name
+    data["space_name"] = space_name
+    data["vectors"] = [{"field": "field_vector", "feature": xb[0].tolist()}]
+    rs = requests.post(
+        router_url + "/document/search", auth=(username, password), json=data
+    )
+    return rs.json()
+
+
+@pytest.mark.parametrize(
+    ["group_size", "groups"],
+    [[1, 10], [2, 5], [3, 0]],
+)
+def test_document_group_by(group_size, groups):
+    create_group_by_space()
+
+    limit = 8
+    rs = search({"limit": total})
+    assert rs["code"] == 0
+    hits = rs["data"]["documents"][0]
+
+    # the expected groups are computed from the whole ranked result
+    expected = []
+    counts = {}
+    for hit in hits:
+        source = hit["field_source"]
+        if source not in counts and len(counts) >= (groups or limit):
+            continue
+        if counts.get(source, 0) >= group_size:
+            continue
+        counts[source] = counts.get(source, 0) + 1
+        expected.append(hit["_id"])
+
+    group_by = {"field": "field_source", "group_size": group_size}
+    if groups:
+        group_by["groups"] = groups
+    rs = search({"limit": limit, "group_by": group_by})
+    assert rs["code"] == 0
+    documents = rs["data"]["documents"][0]
+    assert [doc["_id"] for doc in documents] == expected
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_group_by_badcase():
+    create_group_by_space()
+
+    bad_group_by = [
+        {"field": "field_unknown"},
+        {"field": "field_vector"},
+        {"field": "field_source", "group_size": -1},
+        {"field": "field_source", "group_size": 1000},
+        {"field": "field_source", "groups": 100000},
+    ]
+    for group_by in bad_group_by:
+        assert search({"limit": 10, "group_by": group_by})["code"] != 0
+    assert search({"limit": 10, "offset": 10, "group_by": {"field": "field_source"}})["code"] != 0
+
+    destroy(router_url, db_name, space_name)
@@ -0,0 +1,189 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import io
+import time
+import json
+import requests
+import pytest
+import zstandard as zstd
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for document import """
+
+
+sift10k = DatasetSift10K()
+xb = sift10k.get_database()
+
+
+def create_import_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {"name": "field_int", "type": "integer"},
+        {
+            "name": "field_string",
+            "type": "string",
+            "index": {"name": "field_string", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=2)
+
+
+def import_progress(import_id):
+    rs = requests.get(
+        router_url + "/document/import/" + import_id,
+        auth=(username, password),
+        params={"db_name": db_name, "space_name": space_name},
+    )
+    return rs.json()
+
+
+def wait_import(rs, timeout=60):
+    """imports run in background, wait until it's finished"""
+    if rs["code"] != 0:
+        return rs
+    for _ in range(timeout * 10):
+        rs = import_progress(rs["data"]["import_id"])
+        if rs["code"] != 0 or rs["data"]["status"] != "running":
+            break
+        time.sleep(0.1)
+    logger.info(rs)
+    return rs
+
+
+def import_files(files, **params):
+    params.update({"db_name": db_name, "space_name": space_name})
+    rs = requests.post(
+        router_url + "/document/import",
+        auth=(username, password),
+        params=params,
+        files=[("file", f) for f in files],
+    )
+    logger.info(rs.json())
+    return wait_import(rs.json())
+
+
+def ndjson(docs):
+    return "".join(json.dumps(doc) + "\n" for doc in docs).encode()
+
+
+def test_document_import_ndjson():
+    create_import_space()
+
+    docs = [
+        {
+            "_id": str(i),
+            "field_int": i,
+            "field_string": "s" + str(i),
+            "field_vector": xb[i].tolist(),
+        }
+        for i in range(100)
+    ]
+    data = ndjson(docs[:50]) + b"{bad json\n" + ndjson([{"_id": "x", "field_int": "a"}])
+    rs = import_files(
+        [
+            ("docs.json", io.BytesIO(data)),
+            ("docs_1.zst", io.BytesIO(zstd.ZstdCompressor().compress(ndjson(docs[50:])))),
+        ],
+        batch_size=20,
+    )
+    assert rs["code"] == 0
+    assert rs["data"]["status"] == "succeeded"
+    files = rs["data"]["files"]
+    assert files[0]["format"] == "ndjson"
+    assert files[0]["imported"] == 50
+    assert files[0]["rejected"] == 2
+    assert [row["row"] for row in files[0]["rejected_rows"]] == [51, 52]
+    assert files[1]["format"] == "ndjson.zst"
+    assert files[1]["imported"] == 50
+    assert rs["data"]["imported"] == 100
+    assert get_space_num() == 100
+
+    # columns are renamed by mapping, unmapped columns are skipped
+    rows = [
+        {"id": str(i), "n": i, "v": xb[i].tolist(), "other": 1}
+        for i in range(100, 110)
+    ]
+    rs = import_files(
+        [("rows", io.BytesIO(ndjson(rows)))],
+        format="jsonl",
+        mapping=json.dumps({"id": "_id", "n": "field_int", "v": "field_vector"}),
+    )
+    assert rs["code"] == 0
+    assert rs["data"]["imported"] == 10
+    assert get_space_num() == 110
+
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "document_ids": ["105"],
+    }
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    assert rs.json()["data"]["documents"][0]["field_int"] == 105
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_import_badcase():
+    create_import_space()
+
+    rs = import_files([("docs.csv", io.BytesIO(b"a,b\n"))])
+    assert rs["code"] == 0
+    assert rs["data"]["files"][0]["error"] != ""
+
+    rs = import_files([("docs.json", io.BytesIO(b"{}\n"))], batch_size=100000)
+    assert rs["code"] != 0
+
+    rs = import_files([("docs.json", io.BytesIO(b"{}\n"))], mapping="[1]")
+    assert rs["code"] != 0
+
+    # progress of an import is got with its db and space
+    rs = import_files([("docs.json", io.BytesIO(b"{}\n"))])
+    assert rs["code"] == 0
+    import_id = rs["data"]["import_id"]
+    rs = requests.get(
+        router_url + "/document/import/" + import_id,
+        auth=(username, password),
+        params={"db_name": db_name, "space_name": "other"},
+    )
+    assert rs.json()["code"] != 0
+    assert import_progress("not_exist")["code"] != 0
+
+    # storage and keys are required without files uploaded
+    rs = requests.post(
+        router_url + "/document/import",
+        auth=(username, password),
+        json={"db_name": db_name, "space_name": space_name},
+    )
+    assert rs.json()["code"] != 0
+
+    destroy(router_url, db_name, space_name)
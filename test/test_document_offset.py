@@ -0,0 +1,126 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for offset of search and query """
+
+total = 100
+
+
+def create_offset_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {
+            "name": "field_int",
+            "type": "integer",
+            "index": {"name": "field_int", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=3)
+
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {"_id": str(i), "field_int": i, "field_vector": xb[i].tolist()}
+            for i in range(total)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+
+def post(interface, data):
+    data["db_name"] = db_name
+    data["space_name"] = space_name
+    rs = requests.post(
+        router_url + "/document/" + interface, auth=(username, password), json=data
+    )
+    return rs.json()
+
+
+def test_document_offset():
+    create_offset_space()
+
+    filters = {
+        "operator": "AND",
+        "conditions": [{"field": "field_int", "operator": ">=", "value": 0}],
+    }
+
+    # pages of a query don't overlap and cover all documents
+    ids = []
+    for offset in range(0, total, 30):
+        rs = post("query", {"filters": filters, "limit": 30, "offset": offset})
+        assert rs["code"] == 0
+        ids.extend(doc["_id"] for doc in rs["data"]["documents"])
+    assert sorted(int(i) for i in ids) == list(range(total))
+
+    rs = post("query", {"filters": filters, "limit": 10, "offset": total})
+    assert rs["code"] == 0
+    assert rs["data"]["total"] == 0
+
+    # pages of a search are slices of the whole result
+    vectors = [{"field": "field_vector", "feature": xb[0].tolist()}]
+    rs = post("search", {"vectors": vectors, "limit": 50})
+    assert rs["code"] == 0
+    all_ids = [doc["_id"] for doc in rs["data"]["documents"][0]]
+    for offset in [0, 10, 45]:
+        rs = post("search", {"vectors": vectors, "limit": 10, "offset": offset})
+        assert rs["code"] == 0
+        page = [doc["_id"] for doc in rs["data"]["documents"][0]]
+        assert page == all_ids[offset : offset + 10]
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_offset_badcase():
+    create_offset_space()
+
+    filters = {
+        "operator": "AND",
+        "conditions": [{"field": "field_int", "operator": ">=", "value": 0}],
+    }
+    vectors = [{"field": "field_vector", "feature": xb[0].tolist()}]
+    bad_requests = [
+        ("query", {"filters": filters, "limit": 10, "offset": -1}),
+        ("query", {"filters": filters, "limit": 10, "offset": 9995}),
+        ("query", {"document_ids": ["1"], "offset": 1}),
+        ("search", {"vectors": vectors, "limit": 10, "offset": 9995}),
+        ("delete", {"filters": filters, "limit": 10, "offset": 1}),
+    ]
+    for interface, data in bad_requests:
+        assert post(interface, data)["code"] != 0
+
+    destroy(router_url, db_name, space_name)
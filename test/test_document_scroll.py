@@ -0,0 +1,169 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for document scroll """
+
+total = 500
+
+
+def create_scroll_space(partition_num):
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {
+            "name": "field_int",
+            "type": "integer",
+            "index": {"name": "field_int", "type": "SCALAR"},
+        },
+        {
+            "name": "field_string",
+            "type": "string",
+            "index": {"name": "field_string", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(
+        router_url, embedding_size, properties, partition_num=partition_num
+    )
+
+    batch_size = 100
+    for i in range(total // batch_size):
+        data = {
+            "db_name": db_name,
+            "space_name": space_name,
+            "documents": [
+                {
+                    "_id": str(j),
+                    "field_int": j,
+                    "field_string": "even" if j % 2 == 0 else "odd",
+                    "field_vector": xb[j].tolist(),
+                }
+                for j in range(i * batch_size, (i + 1) * batch_size)
+            ],
+        }
+        rs = requests.post(
+            router_url + "/document/upsert", auth=(username, password), json=data
+        )
+        assert rs.json()["code"] == 0
+
+
+def scroll(data):
+    rs = requests.post(
+        router_url + "/document/scroll", auth=(username, password), json=data
+    )
+    return rs.json()
+
+
+def scroll_all(data):
+    documents = []
+    rs = scroll(data)
+    while True:
+        assert rs["code"] == 0
+        documents.extend(rs["data"]["documents"])
+        if "scroll_id" not in rs["data"]:
+            break
+        rs = scroll({"scroll_id": rs["data"]["scroll_id"], "limit": data["limit"]})
+    return documents
+
+
+@pytest.mark.parametrize(
+    ["partition_num"],
+    [[1], [3]],
+)
+def test_document_scroll(partition_num):
+    create_scroll_space(partition_num)
+
+    data = {"db_name": db_name, "space_name": space_name, "limit": 33}
+    documents = scroll_all(data)
+    assert sorted(int(doc["_id"]) for doc in documents) == list(range(total))
+    assert "field_vector" not in documents[0]
+
+    # filter and projection
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "limit": 20,
+        "fields": ["field_int"],
+        "vector_value": True,
+        "filters": {
+            "operator": "AND",
+            "conditions": [
+                {"field": "field_int", "operator": ">=", "value": 100},
+                {"field": "field_string", "operator": "IN", "value": ["even"]},
+            ],
+        },
+    }
+    documents = scroll_all(data)
+    assert sorted(int(doc["_id"]) for doc in documents) == list(range(100, total, 2))
+    for doc in documents:
+        assert doc["field_int"] == int(doc["_id"])
+        assert "field_string" not in doc
+
+    # vector values are returned when asked for
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "limit": 1,
+        "vector_value": True,
+    }
+    rs = scroll(data)
+    assert rs["code"] == 0
+    assert len(rs["data"]["documents"][0]["field_vector"]) == xb.shape[1]
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_scroll_badcase():
+    create_scroll_space(1)
+
+    bad_requests = [
+        {"scroll_id": "not a scroll id"},
+        {"db_name": db_name, "space_name": space_name, "limit": -1},
+        {"db_name": db_name, "space_name": space_name, "keep_alive": "forever"},
+        {"db_name": db_name, "space_name": space_name, "fields": ["field_unknown"]},
+    ]
+    for data in bad_requests:
+        assert scroll(data)["code"] != 0
+
+    # an expired scroll can't be continued
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "limit": 1,
+        "keep_alive": "1s",
+    }
+    rs = scroll(data)
+    assert rs["code"] == 0
+    time.sleep(3)
+    assert scroll({"scroll_id": rs["data"]["scroll_id"]})["code"] != 0
+
+    destroy(router_url, db_name, space_name)
@@ -0,0 +1,213 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import time
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for ttl of documents """
+
+
+sift10k = DatasetSift10K()
+xb = sift10k.get_database()
+
+
+def create_ttl_space(ttl, with_date=False):
+    embedding_size = xb.shape[1]
+    fields = [
+        {
+            "name": "field_int",
+            "type": "integer",
+            "index": {"name": "field_int", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    if with_date:
+        fields.append(
+            {
+                "name": "field_date",
+                "type": "date",
+                "index": {"name": "field_date", "type": "SCALAR"},
+            }
+        )
+    response = create_db(router_url, db_name)
+    assert response.json()["code"] == 0
+    space_config = {
+        "name": space_name,
+        "partition_num": 1,
+        "replica_num": 1,
+        "fields": fields,
+        "ttl": ttl,
+    }
+    response = create_space(router_url, db_name, space_config)
+    logger.info(response.json())
+    return response.json()
+
+
+def upsert(docs):
+    data = {"db_name": db_name, "space_name": space_name, "documents": docs}
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    logger.info(rs.json())
+    return rs.json()
+
+
+def document(i, **fields):
+    doc = {"_id": str(i), "field_int": i, "field_vector": xb[i].tolist()}
+    doc.update(fields)
+    return doc
+
+
+def query_ids(ids):
+    data = {"db_name": db_name, "space_name": space_name, "document_ids": ids}
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    return sorted(d["_id"] for d in rs.json()["data"]["documents"])
+
+
+def query_all():
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "filters": {
+            "operator": "AND",
+            "conditions": [{"field": "field_int", "operator": ">=", "value": 0}],
+        },
+        "limit": 100,
+    }
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    return sorted(d["_id"] for d in rs.json()["data"]["documents"])
+
+
+def search_all():
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "vectors": [{"field": "field_vector", "feature": xb[0].tolist()}],
+        "limit": 100,
+    }
+    rs = requests.post(
+        router_url + "/document/search", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    return sorted(d["_id"] for d in rs.json()["data"]["documents"][0])
+
+
+def wait_expired(num, timeout=120):
+    for _ in range(timeout):
+        partitions = get_space(router_url, db_name, space_name, detail=True).json()[
+            "data"
+        ]["partitions"]
+        expired = sum(p.get("expiry", {}).get("expired", 0) for p in partitions)
+        if expired >= num:
+            return expired
+        time.sleep(1)
+    return 0
+
+
+def test_document_ttl():
+    assert create_ttl_space({"seconds": 3600})["code"] == 0
+    space = get_space(router_url, db_name, space_name).json()["data"]
+    assert space["ttl"]["seconds"] == 3600
+
+    # documents 0-4 expire in 2 seconds, the others after the space ttl
+    docs = [document(i, _ttl=2) for i in range(5)]
+    docs += [document(i) for i in range(5, 10)]
+    assert upsert(docs)["code"] == 0
+    ids = [str(i) for i in range(10)]
+    assert query_ids(ids) == sorted(ids)
+
+    # expired documents are hidden before they are deleted
+    time.sleep(3)
+    live = sorted(str(i) for i in range(5, 10))
+    assert query_ids(ids) == live
+    assert query_all() == live
+    assert search_all() == live
+
+    # a _ttl set by update restarts the expiry
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [{"_id": "5", "set": {"_ttl": 1}}],
+    }
+    rs = requests.post(
+        router_url + "/document/update", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    time.sleep(2)
+    assert query_ids(["5"]) == []
+
+    assert wait_expired(6) >= 6
+    assert get_space_num() == 4
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_ttl_field():
+    assert create_ttl_space({"seconds": 60, "field": "field_date"}, True)["code"] == 0
+
+    now = int(time.time())
+    docs = [document(i, field_date=now - 3600) for i in range(5)]
+    docs += [document(i, field_date=now) for i in range(5, 10)]
+    assert upsert(docs)["code"] == 0
+
+    live = sorted(str(i) for i in range(5, 10))
+    assert query_all() == live
+    assert search_all() == live
+
+    # a ttl of a date field has no _ttl
+    assert upsert([document(10, field_date=now, _ttl=10)])["code"] != 0
+
+    assert wait_expired(5) >= 5
+    assert get_space_num() == 5
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_ttl_badcase():
+    assert create_ttl_space({"seconds": -1})["code"] != 0
+    drop_db(router_url, db_name)
+    assert create_ttl_space({"seconds": 60, "field": "field_int"})["code"] != 0
+    drop_db(router_url, db_name)
+    assert create_ttl_space({"seconds": 60, "field": "not_exist"})["code"] != 0
+    drop_db(router_url, db_name)
+    assert create_ttl_space({"field": "field_date"}, True)["code"] != 0
+    drop_db(router_url, db_name)
+
+    # documents of a space without ttl have no _ttl
+    assert create_ttl_space(None)["code"] == 0
+    assert upsert([document(0, _ttl=10)])["code"] != 0
+
+    destroy(router_url, db_name, space_name)
@@ -0,0 +1,192 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for document partial update """
+
+
+def create_update_space():
+    embedding_size = xb.shape[1]
+    properties = {}
+    properties["fields"] = [
+        {"name": "field_int", "type": "integer"},
+        {"name": "field_double", "type": "double"},
+        {
+            "name": "field_string",
+            "type": "string",
+            "index": {"name": "field_string", "type": "SCALAR"},
+        },
+        {
+            "name": "field_string_array",
+            "type": "stringArray",
+            "index": {"name": "field_string_array", "type": "SCALAR"},
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "gamma",
+                "type": "FLAT",
+                "params": {"metric_type": "L2"},
+            },
+            "dimension": embedding_size,
+            "store_type": "MemoryOnly",
+        },
+    ]
+    create_for_document_test(router_url, embedding_size, properties, partition_num=2)
+
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {
+                "_id": str(i),
+                "field_int": i,
+                "field_double": float(i),
+                "field_string": "s" + str(i),
+                "field_string_array": ["a", "b"],
+                "field_vector": xb[i].tolist(),
+            }
+            for i in range(10)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+
+def update(documents):
+    data = {"db_name": db_name, "space_name": space_name, "documents": documents}
+    rs = requests.post(
+        router_url + "/document/update", auth=(username, password), json=data
+    )
+    logger.info(rs.json())
+    return rs.json()
+
+
+def get_document(doc_id):
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "document_ids": [doc_id],
+        "vector_value": True,
+    }
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    return rs.json()["data"]["documents"][0]
+
+
+def test_document_update():
+    create_update_space()
+
+    rs = update(
+        [
+            {
+                "_id": "1",
+                "set": {"field_string": "updated"},
+                "increment": {"field_int": 10, "field_double": 0.5},
+                "append": {"field_string_array": ["c", "a"]},
+            },
+            {"_id": "2", "remove": {"field_string_array": ["a"]}},
+        ]
+    )
+    assert rs["code"] == 0
+    assert rs["data"]["total"] == 2
+
+    doc = get_document("1")
+    assert doc["field_string"] == "updated"
+    assert doc["field_int"] == 11
+    assert abs(doc["field_double"] - 1.5) < 1e-6
+    assert doc["field_string_array"] == ["a", "b", "c"]
+    # the vector is kept
+    assert abs(doc["field_vector"][0] - xb[1][0]) < 1e-4
+
+    doc = get_document("2")
+    assert doc["field_string_array"] == ["b"]
+    assert doc["field_int"] == 2
+
+    # updates of the same document in one request are applied in order
+    rs = update(
+        [
+            {"_id": "3", "increment": {"field_int": 1}},
+            {"_id": "3", "increment": {"field_int": 1}},
+        ]
+    )
+    assert rs["code"] == 0
+    assert get_document("3")["field_int"] == 5
+
+    # the updated fields are searchable
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "filters": {
+            "operator": "AND",
+            "conditions": [
+                {"field": "field_string", "operator": "IN", "value": ["updated"]}
+            ],
+        },
+        "limit": 10,
+    }
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    assert [doc["_id"] for doc in rs.json()["data"]["documents"]] == ["1"]
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_document_update_badcase():
+    create_update_space()
+
+    # a missing document is reported by its item
+    rs = update(
+        [
+            {"_id": "1", "set": {"field_int": 100}},
+            {"_id": "not_exist", "set": {"field_int": 100}},
+        ]
+    )
+    assert rs["code"] == 0
+    assert rs["data"]["total"] == 1
+    items = {item["_id"]: item for item in rs["data"]["document_ids"]}
+    assert items["not_exist"]["code"] != 0
+    assert get_document("1")["field_int"] == 100
+
+    bad_documents = [
+        # no _id
+        [{"set": {"field_int": 1}}],
+        # nothing to update
+        [{"_id": "1"}],
+        # increment of a string
+        [{"_id": "1", "increment": {"field_string": "a"}}],
+        # append to a scalar
+        [{"_id": "1", "append": {"field_int": [1]}}],
+        # a field updated twice
+        [{"_id": "1", "set": {"field_int": 1}, "increment": {"field_int": 1}}],
+        # unknown field
+        [{"_id": "1", "set": {"field_unknown": 1}}],
+    ]
+    for documents in bad_documents:
+        assert update(documents)["code"] != 0
+
+    destroy(router_url, db_name, space_name)
@@ -0,0 +1,122 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import time
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for module audit """
+
+
+class TestAudit:
+    def setup_class(self):
+        self.db_name = "audit_db"
+        self.space_name = "audit_space"
+        self.start_time = int(time.time()) - 1
+
+    def test_mutating_calls(self):
+        response = create_db(router_url, self.db_name)
+        assert response.json()["code"] == 0
+        space_config = {
+            "name": self.space_name,
+            "partition_num": 1,
+            "replica_num": 1,
+            "fields": [
+                {"name": "field_int", "type": "integer", "index": {"name": "field_int", "type": "SCALAR"}},
+                {
+                    "name": "field_vector",
+                    "type": "vector",
+                    "dimension": 4,
+                    "index": {"name": "gamma", "type": "FLAT", "params": {"metric_type": "L2"}},
+                },
+            ],
+        }
+        response = create_space(router_url, self.db_name, space_config)
+        assert response.json()["code"] == 0
+
+        data = {
+            "db_name": self.db_name,
+            "space_name": self.space_name,
+            "filters": {
+                "operator": "AND",
+                "conditions": [{"field": "field_int", "operator": ">=", "value": 0}],
+            },
+        }
+        response = requests.post(
+            router_url + "/document/delete", json=data, auth=(username, password)
+        )
+        logger.info(response.json())
+
+        # calls failing auth are audited too
+        response = requests.delete(
+            router_url + "/dbs/" + self.db_name, auth=(username, "wrong_password")
+        )
+        assert response.json()["code"] != 0
+
+        # reads are not audited
+        response = get_space(router_url, self.db_name, self.space_name)
+        assert response.json()["code"] == 0
+
+        response = drop_space(router_url, self.db_name, self.space_name)
+        assert response.json()["code"] == 0
+        response = drop_db(router_url, self.db_name)
+        assert response.json()["code"] == 0
+
+    def test_query(self):
+        time.sleep(2)
+        response = get_audit(router_url, {"start_time": self.start_time})
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        records = response.json().get("data") or []
+        if len(records) == 0:
+            pytest.skip("audit in etcd is not enabled")
+
+        calls = [(r["method"], r["endpoint"], r["code"]) for r in records]
+        assert ("POST", "/dbs/" + self.db_name, 0) in calls
+        assert ("DELETE", "/dbs/" + self.db_name, 0) in calls
+        assert ("DELETE", "/dbs/%s/spaces/%s" % (self.db_name, self.space_name), 0) in calls
+        assert ("GET", "/dbs/%s/spaces/%s" % (self.db_name, self.space_name), 0) not in calls
+
+        deletes = [r for r in records if r["endpoint"] == "/document/delete"]
+        assert len(deletes) == 1
+        assert deletes[0]["module"] == "router"
+        assert deletes[0]["db"] == self.db_name
+        assert deletes[0]["space"] == self.space_name
+        assert deletes[0]["user"] == username
+
+        failed = [r for r in records if r["method"] == "DELETE" and r["code"] != 0]
+        assert len(failed) == 1
+        assert failed[0]["user"] == username
+        assert failed[0]["source_ip"] != ""
+
+        # latest first
+        seqs = [r["seq"] for r in records]
+        assert seqs == sorted(seqs, reverse=True)
+
+        response = get_audit(router_url, {"start_time": self.start_time, "user": "not_exist"})
+        assert response.json()["code"] == 0
+        assert len(response.json().get("data") or []) == 0
+        response = get_audit(router_url, {"start_time": self.start_time, "limit": 1})
+        assert len(response.json()["data"]) == 1
+
+    def test_query_badcase(self):
+        response = get_audit(router_url, {"limit": 0})
+        assert response.json()["code"] != 0
+        response = get_audit(router_url, {"start_time": "not a time"})
+        assert response.json()["code"] != 0
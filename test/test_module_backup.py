@@ -47,8 +47,10 @@ class TestBackup:
         self.secure = self.use_ssl_str.lower() in ['true', '1']
         self.region = os.getenv("S3_REGION", "")
         self.cluster_name = os.getenv("CLUSTER_NAME", "vearch")
+        # the dir should be shared by masters and ps, e.g. by NFS
+        self.local_path = os.getenv("BACKUP_LOCAL_PATH", "/tmp/vearch_backup")
 
-    def backup(self, router_url, command, corrupted=False, error_param=False):
+    def backup(self, router_url, command, corrupted=False, error_param=False, storage="s3"):
         url = router_url + "/backup/dbs/" + self.db_name + "/spaces/" + self.space_name
 
         data = {
@@ -62,6 +64,13 @@ class TestBackup:
                 "use_ssl": self.use_ssl_str.lower() in ['true', '1']
             },
         }
+        if storage == "local":
+            data = {
+                "command": command,
+                "backup_id": 1,
+                "storage": "local",
+                "local_param": {"path": self.local_path},
+            }
         if error_param:
             data["s3_param"]["access_key"] = "error_key"
             response = requests.post(url, auth=(username, password), json=data)
@@ -103,6 +112,8 @@ class TestBackup:
         response = requests.post(url, auth=(username, password), json=data)
 
         assert response.json()["code"] == 0
+        if command == "list":
+            return response.json()["data"]["backup_ids"]
         return response.json()["data"]["backup_id"]
 
     def create_db(self, router_url):
@@ -369,3 +380,218 @@ class TestBackup:
         self.backup(router_url, "export", error_param=True)
 
         destroy(router_url, self.db_name, self.space_name)
+
+    def test_vearch_backup_local(self):
+        embedding_size = self.xb.shape[1]
+        batch_size = 100
+        k = 100
+
+        total = self.xb.shape[0]
+        total_batch = int(total / batch_size)
+
+        self.create_db(router_url)
+        self.create_space(router_url, embedding_size)
+
+        add(total_batch, batch_size, self.xb, with_id=True)
+
+        waiting_index_finish(total)
+
+        backup_id = self.backup(router_url, "create", storage="local")
+        time.sleep(30)
+        assert backup_id in self.backup(router_url, "list", storage="local")
+
+        destroy(router_url, self.db_name, self.space_name)
+        time.sleep(30)
+        self.create_db(router_url)
+
+        self.backup(router_url, "restore", storage="local")
+        waiting_index_finish(total)
+
+        for parallel_on_queries in [0, 1]:
+            self.query(parallel_on_queries, k)
+
+        destroy(router_url, self.db_name, self.space_name)
+
+    @pytest.mark.parametrize(["storage", "local_param"], [
+        ["local", {}],
+        ["local", {"path": "relative/path"}],
+        ["unknown", {}],
+    ])
+    def test_error_storage(self, storage, local_param):
+        url = router_url + "/backup/dbs/" + self.db_name + "/spaces/" + self.space_name
+        data = {"command": "list", "storage": storage, "local_param": local_param}
+        response = requests.post(url, auth=(username, password), json=data)
+        logger.info(response.json())
+        assert response.json()["code"] != 0
+
+    def local_backup(self, command, params=None, is_async=False, db=None, space=None):
+        url = router_url + "/backup/dbs/" + (db or self.db_name) + "/spaces/" + (space or self.space_name)
+        if is_async:
+            url += "?async=true"
+        data = {"command": command, "storage": "local", "local_param": {"path": self.local_path}}
+        data.update(params or {})
+        return requests.post(url, auth=(username, password), json=data)
+
+    def test_backup_versions(self):
+        embedding_size = self.xb.shape[1]
+        batch_size = 100
+        total = self.xb.shape[0]
+
+        self.create_db(router_url)
+        self.create_space(router_url, embedding_size)
+        add(int(total / batch_size / 2), batch_size, self.xb, with_id=True)
+        waiting_index_finish(int(total / 2))
+
+        response = self.local_backup("create", is_async=True)
+        assert response.json()["code"] == 0
+        task = wait_task(router_url, response.json()["data"]["task_id"], 600)
+        assert task["status"] == "succeeded"
+        full_id = task["result"]["backup_id"]
+
+        add(int(total / batch_size), batch_size, self.xb, with_id=True)
+        waiting_index_finish(total)
+
+        response = self.local_backup("create", {"incremental": True, "keep_last": 5}, is_async=True)
+        assert response.json()["code"] == 0
+        task = wait_task(router_url, response.json()["data"]["task_id"], 600)
+        assert task["status"] == "succeeded"
+        incremental_id = task["result"]["backup_id"]
+        assert incremental_id > full_id
+        assert task["result"]["base_backup_id"] == full_id
+
+        response = self.local_backup("list")
+        assert response.json()["data"]["backup_ids"] == [full_id, incremental_id]
+
+        response = self.local_backup("describe", {"backup_id": incremental_id})
+        backup = response.json()["data"]["backup"]
+        logger.info(backup)
+        assert backup["done"]
+        assert backup["doc_num"] == total
+        assert backup["base_backup_id"] == full_id
+        assert len(backup["partitions"]) == 1
+
+        # files of the full backup used by the incremental one are kept
+        response = self.local_backup("delete", {"backup_id": full_id})
+        assert response.json()["code"] == 0
+        response = self.local_backup("list")
+        assert response.json()["data"]["backup_ids"] == [incremental_id]
+        response = self.local_backup("describe", {"backup_id": full_id})
+        assert response.json()["code"] != 0
+
+        destroy(router_url, self.db_name, self.space_name)
+        time.sleep(30)
+        self.create_db(router_url)
+
+        response = self.local_backup("restore", {"backup_id": incremental_id}, is_async=True)
+        task = wait_task(router_url, response.json()["data"]["task_id"], 600)
+        assert task["status"] == "succeeded"
+        waiting_index_finish(total)
+        response = get_space(router_url, self.db_name, self.space_name)
+        assert response.json()["data"]["doc_num"] == total
+
+        response = self.local_backup("delete", {"backup_id": incremental_id})
+        assert response.json()["code"] == 0
+        destroy(router_url, self.db_name, self.space_name)
+
+    def test_backup_restore_target(self):
+        embedding_size = self.xb.shape[1]
+        batch_size = 100
+        total = self.xb.shape[0]
+
+        self.create_db(router_url)
+        self.create_space(router_url, embedding_size)
+        add(int(total / batch_size), batch_size, self.xb, with_id=True)
+        waiting_index_finish(total)
+
+        response = self.local_backup("create", is_async=True)
+        task = wait_task(router_url, response.json()["data"]["task_id"], 600)
+        assert task["status"] == "succeeded"
+        backup_id = task["result"]["backup_id"]
+
+        source = {"db_name": self.db_name, "space_name": self.space_name, "backup_id": backup_id}
+        target_db = self.db_name + "_target"
+        target_space = self.space_name + "_target"
+        create_db(router_url, target_db)
+
+        # resharding needs the restore to run as a task
+        response = self.local_backup("restore", {"source": source, "partition_num": 3}, db=target_db, space=target_space)
+        assert response.json()["code"] != 0
+        response = self.local_backup("create", {"source": source}, db=target_db, space=target_space)
+        assert response.json()["code"] != 0
+
+        # the same partitions as the backup
+        response = self.local_backup("restore", {"source": source}, is_async=True, db=target_db, space=target_space)
+        assert response.json()["code"] == 0
+        task = wait_task(router_url, response.json()["data"]["task_id"], 600)
+        assert task["status"] == "succeeded"
+        response = get_space(router_url, target_db, target_space)
+        assert response.json()["data"]["doc_num"] == total
+        drop_space(router_url, target_db, target_space)
+
+        # resharded to more partitions
+        params = {"source": source, "partition_num": 3}
+        response = self.local_backup("restore", params, is_async=True, db=target_db, space=target_space)
+        assert response.json()["code"] == 0
+        task = wait_task(router_url, response.json()["data"]["task_id"], 1200)
+        logger.info(task)
+        assert task["status"] == "succeeded"
+        assert task["result"]["reshard_space"] != ""
+        response = get_space(router_url, target_db, target_space)
+        assert response.json()["data"]["partition_num"] == 3
+        assert response.json()["data"]["doc_num"] == total
+        response = get_space(router_url, target_db, task["result"]["reshard_space"])
+        assert response.json()["code"] != 0
+
+        response = requests.post(
+            router_url + "/document/query",
+            auth=(username, password),
+            json={"db_name": target_db, "space_name": target_space, "document_ids": ["0"], "vector_value": True},
+        )
+        assert response.json()["data"]["total"] == 1
+
+        destroy(router_url, target_db, target_space)
+        self.local_backup("delete", {"backup_id": backup_id})
+        destroy(router_url, self.db_name, self.space_name)
+
+    def test_backup_schedule(self):
+        self.create_db(router_url)
+        self.create_space(router_url, self.xb.shape[1])
+        url = router_url + "/backup/dbs/" + self.db_name + "/spaces/" + self.space_name + "/schedule"
+
+        schedule = {
+            "interval": "24h",
+            "backup": {
+                "storage": "local",
+                "local_param": {"path": self.local_path},
+                "incremental": True,
+                "keep_last": 7,
+                "keep_days": 30,
+            },
+        }
+        response = requests.put(url, auth=(username, password), json=schedule)
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        assert response.json()["data"]["backup"]["command"] == "create"
+
+        response = requests.get(url, auth=(username, password))
+        assert response.json()["data"]["interval"] == "24h"
+        assert response.json()["data"]["backup"]["keep_last"] == 7
+
+        response = requests.get(router_url + "/backup/schedules", auth=(username, password))
+        assert len([s for s in response.json()["data"] if s["space_name"] == self.space_name]) == 1
+
+        for interval in ["1s", "one day"]:
+            schedule["interval"] = interval
+            response = requests.put(url, auth=(username, password), json=schedule)
+            assert response.json()["code"] != 0
+
+        # retention needs the backup to run as a task
+        response = self.local_backup("create", {"keep_last": 1})
+        assert response.json()["code"] != 0
+
+        response = requests.delete(url, auth=(username, password))
+        assert response.json()["code"] == 0
+        response = requests.get(url, auth=(username, password))
+        assert response.json()["code"] != 0
+
+        destroy(router_url, self.db_name, self.space_name)
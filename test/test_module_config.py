@@ -240,3 +240,89 @@ class TestQueryLimitConfig:
     # destroy
     def test_destroy_cluster(self):
         destroy(router_url, db_name, space_name)
+
+
+class TestKeyedLimitConfig:
+    def setup_class(self):
+        self.xb = xb
+
+    def set_request_limit(self, request_limit):
+        url = router_url + "/config/" + "request_limit"
+        rs = requests.post(url, auth=(username, password), json=request_limit)
+        assert rs.status_code == 200
+        return rs
+
+    def upsert(self, start, num):
+        url = router_url + "/document/upsert"
+        data = {}
+        data["db_name"] = db_name
+        data["space_name"] = space_name
+        data["documents"] = []
+        for i in range(start, start + num):
+            param_dict = {}
+            param_dict["_id"] = str(i)
+            param_dict["field_int"] = i
+            param_dict["field_vector"] = xb[i : i + 1].tolist()[0]
+            data["documents"].append(param_dict)
+        return requests.post(url, auth=(username, password), json=data)
+
+    # prepare
+    def test_prepare_cluster(self):
+        create(router_url, "MemoryOnly")
+
+    def test_space_write_limit(self):
+        space_key = db_name + "/" + space_name
+        self.set_request_limit(
+            {"space_limits": {space_key: {"write_request_limit_count": 0.5}}}
+        )
+        time.sleep(3)
+        rs = self.upsert(0, 1)
+        assert rs.json()["code"] == 0
+        rs = self.upsert(1, 1)
+        assert rs.status_code == 429
+        assert rs.json()["code"] == 800
+
+        self.set_request_limit({"space_limits": {space_key: None}})
+        time.sleep(3)
+        rs = self.upsert(1, 1)
+        assert rs.json()["code"] == 0
+
+    def test_user_write_limit(self):
+        self.set_request_limit(
+            {"user_limits": {username: {"write_request_limit_count": 0.5}}}
+        )
+        time.sleep(3)
+        rs = self.upsert(2, 1)
+        assert rs.json()["code"] == 0
+        rs = self.upsert(3, 1)
+        assert rs.json()["code"] == 800
+
+        self.set_request_limit({"user_limits": {username: None}})
+        time.sleep(3)
+
+    def test_space_document_quota(self):
+        space_key = db_name + "/" + space_name
+        self.set_request_limit({"space_quotas": {space_key: {"max_documents": 5}}})
+        time.sleep(3)
+        rs = self.upsert(3, 2)
+        assert rs.json()["code"] == 0
+        rs = self.upsert(5, 2)
+        assert rs.json()["code"] == 801
+        # upserting documents already in the space adds none
+        rs = self.upsert(3, 2)
+        assert rs.json()["code"] == 0
+
+        self.set_request_limit({"space_quotas": {space_key: None}})
+        time.sleep(3)
+        rs = self.upsert(5, 2)
+        assert rs.json()["code"] == 0
+
+    def test_invalid_space_key(self):
+        url = router_url + "/config/" + "request_limit"
+        request_limit = {"space_quotas": {"no_space": {"max_documents": 5}}}
+        rs = requests.post(url, auth=(username, password), json=request_limit)
+        assert rs.json()["code"] != 0
+
+    # destroy
+    def test_destroy_cluster(self):
+        destroy(router_url, db_name, space_name)
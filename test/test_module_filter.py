@@ -875,6 +875,141 @@ def test_string_array_filter():
     logger.info("String array filter tests completed successfully")
 
 
+def test_nested_filter():
+    """Test nested AND/OR/NOT filter groups for query, search and delete"""
+    dim = xb.shape[1]
+
+    properties = {}
+    properties["fields"] = [
+        {
+            "name": "field_int",
+            "type": "integer",
+            "index": {
+                "name": "field_int",
+                "type": "SCALAR",
+            },
+        },
+        {
+            "name": "field_string",
+            "type": "string",
+            "index": {
+                "name": "field_string",
+                "type": "SCALAR",
+            },
+        },
+        {
+            "name": "field_vector",
+            "type": "vector",
+            "index": {
+                "name": "name",
+                "type": "FLAT",
+                "params": {
+                    "metric_type": "L2",
+                },
+            },
+            "dimension": dim,
+            "store_type": "MemoryOnly",
+        },
+    ]
+
+    create(router_url, properties)
+
+    url = router_url + "/document/upsert"
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {
+                "_id": str(i),
+                "field_int": i,
+                "field_string": ["a", "b", "c", "d"][i],
+                "field_vector": xb[i].tolist(),
+            }
+            for i in range(4)
+        ],
+    }
+    rs = requests.post(url, auth=(username, password), json=data)
+    assert rs.json()["code"] == 0
+
+    # (field_string IN [a, b] AND field_int < 1) OR (field_string = c AND NOT field_int >= 3)
+    filters = {
+        "operator": "OR",
+        "filters": [
+            {
+                "operator": "AND",
+                "conditions": [
+                    {"field": "field_string", "operator": "IN", "value": ["a", "b"]},
+                    {"field": "field_int", "operator": "<", "value": 1},
+                ],
+            },
+            {
+                "operator": "AND",
+                "conditions": [
+                    {"field": "field_string", "operator": "IN", "value": ["c"]},
+                ],
+                "filters": [
+                    {
+                        "operator": "NOT",
+                        "conditions": [
+                            {"field": "field_int", "operator": ">=", "value": 3},
+                        ],
+                    }
+                ],
+            },
+        ],
+    }
+
+    query_url = f"{router_url}/document/query"
+    query_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "vector_value": False,
+        "filters": filters,
+        "limit": 10,
+    }
+    rs = requests.post(query_url, auth=(username, password), json=query_data)
+    assert rs.status_code == 200
+    doc_ids = sorted(doc["_id"] for doc in rs.json()["data"]["documents"])
+    assert doc_ids == ["0", "2"]
+
+    search_url = f"{router_url}/document/search"
+    search_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "vectors": [{"field": "field_vector", "feature": xb[0].tolist()}],
+        "filters": filters,
+        "limit": 10,
+    }
+    rs = requests.post(search_url, auth=(username, password), json=search_data)
+    assert rs.status_code == 200
+    doc_ids = sorted(doc["_id"] for doc in rs.json()["data"]["documents"][0])
+    assert doc_ids == ["0", "2"]
+
+    # an empty group is rejected
+    query_data["filters"] = {"operator": "NOT"}
+    rs = requests.post(query_url, auth=(username, password), json=query_data)
+    assert rs.json()["code"] != 0
+
+    # delete everything except field_string = a
+    delete_url = f"{router_url}/document/delete"
+    delete_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "filters": {
+            "operator": "NOT",
+            "conditions": [
+                {"field": "field_string", "operator": "IN", "value": ["a"]},
+            ],
+        },
+        "limit": 10,
+    }
+    rs = requests.post(delete_url, auth=(username, password), json=delete_data)
+    assert rs.json()["code"] == 0
+    assert get_space_num() == 1
+
+    destroy(router_url, db_name, space_name)
+
+
 @pytest.mark.parametrize(
     ["full_field", "mode"], 
     [
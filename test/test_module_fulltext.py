@@ -0,0 +1,272 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for full text and hybrid search """
+
+
+texts = [
+    "vearch is a distributed vector database",
+    "the quick brown fox jumps over the lazy dog",
+    "hybrid search fuses keyword relevance with vector similarity",
+    "bm25 ranks documents by keyword relevance",
+    "a lazy afternoon",
+]
+
+
+def create_fulltext_space(partition_num=2):
+    dim = xb.shape[1]
+    space_config = {
+        "name": space_name,
+        "partition_num": partition_num,
+        "replica_num": 1,
+        "fields": [
+            {
+                "name": "field_int",
+                "type": "integer",
+                "index": {"name": "field_int", "type": "SCALAR"},
+            },
+            {
+                "name": "content",
+                "type": "text",
+                "index": {
+                    "name": "content",
+                    "type": "FULLTEXT",
+                    "params": {"k1": 1.2, "b": 0.75},
+                },
+            },
+            {
+                "name": "title",
+                "type": "string",
+                "index": {"name": "title", "type": "SCALAR"},
+            },
+            {
+                "name": "field_vector",
+                "type": "vector",
+                "index": {
+                    "name": "field_vector",
+                    "type": "FLAT",
+                    "params": {"metric_type": "InnerProduct"},
+                },
+                "dimension": dim,
+                "store_type": "MemoryOnly",
+            },
+        ],
+    }
+    response = create_db(router_url, db_name)
+    logger.info(response.json())
+    response = create_space(router_url, db_name, space_config)
+    logger.info(response.json())
+    assert response.json()["code"] == 0
+
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {
+                "_id": str(i),
+                "field_int": i,
+                "content": text,
+                "title": "title_" + str(i),
+                "field_vector": xb[i].tolist(),
+            }
+            for i, text in enumerate(texts)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+
+
+def search_ids(search_data):
+    rs = requests.post(
+        router_url + "/document/search", auth=(username, password), json=search_data
+    )
+    logger.info(rs.json())
+    assert rs.json()["code"] == 0
+    return [doc["_id"] for doc in rs.json()["data"]["documents"][0]]
+
+
+def match_filter(query, conditions=[]):
+    return {
+        "operator": "AND",
+        "conditions": [{"field": "content", "operator": "MATCH", "value": query}]
+        + conditions,
+    }
+
+
+def test_fulltext_search():
+    create_fulltext_space()
+
+    search_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "filters": match_filter("keyword relevance"),
+        "limit": 10,
+    }
+    assert sorted(search_ids(search_data)) == ["2", "3"]
+
+    # the shorter document has a higher BM25 score
+    search_data["filters"] = match_filter("lazy")
+    assert search_ids(search_data) == ["4", "1"]
+
+    # scalar filters apply to documents matched by text
+    search_data["filters"] = match_filter(
+        "lazy", [{"field": "field_int", "operator": "<", "value": 3}]
+    )
+    assert search_ids(search_data) == ["1"]
+
+    search_data["filters"] = match_filter("nothing matches this")
+    assert search_ids(search_data) == []
+
+    # deleted documents are removed from the text index
+    rs = requests.post(
+        router_url + "/document/delete",
+        auth=(username, password),
+        json={"db_name": db_name, "space_name": space_name, "document_ids": ["4"]},
+    )
+    assert rs.json()["code"] == 0
+    search_data["filters"] = match_filter("lazy")
+    assert search_ids(search_data) == ["1"]
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_fulltext_search_filter_limit():
+    create_fulltext_space(partition_num=1)
+
+    # documents filtered out don't take the places of the limit
+    search_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "filters": match_filter(
+            "lazy", [{"field": "field_int", "operator": "<", "value": 3}]
+        ),
+        "limit": 1,
+    }
+    assert search_ids(search_data) == ["1"]
+
+    destroy(router_url, db_name, space_name)
+
+
+@pytest.mark.parametrize(
+    ["ranker"],
+    [
+        [None],
+        [{"type": "RRF", "params": {"k": 60}}],
+        [{"type": "WeightedRanker", "params": [0.3, 0.7]}],
+    ],
+)
+def test_hybrid_search(ranker):
+    create_fulltext_space()
+
+    search_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "vectors": [{"field": "field_vector", "feature": xb[0].tolist()}],
+        "filters": match_filter("keyword relevance"),
+        "limit": 3,
+    }
+    if ranker is not None:
+        search_data["ranker"] = ranker
+    ids = search_ids(search_data)
+    assert len(ids) == 3
+    # the nearest vector and the text matches are fused
+    assert "0" in ids
+    assert "2" in ids or "3" in ids
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_hybrid_search_badcase():
+    create_fulltext_space()
+
+    search_data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "vectors": [{"field": "field_vector", "feature": xb[0].tolist()}],
+        "filters": match_filter("keyword"),
+        "limit": 3,
+    }
+    url = router_url + "/document/search"
+
+    # one weight per vector and match condition
+    search_data["ranker"] = {"type": "WeightedRanker", "params": [1.0]}
+    rs = requests.post(url, auth=(username, password), json=search_data)
+    assert rs.json()["code"] != 0
+
+    search_data["ranker"] = {"type": "RRF", "params": {"k": -1}}
+    rs = requests.post(url, auth=(username, password), json=search_data)
+    assert rs.json()["code"] != 0
+    del search_data["ranker"]
+
+    # match needs a FULLTEXT field
+    search_data["filters"] = {
+        "operator": "AND",
+        "conditions": [{"field": "title", "operator": "MATCH", "value": "title"}],
+    }
+    rs = requests.post(url, auth=(username, password), json=search_data)
+    assert rs.json()["code"] != 0
+
+    # match is not a filter of query
+    rs = requests.post(
+        router_url + "/document/query",
+        auth=(username, password),
+        json={
+            "db_name": db_name,
+            "space_name": space_name,
+            "filters": match_filter("keyword"),
+            "limit": 3,
+        },
+    )
+    assert rs.json()["code"] != 0
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_fulltext_field_badcase():
+    space_config = {
+        "name": space_name,
+        "partition_num": 1,
+        "replica_num": 1,
+        "fields": [
+            {
+                "name": "field_int",
+                "type": "integer",
+                "index": {"name": "field_int", "type": "FULLTEXT"},
+            },
+            {
+                "name": "field_vector",
+                "type": "vector",
+                "index": {
+                    "name": "field_vector",
+                    "type": "FLAT",
+                    "params": {"metric_type": "L2"},
+                },
+                "dimension": xb.shape[1],
+            },
+        ],
+    }
+    create_db(router_url, db_name)
+    response = create_space(router_url, db_name, space_config)
+    assert response.json()["code"] != 0
+    drop_db(router_url, db_name)
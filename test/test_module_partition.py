@@ -431,6 +431,41 @@ class TestPartitionRule:
         assert response.json()["code"] == 0
         assert "errors" not in response.json()["data"]
 
+    def test_prune_partitions(self):
+        today = datetime.datetime.today().date()
+        tomorrow = today + datetime.timedelta(days=1)
+        date_format = "%Y-%m-%d"
+        query_dict = {
+            "db_name": db_name,
+            "space_name": space_name,
+            "filters": {
+                "operator": "AND",
+                "conditions": [
+                    {"field": "field_date", "operator": ">=", "value": today.strftime(date_format)},
+                    {"field": "field_date", "operator": "<", "value": tomorrow.strftime(date_format)},
+                ],
+            },
+            "limit": 100,
+        }
+        url = router_url + "/document/query"
+        rs = requests.post(url, auth=(username, password), json=query_dict)
+        logger.info(rs.json())
+        assert rs.json()["code"] == 0
+        # only the 2 partitions of p1 hold today
+        assert rs.json()["data"]["pruned_partitions"] == 6
+        assert len(rs.json()["data"]["documents"]) > 0
+        for doc in rs.json()["data"]["documents"]:
+            dt = datetime.datetime.strptime(doc["field_date"], "%Y-%m-%dT%H:%M:%S%z")
+            assert dt.strftime(date_format) == today.strftime(date_format)
+
+        # a filter not bounding the rule field reaches every partition
+        query_dict["filters"]["conditions"] = [
+            {"field": "field_int", "operator": ">=", "value": 0}
+        ]
+        rs = requests.post(url, auth=(username, password), json=query_dict)
+        assert rs.json()["code"] == 0
+        assert rs.json()["data"]["pruned_partitions"] == 0
+
     def test_destroy_db(self):
         response = list_spaces(router_url, db_name)
         logger.info(response.json())
@@ -0,0 +1,133 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for module task """
+
+
+class TestTask:
+    def setup_class(self):
+        self.db_name = "task_db"
+        self.space_name = "task_space"
+
+    def test_prepare(self):
+        response = create_db(router_url, self.db_name)
+        assert response.json()["code"] == 0
+        space_config = {
+            "name": self.space_name,
+            "partition_num": 1,
+            "replica_num": 1,
+            "fields": [
+                {"name": "field_int", "type": "integer", "index": {"name": "field_int", "type": "SCALAR"}},
+                {
+                    "name": "field_vector",
+                    "type": "vector",
+                    "dimension": 4,
+                    "index": {"name": "gamma", "type": "FLAT", "params": {"metric_type": "L2"}},
+                },
+            ],
+        }
+        response = create_space(router_url, self.db_name, space_config)
+        assert response.json()["code"] == 0
+
+    def test_rebuild_index(self):
+        task = {
+            "type": "rebuild_index",
+            "db_name": self.db_name,
+            "space_name": self.space_name,
+            "params": {"drop_before_rebuild": True},
+        }
+        response = submit_task(router_url, task)
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        task_id = response.json()["data"]["task_id"]
+        assert response.json()["data"]["user"] == username
+
+        task = wait_task(router_url, task_id)
+        logger.info(task)
+        assert task["status"] == "succeeded"
+        assert len(task["steps"]) == 1
+        assert task["steps"][0]["status"] == "succeeded"
+
+        # finished tasks can't be canceled
+        response = cancel_task(router_url, task_id)
+        assert response.json()["code"] != 0
+
+    def test_async_expand_partitions(self):
+        url = f"{router_url}/dbs/{self.db_name}/spaces/{self.space_name}?async=true"
+        response = requests.put(url, auth=(username, password), json={"partition_num": 2})
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        task_id = response.json()["data"]["task_id"]
+        assert response.json()["data"]["type"] == "expand_partitions"
+
+        task = wait_task(router_url, task_id)
+        assert task["status"] == "succeeded"
+        response = get_space(router_url, self.db_name, self.space_name)
+        assert response.json()["data"]["partition_num"] == 2
+
+        # the same expansion again fails in the task
+        response = requests.put(url, auth=(username, password), json={"partition_num": 2})
+        task = wait_task(router_url, response.json()["data"]["task_id"])
+        assert task["status"] == "failed"
+        assert task["error"] != ""
+
+    def test_list(self):
+        response = list_tasks(router_url)
+        assert response.json()["code"] == 0
+        tasks = response.json()["data"]
+        assert len(tasks) >= 3
+        ids = [t["task_id"] for t in tasks]
+        assert ids == sorted(ids, reverse=True)
+
+        response = list_tasks(router_url, {"type": "rebuild_index", "status": "succeeded"})
+        tasks = response.json()["data"]
+        assert len(tasks) >= 1
+        for t in tasks:
+            assert t["type"] == "rebuild_index"
+            assert t["status"] == "succeeded"
+
+    @pytest.mark.parametrize(
+        ["wrong_index", "wrong_type"],
+        [
+            [0, "unknown_type"],
+            [1, "without_space"],
+            [2, "not_exist_task"],
+            [3, "invalid_task_id"],
+        ],
+    )
+    def test_badcase(self, wrong_index, wrong_type):
+        if wrong_index == 0:
+            response = submit_task(router_url, {"type": "unknown", "db_name": self.db_name, "space_name": self.space_name})
+        if wrong_index == 1:
+            response = submit_task(router_url, {"type": "rebuild_index", "db_name": self.db_name})
+        if wrong_index == 2:
+            response = get_task(router_url, 1 << 60)
+        if wrong_index == 3:
+            response = cancel_task(router_url, "abc")
+        logger.info(response.json())
+        assert response.json()["code"] != 0
+
+    def test_destroy(self):
+        response = drop_space(router_url, self.db_name, self.space_name)
+        assert response.json()["code"] == 0
+        response = drop_db(router_url, self.db_name)
+        assert response.json()["code"] == 0
@@ -16,6 +16,7 @@
 # -*- coding: UTF-8 -*-
 
 import requests
+import time
 import json
 import pytest
 from multiprocessing import Pool as ThreadPool
@@ -470,6 +471,233 @@ class TestUserDefaultReadSpaceEditDocument:
         assert response.json()["code"] == 0
 
 
+class TestUserAPIKey:
+    def setup_class(self):
+        pass
+
+    def bearer(self, token):
+        return {"Authorization": "Bearer " + token}
+
+    def test_create_user(self):
+        response = create_user(router_url, "user_name", "password", "defaultClusterAdmin")
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+
+        # only the hash of the password is stored
+        response = requests.get(
+            router_url + "/cache/users/user_name", auth=(username, password)
+        )
+        logger.info(response.json())
+        assert response.json()["data"]["password"] != "password"
+
+    def test_api_key(self):
+        response = create_api_key(router_url, "user_name", "key1")
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        token = response.json()["data"]["token"]
+
+        time.sleep(1)
+        response = requests.get(router_url + "/dbs", headers=self.bearer(token))
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+
+        response = requests.get(router_url + "/dbs", headers=self.bearer(token + "0"))
+        assert response.json()["code"] != 0
+
+        # the token can't be got again
+        response = get_api_keys(router_url, "user_name")
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        assert len(response.json()["data"]) == 1
+        assert "token" not in response.json()["data"][0]
+        assert "hash" not in response.json()["data"][0]
+
+        # names are unique for a user
+        response = create_api_key(router_url, "user_name", "key1")
+        assert response.json()["code"] != 0
+
+        # an api key can't create api keys
+        response = requests.post(
+            router_url + "/users/user_name/api_keys",
+            json={"name": "key2"},
+            headers=self.bearer(token),
+        )
+        assert response.json()["code"] != 0
+
+        response = delete_api_key(router_url, "user_name", "key1")
+        assert response.json()["code"] == 0
+        time.sleep(1)
+        response = requests.get(router_url + "/dbs", headers=self.bearer(token))
+        assert response.json()["code"] != 0
+
+    def test_api_key_role(self):
+        # the role of a key limits the privileges of the user
+        response = create_api_key(
+            router_url, "user_name", "reader", role_name="defaultDocumentAdmin"
+        )
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        token = response.json()["data"]["token"]
+        time.sleep(1)
+        response = requests.get(router_url + "/dbs", headers=self.bearer(token))
+        assert response.json()["code"] != 0
+
+    def test_api_key_expire(self):
+        response = create_api_key(router_url, "user_name", "short", expire_seconds=1)
+        assert response.json()["code"] == 0
+        token = response.json()["data"]["token"]
+        time.sleep(2)
+        response = requests.get(router_url + "/dbs", headers=self.bearer(token))
+        assert response.json()["code"] != 0
+
+    def test_api_key_badcase(self):
+        response = create_api_key(router_url, "user_name", "bad", role_name="not_exist")
+        assert response.json()["code"] != 0
+        response = create_api_key(router_url, "not_exist", "bad")
+        assert response.json()["code"] != 0
+        response = create_api_key(router_url, "user_name", "bad", expire_seconds=-1)
+        assert response.json()["code"] != 0
+
+        # a user can only manage its own keys
+        response = create_user(router_url, "user_name1", "password", "defaultClusterAdmin")
+        assert response.json()["code"] == 0
+        response = create_api_key(
+            router_url, "user_name", "bad", auth=("user_name1", "password")
+        )
+        assert response.json()["code"] != 0
+        response = drop_user(router_url, "user_name1")
+        assert response.json()["code"] == 0
+
+    def test_drop_user(self):
+        response = drop_user(router_url, "user_name")
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+
+
+class TestRoleScope:
+    def setup_class(self):
+        self.space_config = {
+            "name": "space_x",
+            "partition_num": 1,
+            "replica_num": 1,
+            "fields": [
+                {"name": "field_int", "type": "integer"},
+                {
+                    "name": "field_vector",
+                    "type": "vector",
+                    "dimension": 4,
+                    "index": {"name": "gamma", "type": "FLAT", "params": {"metric_type": "L2"}},
+                },
+            ],
+        }
+        self.auth = ("user_name", "password")
+
+    def upsert(self, db_name, space_name):
+        data = {
+            "db_name": db_name,
+            "space_name": space_name,
+            "documents": [{"_id": "1", "field_int": 1, "field_vector": [0.1, 0.2, 0.3, 0.4]}],
+        }
+        return requests.post(router_url + "/document/upsert", json=data, auth=self.auth)
+
+    def query(self, db_name, space_name):
+        data = {"db_name": db_name, "space_name": space_name, "document_ids": ["1"]}
+        return requests.post(router_url + "/document/query", json=data, auth=self.auth)
+
+    def test_create_role(self):
+        for db_name in ["db_a1", "db_b"]:
+            response = create_db(router_url, db_name)
+            assert response.json()["code"] == 0
+        for db_name, space_name in [("db_a1", "space_x"), ("db_b", "space_x"), ("db_b", "space_y")]:
+            self.space_config["name"] = space_name
+            response = create_space(router_url, db_name, self.space_config)
+            logger.info(response.json())
+            assert response.json()["code"] == 0
+
+        scopes = [
+            {"db": "db_a*", "privileges": {"ResourceDocument": "WriteRead"}},
+            {"db": "db_b", "space": "space_x", "privileges": {"ResourceDocument": "ReadOnly", "ResourceSpace": "ReadOnly"}},
+        ]
+        response = create_role(router_url, "scoped_role", {"ResourceDB": "ReadOnly"}, scopes)
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+
+        response = get_role(router_url, "scoped_role")
+        logger.info(response.json())
+        assert len(response.json()["data"]["scopes"]) == 2
+
+        response = create_user(router_url, "user_name", "password", "scoped_role")
+        assert response.json()["code"] == 0
+        time.sleep(1)
+
+    def test_scoped_privileges(self):
+        # root writes the document read below
+        data = {
+            "db_name": "db_b",
+            "space_name": "space_x",
+            "documents": [{"_id": "1", "field_int": 1, "field_vector": [0.1, 0.2, 0.3, 0.4]}],
+        }
+        response = requests.post(router_url + "/document/upsert", json=data, auth=(username, password))
+        assert response.json()["code"] == 0
+
+        response = self.upsert("db_a1", "space_x")
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        response = self.query("db_a1", "space_x")
+        assert response.json()["code"] == 0
+
+        response = self.upsert("db_b", "space_x")
+        logger.info(response.json())
+        assert response.json()["code"] != 0
+        response = self.query("db_b", "space_x")
+        assert response.json()["code"] == 0
+        response = requests.get(router_url + "/dbs/db_b/spaces/space_x", auth=self.auth)
+        assert response.json()["code"] == 0
+
+        response = self.query("db_b", "space_y")
+        assert response.json()["code"] != 0
+        response = requests.get(router_url + "/dbs/db_b/spaces/space_y", auth=self.auth)
+        assert response.json()["code"] != 0
+
+    def test_change_scopes(self):
+        scopes = [{"db": "db_b", "space": "space_x", "privileges": {"ResourceDocument": "WriteRead"}}]
+        response = change_role_privilege(router_url, "scoped_role", "Grant", {}, scopes)
+        logger.info(response.json())
+        assert response.json()["code"] == 0
+        time.sleep(1)
+        response = self.upsert("db_b", "space_x")
+        assert response.json()["code"] == 0
+
+        scopes = [{"db": "db_a*"}]
+        response = change_role_privilege(router_url, "scoped_role", "Revoke", {}, scopes)
+        assert response.json()["code"] == 0
+        response = get_role(router_url, "scoped_role")
+        assert len(response.json()["data"]["scopes"]) == 1
+        time.sleep(1)
+        response = self.upsert("db_a1", "space_x")
+        assert response.json()["code"] != 0
+
+    def test_scope_badcase(self):
+        for scopes in [
+            [{"privileges": {"ResourceDocument": "ReadOnly"}}],
+            [{"db": "db_[", "privileges": {"ResourceDocument": "ReadOnly"}}],
+            [{"db": "db_b", "privileges": {"ResourceCluster": "ReadOnly"}}],
+        ]:
+            response = create_role(router_url, "bad_role", {}, scopes)
+            logger.info(response.json())
+            assert response.json()["code"] != 0
+
+    def test_drop(self):
+        response = drop_user(router_url, "user_name")
+        assert response.json()["code"] == 0
+        response = drop_role(router_url, "scoped_role")
+        assert response.json()["code"] == 0
+        for db_name, space_name in [("db_a1", "space_x"), ("db_b", "space_x"), ("db_b", "space_y")]:
+            drop_space(router_url, db_name, space_name)
+        for db_name in ["db_a1", "db_b"]:
+            drop_db(router_url, db_name)
+
+
 class TestResetRoot:
     def test_update_root_password(self):
         url = f"{router_url}/users"
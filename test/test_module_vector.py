@@ -310,6 +310,76 @@ class TestSearchWeightRanker:
     def test_destroy_cluster(self):
         destroy(router_url, db_name, space_name)
 
+class TestSearchRRFRanker:
+    def setup_class(self):
+        self.xb = xb
+
+    # prepare
+    def test_prepare_cluster(self):
+        create(router_url, self.xb.shape[1], "MemoryOnly")
+
+    def test_prepare_upsert(self):
+        batch_size = 100
+        total_batch = 1
+        add_multi_vector(total_batch, batch_size, xb)
+        assert get_space_num() == int(total_batch * batch_size)
+
+    @pytest.mark.parametrize(
+        ["params", "k"],
+        [[None, 60], [{"k": 10}, 10]],
+    )
+    def test_search_rrf_ranker(self, params, k):
+        query_dict = {
+            "vectors": [
+                {"field": "field_vector", "feature": xb[:1].flatten().tolist()},
+                {"field": "field_vector1", "feature": xb[:1].flatten().tolist()},
+            ],
+            "fields": ["field_int"],
+            "limit": 10,
+            "db_name": db_name,
+            "space_name": space_name,
+            "ranker": {"type": "RRF"},
+        }
+        if params is not None:
+            query_dict["ranker"]["params"] = params
+        url = router_url + "/document/search"
+        rs = requests.post(url, auth=(username, password), json=query_dict)
+        assert rs.status_code == 200
+        documents = rs.json()["data"]["documents"][0]
+        assert len(documents) == 10
+        # the document itself ranks first by both vectors
+        assert documents[0]["field_int"] == 0
+        assert abs(documents[0]["_score"] - 2 / (k + 1)) <= 1e-6
+        scores = [doc["_score"] for doc in documents]
+        assert scores == sorted(scores, reverse=True)
+
+    def test_search_rrf_ranker_badcase(self):
+        query_dict = {
+            "vectors": [
+                {"field": "field_vector", "feature": xb[:1].flatten().tolist()},
+                {"field": "field_vector1", "feature": xb[:1].flatten().tolist()},
+            ],
+            "limit": 10,
+            "db_name": db_name,
+            "space_name": space_name,
+            "ranker": {"type": "RRF", "params": {"k": 0}},
+        }
+        url = router_url + "/document/search"
+        rs = requests.post(url, auth=(username, password), json=query_dict)
+        assert rs.json()["code"] != 0
+
+        query_dict["ranker"] = {"type": "UnknownRanker"}
+        rs = requests.post(url, auth=(username, password), json=query_dict)
+        assert rs.json()["code"] != 0
+
+        query_dict["ranker"] = {"type": "WeightedRanker", "params": [1.0]}
+        rs = requests.post(url, auth=(username, password), json=query_dict)
+        assert rs.json()["code"] != 0
+
+    # destroy
+    def test_destroy_cluster(self):
+        destroy(router_url, db_name, space_name)
+
 class TestSearchScore:
     def setup_class(self):
         self.xb = xb
@@ -0,0 +1,180 @@
+#
+# Copyright 2019 The Vearch Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+# implied. See the License for the specific language governing
+# permissions and limitations under the License.
+
+# -*- coding: UTF-8 -*-
+
+import requests
+import pytest
+from utils.vearch_utils import *
+from utils.data_utils import *
+
+__description__ = """ test case for LIST and HASH partition rules """
+
+tenants = ["t1", "t2", "t3", "t4"]
+
+
+def space_config(partition_rule):
+    return {
+        "name": space_name,
+        "partition_num": 1,
+        "replica_num": 1,
+        "fields": [
+            {
+                "name": "field_int",
+                "type": "integer",
+                "index": {"name": "field_int", "type": "SCALAR"},
+            },
+            {
+                "name": "field_tenant",
+                "type": "string",
+                "index": {"name": "field_tenant", "type": "SCALAR"},
+            },
+            {
+                "name": "field_vector",
+                "type": "vector",
+                "dimension": xb.shape[1],
+                "index": {
+                    "name": "gamma",
+                    "type": "FLAT",
+                    "params": {"metric_type": "L2"},
+                },
+            },
+        ],
+        "partition_rule": partition_rule,
+    }
+
+
+def upsert(start, end, tenant_of):
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "documents": [
+            {
+                "_id": str(i),
+                "field_int": i,
+                "field_tenant": tenant_of(i),
+                "field_vector": xb[i].tolist(),
+            }
+            for i in range(start, end)
+        ],
+    }
+    rs = requests.post(
+        router_url + "/document/upsert", auth=(username, password), json=data
+    )
+    return rs.json()
+
+
+def query_ids(conditions):
+    data = {
+        "db_name": db_name,
+        "space_name": space_name,
+        "filters": {"operator": "AND", "conditions": conditions},
+        "limit": 1000,
+    }
+    rs = requests.post(
+        router_url + "/document/query", auth=(username, password), json=data
+    )
+    assert rs.json()["code"] == 0
+    return sorted(int(doc["_id"]) for doc in rs.json()["data"]["documents"])
+
+
+def test_partition_rule_list():
+    create_db(router_url, db_name)
+    rule = {
+        "type": "LIST",
+        "field": "field_tenant",
+        "lists": [
+            {"name": "p0", "values": ["t1", "t2"]},
+            {"name": "p1", "values": ["t3"]},
+        ],
+    }
+    response = create_space(router_url, db_name, space_config(rule))
+    assert response.json()["code"] == 0
+
+    tenant_of = lambda i: tenants[i % 3]
+    assert upsert(0, 90, tenant_of)["code"] == 0
+    # a tenant not in the lists can't be written
+    assert upsert(90, 91, lambda i: "t4")["code"] != 0
+
+    ids = query_ids([{"field": "field_tenant", "operator": "IN", "value": ["t3"]}])
+    assert ids == list(range(2, 90, 3))
+
+    # a tenant is added as a new list and dropped with one call
+    rule = {"partition_rule": {"type": "LIST", "lists": [{"name": "p2", "values": ["t4"]}]}}
+    response = update_space_partition_rule(
+        router_url, db_name, space_name, operator_type="ADD", partition_rule=rule
+    )
+    assert response.json()["code"] == 0
+    assert len(response.json()["data"]["partitions"]) == 3
+    assert upsert(90, 100, lambda i: "t4")["code"] == 0
+    ids = query_ids([{"field": "field_tenant", "operator": "IN", "value": ["t4"]}])
+    assert ids == list(range(90, 100))
+
+    response = update_space_partition_rule(
+        router_url, db_name, space_name, partition_name="p2", operator_type="DROP"
+    )
+    assert response.json()["code"] == 0
+    response = describe_space(router_url, db_name, space_name)
+    assert len(response.json()["data"]["partition_rule"]["lists"]) == 2
+    ids = query_ids([{"field": "field_int", "operator": ">=", "value": 0}])
+    assert ids == list(range(90))
+
+    destroy(router_url, db_name, space_name)
+
+
+def test_partition_rule_hash():
+    create_db(router_url, db_name)
+    rule = {"type": "HASH", "field": "field_int", "partitions": 4}
+    response = create_space(router_url, db_name, space_config(rule))
+    assert response.json()["code"] == 0
+    assert len(response.json()["data"]["partitions"]) == 4
+
+    assert upsert(0, 100, lambda i: tenants[i % 4])["code"] == 0
+    for i in [0, 17, 99]:
+        assert query_ids([{"field": "field_int", "operator": "=", "value": i}]) == [i]
+
+    # hash partitions can't be added or dropped
+    response = update_space_partition_rule(
+        router_url, db_name, space_name, partition_name="hash_0", operator_type="DROP"
+    )
+    assert response.json()["code"] != 0
+
+    destroy(router_url, db_name, space_name)
+
+
+@pytest.mark.parametrize(
+    ["rule"],
+    [
+        [{"type": "LIST", "field": "field_int", "lists": [{"name": "p0", "values": ["1"]}]}],
+        [{"type": "LIST", "field": "field_tenant", "lists": []}],
+        [
+            {
+                "type": "LIST",
+                "field": "field_tenant",
+                "lists": [
+                    {"name": "p0", "values": ["t1"]},
+                    {"name": "p1", "values": ["t1"]},
+                ],
+            }
+        ],
+        [{"type": "HASH", "field": "field_vector", "partitions": 2}],
+        [{"type": "HASH", "field": "field_int"}],
+    ],
+)
+def test_partition_rule_badcase(rule):
+    create_db(router_url, db_name)
+    response = create_space(router_url, db_name, space_config(rule))
+    assert response.json()["code"] != 0
+    drop_db(router_url, db_name)
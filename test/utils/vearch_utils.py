@@ -1759,18 +1759,54 @@ def drop_user(router_url: str, user_name: str):
     return resp
 
 
-def create_role(router_url: str, role_name: str, privileges: dict):
+def create_api_key(
+    router_url: str,
+    user_name: str,
+    key_name: str,
+    role_name: str = None,
+    expire_seconds: int = None,
+    auth=None,
+):
+    url = f"{router_url}/users/{user_name}/api_keys"
+    data = {"name": key_name}
+    if role_name is not None:
+        data["role_name"] = role_name
+    if expire_seconds is not None:
+        data["expire_seconds"] = expire_seconds
+    resp = requests.post(url, json=data, auth=auth or (username, password))
+    return resp
+
+
+def get_api_keys(router_url: str, user_name: str):
+    url = f"{router_url}/users/{user_name}/api_keys"
+    resp = requests.get(url, auth=(username, password))
+    return resp
+
+
+def delete_api_key(router_url: str, user_name: str, key_name: str):
+    url = f"{router_url}/users/{user_name}/api_keys/{key_name}"
+    resp = requests.delete(url, auth=(username, password))
+    return resp
+
+
+def create_role(
+    router_url: str, role_name: str, privileges: dict, scopes: list = None
+):
     url = f"{router_url}/roles"
     data = {"name": role_name, "privileges": privileges}
+    if scopes is not None:
+        data["scopes"] = scopes
     resp = requests.post(url, json=data, auth=(username, password))
     return resp
 
 
 def change_role_privilege(
-    router_url: str, role_name: str, operator: str, privileges: dict
+    router_url: str, role_name: str, operator: str, privileges: dict, scopes: list = None
 ):
     url = f"{router_url}/roles"
     data = {"name": role_name, "operator": operator, "privileges": privileges}
+    if scopes is not None:
+        data["scopes"] = scopes
     resp = requests.put(url, json=data, auth=(username, password))
     return resp
 
@@ -1799,6 +1835,45 @@ def drop_role(router_url: str, role_name: str):
     return resp
 
 
+def get_audit(router_url: str, params: dict = None):
+    url = f"{router_url}/audit"
+    resp = requests.get(url, params=params, auth=(username, password))
+    return resp
+
+
+def submit_task(router_url: str, task: dict):
+    url = f"{router_url}/tasks"
+    resp = requests.post(url, auth=(username, password), json=task)
+    return resp
+
+
+def get_task(router_url: str, task_id: int):
+    url = f"{router_url}/tasks/{task_id}"
+    resp = requests.get(url, auth=(username, password))
+    return resp
+
+
+def list_tasks(router_url: str, params: dict = None):
+    url = f"{router_url}/tasks"
+    resp = requests.get(url, params=params, auth=(username, password))
+    return resp
+
+
+def cancel_task(router_url: str, task_id: int):
+    url = f"{router_url}/tasks/{task_id}/cancel"
+    resp = requests.post(url, auth=(username, password))
+    return resp
+
+
+def wait_task(router_url: str, task_id: int, timeout: int = 120):
+    for _ in range(timeout):
+        task = get_task(router_url, task_id).json()["data"]
+        if task["status"] in ("succeeded", "failed", "canceled"):
+            return task
+        time.sleep(1)
+    return get_task(router_url, task_id).json()["data"]
+
+
 def server_resource_limit(
     router_url: str,
     resource_exhausted: bool = None,
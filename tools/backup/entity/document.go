@@ -42,3 +42,29 @@ type Document struct {
 	SpaceName string            `json:"space_name"`
 	Documents []json.RawMessage `json:"documents"`
 }
+
+type ImportResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	Data struct {
+		ImportID string `json:"import_id"`
+		Status   string `json:"status"`
+		Total    int64  `json:"total"`
+		Imported int64  `json:"imported"`
+		Rejected int64  `json:"rejected"`
+		Files    []struct {
+			File         string `json:"file"`
+			Format       string `json:"format"`
+			Total        int64  `json:"total"`
+			Imported     int64  `json:"imported"`
+			Rejected     int64  `json:"rejected"`
+			RejectedRows []struct {
+				Row   int64  `json:"row"`
+				ID    string `json:"_id"`
+				Error string `json:"error"`
+			} `json:"rejected_rows"`
+			Error string `json:"error"`
+		} `json:"files"`
+		Error string `json:"error"`
+	} `json:"data"`
+}
@@ -21,6 +21,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/bytedance/sonic"
 	"github.com/go-resty/resty/v2"
@@ -237,16 +239,129 @@ func restore(url string, db_name string, space_name string, path string) error {
 	return nil
 }
 
+// importFiles uploads the files of input, a file or a dir, to the import
+// api one by one, and prints the rows imported and rejected of each file.
+func importFiles(url string, db_name string, space_name string, input string, format string, mapping string, batchSize int, timeout time.Duration) error {
+	files := []string{input}
+	info, err := os.Stat(input)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		entries, err := os.ReadDir(input)
+		if err != nil {
+			return err
+		}
+		files = files[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(input, entry.Name()))
+			}
+		}
+	}
+
+	client := resty.New().SetTimeout(timeout)
+	importUrl := fmt.Sprintf("%s/document/import", url)
+	params := map[string]string{
+		"db_name":    db_name,
+		"space_name": space_name,
+	}
+	if format != "" {
+		params["format"] = format
+	}
+	if mapping != "" {
+		params["mapping"] = mapping
+	}
+	if batchSize > 0 {
+		params["batch_size"] = fmt.Sprint(batchSize)
+	}
+
+	var rejected int64
+	for _, name := range files {
+		importResponse := &entity.ImportResponse{}
+		resp, err := client.R().
+			SetQueryParams(params).
+			SetFile("file", name).
+			SetResult(importResponse).
+			SetError(importResponse).
+			Post(importUrl)
+		if err != nil {
+			return err
+		}
+		if resp.IsError() {
+			return fmt.Errorf("import %s failed, code %d, msg %s", name, importResponse.Code, importResponse.Msg)
+		}
+		// the file is imported in background
+		importResponse, err = waitImport(client, importUrl, importResponse.Data.ImportID, db_name, space_name, timeout)
+		if err != nil {
+			return fmt.Errorf("import %s failed: %v", name, err)
+		}
+		for _, f := range importResponse.Data.Files {
+			log.Printf("file %s format %s, total %d, imported %d, rejected %d\n", name, f.Format, f.Total, f.Imported, f.Rejected)
+			for _, row := range f.RejectedRows {
+				log.Printf("  rejected row %d _id [%s]: %s\n", row.Row, row.ID, row.Error)
+			}
+			if f.Error != "" {
+				return fmt.Errorf("import %s failed: %s", name, f.Error)
+			}
+			rejected += f.Rejected
+		}
+		if importResponse.Data.Error != "" {
+			return fmt.Errorf("import %s %s: %s", name, importResponse.Data.Status, importResponse.Data.Error)
+		}
+	}
+	if rejected > 0 {
+		return fmt.Errorf("%d rows are rejected", rejected)
+	}
+	return nil
+}
+
+// waitImport polls the progress of an import until it's finished, the import
+// is canceled if it runs longer than timeout.
+func waitImport(client *resty.Client, importUrl, id, db_name, space_name string, timeout time.Duration) (*entity.ImportResponse, error) {
+	params := map[string]string{"db_name": db_name, "space_name": space_name}
+	deadline := time.Now().Add(timeout)
+	for {
+		importResponse := &entity.ImportResponse{}
+		resp, err := client.R().
+			SetQueryParams(params).
+			SetResult(importResponse).
+			SetError(importResponse).
+			Get(importUrl + "/" + id)
+		if err != nil {
+			return nil, err
+		}
+		if resp.IsError() {
+			return nil, fmt.Errorf("code %d, msg %s", importResponse.Code, importResponse.Msg)
+		}
+		if importResponse.Data.Status != "running" {
+			return importResponse, nil
+		}
+		if time.Now().After(deadline) {
+			client.R().SetQueryParams(params).Post(importUrl + "/" + id + "/cancel")
+			return nil, fmt.Errorf("not finished in %v, canceled", timeout)
+		}
+		log.Printf("importing, total %d, imported %d, rejected %d\n", importResponse.Data.Total, importResponse.Data.Imported, importResponse.Data.Rejected)
+		time.Sleep(time.Second)
+	}
+}
+
 func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
-	var command, url, db, space, output, input string
+	var command, url, db, space, output, input, format, mapping string
+	var batchSize int
+	var timeout time.Duration
 
 	flag.StringVar(&command, "command", "create", "backup command")
 	flag.StringVar(&url, "url", "", "vearch url")
 	flag.StringVar(&db, "db", "", "vearch db name")
 	flag.StringVar(&space, "space", "", "vearch space name")
 	flag.StringVar(&output, "output", "data", "create output directory")
-	flag.StringVar(&input, "input", "data", "restore input directory")
+	flag.StringVar(&input, "input", "data", "restore input directory, or the file or directory to import")
+	flag.StringVar(&format, "format", "", "import file format: ndjson, ndjson.zst, parquet or arrow, detected by file extension if empty")
+	flag.StringVar(&mapping, "mapping", "", "import json object mapping file columns to space fields")
+	flag.IntVar(&batchSize, "batch-size", 0, "import documents upserted per batch")
+	flag.DurationVar(&timeout, "timeout", time.Hour, "import timeout of each file")
 	flag.Parse()
 
 	switch command {
@@ -262,5 +377,11 @@ func main() {
 			log.Printf("err %v\n", err)
 			os.Exit(1)
 		}
+	case "import":
+		err := importFiles(url, db, space, input, format, mapping, batchSize, timeout)
+		if err != nil {
+			log.Printf("err %v\n", err)
+			os.Exit(1)
+		}
 	}
 }